@@ -150,4 +150,5 @@ export interface FeatureToggles {
   costManagementUi?: boolean;
   managedPluginsInstall?: boolean;
   prometheusPromQAIL?: boolean;
+  entityEventsStream?: boolean;
 }
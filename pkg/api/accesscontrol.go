@@ -27,6 +27,7 @@ var (
 	ScopeProvisionersDatasources   = ac.Scope("provisioners", "datasources")
 	ScopeProvisionersNotifications = ac.Scope("provisioners", "notifications")
 	ScopeProvisionersAlertRules    = ac.Scope("provisioners", "alerting")
+	ScopeProvisionersTeams         = ac.Scope("provisioners", "teams")
 )
 
 // declareFixedRoles declares to the AccessControl service fixed roles and their
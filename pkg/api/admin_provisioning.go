@@ -107,3 +107,26 @@ func (hs *HTTPServer) AdminProvisioningReloadAlerting(c *contextmodel.ReqContext
 	}
 	return response.Success("Alerting config reloaded")
 }
+
+// swagger:route POST /admin/provisioning/teams/reload admin_provisioning adminProvisioningReloadTeams
+//
+// Reload teams and permissions provisioning configurations.
+//
+// Reloads the provisioning config files for teams, team members, folder and dashboard permissions again. It won’t return until the new provisioned entities are already stored in the database.
+// If you are running Grafana Enterprise and have Fine-grained access control enabled, you need to have a permission with action `provisioning:reload` and scope `provisioners:teams`.
+//
+// Security:
+// - basic:
+//
+// Responses:
+// 200: okResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (hs *HTTPServer) AdminProvisioningReloadTeams(c *contextmodel.ReqContext) response.Response {
+	err := hs.ProvisioningService.ProvisionTeams(c.Req.Context())
+	if err != nil {
+		return response.Error(500, "", err)
+	}
+	return response.Success("Teams config reloaded")
+}
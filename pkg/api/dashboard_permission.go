@@ -138,6 +138,10 @@ func (hs *HTTPServer) UpdateDashboardPermissions(c *contextmodel.ReqContext) res
 		return rsp
 	}
 
+	if rsp := hs.provisionedPermissionsResponse(c.Req.Context(), dash.OrgID, "dashboards", dash.UID); rsp != nil {
+		return rsp
+	}
+
 	items := make([]*dashboards.DashboardACL, 0, len(apiCmd.Items))
 	for _, item := range apiCmd.Items {
 		items = append(items, &dashboards.DashboardACL{
@@ -310,6 +314,21 @@ func (hs *HTTPServer) updateDashboardAccessControl(ctx context.Context, orgID in
 	return nil
 }
 
+// provisionedPermissionsResponse returns an error response if the permissions of the dashboard or folder are managed by provisioning
+func (hs *HTTPServer) provisionedPermissionsResponse(ctx context.Context, orgID int64, resource, uid string) response.Response {
+	if hs.provisionedPermissions == nil {
+		return nil
+	}
+	provisioned, err := hs.provisionedPermissions.IsProvisioned(ctx, orgID, resource, uid)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "failed to check if permissions are provisioned", err)
+	}
+	if provisioned {
+		return response.Error(http.StatusBadRequest, "cannot modify provisioned permissions", nil)
+	}
+	return nil
+}
+
 func validatePermissionsUpdate(apiCmd dtos.UpdateDashboardACLCommand) error {
 	for _, item := range apiCmd.Items {
 		if item.UserID > 0 && item.TeamID > 0 {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -109,6 +110,26 @@ func TestHTTPServer_UpdateDashboardPermissions(t *testing.T) {
 		require.NoError(t, res.Body.Close())
 	})
 
+	t.Run("should not be able to update provisioned permissions", func(t *testing.T) {
+		server := SetupAPITestServer(t, func(hs *HTTPServer) {
+			svc := dashboards.NewFakeDashboardService(t)
+			svc.On("GetDashboard", mock.Anything, mock.Anything).Return(&dashboards.Dashboard{ID: 1, UID: "1", OrgID: 1}, nil)
+
+			hs.DashboardService = svc
+			hs.dashboardPermissionsService = &actest.FakePermissionsService{}
+			hs.provisionedPermissions = fakeProvisionedPermissions{"dashboards:1": true}
+		})
+
+		body := `{"items": []}`
+		res, err := server.SendJSON(webtest.RequestWithSignedInUser(server.NewPostRequest("/api/dashboards/uid/1/permissions", strings.NewReader(body)), userWithPermissions(1, []accesscontrol.Permission{
+			{Action: dashboards.ActionDashboardsPermissionsWrite, Scope: "dashboards:uid:1"},
+		})))
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
 	t.Run("should not be able to specify team and user in same acl", func(t *testing.T) {
 		server := SetupAPITestServer(t, func(hs *HTTPServer) {
 			hs.DashboardService = dashboards.NewFakeDashboardService(t)
@@ -157,3 +178,9 @@ func TestHTTPServer_UpdateDashboardPermissions(t *testing.T) {
 		require.NoError(t, res.Body.Close())
 	})
 }
+
+type fakeProvisionedPermissions map[string]bool
+
+func (f fakeProvisionedPermissions) IsProvisioned(ctx context.Context, orgID int64, resource, resourceID string) (bool, error) {
+	return f[resource+":"+resourceID], nil
+}
@@ -31,6 +31,7 @@ type DashboardMeta struct {
 	FolderUrl              string                `json:"folderUrl"`
 	Provisioned            bool                  `json:"provisioned"`
 	ProvisionedExternalId  string                `json:"provisionedExternalId"`
+	ProvisionedCommit      string                `json:"provisionedCommit,omitempty"`
 	AnnotationsPermissions *AnnotationPermission `json:"annotationsPermissions"`
 	PublicDashboardUID     string                `json:"publicDashboardUid,omitempty"`
 	PublicDashboardEnabled bool                  `json:"publicDashboardEnabled,omitempty"`
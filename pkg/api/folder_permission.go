@@ -89,6 +89,10 @@ func (hs *HTTPServer) UpdateFolderPermissions(c *contextmodel.ReqContext) respon
 		return apierrors.ToFolderErrorResponse(err)
 	}
 
+	if rsp := hs.provisionedPermissionsResponse(c.Req.Context(), c.SignedInUser.GetOrgID(), "folders", folder.UID); rsp != nil {
+		return rsp
+	}
+
 	items := make([]*dashboards.DashboardACL, 0, len(apiCmd.Items))
 	for _, item := range apiCmd.Items {
 		items = append(items, &dashboards.DashboardACL{
@@ -1,13 +1,17 @@
 package api
 
 import (
+	"errors"
 	"net"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"runtime"
+	"strings"
 	"time"
 
 	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/plugins/repo"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/util/proxyutil"
@@ -47,7 +51,122 @@ func ReverseProxyGnetReq(logger log.Logger, proxyPath string, version string, gr
 
 func (hs *HTTPServer) ProxyGnetRequest(c *contextmodel.ReqContext) {
 	proxyPath := web.Params(c.Req)["*"]
+
+	// When plugins are installed from a plugin index, serve the catalog from the index
+	// instead of grafana.com so it keeps working on air-gapped instances
+	if hs.pluginRepo != nil && strings.HasPrefix(strings.Trim(proxyPath, "/"), "plugins") {
+		compatOpts := repo.NewCompatOpts(hs.Cfg.BuildVersion, runtime.GOOS, runtime.GOARCH)
+		index, err := hs.pluginRepo.GetPluginIndex(c.Req.Context(), compatOpts)
+		if err == nil {
+			hs.servePluginIndex(c, index, proxyPath)
+			return
+		}
+		if !errors.Is(err, repo.ErrPluginIndexNotConfigured) {
+			c.JsonApiErr(http.StatusBadGateway, "Failed to load plugin index", err)
+			return
+		}
+	}
+
 	proxy := ReverseProxyGnetReq(c.Logger, proxyPath, hs.Cfg.BuildVersion, hs.Cfg.GrafanaComAPIURL)
 	proxy.Transport = grafanaComProxyTransport
 	proxy.ServeHTTP(c.Resp, c.Req)
 }
+
+// gnetPlugin is the subset of the grafana.com plugin model the plugin catalog relies on.
+type gnetPlugin struct {
+	Slug                 string                       `json:"slug"`
+	Name                 string                       `json:"name"`
+	Description          string                       `json:"description"`
+	OrgName              string                       `json:"orgName"`
+	TypeCode             string                       `json:"typeCode"`
+	Version              string                       `json:"version"`
+	Status               string                       `json:"status"`
+	SignatureType        string                       `json:"signatureType"`
+	VersionSignatureType string                       `json:"versionSignatureType"`
+	Packages             map[string]gnetPluginPackage `json:"packages"`
+	Links                []any                        `json:"links"`
+}
+
+type gnetPluginPackage struct {
+	DownloadURL string `json:"downloadUrl"`
+}
+
+type gnetPluginVersion struct {
+	Version           string `json:"version"`
+	GrafanaDependency string `json:"grafanaDependency"`
+	IsCompatible      bool   `json:"isCompatible"`
+}
+
+// servePluginIndex answers the grafana.com plugin API requests made by the plugin catalog from `index`.
+func (hs *HTTPServer) servePluginIndex(c *contextmodel.ReqContext, index *repo.PluginIndex, proxyPath string) {
+	parts := strings.Split(strings.Trim(proxyPath, "/"), "/")
+	if len(parts) > 3 || (len(parts) == 3 && parts[2] != "versions") {
+		c.JsonApiErr(http.StatusNotFound, "Not available in the plugin index", nil)
+		return
+	}
+
+	if len(parts) == 1 {
+		items := make([]gnetPlugin, 0, len(index.Plugins))
+		for _, p := range index.Plugins {
+			items = append(items, hs.toGnetPlugin(p))
+		}
+		c.JSON(http.StatusOK, map[string]any{"items": items})
+		return
+	}
+
+	for _, p := range index.Plugins {
+		if p.ID != parts[1] {
+			continue
+		}
+
+		if len(parts) == 2 {
+			c.JSON(http.StatusOK, hs.toGnetPlugin(p))
+			return
+		}
+
+		items := make([]gnetPluginVersion, 0, len(p.Versions))
+		for _, v := range p.Versions {
+			items = append(items, gnetPluginVersion{
+				Version:           v.Version,
+				GrafanaDependency: v.GrafanaDependency,
+				IsCompatible:      repo.IsGrafanaCompatible(hs.Cfg.BuildVersion, v.GrafanaDependency),
+			})
+		}
+		c.JSON(http.StatusOK, map[string]any{"items": items})
+		return
+	}
+
+	c.JsonApiErr(http.StatusNotFound, "Plugin not found", nil)
+}
+
+func (hs *HTTPServer) toGnetPlugin(p repo.IndexedPlugin) gnetPlugin {
+	gp := gnetPlugin{
+		Slug:                 p.ID,
+		Name:                 p.Name,
+		Description:          p.Description,
+		OrgName:              p.OrgName,
+		TypeCode:             p.Type,
+		Status:               "active",
+		SignatureType:        p.SignatureType,
+		VersionSignatureType: p.SignatureType,
+		Packages:             map[string]gnetPluginPackage{},
+		Links:                []any{},
+	}
+	if gp.Name == "" {
+		gp.Name = p.ID
+	}
+
+	// Advertise the latest version compatible with this Grafana instance
+	for _, v := range p.Versions {
+		if !repo.IsGrafanaCompatible(hs.Cfg.BuildVersion, v.GrafanaDependency) {
+			continue
+		}
+		gp.Version = v.Version
+		for arch, meta := range v.Arch {
+			gp.Packages[arch] = gnetPluginPackage{DownloadURL: meta.URL}
+		}
+		break
+	}
+
+	return gp
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/plugins/manager/fakes"
+	"github.com/grafana/grafana/pkg/plugins/repo"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/web/webtest"
+)
+
+func TestProxyGnetRequest_PluginIndex(t *testing.T) {
+	index := &repo.PluginIndex{
+		Plugins: []repo.IndexedPlugin{
+			{
+				ID:   "grafana-test-panel",
+				Name: "Test panel",
+				Type: "panel",
+				Versions: []repo.Version{
+					{Version: "2.0.0", GrafanaDependency: ">=11.0.0"},
+					{Version: "1.0.0", GrafanaDependency: ">=9.0.0", Arch: map[string]repo.ArchMeta{"any": {URL: "https://mirror.example.com/panel-1.0.0.zip"}}},
+				},
+			},
+		},
+	}
+
+	server := SetupAPITestServer(t, func(hs *HTTPServer) {
+		hs.Cfg = setting.NewCfg()
+		hs.Cfg.BuildVersion = "10.0.0"
+		hs.pluginRepo = &fakes.FakePluginRepo{
+			GetPluginIndexFunc: func(_ context.Context, _ repo.CompatOpts) (*repo.PluginIndex, error) {
+				return index, nil
+			},
+		}
+	})
+
+	get := func(t *testing.T, path string, expectedCode int, v any) {
+		t.Helper()
+
+		res, err := server.Send(webtest.RequestWithSignedInUser(server.NewGetRequest(path), userWithPermissions(1, nil)))
+		require.NoError(t, err)
+		require.Equal(t, expectedCode, res.StatusCode)
+		if v != nil {
+			require.NoError(t, json.NewDecoder(res.Body).Decode(v))
+		}
+		require.NoError(t, res.Body.Close())
+	}
+
+	t.Run("lists plugins with their latest compatible version", func(t *testing.T) {
+		var result struct {
+			Items []gnetPlugin `json:"items"`
+		}
+		get(t, "/api/gnet/plugins", http.StatusOK, &result)
+		require.Len(t, result.Items, 1)
+		require.Equal(t, "grafana-test-panel", result.Items[0].Slug)
+		require.Equal(t, "panel", result.Items[0].TypeCode)
+		require.Equal(t, "1.0.0", result.Items[0].Version)
+		require.Equal(t, "https://mirror.example.com/panel-1.0.0.zip", result.Items[0].Packages["any"].DownloadURL)
+	})
+
+	t.Run("returns a single plugin", func(t *testing.T) {
+		var result gnetPlugin
+		get(t, "/api/gnet/plugins/grafana-test-panel", http.StatusOK, &result)
+		require.Equal(t, "Test panel", result.Name)
+	})
+
+	t.Run("returns plugin versions with compatibility", func(t *testing.T) {
+		var result struct {
+			Items []gnetPluginVersion `json:"items"`
+		}
+		get(t, "/api/gnet/plugins/grafana-test-panel/versions", http.StatusOK, &result)
+		require.Equal(t, []gnetPluginVersion{
+			{Version: "2.0.0", GrafanaDependency: ">=11.0.0", IsCompatible: false},
+			{Version: "1.0.0", GrafanaDependency: ">=9.0.0", IsCompatible: true},
+		}, result.Items)
+	})
+
+	t.Run("returns not found for unknown plugins", func(t *testing.T) {
+		get(t, "/api/gnet/plugins/unknown", http.StatusNotFound, nil)
+	})
+}
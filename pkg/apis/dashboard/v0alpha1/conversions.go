@@ -0,0 +1,64 @@
+package v0alpha1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+)
+
+func convertToK8sResource(v *dashboards.Dashboard, namespacer grafanarequest.NamespaceMapper) (*Dashboard, error) {
+	spec := Unstructured{}
+	if v.Data != nil {
+		// The round trip makes sure every value is JSON compatible (required for deep copy)
+		body, err := v.Data.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(body, &spec.Object); err != nil {
+			return nil, err
+		}
+		delete(spec.Object, "id") // internal identifier
+	}
+
+	dash := &Dashboard{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Dashboard",
+			APIVersion: APIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              v.UID,
+			UID:               types.UID(v.UID),
+			ResourceVersion:   fmt.Sprintf("%d", v.Updated.UnixMilli()),
+			CreationTimestamp: metav1.NewTime(v.Created),
+			Namespace:         namespacer(v.OrgID),
+		},
+		Spec: spec,
+	}
+	if v.FolderUID != "" {
+		dash.Annotations = map[string]string{
+			AnnoKeyFolder: v.FolderUID,
+		}
+	}
+	return dash, nil
+}
+
+// convertToLegacyDashboard returns the legacy model with the uid and title taken from the resource
+func convertToLegacyDashboard(v *Dashboard) *dashboards.Dashboard {
+	data := simplejson.New()
+	if v.Spec.Object != nil {
+		data = simplejson.NewFromAny(runtime.DeepCopyJSON(v.Spec.Object))
+	}
+	data.Del("id")
+	data.Set("uid", v.Name)
+
+	dash := dashboards.NewDashboardFromJson(data)
+	dash.FolderUID = v.Annotations[AnnoKeyFolder]
+	return dash
+}
@@ -0,0 +1,70 @@
+package v0alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+)
+
+func TestDashboardConversion(t *testing.T) {
+	data := simplejson.NewFromAny(map[string]any{
+		"id":    123,
+		"uid":   "abc",
+		"title": "My dashboard",
+		"tags":  []string{"a", "b"},
+	})
+	data.Set("version", 4) // an int (not JSON compatible) set by the dashboard service
+	src := &dashboards.Dashboard{
+		ID:        123,
+		OrgID:     1,
+		UID:       "abc", // becomes k8s name
+		Title:     "My dashboard",
+		FolderUID: "folder",
+		Version:   4,
+		Created:   time.UnixMilli(12345),
+		Updated:   time.UnixMilli(54321),
+		Data:      data,
+	}
+	dst, err := convertToK8sResource(src, grafanarequest.OrgNamespaceFormatter)
+	require.NoError(t, err)
+	require.Equal(t, "abc", dst.Name)
+
+	// Must not panic
+	cpy := dst.DeepCopy()
+	require.Equal(t, dst, cpy)
+
+	out, err := json.MarshalIndent(dst, "", "  ")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"kind": "Dashboard",
+		"apiVersion": "dashboard.grafana.app/v0alpha1",
+		"metadata": {
+		  "name": "abc",
+		  "namespace": "default",
+		  "uid": "abc",
+		  "resourceVersion": "54321",
+		  "creationTimestamp": "1970-01-01T00:00:12Z",
+		  "annotations": {
+			"grafana.app/folder": "folder"
+		  }
+		},
+		"spec": {
+		  "uid": "abc",
+		  "title": "My dashboard",
+		  "tags": ["a", "b"],
+		  "version": 4
+		}
+	  }`, string(out))
+
+	legacy := convertToLegacyDashboard(dst)
+	require.Equal(t, "abc", legacy.UID)
+	require.Equal(t, "folder", legacy.FolderUID)
+	require.Equal(t, "My dashboard", legacy.Title)
+	require.Equal(t, int64(0), legacy.ID)
+}
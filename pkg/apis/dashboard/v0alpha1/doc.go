@@ -0,0 +1,5 @@
+// +k8s:deepcopy-gen=package
+// +k8s:openapi-gen=true
+// +groupName=dashboard.grafana.app
+
+package v0alpha1 // import "github.com/grafana/grafana/pkg/apis/dashboard/v0alpha1"
@@ -0,0 +1,283 @@
+package v0alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/grafana/grafana/pkg/infra/appcontext"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/sqlstore/searchstore"
+)
+
+var (
+	_ rest.Scoper               = (*legacyStorage)(nil)
+	_ rest.SingularNameProvider = (*legacyStorage)(nil)
+	_ rest.Getter               = (*legacyStorage)(nil)
+	_ rest.Lister               = (*legacyStorage)(nil)
+	_ rest.Storage              = (*legacyStorage)(nil)
+	_ rest.Creater              = (*legacyStorage)(nil)
+	_ rest.Updater              = (*legacyStorage)(nil)
+	_ rest.GracefulDeleter      = (*legacyStorage)(nil)
+	_ rest.Watcher              = (*legacyStorage)(nil)
+)
+
+type legacyStorage struct {
+	service       dashboards.DashboardService
+	folderService folder.Service
+	namespacer    grafanarequest.NamespaceMapper
+}
+
+func (s *legacyStorage) New() runtime.Object {
+	return &Dashboard{}
+}
+
+func (s *legacyStorage) Destroy() {}
+
+func (s *legacyStorage) NamespaceScoped() bool {
+	return true // namespace == org
+}
+
+func (s *legacyStorage) GetSingularName() string {
+	return "dashboard"
+}
+
+func (s *legacyStorage) NewList() runtime.Object {
+	return &DashboardList{}
+}
+
+func (s *legacyStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return rest.NewDefaultTableConvertor(Resource("dashboards")).ConvertToTable(ctx, object, tableOptions)
+}
+
+func (s *legacyStorage) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(100)
+	if options.Limit > 0 {
+		limit = options.Limit
+	}
+	// the continue token is the number of the next page of the search
+	page := int64(1)
+	if options.Continue != "" {
+		page, err = strconv.ParseInt(options.Continue, 10, 64)
+		if err != nil || page < 1 {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid continue token %q", options.Continue))
+		}
+	}
+	hits, err := s.service.FindDashboards(ctx, &dashboards.FindPersistedDashboardsQuery{
+		OrgId:        info.OrgID,
+		SignedInUser: user,
+		Type:         searchstore.TypeDashboard,
+		Limit:        limit,
+		Page:         page,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := &DashboardList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DashboardList",
+			APIVersion: APIVersion,
+		},
+	}
+	if len(hits) == 0 {
+		return list, nil
+	}
+
+	uids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		uids = append(uids, hit.UID)
+	}
+	res, err := s.service.GetDashboards(ctx, &dashboards.GetDashboardsQuery{
+		OrgID:         info.OrgID,
+		DashboardUIDs: uids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var rv int64
+	for _, v := range res {
+		dash, err := convertToK8sResource(v, s.namespacer)
+		if err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, *dash)
+		if v.Updated.UnixMilli() > rv {
+			rv = v.Updated.UnixMilli()
+		}
+	}
+	list.ResourceVersion = fmt.Sprintf("%d", rv)
+	if int64(len(hits)) == limit {
+		list.Continue = strconv.FormatInt(page+1, 10)
+	}
+	return list, nil
+}
+
+// Watch is only supported by the unified storage. The legacy storage has no
+// resource versions to watch from, so it is used on its own only when the
+// dual writer is disabled.
+func (s *legacyStorage) Watch(ctx context.Context, options *internalversion.ListOptions) (watch.Interface, error) {
+	return nil, &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotImplemented,
+		Reason:  metav1.StatusReasonMethodNotAllowed,
+		Message: "watching dashboards is not implemented without the unified storage",
+		Details: &metav1.StatusDetails{Group: GroupName, Kind: "dashboards"},
+	}}
+}
+
+func (s *legacyStorage) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	dash, err := s.getDashboard(ctx, name, guardian.DashboardGuardian.CanView)
+	if err != nil {
+		return nil, err
+	}
+	return convertToK8sResource(dash, s.namespacer)
+}
+
+// getDashboard returns the dashboard with the UID if the dashboard guardian allows the access checked by canAccess.
+// Folders are not dashboards, so they are not found.
+func (s *legacyStorage) getDashboard(ctx context.Context, name string, canAccess func(guardian.DashboardGuardian) (bool, error)) (*dashboards.Dashboard, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dash, err := s.service.GetDashboard(ctx, &dashboards.GetDashboardQuery{
+		UID:   name,
+		OrgID: info.OrgID,
+	})
+	if err != nil {
+		if errors.Is(err, dashboards.ErrDashboardNotFound) {
+			return nil, apierrors.NewNotFound(Resource("dashboards"), name)
+		}
+		return nil, err
+	}
+	if dash.IsFolder {
+		return nil, apierrors.NewNotFound(Resource("dashboards"), name)
+	}
+
+	g, err := guardian.NewByDashboard(ctx, dash, info.OrgID, user)
+	if err != nil {
+		return nil, err
+	}
+	ok, err := canAccess(g)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, apierrors.NewForbidden(Resource("dashboards"), name, errors.New("access denied to dashboard"))
+	}
+	return dash, nil
+}
+
+func (s *legacyStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	p, ok := obj.(*Dashboard)
+	if !ok {
+		return nil, fmt.Errorf("expected dashboard?")
+	}
+	return s.save(ctx, p, false)
+}
+
+func (s *legacyStorage) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	dash, err := s.getDashboard(ctx, name, guardian.DashboardGuardian.CanSave)
+	if err != nil {
+		return nil, false, err
+	}
+	old, err := convertToK8sResource(dash, s.namespacer)
+	if err != nil {
+		return nil, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return old, false, err
+	}
+	p, ok := obj.(*Dashboard)
+	if !ok {
+		return nil, false, fmt.Errorf("expected dashboard after update")
+	}
+	p.Name = name
+
+	out, err := s.save(ctx, p, true)
+	return out, false, err
+}
+
+func (s *legacyStorage) save(ctx context.Context, p *Dashboard, overwrite bool) (runtime.Object, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dash := convertToLegacyDashboard(p)
+	if dash.FolderUID != "" {
+		f, err := s.folderService.Get(ctx, &folder.GetFolderQuery{
+			UID:          &dash.FolderUID,
+			OrgID:        info.OrgID,
+			SignedInUser: user,
+		})
+		if err != nil {
+			return nil, err
+		}
+		dash.FolderID = f.ID
+	}
+
+	out, err := s.service.SaveDashboard(ctx, &dashboards.SaveDashboardDTO{
+		OrgID:     info.OrgID,
+		User:      user,
+		Overwrite: overwrite,
+		Dashboard: dash,
+	}, false)
+	if err != nil {
+		return nil, err
+	}
+	return convertToK8sResource(out, s.namespacer)
+}
+
+// GracefulDeleter
+func (s *legacyStorage) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, false, err
+	}
+
+	dash, err := s.getDashboard(ctx, name, guardian.DashboardGuardian.CanDelete)
+	if err != nil {
+		return nil, false, err
+	}
+	v, err := convertToK8sResource(dash, s.namespacer)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = s.service.DeleteDashboard(ctx, dash.ID, info.OrgID)
+	return v, true, err // true is instant delete
+}
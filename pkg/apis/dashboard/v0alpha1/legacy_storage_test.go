@@ -0,0 +1,111 @@
+package v0alpha1
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apiserver/pkg/endpoints/request"
+
+	"github.com/grafana/grafana/pkg/components/simplejson"
+	"github.com/grafana/grafana/pkg/infra/appcontext"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+	"github.com/grafana/grafana/pkg/services/guardian"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestLegacyStorageAuthorization(t *testing.T) {
+	ctx := appcontext.WithUser(request.WithNamespace(context.Background(), "default"), &user.SignedInUser{OrgID: 1})
+	dash := &dashboards.Dashboard{ID: 1, OrgID: 1, UID: "dash", Title: "Dashboard", Data: simplejson.New()}
+	folder := &dashboards.Dashboard{ID: 2, OrgID: 1, UID: "folder", Title: "Folder", IsFolder: true, Data: simplejson.New()}
+
+	setup := func(t *testing.T, g *guardian.FakeDashboardGuardian) (*legacyStorage, *dashboards.FakeDashboardService) {
+		service := dashboards.NewFakeDashboardService(t)
+		service.On("GetDashboard", mock.Anything, mock.Anything).Return(func(_ context.Context, q *dashboards.GetDashboardQuery) (*dashboards.Dashboard, error) {
+			if q.UID == folder.UID {
+				return folder, nil
+			}
+			return dash, nil
+		}).Maybe()
+		guardian.MockDashboardGuardian(g)
+		return &legacyStorage{service: service, namespacer: grafanarequest.GetNamespaceMapper(nil)}, service
+	}
+
+	t.Run("should not get a folder", func(t *testing.T) {
+		s, _ := setup(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		_, err := s.Get(ctx, folder.UID, nil)
+		require.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("should not delete a folder", func(t *testing.T) {
+		s, service := setup(t, &guardian.FakeDashboardGuardian{CanSaveValue: true})
+		_, _, err := s.Delete(ctx, folder.UID, nil, nil)
+		require.True(t, apierrors.IsNotFound(err))
+		service.AssertNotCalled(t, "DeleteDashboard", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should return forbidden if the user cannot view the dashboard", func(t *testing.T) {
+		s, _ := setup(t, &guardian.FakeDashboardGuardian{})
+		_, err := s.Get(ctx, dash.UID, nil)
+		require.True(t, apierrors.IsForbidden(err))
+	})
+
+	t.Run("should return forbidden if the user cannot delete the dashboard", func(t *testing.T) {
+		s, service := setup(t, &guardian.FakeDashboardGuardian{CanViewValue: true})
+		_, _, err := s.Delete(ctx, dash.UID, nil, nil)
+		require.True(t, apierrors.IsForbidden(err))
+		service.AssertNotCalled(t, "DeleteDashboard", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should delete the dashboard if the user can delete it", func(t *testing.T) {
+		s, service := setup(t, &guardian.FakeDashboardGuardian{CanSaveValue: true})
+		service.On("DeleteDashboard", mock.Anything, dash.ID, int64(1)).Return(nil)
+		_, _, err := s.Delete(ctx, dash.UID, nil, nil)
+		require.NoError(t, err)
+	})
+}
+
+func TestLegacyStorageList(t *testing.T) {
+	ctx := appcontext.WithUser(request.WithNamespace(context.Background(), "default"), &user.SignedInUser{OrgID: 1})
+	dash := &dashboards.Dashboard{ID: 1, OrgID: 1, UID: "dash", Title: "Dashboard", Data: simplejson.New()}
+
+	setup := func(t *testing.T, page int64) *legacyStorage {
+		service := dashboards.NewFakeDashboardService(t)
+		service.On("FindDashboards", mock.Anything, mock.MatchedBy(func(q *dashboards.FindPersistedDashboardsQuery) bool {
+			return q.Limit == 1 && q.Page == page
+		})).Return([]dashboards.DashboardSearchProjection{{UID: dash.UID}}, nil)
+		service.On("GetDashboards", mock.Anything, mock.Anything).Return([]*dashboards.Dashboard{dash}, nil)
+		return &legacyStorage{service: service, namespacer: grafanarequest.GetNamespaceMapper(nil)}
+	}
+
+	t.Run("should return the next page as continue token", func(t *testing.T) {
+		obj, err := setup(t, 1).List(ctx, &internalversion.ListOptions{Limit: 1})
+		require.NoError(t, err)
+		require.Equal(t, "2", obj.(*DashboardList).Continue)
+
+		obj, err = setup(t, 2).List(ctx, &internalversion.ListOptions{Limit: 1, Continue: "2"})
+		require.NoError(t, err)
+		require.Equal(t, "3", obj.(*DashboardList).Continue)
+	})
+
+	t.Run("should reject an invalid continue token", func(t *testing.T) {
+		s := &legacyStorage{service: dashboards.NewFakeDashboardService(t), namespacer: grafanarequest.GetNamespaceMapper(nil)}
+		for _, token := range []string{"<more>", "0"} {
+			_, err := s.List(ctx, &internalversion.ListOptions{Limit: 1, Continue: token})
+			require.True(t, apierrors.IsBadRequest(err))
+		}
+	})
+
+	t.Run("should not implement watch", func(t *testing.T) {
+		s := &legacyStorage{}
+		_, err := s.Watch(ctx, &internalversion.ListOptions{})
+		var status apierrors.APIStatus
+		require.ErrorAs(t, err, &status)
+		require.Equal(t, int32(http.StatusNotImplemented), status.Status().Code)
+	})
+}
@@ -0,0 +1,34 @@
+package v0alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GroupName is the group name for this API.
+const GroupName = "dashboard.grafana.app"
+const VersionID = "v0alpha1" //
+const APIVersion = GroupName + "/" + VersionID
+
+// AnnoKeyFolder is the annotation that holds the UID of the folder containing the dashboard
+const AnnoKeyFolder = "grafana.app/folder"
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Dashboard struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// The dashboard body (unstructured for now)
+	Spec Unstructured `json:"spec,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type DashboardList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Dashboard `json:"items,omitempty"`
+}
@@ -0,0 +1,42 @@
+package v0alpha1
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Unstructured allows objects that do not have Golang structs registered to be manipulated
+// generically.  The dashboard schema is validated by the dashboard kind, not by the apiserver
+// +k8s:deepcopy-gen=false
+type Unstructured struct {
+	// Object is a JSON compatible map with string, float, int, bool, []interface{},
+	// or map[string]interface{} children.
+	Object map[string]interface{}
+}
+
+// MarshalJSON ensures that the unstructured object produces proper
+// JSON when passed to Go's standard JSON library.
+func (u Unstructured) MarshalJSON() ([]byte, error) {
+	return json.Marshal(u.Object)
+}
+
+// UnmarshalJSON ensures that the unstructured object properly decodes
+// JSON when passed to Go's standard JSON library.
+func (u *Unstructured) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &u.Object)
+}
+
+func (u *Unstructured) DeepCopy() *Unstructured {
+	if u == nil {
+		return nil
+	}
+	out := new(Unstructured)
+	u.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto expects the values to be JSON compatible (see runtime.DeepCopyJSONValue)
+func (u *Unstructured) DeepCopyInto(out *Unstructured) {
+	out.Object = runtime.DeepCopyJSON(u.Object)
+}
@@ -0,0 +1,37 @@
+package v0alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/grafana/grafana/pkg/services/folder"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+)
+
+func convertToK8sResource(v *folder.Folder, namespacer grafanarequest.NamespaceMapper) *Folder {
+	f := &Folder{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Folder",
+			APIVersion: APIVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              v.UID,
+			UID:               types.UID(v.UID),
+			ResourceVersion:   fmt.Sprintf("%d", v.Updated.UnixMilli()),
+			CreationTimestamp: metav1.NewTime(v.Created),
+			Namespace:         namespacer(v.OrgID),
+		},
+		Spec: Spec{
+			Title:       v.Title,
+			Description: v.Description,
+		},
+	}
+	if v.ParentUID != "" {
+		f.Annotations = map[string]string{
+			AnnoKeyParent: v.ParentUID,
+		}
+	}
+	return f
+}
@@ -0,0 +1,50 @@
+package v0alpha1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/folder"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+)
+
+func TestFolderConversion(t *testing.T) {
+	src := &folder.Folder{
+		ID:          10,
+		OrgID:       3,
+		UID:         "abc", // becomes k8s name
+		ParentUID:   "parent",
+		Title:       "My folder",
+		Description: "something",
+		Created:     time.UnixMilli(12345),
+		Updated:     time.UnixMilli(54321),
+	}
+	dst := convertToK8sResource(src, grafanarequest.OrgNamespaceFormatter)
+
+	require.Equal(t, "abc", dst.Name)
+	require.Equal(t, src.Title, dst.Spec.Title)
+
+	out, err := json.MarshalIndent(dst, "", "  ")
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"kind": "Folder",
+		"apiVersion": "folder.grafana.app/v0alpha1",
+		"metadata": {
+		  "name": "abc",
+		  "namespace": "org-3",
+		  "uid": "abc",
+		  "resourceVersion": "54321",
+		  "creationTimestamp": "1970-01-01T00:00:12Z",
+		  "annotations": {
+			"grafana.app/folder": "parent"
+		  }
+		},
+		"spec": {
+		  "title": "My folder",
+		  "description": "something"
+		}
+	  }`, string(out))
+}
@@ -0,0 +1,228 @@
+package v0alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/grafana/grafana/pkg/infra/appcontext"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/folder"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+)
+
+var (
+	_ rest.Scoper               = (*legacyStorage)(nil)
+	_ rest.SingularNameProvider = (*legacyStorage)(nil)
+	_ rest.Getter               = (*legacyStorage)(nil)
+	_ rest.Lister               = (*legacyStorage)(nil)
+	_ rest.Storage              = (*legacyStorage)(nil)
+	_ rest.Creater              = (*legacyStorage)(nil)
+	_ rest.Updater              = (*legacyStorage)(nil)
+	_ rest.GracefulDeleter      = (*legacyStorage)(nil)
+)
+
+type legacyStorage struct {
+	service    folder.Service
+	namespacer grafanarequest.NamespaceMapper
+}
+
+func (s *legacyStorage) New() runtime.Object {
+	return &Folder{}
+}
+
+func (s *legacyStorage) Destroy() {}
+
+func (s *legacyStorage) NamespaceScoped() bool {
+	return true // namespace == org
+}
+
+func (s *legacyStorage) GetSingularName() string {
+	return "folder"
+}
+
+func (s *legacyStorage) NewList() runtime.Object {
+	return &FolderList{}
+}
+
+func (s *legacyStorage) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return rest.NewDefaultTableConvertor(Resource("folders")).ConvertToTable(ctx, object, tableOptions)
+}
+
+// List returns the folders at the root level of the namespace
+func (s *legacyStorage) List(ctx context.Context, options *internalversion.ListOptions) (runtime.Object, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(100)
+	if options.Limit > 0 {
+		limit = options.Limit
+	}
+	res, err := s.service.GetChildren(ctx, &folder.GetChildrenQuery{
+		OrgID:        info.OrgID,
+		Limit:        limit,
+		SignedInUser: user,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list := &FolderList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "FolderList",
+			APIVersion: APIVersion,
+		},
+	}
+	var rv int64
+	for _, v := range res {
+		list.Items = append(list.Items, *convertToK8sResource(v, s.namespacer))
+		if v.Updated.UnixMilli() > rv {
+			rv = v.Updated.UnixMilli()
+		}
+	}
+	list.ResourceVersion = fmt.Sprintf("%d", rv)
+	if int64(len(list.Items)) == limit {
+		list.Continue = "<more>" // TODO?
+	}
+	return list, nil
+}
+
+func (s *legacyStorage) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := s.service.Get(ctx, &folder.GetFolderQuery{
+		UID:          &name,
+		OrgID:        info.OrgID,
+		SignedInUser: user,
+	})
+	if err != nil {
+		if isNotFound(err) {
+			return nil, apierrors.NewNotFound(Resource("folders"), name)
+		}
+		return nil, err
+	}
+	return convertToK8sResource(f, s.namespacer), nil
+}
+
+func (s *legacyStorage) Create(ctx context.Context, obj runtime.Object, createValidation rest.ValidateObjectFunc, options *metav1.CreateOptions) (runtime.Object, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := obj.(*Folder)
+	if !ok {
+		return nil, fmt.Errorf("expected folder?")
+	}
+	f, err := s.service.Create(ctx, &folder.CreateFolderCommand{
+		UID:          p.Name,
+		OrgID:        info.OrgID,
+		Title:        p.Spec.Title,
+		Description:  p.Spec.Description,
+		ParentUID:    p.Annotations[AnnoKeyParent],
+		SignedInUser: user,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return convertToK8sResource(f, s.namespacer), nil
+}
+
+func (s *legacyStorage) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, false, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	old, err := s.Get(ctx, name, nil)
+	if err != nil {
+		return old, false, err
+	}
+	obj, err := objInfo.UpdatedObject(ctx, old)
+	if err != nil {
+		return old, false, err
+	}
+	p, ok := obj.(*Folder)
+	if !ok {
+		return nil, false, fmt.Errorf("expected folder after update")
+	}
+
+	f, err := s.service.Update(ctx, &folder.UpdateFolderCommand{
+		UID:            name,
+		OrgID:          info.OrgID,
+		NewTitle:       &p.Spec.Title,
+		NewDescription: &p.Spec.Description,
+		Overwrite:      true,
+		SignedInUser:   user,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if parent := p.Annotations[AnnoKeyParent]; parent != old.(*Folder).Annotations[AnnoKeyParent] {
+		f, err = s.service.Move(ctx, &folder.MoveFolderCommand{
+			UID:          name,
+			OrgID:        info.OrgID,
+			NewParentUID: parent,
+			SignedInUser: user,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	return convertToK8sResource(f, s.namespacer), false, nil
+}
+
+// GracefulDeleter
+func (s *legacyStorage) Delete(ctx context.Context, name string, deleteValidation rest.ValidateObjectFunc, options *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	v, err := s.Get(ctx, name, &metav1.GetOptions{})
+	if err != nil {
+		return v, false, err // includes the not-found error
+	}
+	info, err := grafanarequest.NamespaceInfoFrom(ctx, true)
+	if err != nil {
+		return nil, false, err
+	}
+	user, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	err = s.service.Delete(ctx, &folder.DeleteFolderCommand{
+		UID:          name,
+		OrgID:        info.OrgID,
+		SignedInUser: user,
+	})
+	return v, true, err // true is instant delete
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, folder.ErrFolderNotFound) || errors.Is(err, dashboards.ErrFolderNotFound)
+}
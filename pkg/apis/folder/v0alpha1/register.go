@@ -0,0 +1,118 @@
+package v0alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/registry/rest"
+	genericapiserver "k8s.io/apiserver/pkg/server"
+	common "k8s.io/kube-openapi/pkg/common"
+
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/folder"
+	grafanaapiserver "github.com/grafana/grafana/pkg/services/grafana-apiserver"
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
+	grafanarest "github.com/grafana/grafana/pkg/services/grafana-apiserver/rest"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+var _ grafanaapiserver.APIGroupBuilder = (*FolderAPIBuilder)(nil)
+
+// This is used just so wire has something unique to return
+type FolderAPIBuilder struct {
+	service    folder.Service
+	namespacer grafanarequest.NamespaceMapper
+}
+
+func RegisterAPIService(cfg *setting.Cfg,
+	features featuremgmt.FeatureToggles,
+	apiregistration grafanaapiserver.APIRegistrar,
+	service folder.Service,
+) *FolderAPIBuilder {
+	if !features.IsEnabled(featuremgmt.FlagGrafanaAPIServerWithExperimentalAPIs) {
+		return nil // skip registration unless opting into experimental apis
+	}
+
+	builder := &FolderAPIBuilder{
+		service:    service,
+		namespacer: grafanarequest.GetNamespaceMapper(cfg),
+	}
+	apiregistration.RegisterAPI(builder)
+	return builder
+}
+
+func (b *FolderAPIBuilder) GetGroupVersion() schema.GroupVersion {
+	return SchemeGroupVersion
+}
+
+func (b *FolderAPIBuilder) InstallSchema(scheme *runtime.Scheme) error {
+	err := AddToScheme(scheme)
+	if err != nil {
+		return err
+	}
+	return scheme.SetVersionPriority(SchemeGroupVersion)
+}
+
+func (b *FolderAPIBuilder) GetAPIGroupInfo(
+	scheme *runtime.Scheme,
+	codecs serializer.CodecFactory, // pointer?
+	optsGetter generic.RESTOptionsGetter,
+) (*genericapiserver.APIGroupInfo, error) {
+	apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(GroupName, scheme, metav1.ParameterCodec, codecs)
+	storage := map[string]rest.Storage{}
+
+	legacyStore := &legacyStorage{
+		service:    b.service,
+		namespacer: b.namespacer,
+	}
+	storage["folders"] = legacyStore
+
+	// enable dual writes if a RESTOptionsGetter is provided
+	// the unified storage supports list+watch with a resourceVersion
+	if optsGetter != nil {
+		store, err := newStorage(scheme, optsGetter)
+		if err != nil {
+			return nil, err
+		}
+		storage["folders"] = grafanarest.NewDualWriter(legacyStore, store)
+	}
+
+	apiGroupInfo.VersionedResourcesStorageMap[VersionID] = storage
+	return &apiGroupInfo, nil
+}
+
+func (b *FolderAPIBuilder) GetOpenAPIDefinitions() common.GetOpenAPIDefinitions {
+	return getOpenAPIDefinitions
+}
+
+func (b *FolderAPIBuilder) GetAPIRoutes() *grafanaapiserver.APIRoutes {
+	return nil // no custom API routes
+}
+
+// SchemeGroupVersion is group version used to register these objects
+var SchemeGroupVersion = schema.GroupVersion{Group: GroupName, Version: VersionID}
+
+// Resource takes an unqualified resource and returns a Group qualified GroupResource
+func Resource(resource string) schema.GroupResource {
+	return SchemeGroupVersion.WithResource(resource).GroupResource()
+}
+
+var (
+	// SchemeBuilder points to a list of functions added to Scheme.
+	SchemeBuilder      = runtime.NewSchemeBuilder(addKnownTypes)
+	localSchemeBuilder = &SchemeBuilder
+	// AddToScheme is a common registration function for mapping packaged scoped group & version keys to a scheme.
+	AddToScheme = localSchemeBuilder.AddToScheme
+)
+
+// Adds the list of known types to the given scheme.
+func addKnownTypes(scheme *runtime.Scheme) error {
+	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Folder{},
+		&FolderList{},
+	)
+	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
+	return nil
+}
@@ -0,0 +1,42 @@
+package v0alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GroupName is the group name for this API.
+const GroupName = "folder.grafana.app"
+const VersionID = "v0alpha1" //
+const APIVersion = GroupName + "/" + VersionID
+
+// AnnoKeyParent is the annotation that holds the UID of the parent folder (nested folders)
+const AnnoKeyParent = "grafana.app/folder"
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type Folder struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata
+	// More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec Spec `json:"spec,omitempty"`
+}
+
+// Spec defines model for Spec.
+type Spec struct {
+	// The folder title
+	Title string `json:"title"`
+
+	// An optional description for the folder
+	Description string `json:"description,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FolderList struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []Folder `json:"items,omitempty"`
+}
@@ -7,25 +7,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 
+	grafanarequest "github.com/grafana/grafana/pkg/services/grafana-apiserver/endpoints/request"
 	"github.com/grafana/grafana/pkg/services/playlist"
-	"github.com/grafana/grafana/pkg/setting"
 )
 
-type namespaceMapper = func(orgId int64) string
+type namespaceMapper = grafanarequest.NamespaceMapper
 
-func orgNamespaceMapper(orgId int64) string {
-	if orgId == 1 {
-		return "default"
-	}
-	return fmt.Sprintf("org-%d", orgId)
-}
-
-func getNamespaceMapper(cfg *setting.Cfg) namespaceMapper {
-	if cfg.StackID != "" {
-		return func(orgId int64) string { return "stack-" + cfg.StackID }
-	}
-	return orgNamespaceMapper
-}
+var (
+	orgNamespaceMapper = grafanarequest.OrgNamespaceFormatter
+	getNamespaceMapper = grafanarequest.GetNamespaceMapper
+)
 
 func convertToK8sResource(v *playlist.PlaylistDTO, namespacer namespaceMapper) *Playlist {
 	spec := Spec{
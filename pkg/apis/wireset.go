@@ -3,7 +3,9 @@ package apis
 import (
 	"github.com/google/wire"
 
+	dashboardv0alpha1 "github.com/grafana/grafana/pkg/apis/dashboard/v0alpha1"
 	examplev0alpha1 "github.com/grafana/grafana/pkg/apis/example/v0alpha1"
+	folderv0alpha1 "github.com/grafana/grafana/pkg/apis/folder/v0alpha1"
 	playlistsv0alpha1 "github.com/grafana/grafana/pkg/apis/playlist/v0alpha1"
 )
 
@@ -12,4 +14,6 @@ import (
 var WireSet = wire.NewSet(
 	playlistsv0alpha1.RegisterAPIService,
 	examplev0alpha1.RegisterAPIService,
+	dashboardv0alpha1.RegisterAPIService,
+	folderv0alpha1.RegisterAPIService,
 )
@@ -225,6 +225,39 @@ grafana-cli user-manager conflicts ingest-file <filepath>
 	},
 }
 
+var alertingCommands = []*cli.Command{
+	{
+		Name:      "import-prometheus-rules",
+		Usage:     "Converts the rule groups of a Prometheus rule file to Grafana-managed alert rules",
+		ArgsUsage: "<path to the Prometheus rule file>",
+		Action:    runPluginCommand(importPrometheusRulesCommand),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "url",
+				Usage: "URL of the Grafana server",
+				Value: "http://localhost:3000",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "Service account token used to authenticate to the Grafana server",
+				EnvVars: []string{"GF_TOKEN"},
+			},
+			&cli.StringFlag{
+				Name:  "datasource-uid",
+				Usage: "UID of the Prometheus data source that the imported rules query",
+			},
+			&cli.StringFlag{
+				Name:  "folder",
+				Usage: "Title of the folder the rule groups are created in",
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Report the incompatibilities without creating the rule groups",
+			},
+		},
+	},
+}
+
 var Commands = []*cli.Command{
 	{
 		Name:        "plugins",
@@ -236,4 +269,9 @@ var Commands = []*cli.Command{
 		Usage:       "Grafana admin commands",
 		Subcommands: adminCommands,
 	},
+	{
+		Name:        "alerting",
+		Usage:       "Grafana Alerting commands",
+		Subcommands: alertingCommands,
+	},
 }
@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/logger"
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+const importPrometheusRulesPath = "/api/ruler/grafana/api/v1/import/prometheus"
+
+var errBlockingIncompatibilities = errors.New("the rules cannot be imported because of blocking incompatibilities")
+
+// importPrometheusRulesCommand sends the rule groups of a Prometheus rule file to the import endpoint of a Grafana
+// server, and prints the incompatibilities between the Prometheus rules and the Grafana-managed rules.
+func importPrometheusRulesCommand(c utils.CommandLine) error {
+	path := c.Args().First()
+	if path == "" {
+		return errors.New("missing path to the Prometheus rule file")
+	}
+	for _, flag := range []string{"url", "datasource-uid", "folder"} {
+		if c.String(flag) == "" {
+			return fmt.Errorf("missing --%s flag", flag)
+		}
+	}
+
+	file, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the Prometheus rule file: %w", err)
+	}
+	var rules apimodels.PrometheusRulesImport
+	if err := yaml.Unmarshal(file, &rules); err != nil {
+		return fmt.Errorf("failed to parse the Prometheus rule file: %w", err)
+	}
+	rules.DatasourceUID = c.String("datasource-uid")
+	body, err := json.Marshal(rules)
+	if err != nil {
+		return err
+	}
+
+	u, err := url.Parse(c.String("url"))
+	if err != nil {
+		return fmt.Errorf("invalid --url flag: %w", err)
+	}
+	u = u.JoinPath(importPrometheusRulesPath, url.PathEscape(c.String("folder")))
+	dryRun := c.Bool("dry-run")
+	if dryRun {
+		u.RawQuery = url.Values{"dryRun": {"true"}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := c.String("token"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send the rules to Grafana: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var result apimodels.PrometheusRulesImportResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return fmt.Errorf("failed to import the rules, Grafana returned %s: %s", resp.Status, respBody)
+	}
+	// bad requests only have a report if the rules have blocking incompatibilities
+	blocked := resp.StatusCode == http.StatusBadRequest && len(result.Incompatibilities) > 0
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted && !blocked {
+		return fmt.Errorf("failed to import the rules, Grafana returned %s: %s", resp.Status, respBody)
+	}
+
+	printPrometheusRulesImport(result)
+	if blocked {
+		return errBlockingIncompatibilities
+	}
+	if dryRun {
+		logger.Infof("Dry run: %d rule groups can be imported to the folder %q\n", len(result.Groups), c.String("folder"))
+	} else {
+		logger.Infof("Imported %d rule groups to the folder %q\n", len(result.Groups), c.String("folder"))
+	}
+	return nil
+}
+
+func printPrometheusRulesImport(result apimodels.PrometheusRulesImportResponse) {
+	for _, incompatibility := range result.Incompatibilities {
+		name := incompatibility.Group
+		if incompatibility.Rule != "" {
+			name += "/" + incompatibility.Rule
+		}
+		if incompatibility.Blocking {
+			logger.Errorf("error: %s: %s\n", name, incompatibility.Message)
+		} else {
+			logger.Warnf("warning: %s: %s\n", name, incompatibility.Message)
+		}
+	}
+}
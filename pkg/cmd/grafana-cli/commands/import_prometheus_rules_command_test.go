@@ -0,0 +1,108 @@
+package commands
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+const prometheusRuleFile = `
+groups:
+  - name: node
+    interval: 1m
+    rules:
+      - alert: InstanceDown
+        expr: up == 0
+        for: 5m
+        labels:
+          severity: critical
+        annotations:
+          summary: "Instance {{ $labels.instance }} is down"
+      - record: job:up:sum
+        expr: sum by (job) (up)
+`
+
+func newImportPrometheusRulesCommandLine(path string, flags map[string]string, dryRun bool) *utils.MockCommandLine {
+	mockArgs := &utils.MockArgs{}
+	mockArgs.On("First").Return(path)
+	mockCmdLine := &utils.MockCommandLine{}
+	mockCmdLine.On("Args").Return(mockArgs)
+	mockCmdLine.On("String", mock.Anything).Return(func(name string) string { return flags[name] })
+	mockCmdLine.On("Bool", "dry-run").Return(dryRun)
+	return mockCmdLine
+}
+
+func TestImportPrometheusRulesCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.yml")
+	require.NoError(t, os.WriteFile(path, []byte(prometheusRuleFile), 0600))
+
+	newServer := func(t *testing.T, status int, result apimodels.PrometheusRulesImportResponse) (*httptest.Server, *http.Request, *apimodels.PrometheusRulesImport) {
+		var received http.Request
+		var body apimodels.PrometheusRulesImport
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = *r
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			require.NoError(t, json.NewEncoder(w).Encode(result))
+		}))
+		t.Cleanup(srv.Close)
+		return srv, &received, &body
+	}
+
+	t.Run("should send the rule groups to the import endpoint", func(t *testing.T) {
+		srv, received, body := newServer(t, http.StatusAccepted, apimodels.PrometheusRulesImportResponse{})
+		c := newImportPrometheusRulesCommandLine(path, map[string]string{
+			"url":            srv.URL,
+			"token":          "secret",
+			"datasource-uid": "prometheus",
+			"folder":         "Prometheus rules",
+		}, true)
+
+		require.NoError(t, importPrometheusRulesCommand(c))
+
+		require.Equal(t, http.MethodPost, received.Method)
+		require.Equal(t, "/api/ruler/grafana/api/v1/import/prometheus/Prometheus%20rules", received.URL.EscapedPath())
+		require.Equal(t, "true", received.URL.Query().Get("dryRun"))
+		require.Equal(t, "Bearer secret", received.Header.Get("Authorization"))
+
+		require.Equal(t, "prometheus", body.DatasourceUID)
+		require.Len(t, body.Groups, 1)
+		require.Equal(t, "node", body.Groups[0].Name)
+		require.Len(t, body.Groups[0].Rules, 2)
+		require.Equal(t, "InstanceDown", body.Groups[0].Rules[0].Alert)
+		require.Equal(t, "critical", body.Groups[0].Rules[0].Labels["severity"])
+		require.Equal(t, "job:up:sum", body.Groups[0].Rules[1].Record)
+	})
+
+	t.Run("should return an error if the rules have blocking incompatibilities", func(t *testing.T) {
+		srv, _, _ := newServer(t, http.StatusBadRequest, apimodels.PrometheusRulesImportResponse{
+			Incompatibilities: []apimodels.PrometheusRuleIncompatibility{{Group: "node", Message: "the folder already has a rule group with this name", Blocking: true}},
+		})
+		c := newImportPrometheusRulesCommandLine(path, map[string]string{
+			"url":            srv.URL,
+			"datasource-uid": "prometheus",
+			"folder":         "Prometheus rules",
+		}, false)
+
+		require.ErrorIs(t, importPrometheusRulesCommand(c), errBlockingIncompatibilities)
+	})
+
+	t.Run("should return an error if a flag is missing", func(t *testing.T) {
+		c := newImportPrometheusRulesCommandLine(path, map[string]string{
+			"url":    "http://localhost:3000",
+			"folder": "Prometheus rules",
+		}, false)
+
+		require.EqualError(t, importPrometheusRulesCommand(c), "missing --datasource-uid flag")
+	})
+}
@@ -96,6 +96,7 @@ func installPlugin(ctx context.Context, pluginID, version string, c utils.Comman
 	repository := repo.NewManager(repo.ManagerCfg{
 		SkipTLSVerify: c.Bool("insecure"),
 		BaseURL:       c.PluginRepoURL(),
+		IndexURL:      c.PluginRepoIndexURL(),
 		Logger:        services.Logger,
 	})
 
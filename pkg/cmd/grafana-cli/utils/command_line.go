@@ -20,6 +20,7 @@ type CommandLine interface {
 
 	PluginDirectory() string
 	PluginRepoURL() string
+	PluginRepoIndexURL() string
 	PluginURL() string
 }
 
@@ -56,6 +57,10 @@ func (c *ContextCommandLine) PluginRepoURL() string {
 	return c.String("repo")
 }
 
+func (c *ContextCommandLine) PluginRepoIndexURL() string {
+	return c.String("repoIndex")
+}
+
 func (c *ContextCommandLine) PluginURL() string {
 	return c.String("pluginUrl")
 }
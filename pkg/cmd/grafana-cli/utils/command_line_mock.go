@@ -132,6 +132,20 @@ func (_m *MockCommandLine) PluginRepoURL() string {
 	return r0
 }
 
+// PluginRepoIndexURL provides a mock function with given fields:
+func (_m *MockCommandLine) PluginRepoIndexURL() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // PluginURL provides a mock function with given fields:
 func (_m *MockCommandLine) PluginURL() string {
 	ret := _m.Called()
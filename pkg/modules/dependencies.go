@@ -6,10 +6,33 @@ const (
 
 	Core             string = "core"
 	GrafanaAPIServer string = "grafana-apiserver"
+
+	// AlertingScheduler runs the core server with the alert rule scheduler, and without the notifier.
+	AlertingScheduler string = "alerting-scheduler"
+	// AlertingNotifier runs the core server with the notifier, and without the alert rule scheduler.
+	AlertingNotifier string = "alerting-notifier"
 )
 
 var dependencyMap = map[string][]string{
-	GrafanaAPIServer: {},
-	Core:             {},
-	All:              {Core},
+	GrafanaAPIServer:  {},
+	Core:              {},
+	AlertingScheduler: {Core},
+	AlertingNotifier:  {Core},
+	All:               {Core},
+}
+
+// AlertingComponents returns whether an instance that runs the targets runs the alert rule
+// scheduler and the notifier. Instances that target the core server run both, unless
+// executeAlerts, the execute_alerts setting, is false: such instances run the core server
+// without the scheduler, for example to only serve the UI and the API.
+func AlertingComponents(targets []string, executeAlerts bool) (scheduler bool, notifier bool) {
+	scheduler, notifier = true, true
+	if len(targets) != 0 && !stringsContain(targets, All) && !stringsContain(targets, Core) {
+		scheduler = stringsContain(targets, AlertingScheduler)
+		notifier = stringsContain(targets, AlertingNotifier)
+		if !scheduler && !notifier {
+			scheduler, notifier = true, true
+		}
+	}
+	return scheduler && executeAlerts, notifier
 }
@@ -0,0 +1,36 @@
+package modules
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlertingComponents(t *testing.T) {
+	testCases := []struct {
+		desc          string
+		targets       []string
+		executeAlerts bool
+		scheduler     bool
+		notifier      bool
+	}{
+		{desc: "no target", targets: nil, executeAlerts: true, scheduler: true, notifier: true},
+		{desc: "all", targets: []string{All}, executeAlerts: true, scheduler: true, notifier: true},
+		{desc: "core", targets: []string{Core}, executeAlerts: true, scheduler: true, notifier: true},
+		{desc: "all without executing alerts", targets: []string{All}, executeAlerts: false, scheduler: false, notifier: true},
+		{desc: "core without executing alerts", targets: []string{Core}, executeAlerts: false, scheduler: false, notifier: true},
+		{desc: "alerting scheduler", targets: []string{AlertingScheduler}, executeAlerts: true, scheduler: true, notifier: false},
+		{desc: "alerting notifier", targets: []string{AlertingNotifier}, executeAlerts: true, scheduler: false, notifier: true},
+		{desc: "alerting scheduler and notifier", targets: []string{AlertingScheduler, AlertingNotifier}, executeAlerts: true, scheduler: true, notifier: true},
+		{desc: "alerting scheduler and core", targets: []string{AlertingScheduler, Core}, executeAlerts: true, scheduler: true, notifier: true},
+		{desc: "other module", targets: []string{GrafanaAPIServer}, executeAlerts: true, scheduler: true, notifier: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			scheduler, notifier := AlertingComponents(tc.targets, tc.executeAlerts)
+			require.Equal(t, tc.scheduler, scheduler)
+			require.Equal(t, tc.notifier, notifier)
+		})
+	}
+}
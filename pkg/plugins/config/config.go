@@ -39,6 +39,8 @@ type Cfg struct {
 	Tracing Tracing
 
 	GrafanaComURL string
+	// PluginRepositoryIndexURL is the location of a static plugin index used instead of grafana.com
+	PluginRepositoryIndexURL string
 
 	GrafanaAppURL    string
 	GrafanaAppSubURL string
@@ -51,27 +53,28 @@ type Cfg struct {
 func NewCfg(devMode bool, pluginsPath string, pluginSettings setting.PluginSettings, pluginsAllowUnsigned []string,
 	awsAllowedAuthProviders []string, awsAssumeRoleEnabled bool, awsExternalId string, azure *azsettings.AzureSettings, secureSocksDSProxy setting.SecureSocksDSProxySettings,
 	grafanaVersion string, logDatasourceRequests bool, pluginsCDNURLTemplate string, appURL string, appSubURL string, tracing Tracing, features plugins.FeatureToggles, angularSupportEnabled bool,
-	grafanaComURL string, disablePlugins []string) *Cfg {
+	grafanaComURL string, disablePlugins []string, pluginRepositoryIndexURL string) *Cfg {
 	return &Cfg{
-		log:                     log.New("plugin.cfg"),
-		PluginsPath:             pluginsPath,
-		BuildVersion:            grafanaVersion,
-		DevMode:                 devMode,
-		PluginSettings:          pluginSettings,
-		PluginsAllowUnsigned:    pluginsAllowUnsigned,
-		DisablePlugins:          disablePlugins,
-		AWSAllowedAuthProviders: awsAllowedAuthProviders,
-		AWSAssumeRoleEnabled:    awsAssumeRoleEnabled,
-		AWSExternalId:           awsExternalId,
-		Azure:                   azure,
-		ProxySettings:           secureSocksDSProxy,
-		LogDatasourceRequests:   logDatasourceRequests,
-		PluginsCDNURLTemplate:   pluginsCDNURLTemplate,
-		Tracing:                 tracing,
-		GrafanaComURL:           grafanaComURL,
-		GrafanaAppURL:           appURL,
-		GrafanaAppSubURL:        appSubURL,
-		Features:                features,
-		AngularSupportEnabled:   angularSupportEnabled,
+		log:                      log.New("plugin.cfg"),
+		PluginsPath:              pluginsPath,
+		BuildVersion:             grafanaVersion,
+		DevMode:                  devMode,
+		PluginSettings:           pluginSettings,
+		PluginsAllowUnsigned:     pluginsAllowUnsigned,
+		DisablePlugins:           disablePlugins,
+		AWSAllowedAuthProviders:  awsAllowedAuthProviders,
+		AWSAssumeRoleEnabled:     awsAssumeRoleEnabled,
+		AWSExternalId:            awsExternalId,
+		Azure:                    azure,
+		ProxySettings:            secureSocksDSProxy,
+		LogDatasourceRequests:    logDatasourceRequests,
+		PluginsCDNURLTemplate:    pluginsCDNURLTemplate,
+		Tracing:                  tracing,
+		GrafanaComURL:            grafanaComURL,
+		PluginRepositoryIndexURL: pluginRepositoryIndexURL,
+		GrafanaAppURL:            appURL,
+		GrafanaAppSubURL:         appSubURL,
+		Features:                 features,
+		AngularSupportEnabled:    angularSupportEnabled,
 	}
 }
@@ -205,6 +205,7 @@ type FakePluginRepo struct {
 	GetPluginArchiveByURLFunc func(_ context.Context, archiveURL string, _ repo.CompatOpts) (*repo.PluginArchive, error)
 	GetPluginArchiveInfoFunc  func(_ context.Context, pluginID, version string, _ repo.CompatOpts) (*repo.PluginArchiveInfo, error)
 	PluginVersionFunc         func(pluginID, version string, compatOpts repo.CompatOpts) (repo.VersionData, error)
+	GetPluginIndexFunc        func(_ context.Context, _ repo.CompatOpts) (*repo.PluginIndex, error)
 }
 
 // GetPluginArchive fetches the requested plugin archive.
@@ -240,6 +241,13 @@ func (r *FakePluginRepo) PluginVersion(pluginID, version string, compatOpts repo
 	return repo.VersionData{}, nil
 }
 
+func (r *FakePluginRepo) GetPluginIndex(ctx context.Context, opts repo.CompatOpts) (*repo.PluginIndex, error) {
+	if r.GetPluginIndexFunc != nil {
+		return r.GetPluginIndexFunc(ctx, opts)
+	}
+	return nil, repo.ErrPluginIndexNotConfigured
+}
+
 type FakePluginStorage struct {
 	ExtractFunc func(_ context.Context, pluginID string, dirNameFunc storage.DirNameGeneratorFunc, z *zip.ReadCloser) (*storage.ExtractedPluginArchive, error)
 }
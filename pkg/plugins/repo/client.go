@@ -103,10 +103,14 @@ func (c *Client) downloadFile(tmpFile *os.File, pluginURL, checksum string, comp
 				c.log.Warn("Failed to close file", "error", err)
 			}
 		}()
-		_, err = io.Copy(tmpFile, f)
+		h := sha256.New()
+		_, err = io.Copy(tmpFile, io.TeeReader(f, h))
 		if err != nil {
 			return fmt.Errorf("%v: %w", "Failed to copy plugin archive", err)
 		}
+		if len(checksum) > 0 && checksum != fmt.Sprintf("%x", h.Sum(nil)) {
+			return ErrChecksumMismatch{archiveURL: pluginURL}
+		}
 		return nil
 	}
 
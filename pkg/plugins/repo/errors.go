@@ -1,6 +1,13 @@
 package repo
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPluginIndexNotConfigured is returned when the plugin index is requested from a
+// repository that resolves plugins from the grafana.com API.
+var ErrPluginIndexNotConfigured = errors.New("plugin repository is not backed by a plugin index")
 
 type ErrResponse4xx struct {
 	message           string
@@ -79,3 +86,11 @@ type ErrChecksumMismatch struct {
 func (e ErrChecksumMismatch) Error() string {
 	return fmt.Sprintf("expected SHA256 checksum does not match the downloaded archive (%s) - please contact security@grafana.com", e.archiveURL)
 }
+
+type ErrPluginNotFound struct {
+	pluginID string
+}
+
+func (e ErrPluginNotFound) Error() string {
+	return fmt.Sprintf("%s was not found in the plugin index", e.pluginID)
+}
@@ -16,6 +16,9 @@ type Service interface {
 	GetPluginArchiveInfo(ctx context.Context, pluginID, version string, opts CompatOpts) (*PluginArchiveInfo, error)
 	// PluginVersion will return plugin version based on the requested information.
 	PluginVersion(pluginID, version string, compatOpts CompatOpts) (VersionData, error)
+	// GetPluginIndex returns the static plugin index the repository is backed by, or
+	// ErrPluginIndexNotConfigured if plugins are resolved from the grafana.com API.
+	GetPluginIndex(ctx context.Context, compatOpts CompatOpts) (*PluginIndex, error)
 }
 
 type CompatOpts struct {
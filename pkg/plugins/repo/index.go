@@ -0,0 +1,203 @@
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/grafana/grafana/pkg/plugins/log"
+)
+
+const pluginIndexFileName = "index.json"
+
+// pluginIndex resolves plugins from a static PluginIndex file, served either over
+// HTTP(S) or from the local filesystem.
+type pluginIndex struct {
+	location string
+	client   *Client
+	log      log.PrettyLogger
+}
+
+func newPluginIndex(location string, client *Client, logger log.PrettyLogger) *pluginIndex {
+	location = strings.TrimPrefix(location, "file://")
+	if !isRemoteLocation(location) {
+		if fi, err := os.Stat(location); err == nil && fi.IsDir() {
+			location = filepath.Join(location, pluginIndexFileName)
+		}
+	}
+
+	return &pluginIndex{
+		location: location,
+		client:   client,
+		log:      logger,
+	}
+}
+
+// load fetches the index and sorts the versions of every plugin so the newest version is first.
+func (i *pluginIndex) load(compatOpts CompatOpts) (*PluginIndex, error) {
+	var body []byte
+	if isRemoteLocation(i.location) {
+		u, err := url.Parse(i.location)
+		if err != nil {
+			return nil, err
+		}
+		if body, err = i.client.SendReq(u, compatOpts); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		// We can ignore the gosec G304 warning since the index location is provided by the configuration.
+		// nolint:gosec
+		if body, err = os.ReadFile(i.location); err != nil {
+			return nil, fmt.Errorf("%v: %w", "failed to read plugin index", err)
+		}
+	}
+
+	var index PluginIndex
+	if err := json.Unmarshal(body, &index); err != nil {
+		i.log.Error("Failed to unmarshal plugin index", err)
+		return nil, err
+	}
+
+	for _, p := range index.Plugins {
+		sortVersions(p.Versions)
+	}
+
+	return &index, nil
+}
+
+func (i *pluginIndex) plugin(pluginID string, compatOpts CompatOpts) (IndexedPlugin, error) {
+	index, err := i.load(compatOpts)
+	if err != nil {
+		return IndexedPlugin{}, err
+	}
+
+	for _, p := range index.Plugins {
+		if p.ID == pluginID {
+			return p, nil
+		}
+	}
+
+	return IndexedPlugin{}, ErrPluginNotFound{pluginID: pluginID}
+}
+
+// grafanaCompatiblePluginVersions returns the versions of the plugin whose Grafana
+// dependency is satisfied by the Grafana version of `compatOpts`.
+func (i *pluginIndex) grafanaCompatiblePluginVersions(pluginID string, compatOpts CompatOpts) ([]Version, error) {
+	p, err := i.plugin(pluginID, compatOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	grafanaVersion, exists := compatOpts.GrafanaVersion()
+	if !exists {
+		return p.Versions, nil
+	}
+
+	var versions []Version
+	for _, v := range p.Versions {
+		if IsGrafanaCompatible(grafanaVersion, v.GrafanaDependency) {
+			versions = append(versions, v)
+		}
+	}
+
+	return versions, nil
+}
+
+// archiveURL returns the location of the archive of the plugin version for the system of `compatOpts`.
+func (i *pluginIndex) archiveURL(pluginID, version string, compatOpts CompatOpts) (string, error) {
+	p, err := i.plugin(pluginID, compatOpts)
+	if err != nil {
+		return "", err
+	}
+
+	sysCompatOpts, _ := compatOpts.System()
+	for _, v := range p.Versions {
+		if v.Version != version {
+			continue
+		}
+
+		archMeta, exists := v.Arch[sysCompatOpts.OSAndArch()]
+		if !exists {
+			archMeta = v.Arch["any"]
+		}
+		if archMeta.URL == "" {
+			return "", ErrArcNotFound{pluginID: pluginID, systemInfo: sysCompatOpts.OSAndArch()}
+		}
+
+		return i.resolve(archMeta.URL)
+	}
+
+	return "", ErrVersionNotFound{pluginID: pluginID, requestedVersion: version, systemInfo: sysCompatOpts.OSAndArch()}
+}
+
+// resolve returns `ref` relative to the location of the index.
+func (i *pluginIndex) resolve(ref string) (string, error) {
+	if isRemoteLocation(ref) {
+		return ref, nil
+	}
+
+	if isRemoteLocation(i.location) {
+		base, err := url.Parse(i.location)
+		if err != nil {
+			return "", err
+		}
+		u, err := url.Parse(ref)
+		if err != nil {
+			return "", err
+		}
+		return base.ResolveReference(u).String(), nil
+	}
+
+	ref = strings.TrimPrefix(ref, "file://")
+	if filepath.IsAbs(ref) {
+		return ref, nil
+	}
+	return filepath.Join(filepath.Dir(i.location), filepath.FromSlash(ref)), nil
+}
+
+// IsGrafanaCompatible reports whether `grafanaVersion` satisfies the semver range `dependency`.
+// An empty dependency is satisfied by every Grafana version.
+func IsGrafanaCompatible(grafanaVersion, dependency string) bool {
+	if dependency == "" {
+		return true
+	}
+
+	v, err := semver.NewVersion(grafanaVersion)
+	if err != nil {
+		return false
+	}
+	// Pre-releases of Grafana should be treated like the release they precede
+	release, err := v.SetPrerelease("")
+	if err != nil {
+		return false
+	}
+
+	c, err := semver.NewConstraint(dependency)
+	if err != nil {
+		return false
+	}
+
+	return c.Check(&release)
+}
+
+func sortVersions(versions []Version) {
+	sort.SliceStable(versions, func(a, b int) bool {
+		va, errA := semver.NewVersion(versions[a].Version)
+		vb, errB := semver.NewVersion(versions[b].Version)
+		if errA != nil || errB != nil {
+			return errA == nil
+		}
+		return va.GreaterThan(vb)
+	})
+}
+
+func isRemoteLocation(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
@@ -0,0 +1,147 @@
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/plugins/log"
+)
+
+func TestGetPluginArchiveFromIndex(t *testing.T) {
+	const (
+		pluginID       = "grafana-test-datasource"
+		opSys          = "darwin"
+		arch           = "amd64"
+		grafanaVersion = "10.0.0"
+	)
+
+	pluginZip := createPluginArchive(t)
+	archive, err := os.ReadFile(pluginZip.Name())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, pluginZip.Close())
+		require.NoError(t, os.RemoveAll(pluginZip.Name()))
+	})
+	sha := fmt.Sprintf("%x", sha256.Sum256(archive))
+
+	index := func(sha string) string {
+		return fmt.Sprintf(`{
+			"plugins": [{
+				"id": "%[1]s",
+				"versions": [
+					{"version": "1.0.0", "arch": {"any": {"url": "%[1]s-1.0.0.zip", "sha256": "%[2]s"}}},
+					{"version": "2.0.0", "grafanaDependency": ">=11.0.0", "arch": {"any": {"url": "%[1]s-2.0.0.zip", "sha256": "%[2]s"}}},
+					{"version": "1.1.0", "grafanaDependency": ">=9.0.0", "arch": {"%[3]s": {"url": "archives/%[1]s-1.1.0.zip", "sha256": "%[2]s"}}}
+				]
+			}]
+		}`, pluginID, sha, opSys+"-"+arch)
+	}
+
+	writeMirror := func(t *testing.T, sha string) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "archives"), 0750))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "index.json"), []byte(index(sha)), 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, pluginID+"-1.0.0.zip"), archive, 0600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "archives", pluginID+"-1.1.0.zip"), archive, 0600))
+		return dir
+	}
+
+	co := NewCompatOpts(grafanaVersion, opSys, arch)
+
+	t.Run("resolves the latest compatible version from a local directory", func(t *testing.T) {
+		dir := writeMirror(t, sha)
+		m := NewManager(ManagerCfg{IndexURL: dir, Logger: log.NewTestPrettyLogger()})
+
+		info, err := m.GetPluginArchiveInfo(context.Background(), pluginID, "", co)
+		require.NoError(t, err)
+		require.Equal(t, "1.1.0", info.Version)
+		require.Equal(t, sha, info.Checksum)
+		require.Equal(t, filepath.Join(dir, "archives", pluginID+"-1.1.0.zip"), info.URL)
+
+		a, err := m.GetPluginArchive(context.Background(), pluginID, "", co)
+		require.NoError(t, err)
+		verifyArchive(t, a)
+	})
+
+	t.Run("rejects versions incompatible with Grafana", func(t *testing.T) {
+		m := NewManager(ManagerCfg{IndexURL: writeMirror(t, sha), Logger: log.NewTestPrettyLogger()})
+
+		_, err := m.GetPluginArchiveInfo(context.Background(), pluginID, "2.0.0", co)
+		require.ErrorAs(t, err, &ErrVersionNotFound{})
+	})
+
+	t.Run("verifies the checksum of local archives", func(t *testing.T) {
+		m := NewManager(ManagerCfg{IndexURL: writeMirror(t, "1a2b3c"), Logger: log.NewTestPrettyLogger()})
+
+		_, err := m.GetPluginArchive(context.Background(), pluginID, "1.0.0", co)
+		require.ErrorAs(t, err, &ErrChecksumMismatch{})
+	})
+
+	t.Run("returns an error for unknown plugins", func(t *testing.T) {
+		m := NewManager(ManagerCfg{IndexURL: writeMirror(t, sha), Logger: log.NewTestPrettyLogger()})
+
+		_, err := m.GetPluginArchiveInfo(context.Background(), "unknown", "", co)
+		require.ErrorAs(t, err, &ErrPluginNotFound{})
+	})
+
+	t.Run("resolves archives relative to an index served over HTTP", func(t *testing.T) {
+		srv := httptest.NewServer(http.FileServer(http.Dir(writeMirror(t, sha))))
+		t.Cleanup(srv.Close)
+
+		m := NewManager(ManagerCfg{IndexURL: srv.URL + "/index.json", Logger: log.NewTestPrettyLogger()})
+
+		info, err := m.GetPluginArchiveInfo(context.Background(), pluginID, "1.0.0", co)
+		require.NoError(t, err)
+		require.Equal(t, srv.URL+"/"+pluginID+"-1.0.0.zip", info.URL)
+
+		a, err := m.GetPluginArchive(context.Background(), pluginID, "1.0.0", co)
+		require.NoError(t, err)
+		verifyArchive(t, a)
+
+		index, err := m.GetPluginIndex(context.Background(), co)
+		require.NoError(t, err)
+		require.Len(t, index.Plugins, 1)
+		require.Equal(t, []string{"2.0.0", "1.1.0", "1.0.0"}, []string{
+			index.Plugins[0].Versions[0].Version,
+			index.Plugins[0].Versions[1].Version,
+			index.Plugins[0].Versions[2].Version,
+		})
+	})
+
+	t.Run("index is not configured", func(t *testing.T) {
+		m := NewManager(ManagerCfg{BaseURL: "https://grafana.com/api/plugins", Logger: log.NewTestPrettyLogger()})
+
+		_, err := m.GetPluginIndex(context.Background(), co)
+		require.ErrorIs(t, err, ErrPluginIndexNotConfigured)
+	})
+}
+
+func TestIsGrafanaCompatible(t *testing.T) {
+	tcs := []struct {
+		grafanaVersion string
+		dependency     string
+		expected       bool
+	}{
+		{grafanaVersion: "10.0.0", dependency: "", expected: true},
+		{grafanaVersion: "10.0.0", dependency: ">=9.0.0", expected: true},
+		{grafanaVersion: "10.0.0", dependency: ">=10.1.0", expected: false},
+		{grafanaVersion: "10.1.0-pre", dependency: ">=10.1.0", expected: true},
+		{grafanaVersion: "10.0.0", dependency: "not a range", expected: false},
+	}
+
+	for _, tc := range tcs {
+		t.Run(fmt.Sprintf("%s satisfies %q", tc.grafanaVersion, tc.dependency), func(t *testing.T) {
+			require.Equal(t, tc.expected, IsGrafanaCompatible(tc.grafanaVersion, tc.dependency))
+		})
+	}
+}
@@ -20,8 +20,31 @@ type PluginRepo struct {
 type Version struct {
 	Version string              `json:"version"`
 	Arch    map[string]ArchMeta `json:"arch"`
+	// GrafanaDependency is the semver range of Grafana versions the plugin version supports.
+	// It is only set for versions listed in a PluginIndex.
+	GrafanaDependency string `json:"grafanaDependency,omitempty"`
 }
 
 type ArchMeta struct {
 	SHA256 string `json:"sha256"`
+	// URL of the archive, either absolute or relative to the PluginIndex location.
+	// It is only set for versions listed in a PluginIndex.
+	URL string `json:"url,omitempty"`
+}
+
+// PluginIndex is the JSON document listing the plugins of a static plugin repository,
+// such as an internal mirror of grafana.com.
+type PluginIndex struct {
+	Plugins []IndexedPlugin `json:"plugins"`
+}
+
+// IndexedPlugin is a plugin listed in a PluginIndex.
+type IndexedPlugin struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Type          string    `json:"type"`
+	Description   string    `json:"description"`
+	OrgName       string    `json:"orgName"`
+	SignatureType string    `json:"signatureType"`
+	Versions      []Version `json:"versions"`
 }
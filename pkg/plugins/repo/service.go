@@ -15,6 +15,7 @@ import (
 type Manager struct {
 	client  *Client
 	baseURL string
+	index   *pluginIndex
 
 	log log.PrettyLogger
 }
@@ -28,6 +29,7 @@ func ProvideService(cfg *config.Cfg) (*Manager, error) {
 	return NewManager(ManagerCfg{
 		SkipTLSVerify: false,
 		BaseURL:       baseURL,
+		IndexURL:      cfg.PluginRepositoryIndexURL,
 		Logger:        log.NewPrettyLogger("plugin.repository"),
 	}), nil
 }
@@ -35,15 +37,22 @@ func ProvideService(cfg *config.Cfg) (*Manager, error) {
 type ManagerCfg struct {
 	SkipTLSVerify bool
 	BaseURL       string
-	Logger        log.PrettyLogger
+	// IndexURL is the URL or local path of a static plugin index. When set, plugins are
+	// resolved from the index instead of the grafana.com API at BaseURL.
+	IndexURL string
+	Logger   log.PrettyLogger
 }
 
 func NewManager(cfg ManagerCfg) *Manager {
-	return &Manager{
+	m := &Manager{
 		baseURL: cfg.BaseURL,
 		client:  NewClient(cfg.SkipTLSVerify, cfg.Logger),
 		log:     cfg.Logger,
 	}
+	if cfg.IndexURL != "" {
+		m.index = newPluginIndex(cfg.IndexURL, m.client, cfg.Logger)
+	}
+	return m
 }
 
 // GetPluginArchive fetches the requested plugin archive
@@ -68,13 +77,29 @@ func (m *Manager) GetPluginArchiveInfo(_ context.Context, pluginID, version stri
 		return nil, err
 	}
 
+	archiveURL := m.downloadURL(pluginID, v.Version)
+	if m.index != nil {
+		if archiveURL, err = m.index.archiveURL(pluginID, v.Version, compatOpts); err != nil {
+			return nil, err
+		}
+	}
+
 	return &PluginArchiveInfo{
 		Version:  v.Version,
 		Checksum: v.Checksum,
-		URL:      m.downloadURL(pluginID, v.Version),
+		URL:      archiveURL,
 	}, nil
 }
 
+// GetPluginIndex returns the plugin index the repository is backed by
+func (m *Manager) GetPluginIndex(_ context.Context, compatOpts CompatOpts) (*PluginIndex, error) {
+	if m.index == nil {
+		return nil, ErrPluginIndexNotConfigured
+	}
+
+	return m.index.load(compatOpts)
+}
+
 // PluginVersion will return plugin version based on the requested information
 func (m *Manager) PluginVersion(pluginID, version string, compatOpts CompatOpts) (VersionData, error) {
 	versions, err := m.grafanaCompatiblePluginVersions(pluginID, compatOpts)
@@ -97,6 +122,10 @@ func (m *Manager) downloadURL(pluginID, version string) string {
 // grafanaCompatiblePluginVersions will get version info from /api/plugins/repo/$pluginID based on
 // the provided compatibility information (sent via HTTP headers)
 func (m *Manager) grafanaCompatiblePluginVersions(pluginID string, compatOpts CompatOpts) ([]Version, error) {
+	if m.index != nil {
+		return m.index.grafanaCompatiblePluginVersions(pluginID, compatOpts)
+	}
+
 	u, err := url.Parse(m.baseURL)
 	if err != nil {
 		return nil, err
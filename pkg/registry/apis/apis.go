@@ -3,7 +3,9 @@ package apiregistry
 import (
 	"context"
 
+	dashboardv0alpha1 "github.com/grafana/grafana/pkg/apis/dashboard/v0alpha1"
 	examplev0alpha1 "github.com/grafana/grafana/pkg/apis/example/v0alpha1"
+	folderv0alpha1 "github.com/grafana/grafana/pkg/apis/folder/v0alpha1"
 	playlistsv0alpha1 "github.com/grafana/grafana/pkg/apis/playlist/v0alpha1"
 	"github.com/grafana/grafana/pkg/registry"
 )
@@ -19,6 +21,8 @@ type Service struct{}
 func ProvideService(
 	_ *playlistsv0alpha1.PlaylistAPIBuilder,
 	_ *examplev0alpha1.TestingAPIBuilder,
+	_ *dashboardv0alpha1.DashboardAPIBuilder,
+	_ *folderv0alpha1.FolderAPIBuilder,
 ) *Service {
 	return &Service{}
 }
@@ -106,11 +106,15 @@ func (s *ModuleServer) Run() error {
 	s.notifySystemd("READY=1")
 	s.log.Debug("Waiting on services...")
 
-	// Only allow individual dskit modules to run in dev mode.
+	// Only allow individual dskit modules to run in dev mode. The alerting targets run the
+	// core server, so they are allowed in all modes.
 	if s.cfg.Env != setting.Dev {
-		if len(s.cfg.Target) > 1 || s.cfg.Target[0] != "all" {
-			s.log.Error("dskit module targeting is only supported in dev mode. Falling back to 'all'")
-			s.cfg.Target = []string{"all"}
+		for _, target := range s.cfg.Target {
+			if target != modules.All && target != modules.AlertingScheduler && target != modules.AlertingNotifier {
+				s.log.Error("dskit module targeting is only supported in dev mode. Falling back to 'all'")
+				s.cfg.Target = []string{"all"}
+				break
+			}
 		}
 	}
 
@@ -129,6 +133,10 @@ func (s *ModuleServer) Run() error {
 	//	s.log.Debug("apiserver feature is disabled")
 	//}
 
+	// The alerting targets only restrict the alerting components that the core server runs.
+	m.RegisterModule(modules.AlertingScheduler, nil)
+	m.RegisterModule(modules.AlertingNotifier, nil)
+
 	m.RegisterModule(modules.All, nil)
 
 	return m.Run(s.context)
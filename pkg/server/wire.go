@@ -38,6 +38,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
 	"github.com/grafana/grafana/pkg/services/accesscontrol/ossaccesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/resourcepermissions"
 	"github.com/grafana/grafana/pkg/services/alerting"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/annotations/annotationsimpl"
@@ -104,6 +105,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/pluginsintegration"
 	pluginDashboards "github.com/grafana/grafana/pkg/services/pluginsintegration/dashboards"
 	"github.com/grafana/grafana/pkg/services/preference/prefimpl"
+	provisioningteams "github.com/grafana/grafana/pkg/services/provisioning/teams"
 	"github.com/grafana/grafana/pkg/services/publicdashboards"
 	publicdashboardsApi "github.com/grafana/grafana/pkg/services/publicdashboards/api"
 	publicdashboardsStore "github.com/grafana/grafana/pkg/services/publicdashboards/database"
@@ -317,6 +319,8 @@ var wireBasicSet = wire.NewSet(
 	cuectx.GrafanaThemaRuntime,
 	csrf.ProvideCSRFFilter,
 	wire.Bind(new(csrf.Service), new(*csrf.CSRF)),
+	provisioningteams.ProvideProvisionedStore,
+	wire.Bind(new(resourcepermissions.ProvisionedChecker), new(*provisioningteams.ProvisionedStore)),
 	ossaccesscontrol.ProvideTeamPermissions,
 	wire.Bind(new(accesscontrol.TeamPermissionsService), new(*ossaccesscontrol.TeamPermissionsService)),
 	ossaccesscontrol.ProvideFolderPermissions,
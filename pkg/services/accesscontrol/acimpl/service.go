@@ -101,11 +101,19 @@ func (s *Service) GetUserPermissions(ctx context.Context, user identity.Requeste
 	timer := prometheus.NewTimer(metrics.MAccessPermissionsSummary)
 	defer timer.ObserveDuration()
 
+	var permissions []accesscontrol.Permission
+	var err error
 	if !s.cfg.RBACPermissionCache || !user.HasUniqueId() {
-		return s.getUserPermissions(ctx, user, options)
+		permissions, err = s.getUserPermissions(ctx, user, options)
+	} else {
+		permissions, err = s.getCachedUserPermissions(ctx, user, options)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return s.getCachedUserPermissions(ctx, user, options)
+	// Conditions depend on the request, they are applied after caching
+	return accesscontrol.ApplyConditions(ctx, permissions), nil
 }
 
 func (s *Service) getUserPermissions(ctx context.Context, user identity.Requester, options accesscontrol.Options) ([]accesscontrol.Permission, error) {
@@ -306,7 +314,7 @@ func (s *Service) SearchUsersPermissions(ctx context.Context, user identity.Requ
 			perms = append(perms, basicPermission...)
 		}
 		if dbPerms, ok := usersPermissions[userID]; ok {
-			perms = append(perms, dbPerms...)
+			perms = append(perms, accesscontrol.ApplyConditions(ctx, dbPerms)...)
 		}
 		if len(perms) > 0 {
 			res[userID] = perms
@@ -325,9 +333,13 @@ func (s *Service) SearchUserPermissions(ctx context.Context, orgID int64, search
 	}
 
 	if permissions, success := s.searchUserPermissionsFromCache(orgID, searchOptions); success {
-		return permissions, nil
+		return accesscontrol.ApplyConditions(ctx, permissions), nil
+	}
+	permissions, err := s.searchUserPermissions(ctx, orgID, searchOptions)
+	if err != nil {
+		return nil, err
 	}
-	return s.searchUserPermissions(ctx, orgID, searchOptions)
+	return accesscontrol.ApplyConditions(ctx, permissions), nil
 }
 
 func (s *Service) searchUserPermissions(ctx context.Context, orgID int64, searchOptions accesscontrol.SearchOptions) ([]accesscontrol.Permission, error) {
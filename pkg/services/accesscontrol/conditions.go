@@ -0,0 +1,271 @@
+package accesscontrol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// Condition restricts when a permission is granted.
+// All the conditions that are set must be satisfied for the permission to apply.
+type Condition struct {
+	// NotBefore and NotAfter restrict the permission to a time range, e.g. for time-boxed break-glass access
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+	// Schedule restricts the permission to recurring time windows, e.g. business hours
+	Schedule *Schedule `json:"schedule,omitempty"`
+	// CIDRs restricts the permission to requests coming from one of the networks
+	CIDRs []string `json:"cidrs,omitempty"`
+	// ResourceTags restricts the permission to resources with at least one of the tags.
+	// Only supported on wildcard scopes, e.g. "dashboards:*" or "dashboards:uid:*"
+	ResourceTags []string `json:"resourceTags,omitempty"`
+}
+
+// Schedule is a recurring weekly time window
+type Schedule struct {
+	// Days of the week, e.g. "monday". All days when empty
+	Days []string `json:"days,omitempty"`
+	// Start and End of the window, formatted as "15:04"
+	Start string `json:"start"`
+	End   string `json:"end"`
+	// Timezone is an IANA timezone name, defaults to UTC
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// ConditionContext holds the request attributes conditions are evaluated against
+type ConditionContext struct {
+	Now        time.Time
+	RemoteAddr string
+}
+
+type conditionContextKey struct{}
+
+// WithConditionContext returns a copy of ctx carrying the condition context
+func WithConditionContext(ctx context.Context, cc ConditionContext) context.Context {
+	return context.WithValue(ctx, conditionContextKey{}, cc)
+}
+
+// ConditionContextFromRequest builds the condition context of an HTTP request
+func ConditionContextFromRequest(req *http.Request) ConditionContext {
+	return ConditionContext{Now: time.Now(), RemoteAddr: web.RemoteAddr(req)}
+}
+
+// ConditionContextFromContext returns the condition context set on ctx.
+// When none is set the current time is used and network conditions never match.
+func ConditionContextFromContext(ctx context.Context) ConditionContext {
+	cc, ok := ctx.Value(conditionContextKey{}).(ConditionContext)
+	if !ok {
+		cc = ConditionContext{}
+	}
+	if cc.Now.IsZero() {
+		cc.Now = time.Now()
+	}
+	return cc
+}
+
+// ParseCondition decodes the conditions stored with a permission
+func ParseCondition(raw string) (*Condition, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	c := &Condition{}
+	if err := json.Unmarshal([]byte(raw), c); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCondition, err)
+	}
+	return c, nil
+}
+
+// Encode returns the representation of the condition stored with a permission
+func (c *Condition) Encode() (string, error) {
+	if c == nil {
+		return "", nil
+	}
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return string(raw), nil
+}
+
+// Validate checks that the condition can be applied to a permission with the given scope
+func (c *Condition) Validate(scope string) error {
+	if c == nil {
+		return nil
+	}
+	if c.NotBefore != nil && c.NotAfter != nil && !c.NotBefore.Before(*c.NotAfter) {
+		return fmt.Errorf("%w: notBefore must be before notAfter", ErrInvalidCondition)
+	}
+	if c.Schedule != nil {
+		if _, _, _, err := c.Schedule.parse(); err != nil {
+			return err
+		}
+	}
+	for _, cidr := range c.CIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("%w: invalid cidr %q", ErrInvalidCondition, cidr)
+		}
+	}
+	if len(c.ResourceTags) > 0 {
+		kind, attribute, identifier := Permission{Scope: scope}.SplitScope()
+		if kind == "*" || (attribute != "*" && identifier != "*") {
+			return fmt.Errorf("%w: resource tags can only restrict wildcard scopes", ErrInvalidCondition)
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the request attributes satisfy the time and network conditions.
+// Resource tags are not checked, see ApplyConditions.
+func (c *Condition) Matches(cc ConditionContext) bool {
+	if c == nil {
+		return true
+	}
+	if c.NotBefore != nil && cc.Now.Before(*c.NotBefore) {
+		return false
+	}
+	if c.NotAfter != nil && !cc.Now.Before(*c.NotAfter) {
+		return false
+	}
+	if c.Schedule != nil && !c.Schedule.contains(cc.Now) {
+		return false
+	}
+	if len(c.CIDRs) > 0 && !matchCIDRs(c.CIDRs, cc.RemoteAddr) {
+		return false
+	}
+	return true
+}
+
+func (s *Schedule) parse() (start, end time.Duration, loc *time.Location, err error) {
+	loc = time.UTC
+	if s.Timezone != "" {
+		if loc, err = time.LoadLocation(s.Timezone); err != nil {
+			return 0, 0, nil, fmt.Errorf("%w: invalid timezone %q", ErrInvalidCondition, s.Timezone)
+		}
+	}
+	for _, day := range s.Days {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return 0, 0, nil, fmt.Errorf("%w: invalid day %q", ErrInvalidCondition, day)
+		}
+	}
+	if start, err = parseTimeOfDay(s.Start); err != nil {
+		return 0, 0, nil, err
+	}
+	if end, err = parseTimeOfDay(s.End); err != nil {
+		return 0, 0, nil, err
+	}
+	return start, end, loc, nil
+}
+
+func (s *Schedule) contains(now time.Time) bool {
+	start, end, loc, err := s.parse()
+	if err != nil {
+		return false
+	}
+
+	now = now.In(loc)
+	if len(s.Days) > 0 {
+		found := false
+		for _, day := range s.Days {
+			if weekdays[strings.ToLower(day)] == now.Weekday() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	timeOfDay := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second
+	if start <= end {
+		return timeOfDay >= start && timeOfDay < end
+	}
+	// window spanning midnight, e.g. 22:00 - 06:00
+	return timeOfDay >= start || timeOfDay < end
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid time of day %q", ErrInvalidCondition, value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func matchCIDRs(cidrs []string, remoteAddr string) bool {
+	ip := net.ParseIP(strings.Trim(remoteAddr, "[]"))
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyConditions removes the conditional permissions that are not granted in the condition context of ctx.
+// Permissions restricted to resource tags are rewritten to tag scopes (e.g. "dashboards:tag:production"),
+// which match the scopes returned by the resource scope resolvers.
+func ApplyConditions(ctx context.Context, permissions []Permission) []Permission {
+	var cc *ConditionContext
+	result := make([]Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if p.Conditions == "" {
+			result = append(result, p)
+			continue
+		}
+
+		if cc == nil {
+			c := ConditionContextFromContext(ctx)
+			cc = &c
+		}
+
+		condition, err := ParseCondition(p.Conditions)
+		if err == nil {
+			err = condition.Validate(p.Scope)
+		}
+		if err != nil {
+			logger.Warn("Ignoring permission with invalid conditions", "action", p.Action, "scope", p.Scope, "error", err)
+			continue
+		}
+		if !condition.Matches(*cc) {
+			continue
+		}
+
+		if len(condition.ResourceTags) == 0 {
+			result = append(result, p)
+			continue
+		}
+		kind, _, _ := p.SplitScope()
+		for _, tag := range condition.ResourceTags {
+			tagged := p
+			tagged.Scope = ScopeTag(kind, tag)
+			result = append(result, tagged)
+		}
+	}
+	return result
+}
+
+// ScopeTag returns the scope matching the resources of a kind that have the tag
+func ScopeTag(kind, tag string) string {
+	return Scope(kind, "tag", tag)
+}
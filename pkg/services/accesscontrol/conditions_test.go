@@ -0,0 +1,196 @@
+package accesscontrol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCondition_Matches(t *testing.T) {
+	// Wednesday
+	now := time.Date(2023, time.November, 15, 10, 30, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		condition *Condition
+		cc        ConditionContext
+		want      bool
+	}{
+		{
+			name:      "no condition",
+			condition: nil,
+			cc:        ConditionContext{Now: now},
+			want:      true,
+		},
+		{
+			name:      "within time range",
+			condition: &Condition{NotBefore: &before, NotAfter: &after},
+			cc:        ConditionContext{Now: now},
+			want:      true,
+		},
+		{
+			name:      "before time range",
+			condition: &Condition{NotBefore: &after},
+			cc:        ConditionContext{Now: now},
+			want:      false,
+		},
+		{
+			name:      "after time range",
+			condition: &Condition{NotAfter: &before},
+			cc:        ConditionContext{Now: now},
+			want:      false,
+		},
+		{
+			name:      "within schedule",
+			condition: &Condition{Schedule: &Schedule{Days: []string{"Monday", "Wednesday"}, Start: "09:00", End: "17:00"}},
+			cc:        ConditionContext{Now: now},
+			want:      true,
+		},
+		{
+			name:      "outside schedule days",
+			condition: &Condition{Schedule: &Schedule{Days: []string{"saturday", "sunday"}, Start: "09:00", End: "17:00"}},
+			cc:        ConditionContext{Now: now},
+			want:      false,
+		},
+		{
+			name:      "outside schedule hours in timezone",
+			condition: &Condition{Schedule: &Schedule{Start: "09:00", End: "17:00", Timezone: "America/New_York"}},
+			cc:        ConditionContext{Now: now},
+			want:      false,
+		},
+		{
+			name:      "within schedule spanning midnight",
+			condition: &Condition{Schedule: &Schedule{Start: "22:00", End: "11:00"}},
+			cc:        ConditionContext{Now: now},
+			want:      true,
+		},
+		{
+			name:      "request from allowed network",
+			condition: &Condition{CIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+			cc:        ConditionContext{Now: now, RemoteAddr: "192.168.1.12"},
+			want:      true,
+		},
+		{
+			name:      "request from other network",
+			condition: &Condition{CIDRs: []string{"10.0.0.0/8"}},
+			cc:        ConditionContext{Now: now, RemoteAddr: "192.168.1.12"},
+			want:      false,
+		},
+		{
+			name:      "request without remote address",
+			condition: &Condition{CIDRs: []string{"10.0.0.0/8"}},
+			cc:        ConditionContext{Now: now},
+			want:      false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.condition.Matches(tt.cc))
+		})
+	}
+}
+
+func TestCondition_Validate(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		condition *Condition
+		scope     string
+		wantErr   bool
+	}{
+		{
+			name:      "valid condition",
+			condition: &Condition{CIDRs: []string{"10.0.0.0/8"}, Schedule: &Schedule{Start: "09:00", End: "17:00"}},
+			scope:     "dashboards:uid:1",
+		},
+		{
+			name:      "invalid time range",
+			condition: &Condition{NotBefore: &now, NotAfter: &now},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid cidr",
+			condition: &Condition{CIDRs: []string{"10.0.0.1"}},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid schedule day",
+			condition: &Condition{Schedule: &Schedule{Days: []string{"someday"}, Start: "09:00", End: "17:00"}},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid schedule time",
+			condition: &Condition{Schedule: &Schedule{Start: "9am", End: "17:00"}},
+			wantErr:   true,
+		},
+		{
+			name:      "resource tags on wildcard scope",
+			condition: &Condition{ResourceTags: []string{"production"}},
+			scope:     "dashboards:uid:*",
+		},
+		{
+			name:      "resource tags on specific scope",
+			condition: &Condition{ResourceTags: []string{"production"}},
+			scope:     "dashboards:uid:1",
+			wantErr:   true,
+		},
+		{
+			name:      "resource tags on global wildcard",
+			condition: &Condition{ResourceTags: []string{"production"}},
+			scope:     "*",
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.condition.Validate(tt.scope)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidCondition)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestApplyConditions(t *testing.T) {
+	now := time.Now()
+	expired := now.Add(-time.Hour)
+
+	encode := func(c *Condition) string {
+		raw, err := c.Encode()
+		require.NoError(t, err)
+		return raw
+	}
+
+	permissions := []Permission{
+		{Action: "dashboards:read", Scope: "dashboards:uid:1"},
+		{Action: "dashboards:write", Scope: "dashboards:uid:1", Conditions: encode(&Condition{NotAfter: &expired})},
+		{Action: "dashboards:delete", Scope: "dashboards:uid:2", Conditions: encode(&Condition{CIDRs: []string{"10.0.0.0/8"}})},
+		{Action: "dashboards:read", Scope: "dashboards:*", Conditions: encode(&Condition{ResourceTags: []string{"production", "staging"}})},
+		{Action: "dashboards:write", Scope: "dashboards:uid:3", Conditions: "not json"},
+	}
+
+	ctx := WithConditionContext(context.Background(), ConditionContext{Now: now, RemoteAddr: "10.1.2.3"})
+	got := ApplyConditions(ctx, permissions)
+
+	assert.Equal(t, []Permission{
+		{Action: "dashboards:read", Scope: "dashboards:uid:1"},
+		permissions[2],
+		{Action: "dashboards:read", Scope: "dashboards:tag:production", Conditions: permissions[3].Conditions},
+		{Action: "dashboards:read", Scope: "dashboards:tag:staging", Conditions: permissions[3].Conditions},
+	}, got)
+
+	t.Run("network conditions do not match without request context", func(t *testing.T) {
+		got := ApplyConditions(context.Background(), permissions[2:3])
+		assert.Empty(t, got)
+	})
+}
@@ -30,7 +30,8 @@ func (s *AccessControlStore) GetUserPermissions(ctx context.Context, query acces
 		q := `
 		SELECT
 			permission.action,
-			permission.scope
+			permission.scope,
+			permission.conditions
 			FROM permission
 			INNER JOIN role ON role.id = permission.role_id
 		` + filter
@@ -56,9 +57,10 @@ func (s *AccessControlStore) GetUserPermissions(ctx context.Context, query acces
 // SearchUsersPermissions returns the list of user permissions indexed by UserID
 func (s *AccessControlStore) SearchUsersPermissions(ctx context.Context, orgID int64, options accesscontrol.SearchOptions) (map[int64][]accesscontrol.Permission, error) {
 	type UserRBACPermission struct {
-		UserID int64  `xorm:"user_id"`
-		Action string `xorm:"action"`
-		Scope  string `xorm:"scope"`
+		UserID     int64  `xorm:"user_id"`
+		Action     string `xorm:"action"`
+		Scope      string `xorm:"scope"`
+		Conditions string `xorm:"conditions"`
 	}
 	dbPerms := make([]UserRBACPermission, 0)
 	if err := s.sql.WithDbSession(ctx, func(sess *db.Session) error {
@@ -67,23 +69,24 @@ func (s *AccessControlStore) SearchUsersPermissions(ctx context.Context, orgID i
 		SELECT
 			user_id,
 			action,
-			scope
+			scope,
+			conditions
 		FROM (
-			SELECT ur.user_id, ur.org_id, p.action, p.scope
+			SELECT ur.user_id, ur.org_id, p.action, p.scope, p.conditions
 				FROM permission AS p
 				INNER JOIN user_role AS ur on ur.role_id = p.role_id
 			UNION ALL
-				SELECT tm.user_id, tr.org_id, p.action, p.scope
+				SELECT tm.user_id, tr.org_id, p.action, p.scope, p.conditions
 					FROM permission AS p
 					INNER JOIN team_role AS tr ON tr.role_id = p.role_id
 					INNER JOIN team_member AS tm ON tm.team_id = tr.team_id
 			UNION ALL
-				SELECT ou.user_id, ou.org_id, p.action, p.scope
+				SELECT ou.user_id, ou.org_id, p.action, p.scope, p.conditions
 					FROM permission AS p
 					INNER JOIN builtin_role AS br ON br.role_id = p.role_id
 					INNER JOIN org_user AS ou ON ou.role = br.role
 			UNION ALL
-				SELECT sa.user_id, br.org_id, p.action, p.scope
+				SELECT sa.user_id, br.org_id, p.action, p.scope, p.conditions
 					FROM permission AS p
 					INNER JOIN builtin_role AS br ON br.role_id = p.role_id
 					INNER JOIN (
@@ -123,7 +126,7 @@ func (s *AccessControlStore) SearchUsersPermissions(ctx context.Context, orgID i
 
 	mapped := map[int64][]accesscontrol.Permission{}
 	for i := range dbPerms {
-		mapped[dbPerms[i].UserID] = append(mapped[dbPerms[i].UserID], accesscontrol.Permission{Action: dbPerms[i].Action, Scope: dbPerms[i].Scope})
+		mapped[dbPerms[i].UserID] = append(mapped[dbPerms[i].UserID], accesscontrol.Permission{Action: dbPerms[i].Action, Scope: dbPerms[i].Scope, Conditions: dbPerms[i].Conditions})
 	}
 
 	return mapped, nil
@@ -137,17 +137,18 @@ func getRolePermissions(ctx context.Context, sess *db.Session, id int64) ([]acce
 }
 
 func permissionDiff(previous, new []accesscontrol.Permission) (added, removed []accesscontrol.Permission) {
-	type key struct{ Action, Scope string }
+	// permissions with different conditions are replaced
+	type key struct{ Action, Scope, Conditions string }
 	prevMap := map[key]int64{}
 	for i := range previous {
-		prevMap[key{previous[i].Action, previous[i].Scope}] = previous[i].ID
+		prevMap[key{previous[i].Action, previous[i].Scope, previous[i].Conditions}] = previous[i].ID
 	}
 	newMap := map[key]int64{}
 	for i := range new {
-		newMap[key{new[i].Action, new[i].Scope}] = 0
+		newMap[key{new[i].Action, new[i].Scope, new[i].Conditions}] = 0
 	}
 	for i := range new {
-		key := key{new[i].Action, new[i].Scope}
+		key := key{new[i].Action, new[i].Scope, new[i].Conditions}
 		if _, already := prevMap[key]; !already {
 			added = append(added, new[i])
 		} else {
@@ -156,7 +157,7 @@ func permissionDiff(previous, new []accesscontrol.Permission) (added, removed []
 	}
 
 	for p, id := range prevMap {
-		removed = append(removed, accesscontrol.Permission{ID: id, Action: p.Action, Scope: p.Scope})
+		removed = append(removed, accesscontrol.Permission{ID: id, Action: p.Action, Scope: p.Scope, Conditions: p.Conditions})
 	}
 
 	return added, removed
@@ -186,6 +186,9 @@ type Permission struct {
 	Attribute  string `json:"-"`
 	Identifier string `json:"-"`
 
+	// Conditions restricting the permission, encoded Condition
+	Conditions string `json:"conditions,omitempty" xorm:"conditions"`
+
 	Updated time.Time `json:"updated"`
 	Created time.Time `json:"created"`
 }
@@ -239,6 +242,7 @@ type ResourcePermission struct {
 	IsManaged        bool
 	IsInherited      bool
 	IsServiceAccount bool
+	Conditions       string
 	Created          time.Time
 	Updated          time.Time
 }
@@ -267,10 +271,11 @@ func (p *ResourcePermission) Contains(targetActions []string) bool {
 }
 
 type SetResourcePermissionCommand struct {
-	UserID      int64  `json:"userId,omitempty"`
-	TeamID      int64  `json:"teamId,omitempty"`
-	BuiltinRole string `json:"builtInRole,omitempty"`
-	Permission  string `json:"permission"`
+	UserID      int64      `json:"userId,omitempty"`
+	TeamID      int64      `json:"teamId,omitempty"`
+	BuiltinRole string     `json:"builtInRole,omitempty"`
+	Permission  string     `json:"permission"`
+	Conditions  *Condition `json:"conditions,omitempty"`
 }
 
 type SaveExternalServiceRoleCommand struct {
@@ -303,6 +308,13 @@ func (cmd *SaveExternalServiceRoleCommand) Validate() error {
 		if len(cmd.Permissions[i].Action) == 0 {
 			return fmt.Errorf("external service %v requests a permission with no Action", cmd.ExternalServiceID)
 		}
+		condition, err := ParseCondition(cmd.Permissions[i].Conditions)
+		if err != nil {
+			return err
+		}
+		if err := condition.Validate(cmd.Permissions[i].Scope); err != nil {
+			return err
+		}
 		if dedupMap[cmd.Permissions[i]] {
 			continue
 		}
@@ -44,7 +44,7 @@ var (
 func ProvideTeamPermissions(
 	features featuremgmt.FeatureToggles, router routing.RouteRegister, sql db.DB,
 	ac accesscontrol.AccessControl, license licensing.Licensing, service accesscontrol.Service,
-	teamService team.Service, userService user.Service,
+	teamService team.Service, userService user.Service, provisioned resourcepermissions.ProvisionedChecker,
 ) (*TeamPermissionsService, error) {
 	options := resourcepermissions.Options{
 		Resource:          "teams",
@@ -75,9 +75,10 @@ func ProvideTeamPermissions(
 			"Member": TeamMemberActions,
 			"Admin":  TeamAdminActions,
 		},
-		ReaderRoleName: "Team permission reader",
-		WriterRoleName: "Team permission writer",
-		RoleGroup:      "Teams",
+		ProvisionedChecker: provisioned,
+		ReaderRoleName:     "Team permission reader",
+		WriterRoleName:     "Team permission writer",
+		RoleGroup:          "Teams",
 		OnSetUser: func(session *db.Session, orgID int64, user accesscontrol.User, resourceID, permission string) error {
 			teamId, err := strconv.ParseInt(resourceID, 10, 64)
 			if err != nil {
@@ -118,7 +119,7 @@ var DashboardAdminActions = append(DashboardEditActions, []string{dashboards.Act
 func ProvideDashboardPermissions(
 	features featuremgmt.FeatureToggles, router routing.RouteRegister, sql db.DB, ac accesscontrol.AccessControl,
 	license licensing.Licensing, dashboardStore dashboards.Store, folderService folder.Service, service accesscontrol.Service,
-	teamService team.Service, userService user.Service,
+	teamService team.Service, userService user.Service, provisioned resourcepermissions.ProvisionedChecker,
 ) (*DashboardPermissionsService, error) {
 	getDashboard := func(ctx context.Context, orgID int64, resourceID string) (*dashboards.Dashboard, error) {
 		query := &dashboards.GetDashboardQuery{UID: resourceID, OrgID: orgID}
@@ -176,9 +177,10 @@ func ProvideDashboardPermissions(
 			"Edit":  DashboardEditActions,
 			"Admin": DashboardAdminActions,
 		},
-		ReaderRoleName: "Dashboard permission reader",
-		WriterRoleName: "Dashboard permission writer",
-		RoleGroup:      "Dashboards",
+		ProvisionedChecker: provisioned,
+		ReaderRoleName:     "Dashboard permission reader",
+		WriterRoleName:     "Dashboard permission writer",
+		RoleGroup:          "Dashboards",
 	}
 
 	srv, err := resourcepermissions.New(options, features, router, license, ac, service, sql, teamService, userService)
@@ -209,7 +211,7 @@ var FolderAdminActions = append(FolderEditActions, []string{dashboards.ActionFol
 func ProvideFolderPermissions(
 	features featuremgmt.FeatureToggles, router routing.RouteRegister, sql db.DB, accesscontrol accesscontrol.AccessControl,
 	license licensing.Licensing, dashboardStore dashboards.Store, folderService folder.Service, service accesscontrol.Service,
-	teamService team.Service, userService user.Service,
+	teamService team.Service, userService user.Service, provisioned resourcepermissions.ProvisionedChecker,
 ) (*FolderPermissionsService, error) {
 	options := resourcepermissions.Options{
 		Resource:          "folders",
@@ -241,9 +243,10 @@ func ProvideFolderPermissions(
 			"Edit":  append(DashboardEditActions, FolderEditActions...),
 			"Admin": append(DashboardAdminActions, FolderAdminActions...),
 		},
-		ReaderRoleName: "Folder permission reader",
-		WriterRoleName: "Folder permission writer",
-		RoleGroup:      "Folders",
+		ProvisionedChecker: provisioned,
+		ReaderRoleName:     "Folder permission reader",
+		WriterRoleName:     "Folder permission writer",
+		RoleGroup:          "Folders",
 	}
 	srv, err := resourcepermissions.New(options, features, router, license, accesscontrol, service, sql, teamService, userService)
 	if err != nil {
@@ -76,20 +76,21 @@ func (a *api) getDescription(c *contextmodel.ReqContext) response.Response {
 }
 
 type resourcePermissionDTO struct {
-	ID               int64    `json:"id"`
-	RoleName         string   `json:"roleName"`
-	IsManaged        bool     `json:"isManaged"`
-	IsInherited      bool     `json:"isInherited"`
-	IsServiceAccount bool     `json:"isServiceAccount"`
-	UserID           int64    `json:"userId,omitempty"`
-	UserLogin        string   `json:"userLogin,omitempty"`
-	UserAvatarUrl    string   `json:"userAvatarUrl,omitempty"`
-	Team             string   `json:"team,omitempty"`
-	TeamID           int64    `json:"teamId,omitempty"`
-	TeamAvatarUrl    string   `json:"teamAvatarUrl,omitempty"`
-	BuiltInRole      string   `json:"builtInRole,omitempty"`
-	Actions          []string `json:"actions"`
-	Permission       string   `json:"permission"`
+	ID               int64                    `json:"id"`
+	RoleName         string                   `json:"roleName"`
+	IsManaged        bool                     `json:"isManaged"`
+	IsInherited      bool                     `json:"isInherited"`
+	IsServiceAccount bool                     `json:"isServiceAccount"`
+	UserID           int64                    `json:"userId,omitempty"`
+	UserLogin        string                   `json:"userLogin,omitempty"`
+	UserAvatarUrl    string                   `json:"userAvatarUrl,omitempty"`
+	Team             string                   `json:"team,omitempty"`
+	TeamID           int64                    `json:"teamId,omitempty"`
+	TeamAvatarUrl    string                   `json:"teamAvatarUrl,omitempty"`
+	BuiltInRole      string                   `json:"builtInRole,omitempty"`
+	Actions          []string                 `json:"actions"`
+	Permission       string                   `json:"permission"`
+	Conditions       *accesscontrol.Condition `json:"conditions,omitempty"`
 }
 
 func (a *api) getPermissions(c *contextmodel.ReqContext) response.Response {
@@ -116,6 +117,11 @@ func (a *api) getPermissions(c *contextmodel.ReqContext) response.Response {
 				teamAvatarUrl = dtos.GetGravatarUrlWithDefault(p.TeamEmail, p.Team)
 			}
 
+			conditions, err := accesscontrol.ParseCondition(p.Conditions)
+			if err != nil {
+				return response.Error(http.StatusInternalServerError, "failed to get permissions", err)
+			}
+
 			dto = append(dto, resourcePermissionDTO{
 				ID:               p.ID,
 				RoleName:         p.RoleName,
@@ -131,6 +137,7 @@ func (a *api) getPermissions(c *contextmodel.ReqContext) response.Response {
 				IsManaged:        p.IsManaged,
 				IsInherited:      p.IsInherited,
 				IsServiceAccount: p.IsServiceAccount,
+				Conditions:       conditions,
 			})
 		}
 	}
@@ -139,7 +146,8 @@ func (a *api) getPermissions(c *contextmodel.ReqContext) response.Response {
 }
 
 type setPermissionCommand struct {
-	Permission string `json:"permission"`
+	Permission string                   `json:"permission"`
+	Conditions *accesscontrol.Condition `json:"conditions,omitempty"`
 }
 
 type setPermissionsCommand struct {
@@ -152,13 +160,20 @@ func (a *api) setUserPermission(c *contextmodel.ReqContext) response.Response {
 		return response.Error(http.StatusBadRequest, "userID is invalid", err)
 	}
 	resourceID := web.Params(c.Req)[":resourceID"]
+	if resp := a.provisionedResponse(c, resourceID); resp != nil {
+		return resp
+	}
 
 	var cmd setPermissionCommand
 	if err := web.Bind(c.Req, &cmd); err != nil {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
 
-	_, err = a.service.SetUserPermission(c.Req.Context(), c.SignedInUser.GetOrgID(), accesscontrol.User{ID: userID}, resourceID, cmd.Permission)
+	_, err = a.service.SetPermissions(c.Req.Context(), c.SignedInUser.GetOrgID(), resourceID, accesscontrol.SetResourcePermissionCommand{
+		UserID:     userID,
+		Permission: cmd.Permission,
+		Conditions: cmd.Conditions,
+	})
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "failed to set user permission", err)
 	}
@@ -172,13 +187,20 @@ func (a *api) setTeamPermission(c *contextmodel.ReqContext) response.Response {
 		return response.Error(http.StatusBadRequest, "teamID is invalid", err)
 	}
 	resourceID := web.Params(c.Req)[":resourceID"]
+	if resp := a.provisionedResponse(c, resourceID); resp != nil {
+		return resp
+	}
 
 	var cmd setPermissionCommand
 	if err := web.Bind(c.Req, &cmd); err != nil {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
 
-	_, err = a.service.SetTeamPermission(c.Req.Context(), c.SignedInUser.GetOrgID(), teamID, resourceID, cmd.Permission)
+	_, err = a.service.SetPermissions(c.Req.Context(), c.SignedInUser.GetOrgID(), resourceID, accesscontrol.SetResourcePermissionCommand{
+		TeamID:     teamID,
+		Permission: cmd.Permission,
+		Conditions: cmd.Conditions,
+	})
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "failed to set team permission", err)
 	}
@@ -189,13 +211,20 @@ func (a *api) setTeamPermission(c *contextmodel.ReqContext) response.Response {
 func (a *api) setBuiltinRolePermission(c *contextmodel.ReqContext) response.Response {
 	builtInRole := web.Params(c.Req)[":builtInRole"]
 	resourceID := web.Params(c.Req)[":resourceID"]
+	if resp := a.provisionedResponse(c, resourceID); resp != nil {
+		return resp
+	}
 
 	cmd := setPermissionCommand{}
 	if err := web.Bind(c.Req, &cmd); err != nil {
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
 
-	_, err := a.service.SetBuiltInRolePermission(c.Req.Context(), c.SignedInUser.GetOrgID(), builtInRole, resourceID, cmd.Permission)
+	_, err := a.service.SetPermissions(c.Req.Context(), c.SignedInUser.GetOrgID(), resourceID, accesscontrol.SetResourcePermissionCommand{
+		BuiltinRole: builtInRole,
+		Permission:  cmd.Permission,
+		Conditions:  cmd.Conditions,
+	})
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "failed to set role permission", err)
 	}
@@ -205,6 +234,9 @@ func (a *api) setBuiltinRolePermission(c *contextmodel.ReqContext) response.Resp
 
 func (a *api) setPermissions(c *contextmodel.ReqContext) response.Response {
 	resourceID := web.Params(c.Req)[":resourceID"]
+	if resp := a.provisionedResponse(c, resourceID); resp != nil {
+		return resp
+	}
 
 	cmd := setPermissionsCommand{}
 	if err := web.Bind(c.Req, &cmd); err != nil {
@@ -219,6 +251,21 @@ func (a *api) setPermissions(c *contextmodel.ReqContext) response.Response {
 	return response.Success("Permissions updated")
 }
 
+// provisionedResponse returns an error response if the permissions of the resource are managed by provisioning
+func (a *api) provisionedResponse(c *contextmodel.ReqContext, resourceID string) response.Response {
+	if a.service.options.ProvisionedChecker == nil {
+		return nil
+	}
+	provisioned, err := a.service.options.ProvisionedChecker.IsProvisioned(c.Req.Context(), c.SignedInUser.GetOrgID(), a.service.options.Resource, resourceID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "failed to check if permissions are provisioned", err)
+	}
+	if provisioned {
+		return response.Error(http.StatusBadRequest, "cannot modify provisioned permissions", nil)
+	}
+	return nil
+}
+
 func permissionSetResponse(cmd setPermissionCommand) response.Response {
 	message := "Permission updated"
 	if cmd.Permission == "" {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/database"
 	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/org/orgimpl"
@@ -430,6 +431,91 @@ func TestApi_setUserPermission(t *testing.T) {
 	}
 }
 
+func TestApi_setPermissionConditions(t *testing.T) {
+	service, sql, _ := setupTestEnvironment(t, testOptions)
+	server := setupTestServer(t, &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{1: accesscontrol.GroupScopesByAction([]accesscontrol.Permission{
+			{Action: "dashboards.permissions:read", Scope: "dashboards:id:1"},
+			{Action: "dashboards.permissions:write", Scope: "dashboards:id:1"},
+			{Action: accesscontrol.ActionOrgUsersRead, Scope: accesscontrol.ScopeUsersAll},
+		})},
+	}, service)
+
+	orgSvc, err := orgimpl.ProvideService(sql, sql.Cfg, quotatest.New(false, nil))
+	require.NoError(t, err)
+	usrSvc, err := userimpl.ProvideService(sql, orgSvc, sql.Cfg, nil, nil, &quotatest.FakeQuotaService{}, supportbundlestest.NewFakeBundleService())
+	require.NoError(t, err)
+	u, err := usrSvc.Create(context.Background(), &user.CreateUserCommand{Login: "test", OrgID: 1})
+	require.NoError(t, err)
+	userID := strconv.FormatInt(u.ID, 10)
+
+	t.Run("should reject invalid conditions", func(t *testing.T) {
+		recorder := setPermissionWithBody(t, server, testOptions.Resource, "1", "users", userID, `{"permission": "View", "conditions": {"resourceTags": ["production"]}}`)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	})
+
+	t.Run("should set a conditional permission", func(t *testing.T) {
+		recorder := setPermissionWithBody(t, server, testOptions.Resource, "1", "users", userID, `{"permission": "View", "conditions": {"cidrs": ["10.0.0.0/8"]}}`)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		permissions, _ := getPermission(t, server, testOptions.Resource, "1")
+		require.Len(t, permissions, 1)
+		require.NotNil(t, permissions[0].Conditions)
+		assert.Equal(t, []string{"10.0.0.0/8"}, permissions[0].Conditions.CIDRs)
+
+		// the condition is applied to the permissions of the user
+		stored, err := database.ProvideService(sql).GetUserPermissions(context.Background(), accesscontrol.GetUserPermissionsQuery{OrgID: 1, UserID: u.ID})
+		require.NoError(t, err)
+		internal := accesscontrol.WithConditionContext(context.Background(), accesscontrol.ConditionContext{RemoteAddr: "10.1.2.3"})
+		assert.True(t, hasPermission(accesscontrol.ApplyConditions(internal, stored), "dashboards:read", "dashboards:id:1"))
+		external := accesscontrol.WithConditionContext(context.Background(), accesscontrol.ConditionContext{RemoteAddr: "192.168.0.1"})
+		assert.False(t, hasPermission(accesscontrol.ApplyConditions(external, stored), "dashboards:read", "dashboards:id:1"))
+	})
+
+	t.Run("should remove the conditions when the permission is set without conditions", func(t *testing.T) {
+		recorder := setPermission(t, server, testOptions.Resource, "1", "View", "users", userID)
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		permissions, _ := getPermission(t, server, testOptions.Resource, "1")
+		require.Len(t, permissions, 1)
+		assert.Nil(t, permissions[0].Conditions)
+	})
+}
+
+func TestApi_setProvisionedPermission(t *testing.T) {
+	options := testOptions
+	options.ProvisionedChecker = fakeProvisionedChecker{"dashboards:1": true}
+	service, _, _ := setupTestEnvironment(t, options)
+	server := setupTestServer(t, &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{1: accesscontrol.GroupScopesByAction([]accesscontrol.Permission{
+			{Action: "dashboards.permissions:write", Scope: "dashboards:id:*"},
+		})},
+	}, service)
+
+	recorder := setPermission(t, server, testOptions.Resource, "1", "View", "builtInRoles", "Viewer")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = setPermission(t, server, testOptions.Resource, "2", "View", "builtInRoles", "Viewer")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+type fakeProvisionedChecker map[string]bool
+
+func (f fakeProvisionedChecker) IsProvisioned(ctx context.Context, orgID int64, resource, resourceID string) (bool, error) {
+	return f[resource+":"+resourceID], nil
+}
+
+func hasPermission(permissions []accesscontrol.Permission, action, scope string) bool {
+	for _, p := range permissions {
+		if p.Action == action && p.Scope == scope {
+			return true
+		}
+	}
+	return false
+}
+
 func setupTestServer(t *testing.T, user *user.SignedInUser, service *Service) *web.Mux {
 	server := web.New()
 	server.UseMiddleware(web.Renderer(path.Join(setting.StaticRootPath, "views"), "[[", "]]"))
@@ -484,8 +570,11 @@ func getPermission(t *testing.T, server *web.Mux, resource, resourceID string) (
 }
 
 func setPermission(t *testing.T, server *web.Mux, resource, resourceID, permission, assignment, assignTo string) *httptest.ResponseRecorder {
-	body := strings.NewReader(fmt.Sprintf(`{"permission": "%s"}`, permission))
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/access-control/%s/%s/%s/%s", resource, resourceID, assignment, assignTo), body)
+	return setPermissionWithBody(t, server, resource, resourceID, assignment, assignTo, fmt.Sprintf(`{"permission": "%s"}`, permission))
+}
+
+func setPermissionWithBody(t *testing.T, server *web.Mux, resource, resourceID, assignment, assignTo, body string) *httptest.ResponseRecorder {
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/access-control/%s/%s/%s/%s", resource, resourceID, assignment, assignTo), strings.NewReader(body))
 	require.NoError(t, err)
 	req.Header.Set("Content-Type", "application/json")
 	recorder := httptest.NewRecorder()
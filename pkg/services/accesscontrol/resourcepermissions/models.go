@@ -11,6 +11,8 @@ type SetResourcePermissionCommand struct {
 	ResourceID        string
 	ResourceAttribute string
 	Permission        string
+	// Conditions restricting the permission, encoded accesscontrol.Condition
+	Conditions string
 }
 
 type SetResourcePermissionsCommand struct {
@@ -11,6 +11,11 @@ import (
 type ResourceValidator func(ctx context.Context, orgID int64, resourceID string) error
 type InheritedScopesSolver func(ctx context.Context, orgID int64, resourceID string) ([]string, error)
 
+// ProvisionedChecker reports if the permissions of a resource are managed by provisioning
+type ProvisionedChecker interface {
+	IsProvisioned(ctx context.Context, orgID int64, resource, resourceID string) (bool, error)
+}
+
 type Options struct {
 	// Resource is the action and scope prefix that is generated
 	Resource string
@@ -40,6 +45,8 @@ type Options struct {
 	OnSetBuiltInRole func(session *db.Session, orgID int64, builtInRole, resourceID, permission string) error
 	// InheritedScopesSolver if configured can generate additional scopes that will be used when fetching permissions for a resource
 	InheritedScopesSolver InheritedScopesSolver
+	// ProvisionedChecker if configured rejects changes to the permissions of provisioned resources through the api
+	ProvisionedChecker ProvisionedChecker
 	// LicenseMV if configured is applied to endpoints that can modify permissions
 	LicenseMW web.Handler
 }
@@ -236,6 +236,11 @@ func (s *Service) SetPermissions(
 			return nil, err
 		}
 
+		conditions, err := s.encodeConditions(cmd.Conditions, resourceID)
+		if err != nil {
+			return nil, err
+		}
+
 		dbCommands = append(dbCommands, SetResourcePermissionsCommand{
 			User:        accesscontrol.User{ID: cmd.UserID},
 			TeamID:      cmd.TeamID,
@@ -246,6 +251,7 @@ func (s *Service) SetPermissions(
 				ResourceID:        resourceID,
 				ResourceAttribute: s.options.ResourceAttribute,
 				Permission:        cmd.Permission,
+				Conditions:        conditions,
 			},
 		})
 	}
@@ -287,6 +293,14 @@ func (s *Service) mapPermission(permission string) ([]string, error) {
 	return nil, ErrInvalidPermission
 }
 
+// encodeConditions validates the conditions against the scope of the resource and returns their stored representation
+func (s *Service) encodeConditions(condition *accesscontrol.Condition, resourceID string) (string, error) {
+	if err := condition.Validate(accesscontrol.Scope(s.options.Resource, s.options.ResourceAttribute, resourceID)); err != nil {
+		return "", err
+	}
+	return condition.Encode()
+}
+
 func (s *Service) validateResource(ctx context.Context, orgID int64, resourceID string) error {
 	if s.options.ResourceValidator != nil {
 		return s.options.ResourceValidator(ctx, orgID, resourceID)
@@ -37,6 +37,7 @@ type flatResourcePermission struct {
 	Team             string
 	BuiltInRole      string
 	IsServiceAccount bool `xorm:"is_service_account"`
+	Conditions       string
 	Created          time.Time
 	Updated          time.Time
 }
@@ -252,10 +253,13 @@ func (s *store) setResourcePermission(
 		missing[a] = struct{}{}
 	}
 
-	var remove []int64
+	var remove, update []int64
 	for _, p := range current {
 		if _, ok := missing[p.Action]; ok {
 			delete(missing, p.Action)
+			if p.Conditions != cmd.Conditions {
+				update = append(update, p.ID)
+			}
 		} else if !ok {
 			remove = append(remove, p.ID)
 		}
@@ -265,7 +269,11 @@ func (s *store) setResourcePermission(
 		return nil, err
 	}
 
-	if err := s.createPermissions(sess, role.ID, cmd.Resource, cmd.ResourceID, cmd.ResourceAttribute, missing); err != nil {
+	if err := updatePermissionConditions(sess, update, cmd.Conditions); err != nil {
+		return nil, err
+	}
+
+	if err := s.createPermissions(sess, role.ID, cmd.Resource, cmd.ResourceID, cmd.ResourceAttribute, cmd.Conditions, missing); err != nil {
 		return nil, err
 	}
 
@@ -497,6 +505,7 @@ func flatPermissionsToResourcePermission(scope string, permissions []flatResourc
 		BuiltInRole:      first.BuiltInRole,
 		Created:          first.Created,
 		Updated:          first.Updated,
+		Conditions:       first.Conditions,
 		IsManaged:        first.IsManaged(scope),
 		IsInherited:      first.IsInherited(scope),
 		IsServiceAccount: first.IsServiceAccount,
@@ -645,7 +654,7 @@ func (s *store) getPermissions(sess *db.Session, resource, resourceID, resourceA
 	return result, nil
 }
 
-func (s *store) createPermissions(sess *db.Session, roleID int64, resource, resourceID, resourceAttribute string, actions map[string]struct{}) error {
+func (s *store) createPermissions(sess *db.Session, roleID int64, resource, resourceID, resourceAttribute, conditions string, actions map[string]struct{}) error {
 	if len(actions) == 0 {
 		return nil
 	}
@@ -653,6 +662,7 @@ func (s *store) createPermissions(sess *db.Session, roleID int64, resource, reso
 	for action := range actions {
 		p := managedPermission(action, resource, resourceID, resourceAttribute)
 		p.RoleID = roleID
+		p.Conditions = conditions
 		p.Created = time.Now()
 		p.Updated = time.Now()
 		if s.features.IsEnabled(featuremgmt.FlagSplitScopes) {
@@ -667,6 +677,17 @@ func (s *store) createPermissions(sess *db.Session, roleID int64, resource, reso
 	return nil
 }
 
+func updatePermissionConditions(sess *db.Session, ids []int64, conditions string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := sess.Table("permission").In("id", ids).Cols("conditions", "updated").Update(map[string]any{
+		"conditions": conditions,
+		"updated":    time.Now(),
+	})
+	return err
+}
+
 func deletePermissions(sess *db.Session, ids []int64) error {
 	if len(ids) == 0 {
 		return nil
@@ -9,6 +9,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/auth"
 	"github.com/grafana/grafana/pkg/services/authn"
 	"github.com/grafana/grafana/pkg/services/contexthandler/ctxkey"
@@ -101,6 +102,8 @@ func (h *ContextHandler) Middleware(next http.Handler) http.Handler {
 		ctx = context.WithValue(ctx, reqContextKey{}, reqContext)
 		// store list of possible auth header in context
 		ctx = WithAuthHTTPHeaders(ctx, h.Cfg)
+		// store the request attributes used to evaluate conditional permissions
+		ctx = accesscontrol.WithConditionContext(ctx, accesscontrol.ConditionContextFromRequest(r))
 		// Set the context for the http.Request.Context
 		// This modifies both r and reqContext.Req since they point to the same value
 		*reqContext.Req = *reqContext.Req.WithContext(ctx)
@@ -167,7 +167,7 @@ func NewDashboardUIDScopeResolver(folderDB folder.FolderStore, ds DashboardServi
 func resolveDashboardScope(ctx context.Context, folderDB folder.FolderStore, orgID int64, dashboard *Dashboard, folderSvc folder.Service) ([]string, error) {
 	var folderUID string
 	if dashboard.FolderID < 0 {
-		return []string{ScopeDashboardsProvider.GetResourceScopeUID(dashboard.UID)}, nil
+		return append([]string{ScopeDashboardsProvider.GetResourceScopeUID(dashboard.UID)}, dashboardTagScopes(dashboard)...), nil
 	}
 
 	if dashboard.FolderID == 0 {
@@ -192,7 +192,20 @@ func resolveDashboardScope(ctx context.Context, folderDB folder.FolderStore, org
 		result...,
 	)
 
-	return result, nil
+	return append(result, dashboardTagScopes(dashboard)...), nil
+}
+
+// dashboardTagScopes returns the scopes matched by permissions restricted to dashboard tags
+func dashboardTagScopes(dashboard *Dashboard) []string {
+	if dashboard.Data == nil {
+		return nil
+	}
+	tags := dashboard.GetTags()
+	scopes := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		scopes = append(scopes, ac.ScopeTag(ScopeDashboardsRoot, tag))
+	}
+	return scopes
 }
 
 func GetInheritedScopes(ctx context.Context, orgID int64, folderUID string, folderSvc folder.Service) ([]string, error) {
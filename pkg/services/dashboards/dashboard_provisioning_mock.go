@@ -185,6 +185,20 @@ func (_m *FakeDashboardProvisioning) UnprovisionDashboard(ctx context.Context, d
 	return r0
 }
 
+// UpdateProvisionedDashboardCommitSHA provides a mock function with given fields: ctx, id, commitSHA
+func (_m *FakeDashboardProvisioning) UpdateProvisionedDashboardCommitSHA(ctx context.Context, id int64, commitSHA string) error {
+	ret := _m.Called(ctx, id, commitSHA)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, commitSHA)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewFakeDashboardProvisioning interface {
 	mock.TestingT
 	Cleanup(func())
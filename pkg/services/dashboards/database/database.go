@@ -66,7 +66,7 @@ func ProvideDashboardStore(sqlStore db.DB, cfg *setting.Cfg, features featuremgm
 }
 
 func (d *dashboardStore) emitEntityEvent() bool {
-	return d.features != nil && d.features.IsEnabled(featuremgmt.FlagPanelTitleSearch)
+	return store.EntityEventsEnabled(d.features)
 }
 
 func (d *dashboardStore) ValidateDashboardBeforeSave(ctx context.Context, dashboard *dashboards.Dashboard, overwrite bool) (bool, error) {
@@ -229,6 +229,14 @@ func (d *dashboardStore) UnprovisionDashboard(ctx context.Context, id int64) err
 	})
 }
 
+// UpdateProvisionedDashboardCommitSHA updates the commit of the dashboard_provisioning row with the given ID.
+func (d *dashboardStore) UpdateProvisionedDashboardCommitSHA(ctx context.Context, id int64, commitSHA string) error {
+	return d.store.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.ID(id).Cols("commit_sha").Update(&dashboards.DashboardProvisioning{CommitSHA: commitSHA})
+		return err
+	})
+}
+
 func (d *dashboardStore) DeleteOrphanedProvisionedDashboards(ctx context.Context, cmd *dashboards.DeleteOrphanedProvisionedDashboardsCommand) error {
 	return d.store.WithDbSession(ctx, func(sess *db.Session) error {
 		var result []*dashboards.DashboardProvisioning
@@ -461,7 +469,9 @@ func saveDashboard(sess *db.Session, cmd *dashboards.SaveDashboardCommand, emitE
 	var affectedRows int64
 	var err error
 
+	eventType := store.EntityEventTypeUpdate
 	if dash.ID == 0 {
+		eventType = store.EntityEventTypeCreate
 		dash.SetVersion(1)
 		dash.Created = time.Now()
 		dash.CreatedBy = userId
@@ -525,7 +535,7 @@ func saveDashboard(sess *db.Session, cmd *dashboards.SaveDashboardCommand, emitE
 	}
 
 	if emitEntityEvent {
-		_, err := sess.Insert(createEntityEvent(dash, store.EntityEventTypeUpdate))
+		_, err := sess.Insert(createEntityEvent(dash, eventType))
 		if err != nil {
 			return dash, err
 		}
@@ -847,21 +857,13 @@ func deleteFolderAlertRules(sess *db.Session, dashboard dashboards.Dashboard, fo
 }
 
 func createEntityEvent(dashboard *dashboards.Dashboard, eventType store.EntityEventType) *store.EntityEvent {
-	var entityEvent *store.EntityEvent
+	kind := store.EntityTypeDashboard
 	if dashboard.IsFolder {
-		entityEvent = &store.EntityEvent{
-			EventType: eventType,
-			EntityId:  store.CreateDatabaseEntityId(dashboard.UID, dashboard.OrgID, store.EntityTypeFolder),
-			Created:   time.Now().Unix(),
-		}
-	} else {
-		entityEvent = &store.EntityEvent{
-			EventType: eventType,
-			EntityId:  store.CreateDatabaseEntityId(dashboard.UID, dashboard.OrgID, store.EntityTypeDashboard),
-			Created:   time.Now().Unix(),
-		}
+		kind = store.EntityTypeFolder
 	}
-	return entityEvent
+	ev := store.NewDatabaseEntityEvent(eventType, dashboard.UID, dashboard.OrgID, kind)
+	ev.FolderUID = dashboard.FolderUID
+	return ev
 }
 
 func (d *dashboardStore) deleteAlertDefinition(dashboardId int64, sess *db.Session) error {
@@ -114,6 +114,19 @@ func TestIntegrationDashboardProvisioningTest(t *testing.T) {
 			require.NotNil(t, data)
 		})
 
+		t.Run("Can update the commit of a provisioned dashboard", func(t *testing.T) {
+			data, err := dashboardStore.GetProvisionedDataByDashboardID(context.Background(), dash.ID)
+			require.Nil(t, err)
+
+			commit := "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+			require.Nil(t, dashboardStore.UpdateProvisionedDashboardCommitSHA(context.Background(), data.ID, commit))
+
+			data, err = dashboardStore.GetProvisionedDataByDashboardID(context.Background(), dash.ID)
+			require.Nil(t, err)
+			require.Equal(t, commit, data.CommitSHA)
+			require.Equal(t, now.Unix(), data.Updated)
+		})
+
 		t.Run("Can query for none provisioned dashboard", func(t *testing.T) {
 			data, err := dashboardStore.GetProvisionedDataByDashboardID(context.Background(), 3000)
 			require.Nil(t, err)
@@ -271,6 +271,7 @@ type DashboardProvisioning struct {
 	Name        string
 	ExternalID  string `xorm:"external_id"`
 	CheckSum    string
+	CommitSHA   string `xorm:"commit_sha"`
 	Updated     int64
 }
 
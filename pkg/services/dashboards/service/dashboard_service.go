@@ -480,6 +480,11 @@ func (dr *DashboardServiceImpl) UnprovisionDashboard(ctx context.Context, dashbo
 	return dr.dashboardStore.UnprovisionDashboard(ctx, dashboardId)
 }
 
+// UpdateProvisionedDashboardCommitSHA records the commit of a provisioned dashboard that is already up to date.
+func (dr *DashboardServiceImpl) UpdateProvisionedDashboardCommitSHA(ctx context.Context, id int64, commitSHA string) error {
+	return dr.dashboardStore.UpdateProvisionedDashboardCommitSHA(ctx, id, commitSHA)
+}
+
 func (dr *DashboardServiceImpl) GetDashboardsByPluginID(ctx context.Context, query *dashboards.GetDashboardsByPluginIDQuery) ([]*dashboards.Dashboard, error) {
 	return dr.dashboardStore.GetDashboardsByPluginID(ctx, query)
 }
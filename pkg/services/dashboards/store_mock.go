@@ -476,6 +476,20 @@ func (_m *FakeDashboardStore) UpdateDashboardACL(ctx context.Context, uid int64,
 	return r0
 }
 
+// UpdateProvisionedDashboardCommitSHA provides a mock function with given fields: ctx, id, commitSHA
+func (_m *FakeDashboardStore) UpdateProvisionedDashboardCommitSHA(ctx context.Context, id int64, commitSHA string) error {
+	ret := _m.Called(ctx, id, commitSHA)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, commitSHA)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ValidateDashboardBeforeSave provides a mock function with given fields: ctx, dashboard, overwrite
 func (_m *FakeDashboardStore) ValidateDashboardBeforeSave(ctx context.Context, dashboard *Dashboard, overwrite bool) (bool, error) {
 	ret := _m.Called(ctx, dashboard, overwrite)
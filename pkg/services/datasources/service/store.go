@@ -16,8 +16,10 @@ import (
 	"github.com/grafana/grafana/pkg/infra/metrics"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/util"
 )
 
@@ -36,8 +38,9 @@ type Store interface {
 }
 
 type SqlStore struct {
-	db     db.DB
-	logger log.Logger
+	db       db.DB
+	logger   log.Logger
+	features featuremgmt.FeatureToggles
 }
 
 func CreateStore(db db.DB, logger log.Logger) *SqlStore {
@@ -164,6 +167,10 @@ func (ss *SqlStore) DeleteDataSource(ctx context.Context, cmd *datasources.Delet
 				ac.Scope(datasources.ScopeProvider.GetResourceScope(ds.UID))); errDeletingPerms != nil {
 				return errDeletingPerms
 			}
+
+			if err := ss.insertEntityEvent(sess, store.EntityEventTypeDelete, ds); err != nil {
+				return err
+			}
 		}
 
 		if cmd.UpdateSecretFn != nil {
@@ -288,6 +295,10 @@ func (ss *SqlStore) AddDataSource(ctx context.Context, cmd *datasources.AddDataS
 			return err
 		}
 
+		if err := ss.insertEntityEvent(sess, store.EntityEventTypeCreate, ds); err != nil {
+			return err
+		}
+
 		if cmd.UpdateSecretFn != nil {
 			if err := cmd.UpdateSecretFn(); err != nil {
 				// ss.logger.Error("Failed to update datasource secrets -- rolling back update", "name", cmd.Name, "type", cmd.Type, "orgId", cmd.OrgID)
@@ -380,6 +391,13 @@ func (ss *SqlStore) UpdateDataSource(ctx context.Context, cmd *datasources.Updat
 		}
 
 		err = updateIsDefaultFlag(ds, sess)
+		if err != nil {
+			return err
+		}
+
+		if err := ss.insertEntityEvent(sess, store.EntityEventTypeUpdate, ds); err != nil {
+			return err
+		}
 
 		if cmd.UpdateSecretFn != nil {
 			if err := cmd.UpdateSecretFn(); err != nil {
@@ -396,6 +414,15 @@ func (ss *SqlStore) UpdateDataSource(ctx context.Context, cmd *datasources.Updat
 	})
 }
 
+// insertEntityEvent records the change so it can be streamed to external consumers
+func (ss *SqlStore) insertEntityEvent(sess *db.Session, eventType store.EntityEventType, ds *datasources.DataSource) error {
+	if !store.EntityEventsEnabled(ss.features) {
+		return nil
+	}
+	_, err := sess.Insert(store.NewDatabaseEntityEvent(eventType, ds.UID, ds.OrgID, store.EntityTypeDataSource))
+	return err
+}
+
 func generateNewDatasourceUid(sess *db.Session, orgId int64) (string, error) {
 	for i := 0; i < 3; i++ {
 		uid := generateNewUid()
@@ -927,5 +927,11 @@ var (
 			FrontendOnly: true,
 			Owner:        grafanaObservabilityMetricsSquad,
 		},
+		{
+			Name:        "entityEventsStream",
+			Description: "Stream create/update/delete events for dashboards, folders, data sources, alert rules and library panels",
+			Stage:       FeatureStageExperimental,
+			Owner:       grafanaAppPlatformSquad,
+		},
 	}
 )
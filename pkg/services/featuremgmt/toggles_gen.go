@@ -534,4 +534,8 @@ const (
 	// FlagPrometheusPromQAIL
 	// Prometheus and AI/ML to assist users in creating a query
 	FlagPrometheusPromQAIL = "prometheusPromQAIL"
+
+	// FlagEntityEventsStream
+	// Stream create/update/delete events for dashboards, folders, data sources, alert rules and library panels
+	FlagEntityEventsStream = "entityEventsStream"
 )
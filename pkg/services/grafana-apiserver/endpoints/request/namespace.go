@@ -0,0 +1,26 @@
+package request
+
+import (
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// NamespaceMapper converts an orgID into a namespace
+type NamespaceMapper = func(orgId int64) string
+
+// GetNamespaceMapper returns a function that will convert orgIds into a consistent namespace
+func GetNamespaceMapper(cfg *setting.Cfg) NamespaceMapper {
+	if cfg != nil && cfg.StackID != "" {
+		return func(orgId int64) string { return "stack-" + cfg.StackID }
+	}
+	return OrgNamespaceFormatter
+}
+
+// OrgNamespaceFormatter is the namespace format used when not running in a cloud stack
+func OrgNamespaceFormatter(id int64) string {
+	if id == 1 {
+		return "default"
+	}
+	return fmt.Sprintf("org-%d", id)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/search"
 	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+	"github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/setting"
 	"github.com/grafana/grafana/pkg/util"
 )
@@ -185,7 +186,7 @@ func (l *LibraryElementService) createLibraryElement(c context.Context, signedIn
 			}
 			return err
 		}
-		return nil
+		return l.insertEntityEvent(session, store.EntityEventTypeCreate, element.UID, element.OrgID, element.FolderID)
 	})
 
 	dto := model.LibraryElementDTO{
@@ -257,7 +258,7 @@ func (l *LibraryElementService) deleteLibraryElement(c context.Context, signedIn
 		}
 
 		elementID = element.ID
-		return nil
+		return l.insertEntityEvent(session, store.EntityEventTypeDelete, element.UID, element.OrgID, element.FolderID)
 	})
 	return elementID, err
 }
@@ -606,6 +607,9 @@ func (l *LibraryElementService) patchLibraryElement(c context.Context, signedInU
 		} else if rowsAffected != 1 {
 			return model.ErrLibraryElementNotFound
 		}
+		if err := l.insertEntityEvent(session, store.EntityEventTypeUpdate, libraryElement.UID, libraryElement.OrgID, libraryElement.FolderID); err != nil {
+			return err
+		}
 
 		dto = model.LibraryElementDTO{
 			ID:          libraryElement.ID,
@@ -859,3 +863,19 @@ func (l *LibraryElementService) deleteLibraryElementsInFolderUID(c context.Conte
 		return nil
 	})
 }
+
+// insertEntityEvent records the change so it can be streamed to external consumers.
+// The event keeps the folder of the element, its events are visible to the users who can read the folder.
+func (l *LibraryElementService) insertEntityEvent(session *db.Session, eventType store.EntityEventType, uid string, orgID int64, folderID int64) error {
+	if !store.EntityEventsEnabled(l.features) {
+		return nil
+	}
+	ev := store.NewDatabaseEntityEvent(eventType, uid, orgID, store.EntityTypeLibraryPanel)
+	if folderID != 0 {
+		if _, err := session.SQL("SELECT uid FROM dashboard WHERE id=? AND org_id=? AND is_folder=?", folderID, orgID, l.SQLStore.GetDialect().BooleanStr(true)).Get(&ev.FolderUID); err != nil {
+			return err
+		}
+	}
+	_, err := session.Insert(ev)
+	return err
+}
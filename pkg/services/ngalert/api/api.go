@@ -8,6 +8,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/modules"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/datasourceproxy"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -49,6 +50,7 @@ type API struct {
 	RuleStore            RuleStore
 	AlertingStore        AlertingStore
 	AdminConfigStore     store.AdminConfigurationStore
+	OrgStore             store.OrgStore
 	DataProxy            *datasourceproxy.DataSourceProxyService
 	MultiOrgAlertmanager *notifier.MultiOrgAlertmanager
 	StateManager         *state.Manager
@@ -77,12 +79,22 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		ac:        api.AccessControl,
 	}
 
+	amSrv := AlertmanagerSrv{crypto: api.MultiOrgAlertmanager.Crypto, log: logger, ac: api.AccessControl, mam: api.MultiOrgAlertmanager}
 	// Register endpoints for proxying to Alertmanager-compatible backends.
 	api.RegisterAlertmanagerApiEndpoints(NewForkingAM(
 		api.DatasourceCache,
 		NewLotexAM(proxy, logger),
-		&AlertmanagerSrv{crypto: api.MultiOrgAlertmanager.Crypto, log: logger, ac: api.AccessControl, mam: api.MultiOrgAlertmanager},
+		&amSrv,
 	), m)
+	// Instances that only run the alert rule scheduler send alerts to the instances that run the notifier.
+	if _, runNotifier := modules.AlertingComponents(api.Cfg.Target, api.Cfg.UnifiedAlerting.ExecuteAlerts); runNotifier && api.Cfg.UnifiedAlerting.NotifierToken != "" {
+		notifierSrv := NotifierSrv{AlertmanagerSrv: amSrv, token: api.Cfg.UnifiedAlerting.NotifierToken, orgStore: api.OrgStore}
+		api.RouteRegister.Post(
+			"/api/alerting/notifier/orgs/:OrgID/api/v2/alerts",
+			notifierSrv.authorize,
+			routing.Wrap(notifierSrv.RoutePostNotifierAlerts),
+		)
+	}
 	// Register endpoints for proxying to Prometheus-compatible backends.
 	api.RegisterPrometheusApiEndpoints(NewForkingProm(
 		api.DatasourceCache,
@@ -94,14 +106,15 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		api.DatasourceCache,
 		NewLotexRuler(proxy, logger),
 		&RulerSrv{
-			conditionValidator: api.EvaluatorFactory,
-			QuotaService:       api.QuotaService,
-			store:              api.RuleStore,
-			provenanceStore:    api.ProvenanceStore,
-			xactManager:        api.TransactionManager,
-			log:                logger,
-			cfg:                &api.Cfg.UnifiedAlerting,
-			ac:                 api.AccessControl,
+			conditionValidator:   api.EvaluatorFactory,
+			QuotaService:         api.QuotaService,
+			store:                api.RuleStore,
+			provenanceStore:      api.ProvenanceStore,
+			xactManager:          api.TransactionManager,
+			log:                  logger,
+			cfg:                  &api.Cfg.UnifiedAlerting,
+			ac:                   api.AccessControl,
+			notificationSettings: api.MultiOrgAlertmanager,
 		},
 	), m)
 	api.RegisterTestingApiEndpoints(NewTestingApi(
@@ -167,6 +167,21 @@ func (srv AlertmanagerSrv) RouteGetAMAlertGroups(c *contextmodel.ReqContext) res
 	return response.JSON(http.StatusOK, groups)
 }
 
+// RoutePostAMAlerts adds the alerts to the Alertmanager of the organization. It lets instances that
+// only run the alert rule scheduler send alerts to instances that run the notifier.
+func (srv AlertmanagerSrv) RoutePostAMAlerts(c *contextmodel.ReqContext, body apimodels.PostableAlerts) response.Response {
+	am, errResp := srv.AlertmanagerFor(c.SignedInUser.GetOrgID())
+	if errResp != nil {
+		return errResp
+	}
+
+	if err := am.PutAlerts(c.Req.Context(), body); err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+
+	return response.JSON(http.StatusOK, util.DynMap{"message": "alerts created"})
+}
+
 func (srv AlertmanagerSrv) RouteGetAMAlerts(c *contextmodel.ReqContext) response.Response {
 	am, errResp := srv.AlertmanagerFor(c.SignedInUser.GetOrgID())
 	if errResp != nil {
@@ -847,3 +847,55 @@ func asGettableHistoricUserConfigs(t *testing.T, r response.Response) []apimodel
 	require.NoError(t, err)
 	return body
 }
+
+func TestRoutePostAMAlerts(t *testing.T) {
+	sut := createSut(t)
+	now := time.Now()
+
+	createRequestCtx := func(orgID int64) *contextmodel.ReqContext {
+		req, err := http.NewRequest(http.MethodPost, "/api/alertmanager/grafana/api/v2/alerts", nil)
+		require.NoError(t, err)
+		return &contextmodel.ReqContext{
+			Context:      &web.Context{Req: req},
+			SignedInUser: &user.SignedInUser{OrgID: orgID},
+		}
+	}
+
+	t.Run("should add the alerts to the Alertmanager of the organization", func(t *testing.T) {
+		alerts := apimodels.PostableAlerts{PostableAlerts: []amv2.PostableAlert{{
+			Alert: amv2.Alert{
+				Labels: amv2.LabelSet{"alertname": "test"},
+			},
+			Annotations: amv2.LabelSet{"summary": "test"},
+			StartsAt:    strfmt.DateTime(now),
+			EndsAt:      strfmt.DateTime(now.Add(time.Hour)),
+		}}}
+		resp := sut.RoutePostAMAlerts(createRequestCtx(1), alerts)
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		resp = sut.RouteGetAMAlerts(createRequestCtx(1))
+		require.Equal(t, http.StatusOK, resp.Status())
+		var gettable apimodels.GettableAlerts
+		require.NoError(t, json.Unmarshal(resp.Body(), &gettable))
+		require.Len(t, gettable, 1)
+		require.Equal(t, "test", gettable[0].Labels["alertname"])
+
+		resp = sut.RouteGetAMAlerts(createRequestCtx(2))
+		require.Equal(t, http.StatusOK, resp.Status())
+		require.NoError(t, json.Unmarshal(resp.Body(), &gettable))
+		require.Empty(t, gettable)
+	})
+
+	t.Run("should return 400 if the alerts are invalid", func(t *testing.T) {
+		alerts := apimodels.PostableAlerts{PostableAlerts: []amv2.PostableAlert{{
+			StartsAt: strfmt.DateTime(now),
+		}}}
+		resp := sut.RoutePostAMAlerts(createRequestCtx(1), alerts)
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("should return 404 if the organization has no Alertmanager", func(t *testing.T) {
+		resp := sut.RoutePostAMAlerts(createRequestCtx(10), apimodels.PostableAlerts{})
+		require.Equal(t, http.StatusNotFound, resp.Status())
+	})
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/util"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// NotifierSrv receives the alerts that instances that only run the alert rule scheduler send to the
+// instances that run the notifier. The requests are authenticated with the notifier token instead of
+// a user, so that the scheduler instances do not need credentials in every organization.
+type NotifierSrv struct {
+	AlertmanagerSrv
+	token    string
+	orgStore store.OrgStore
+}
+
+// authorize rejects requests that do not have the notifier token.
+func (srv NotifierSrv) authorize(c *contextmodel.ReqContext) {
+	token := c.Req.Header.Get(apimodels.NotifierTokenHeader)
+	if srv.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(srv.token)) != 1 {
+		srv.log.Warn("Rejected alerts from a notifier instance", "reason", "invalid notifier token", "remoteAddr", c.Req.RemoteAddr)
+		c.JsonApiErr(http.StatusUnauthorized, "invalid notifier token", nil)
+	}
+}
+
+// RoutePostNotifierAlerts adds the alerts to the Alertmanager of the organization in the path.
+func (srv NotifierSrv) RoutePostNotifierAlerts(c *contextmodel.ReqContext) response.Response {
+	orgID, err := strconv.ParseInt(web.Params(c.Req)[":OrgID"], 10, 64)
+	if err != nil {
+		srv.log.Warn("Rejected alerts from a notifier instance", "reason", "invalid organization ID", "remoteAddr", c.Req.RemoteAddr)
+		return ErrResp(http.StatusBadRequest, errors.New("invalid organization ID"), "")
+	}
+	exists, err := srv.orgExists(c.Req.Context(), orgID)
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to check the organization")
+	}
+	if !exists {
+		srv.log.Warn("Rejected alerts from a notifier instance", "reason", "organization not found", "org", orgID, "remoteAddr", c.Req.RemoteAddr)
+		return ErrResp(http.StatusNotFound, fmt.Errorf("organization %d not found", orgID), "")
+	}
+	alerts := apimodels.PostableAMAlerts{}
+	if err := web.Bind(c.Req, &alerts); err != nil {
+		srv.log.Warn("Rejected alerts from a notifier instance", "reason", "bad request data", "org", orgID, "remoteAddr", c.Req.RemoteAddr, "error", err)
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+
+	am, errResp := srv.AlertmanagerFor(orgID)
+	if errResp != nil {
+		srv.log.Warn("Rejected alerts from a notifier instance", "reason", "no Alertmanager for the organization", "org", orgID, "remoteAddr", c.Req.RemoteAddr)
+		return errResp
+	}
+	if err := am.PutAlerts(c.Req.Context(), apimodels.PostableAlerts{PostableAlerts: alerts}); err != nil {
+		srv.log.Warn("Rejected alerts from a notifier instance", "reason", "invalid alerts", "org", orgID, "remoteAddr", c.Req.RemoteAddr, "error", err)
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	return response.JSON(http.StatusOK, util.DynMap{"message": "alerts created"})
+}
+
+func (srv NotifierSrv) orgExists(ctx context.Context, orgID int64) (bool, error) {
+	orgIDs, err := srv.orgStore.GetOrgs(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, id := range orgIDs {
+		if id == orgID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
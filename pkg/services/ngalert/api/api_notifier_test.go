@@ -0,0 +1,118 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/log/logtest"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+func TestNotifierSrv(t *testing.T) {
+	orgStore := notifier.NewFakeOrgStore(t, []int64{1, 2, 3, 10})
+	sut := NotifierSrv{AlertmanagerSrv: createSut(t), token: "secret", orgStore: &orgStore}
+	now := time.Now()
+
+	createRequestCtx := func(t *testing.T, orgID string, token string, body any) *contextmodel.ReqContext {
+		t.Helper()
+		b, err := json.Marshal(body)
+		require.NoError(t, err)
+		req, err := http.NewRequest(http.MethodPost, "/api/alerting/notifier/orgs/"+orgID+"/api/v2/alerts", bytes.NewReader(b))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set(apimodels.NotifierTokenHeader, token)
+		}
+		req = web.SetURLParams(req, map[string]string{":OrgID": orgID})
+		return &contextmodel.ReqContext{
+			Context: &web.Context{
+				Req:  req,
+				Resp: web.NewResponseWriter(http.MethodPost, httptest.NewRecorder()),
+			},
+			SignedInUser: &user.SignedInUser{},
+			Logger:       log.NewNopLogger(),
+		}
+	}
+
+	alerts := apimodels.PostableAMAlerts{{
+		Alert: amv2.Alert{
+			Labels: amv2.LabelSet{"alertname": "test"},
+		},
+		StartsAt: strfmt.DateTime(now),
+		EndsAt:   strfmt.DateTime(now.Add(time.Hour)),
+	}}
+
+	t.Run("authorize should reject and log requests without the notifier token", func(t *testing.T) {
+		for _, token := range []string{"", "wrong"} {
+			logger := &logtest.Fake{}
+			srv := sut
+			srv.log = logger
+			c := createRequestCtx(t, "1", token, alerts)
+			srv.authorize(c)
+			require.Equal(t, http.StatusUnauthorized, c.Resp.Status())
+			require.Equal(t, 1, logger.WarnLogs.Calls)
+		}
+	})
+
+	t.Run("authorize should reject all requests if the notifier token is not set", func(t *testing.T) {
+		c := createRequestCtx(t, "1", "secret", alerts)
+		NotifierSrv{AlertmanagerSrv: sut.AlertmanagerSrv}.authorize(c)
+		require.Equal(t, http.StatusUnauthorized, c.Resp.Status())
+	})
+
+	t.Run("authorize should accept requests with the notifier token", func(t *testing.T) {
+		c := createRequestCtx(t, "1", "secret", alerts)
+		sut.authorize(c)
+		require.False(t, c.Resp.Written())
+	})
+
+	t.Run("should add the alerts to the Alertmanager of the organization in the path", func(t *testing.T) {
+		resp := sut.RoutePostNotifierAlerts(createRequestCtx(t, "2", "secret", alerts))
+		require.Equal(t, http.StatusOK, resp.Status())
+
+		resp = sut.RouteGetAMAlerts(createRequestCtxInOrg(2))
+		require.Equal(t, http.StatusOK, resp.Status())
+		var gettable apimodels.GettableAlerts
+		require.NoError(t, json.Unmarshal(resp.Body(), &gettable))
+		require.Len(t, gettable, 1)
+		require.Equal(t, "test", gettable[0].Labels["alertname"])
+
+		resp = sut.RouteGetAMAlerts(createRequestCtxInOrg(1))
+		require.Equal(t, http.StatusOK, resp.Status())
+		require.NoError(t, json.Unmarshal(resp.Body(), &gettable))
+		require.Empty(t, gettable)
+	})
+
+	t.Run("should return 400 if the organization ID is invalid", func(t *testing.T) {
+		resp := sut.RoutePostNotifierAlerts(createRequestCtx(t, "abc", "secret", alerts))
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("should return 404 and log if the organization does not exist", func(t *testing.T) {
+		logger := &logtest.Fake{}
+		srv := sut
+		srv.log = logger
+		resp := srv.RoutePostNotifierAlerts(createRequestCtx(t, "20", "secret", alerts))
+		require.Equal(t, http.StatusNotFound, resp.Status())
+		require.Equal(t, 1, logger.WarnLogs.Calls)
+		require.Contains(t, logger.WarnLogs.Ctx, "organization not found")
+	})
+
+	t.Run("should return 404 if the organization has no Alertmanager", func(t *testing.T) {
+		resp := sut.RoutePostNotifierAlerts(createRequestCtx(t, "10", "secret", alerts))
+		require.Equal(t, http.StatusNotFound, resp.Status())
+	})
+}
@@ -63,9 +63,10 @@ func (srv PrometheusSrv) RouteGetAlertStatuses(c *contextmodel.ReqContext) respo
 
 			// TODO: or should we make this two fields? Using one field lets the
 			// frontend use the same logic for parsing text on annotations and this.
-			State:    state.FormatStateAndReason(alertState.State, alertState.StateReason),
-			ActiveAt: &startsAt,
-			Value:    valString,
+			State:           state.FormatStateAndReason(alertState.State, alertState.StateReason),
+			ActiveAt:        &startsAt,
+			Value:           valString,
+			Acknowledgement: srv.getAlertAcknowledgement(alertState),
 		})
 	}
 
@@ -318,6 +319,10 @@ func (srv PrometheusSrv) toRuleGroup(groupKey ngmodels.AlertRuleGroupKey, folder
 			Duration:    rule.For.Seconds(),
 			Annotations: rule.Annotations,
 		}
+		if rule.HasDependencies() {
+			alertingRule.DependsOn = rule.DependsOn
+			alertingRule.InhibitedBy = state.GetFiringDependencies(srv.manager, rule)
+		}
 
 		newRule := apimodels.Rule{
 			Name:           rule.Title,
@@ -326,6 +331,11 @@ func (srv PrometheusSrv) toRuleGroup(groupKey ngmodels.AlertRuleGroupKey, folder
 			Type:           apiv1.RuleTypeAlerting,
 			LastEvaluation: time.Time{},
 		}
+		if rule.IsRecordingRule() {
+			// recording rules do not have alerts, their results are written to the remote write endpoint
+			alertingRule.State = ""
+			newRule.Type = apiv1.RuleTypeRecording
+		}
 
 		states := srv.manager.GetStatesForRuleUID(rule.OrgID, rule.UID)
 		totals := make(map[string]int64)
@@ -348,9 +358,10 @@ func (srv PrometheusSrv) toRuleGroup(groupKey ngmodels.AlertRuleGroupKey, folder
 
 				// TODO: or should we make this two fields? Using one field lets the
 				// frontend use the same logic for parsing text on annotations and this.
-				State:    state.FormatStateAndReason(alertState.State, alertState.StateReason),
-				ActiveAt: &activeAt,
-				Value:    valString,
+				State:           state.FormatStateAndReason(alertState.State, alertState.StateReason),
+				ActiveAt:        &activeAt,
+				Value:           valString,
+				Acknowledgement: srv.getAlertAcknowledgement(alertState),
 			}
 
 			if alertState.LastEvaluationTime.After(newRule.LastEvaluation) {
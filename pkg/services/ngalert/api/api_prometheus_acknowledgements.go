@@ -0,0 +1,128 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// RoutePostAlertAcknowledgement acknowledges an alert instance of a Grafana managed rule.
+func (srv PrometheusSrv) RoutePostAlertAcknowledgement(c *contextmodel.ReqContext, body apimodels.PostableAlertAcknowledgement) response.Response {
+	key, errResp := srv.getAuthorizedAlertInstanceKey(c, body.AlertInstanceRef)
+	if errResp != nil {
+		return errResp
+	}
+	ack := ngmodels.AlertInstanceAcknowledgement{
+		AlertInstanceKey:      key,
+		UserID:                c.SignedInUser.UserID,
+		Login:                 c.SignedInUser.Login,
+		Comment:               body.Comment,
+		CreatedAt:             timeNow(),
+		SuppressNotifications: body.SuppressNotifications,
+	}
+	if body.ExpiresAt != nil {
+		ack.ExpiresAt = *body.ExpiresAt
+	}
+	if err := ngmodels.ValidateAlertInstanceAcknowledgement(ack); err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	if err := srv.manager.Acknowledge(c.Req.Context(), ack); err != nil {
+		if errors.Is(err, state.ErrAlertInstanceNotFound) {
+			return ErrResp(http.StatusNotFound, err, "")
+		}
+		if errors.Is(err, state.ErrAlertInstanceNotActive) {
+			return ErrResp(http.StatusBadRequest, err, "")
+		}
+		return ErrResp(http.StatusInternalServerError, err, "failed to acknowledge alert instance")
+	}
+	return response.JSON(http.StatusOK, toAlertAcknowledgement(ack))
+}
+
+// RoutePostAlertUnacknowledgement removes the acknowledgement of an alert instance of a Grafana managed rule.
+func (srv PrometheusSrv) RoutePostAlertUnacknowledgement(c *contextmodel.ReqContext, body apimodels.AlertInstanceRef) response.Response {
+	key, errResp := srv.getAuthorizedAlertInstanceKey(c, body)
+	if errResp != nil {
+		return errResp
+	}
+	if err := srv.manager.Unacknowledge(c.Req.Context(), key); err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to remove acknowledgement")
+	}
+	return response.JSON(http.StatusOK, util.DynMap{"message": "acknowledgement removed"})
+}
+
+// getAuthorizedAlertInstanceKey finds the stored alert instance of the rule with the labels of the reference,
+// and checks that the user can access the rule and its folder.
+func (srv PrometheusSrv) getAuthorizedAlertInstanceKey(c *contextmodel.ReqContext, ref apimodels.AlertInstanceRef) (ngmodels.AlertInstanceKey, response.Response) {
+	if ref.RuleUID == "" {
+		return ngmodels.AlertInstanceKey{}, ErrResp(http.StatusBadRequest, errors.New("ruleUid is required"), "")
+	}
+	if len(ref.Labels) == 0 {
+		return ngmodels.AlertInstanceKey{}, ErrResp(http.StatusBadRequest, errors.New("labels are required"), "")
+	}
+	orgID := c.SignedInUser.GetOrgID()
+	rules, err := srv.store.GetAlertRulesGroupByRuleUID(c.Req.Context(), &ngmodels.GetAlertRulesGroupByRuleUIDQuery{
+		UID:   ref.RuleUID,
+		OrgID: orgID,
+	})
+	if err != nil {
+		return ngmodels.AlertInstanceKey{}, errorToResponse(err)
+	}
+	var rule *ngmodels.AlertRule
+	for _, r := range rules {
+		if r.UID == ref.RuleUID {
+			rule = r
+			break
+		}
+	}
+	if rule == nil {
+		return ngmodels.AlertInstanceKey{}, ErrResp(http.StatusNotFound, ngmodels.ErrAlertRuleNotFound, "")
+	}
+	if !authorizeAccessToRuleGroup(rules, accesscontrol.HasAccess(srv.ac, c)) {
+		return ngmodels.AlertInstanceKey{}, errorToResponse(fmt.Errorf("%w to access rules in this group", ErrAuthorization))
+	}
+	if _, err := srv.store.GetNamespaceByUID(c.Req.Context(), rule.NamespaceUID, orgID, c.SignedInUser); err != nil {
+		return ngmodels.AlertInstanceKey{}, errorToResponse(errors.Join(errFolderAccess, err))
+	}
+
+	key, err := srv.manager.GetAlertInstanceKey(c.Req.Context(), orgID, rule.UID, data.Labels(ref.Labels))
+	if err != nil {
+		if errors.Is(err, state.ErrAlertInstanceNotFound) {
+			return ngmodels.AlertInstanceKey{}, ErrResp(http.StatusNotFound, err, "")
+		}
+		return ngmodels.AlertInstanceKey{}, ErrResp(http.StatusInternalServerError, err, "failed to find alert instance")
+	}
+	return key, nil
+}
+
+func toAlertAcknowledgement(ack ngmodels.AlertInstanceAcknowledgement) *apimodels.AlertAcknowledgement {
+	result := &apimodels.AlertAcknowledgement{
+		AcknowledgedBy:        ack.Login,
+		AcknowledgedAt:        ack.CreatedAt,
+		Comment:               ack.Comment,
+		SuppressNotifications: ack.SuppressNotifications,
+	}
+	if !ack.ExpiresAt.IsZero() {
+		expiresAt := ack.ExpiresAt
+		result.ExpiresAt = &expiresAt
+	}
+	return result
+}
+
+// getAlertAcknowledgement returns the acknowledgement of the state that applied at its last evaluation,
+// if it still applies.
+func (srv PrometheusSrv) getAlertAcknowledgement(s *state.State) *apimodels.AlertAcknowledgement {
+	if s.Acknowledgement == nil || !s.Acknowledgement.IsActive(timeNow()) {
+		return nil
+	}
+	return toAlertAcknowledgement(*s.Acknowledgement)
+}
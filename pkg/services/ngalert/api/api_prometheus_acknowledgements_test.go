@@ -0,0 +1,136 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestRoutePostAlertAcknowledgement(t *testing.T) {
+	orgID := int64(1)
+	ruleUID := "RuleUID"
+
+	setup := func(t *testing.T) (*fakeAlertInstanceManager, PrometheusSrv, ngmodels.AlertInstanceKey) {
+		fakeStore, fakeAIM, api := setupAPI(t)
+		generateRuleAndInstanceWithQuery(t, orgID, fakeAIM, fakeStore, withClassicConditionSingleQuery())
+		states := fakeAIM.GetStatesForRuleUID(orgID, ruleUID)
+		require.Len(t, states, 1)
+		key, err := states[0].GetAlertInstanceKey()
+		require.NoError(t, err)
+		return fakeAIM, api, key
+	}
+
+	t.Run("should acknowledge the alert instance with the labels", func(t *testing.T) {
+		fakeAIM, api, key := setup(t)
+		c := createRequestContext(orgID, nil)
+		c.SignedInUser.UserID = 2
+		c.SignedInUser.Login = "editor"
+		expiresAt := timeNow().Add(time.Hour).UTC().Truncate(time.Second)
+
+		r := api.RoutePostAlertAcknowledgement(c, apimodels.PostableAlertAcknowledgement{
+			AlertInstanceRef: apimodels.AlertInstanceRef{
+				RuleUID: ruleUID,
+				Labels:  map[string]string{"job": "prometheus"},
+			},
+			Comment:               "looking into it",
+			ExpiresAt:             &expiresAt,
+			SuppressNotifications: true,
+		})
+		require.Equal(t, http.StatusOK, r.Status())
+
+		ack, ok := fakeAIM.GetAcknowledgement(key)
+		require.True(t, ok)
+		require.Equal(t, int64(2), ack.UserID)
+		require.Equal(t, "editor", ack.Login)
+		require.Equal(t, "looking into it", ack.Comment)
+		require.Equal(t, expiresAt, ack.ExpiresAt)
+		require.True(t, ack.SuppressNotifications)
+
+		var body apimodels.AlertAcknowledgement
+		require.NoError(t, json.Unmarshal(r.Body(), &body))
+		require.Equal(t, "editor", body.AcknowledgedBy)
+		require.NotNil(t, body.ExpiresAt)
+		require.True(t, expiresAt.Equal(*body.ExpiresAt))
+	})
+
+	t.Run("should show the acknowledgement of the last evaluation in the alerts", func(t *testing.T) {
+		fakeAIM, api, key := setup(t)
+		c := createRequestContext(orgID, nil)
+		getAlerts := func() apimodels.AlertResponse {
+			r := api.RouteGetAlertStatuses(c)
+			require.Equal(t, http.StatusOK, r.Status())
+			var res apimodels.AlertResponse
+			require.NoError(t, json.Unmarshal(r.Body(), &res))
+			require.Len(t, res.Data.Alerts, 1)
+			return res
+		}
+		s := fakeAIM.GetStatesForRuleUID(orgID, ruleUID)[0]
+
+		s.SetAcknowledgement(&ngmodels.AlertInstanceAcknowledgement{AlertInstanceKey: key, Login: "editor", CreatedAt: timeNow()})
+		res := getAlerts()
+		require.NotNil(t, res.Data.Alerts[0].Acknowledgement)
+		require.Equal(t, "editor", res.Data.Alerts[0].Acknowledgement.AcknowledgedBy)
+		require.Nil(t, res.Data.Alerts[0].Acknowledgement.ExpiresAt)
+
+		s.SetAcknowledgement(&ngmodels.AlertInstanceAcknowledgement{AlertInstanceKey: key, Login: "editor", ExpiresAt: timeNow().Add(-time.Minute)})
+		res = getAlerts()
+		require.Nil(t, res.Data.Alerts[0].Acknowledgement, "expired acknowledgements should not be shown")
+	})
+
+	t.Run("should remove the acknowledgement", func(t *testing.T) {
+		fakeAIM, api, key := setup(t)
+		c := createRequestContext(orgID, nil)
+		c.SignedInUser.Login = "editor"
+		ref := apimodels.AlertInstanceRef{RuleUID: ruleUID, Labels: map[string]string{"job": "prometheus"}}
+		r := api.RoutePostAlertAcknowledgement(c, apimodels.PostableAlertAcknowledgement{AlertInstanceRef: ref})
+		require.Equal(t, http.StatusOK, r.Status())
+
+		r = api.RoutePostAlertUnacknowledgement(c, ref)
+		require.Equal(t, http.StatusOK, r.Status())
+		_, ok := fakeAIM.GetAcknowledgement(key)
+		require.False(t, ok)
+	})
+
+	t.Run("should return 400 if the acknowledgement expires in the past", func(t *testing.T) {
+		_, api, _ := setup(t)
+		c := createRequestContext(orgID, nil)
+		c.SignedInUser.Login = "editor"
+		expiresAt := timeNow().Add(-time.Hour)
+		r := api.RoutePostAlertAcknowledgement(c, apimodels.PostableAlertAcknowledgement{
+			AlertInstanceRef: apimodels.AlertInstanceRef{RuleUID: ruleUID, Labels: map[string]string{"job": "prometheus"}},
+			ExpiresAt:        &expiresAt,
+		})
+		require.Equal(t, http.StatusBadRequest, r.Status())
+	})
+
+	t.Run("should return 404 if the rule does not exist", func(t *testing.T) {
+		_, api, _ := setup(t)
+		r := api.RoutePostAlertAcknowledgement(createRequestContext(orgID, nil), apimodels.PostableAlertAcknowledgement{
+			AlertInstanceRef: apimodels.AlertInstanceRef{RuleUID: "unknown", Labels: map[string]string{"job": "prometheus"}},
+		})
+		require.Equal(t, http.StatusNotFound, r.Status())
+	})
+
+	t.Run("should return 404 if no alert instance has the labels", func(t *testing.T) {
+		_, api, _ := setup(t)
+		r := api.RoutePostAlertAcknowledgement(createRequestContext(orgID, nil), apimodels.PostableAlertAcknowledgement{
+			AlertInstanceRef: apimodels.AlertInstanceRef{RuleUID: ruleUID, Labels: map[string]string{"job": "node"}},
+		})
+		require.Equal(t, http.StatusNotFound, r.Status())
+	})
+
+	t.Run("should return 401 if the user cannot query the data sources of the rule", func(t *testing.T) {
+		_, api, _ := setup(t)
+		c := createRequestContextWithPerms(orgID, map[int64]map[string][]string{}, nil)
+		r := api.RoutePostAlertAcknowledgement(c, apimodels.PostableAlertAcknowledgement{
+			AlertInstanceRef: apimodels.AlertInstanceRef{RuleUID: ruleUID, Labels: map[string]string{"job": "prometheus"}},
+		})
+		require.Equal(t, http.StatusUnauthorized, r.Status())
+	})
+}
@@ -688,6 +688,50 @@ func TestRouteGetRuleStatuses(t *testing.T) {
 		require.Equal(t, lastActiveAt, rg.Rules[0].ActiveAt)
 	})
 
+	t.Run("test dependencies of rules", func(t *testing.T) {
+		fakeStore, fakeAIM, api := setupAPI(t)
+		dependency := ngmodels.AlertRuleGen(withOrgID(orgID), withGroup("dependency"))()
+		rule := ngmodels.AlertRuleGen(withOrgID(orgID), withGroup("rule"), func(rule *ngmodels.AlertRule) {
+			rule.DependsOn = []string{dependency.UID}
+		})()
+		fakeStore.PutRule(context.Background(), dependency, rule)
+
+		getRule := func() apimodels.AlertingRule {
+			r, err := http.NewRequest("GET", "/api/v1/rules", nil)
+			require.NoError(t, err)
+			c := &contextmodel.ReqContext{
+				Context: &web.Context{Req: r},
+				SignedInUser: &user.SignedInUser{
+					OrgID:       orgID,
+					Permissions: queryPermissions,
+				},
+			}
+			resp := api.RouteGetRuleStatuses(c)
+			require.Equal(t, http.StatusOK, resp.Status())
+			var res apimodels.RuleResponse
+			require.NoError(t, json.Unmarshal(resp.Body(), &res))
+			for _, rg := range res.Data.RuleGroups {
+				if rg.Name == rule.RuleGroup {
+					require.Len(t, rg.Rules, 1)
+					return rg.Rules[0]
+				}
+			}
+			require.FailNow(t, "rule group not found")
+			return apimodels.AlertingRule{}
+		}
+
+		// the dependency is not firing
+		result := getRule()
+		require.Equal(t, []string{dependency.UID}, result.DependsOn)
+		require.Empty(t, result.InhibitedBy)
+
+		// the dependency is firing, the rule is inhibited
+		fakeAIM.GenerateAlertInstances(orgID, dependency.UID, 1, withAlertingState())
+		result = getRule()
+		require.Equal(t, []string{dependency.UID}, result.DependsOn)
+		require.Equal(t, []string{dependency.UID}, result.InhibitedBy)
+	})
+
 	t.Run("test with limit on Rule Groups", func(t *testing.T) {
 		fakeStore, _, api := setupAPI(t)
 
@@ -32,15 +32,23 @@ type ConditionValidator interface {
 	Validate(ctx eval.EvaluationContext, condition ngmodels.Condition) error
 }
 
+// NotificationSettingsService validates the notification settings of alert rules against the Alertmanager
+// configuration, and updates the routes that are generated from them.
+type NotificationSettingsService interface {
+	ValidateNotificationSettings(ctx context.Context, orgID int64, settings ngmodels.NotificationSettings) error
+	UpdateAutogeneratedRoutes(ctx context.Context, orgID int64) error
+}
+
 type RulerSrv struct {
-	xactManager        provisioning.TransactionManager
-	provenanceStore    provisioning.ProvisioningStore
-	store              RuleStore
-	QuotaService       quota.Service
-	log                log.Logger
-	cfg                *setting.UnifiedAlertingSettings
-	ac                 accesscontrol.AccessControl
-	conditionValidator ConditionValidator
+	xactManager          provisioning.TransactionManager
+	provenanceStore      provisioning.ProvisioningStore
+	store                RuleStore
+	QuotaService         quota.Service
+	log                  log.Logger
+	cfg                  *setting.UnifiedAlertingSettings
+	ac                   accesscontrol.AccessControl
+	conditionValidator   ConditionValidator
+	notificationSettings NotificationSettingsService
 }
 
 var (
@@ -257,121 +265,286 @@ func (srv RulerSrv) RoutePostNameRulesConfig(c *contextmodel.ReqContext, ruleGro
 		RuleGroup:    ruleGroupConfig.Name,
 	}
 
-	return srv.updateAlertRulesInGroup(c, groupKey, rules)
+	return srv.updateAlertRulesInGroup(c, groupKey, rules, nil)
 }
 
 // updateAlertRulesInGroup calculates changes (rules to add,update,delete), verifies that the user is authorized to do the calculated changes and updates database.
+// restoredFrom maps UIDs of rules that are restored from a previous version to that version, and is recorded in the version history.
 // All operations are performed in a single transaction
-func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey ngmodels.AlertRuleGroupKey, rules []*ngmodels.AlertRuleWithOptionals) response.Response {
+func (srv RulerSrv) updateAlertRulesInGroup(c *contextmodel.ReqContext, groupKey ngmodels.AlertRuleGroupKey, rules []*ngmodels.AlertRuleWithOptionals, restoredFrom map[string]int64) response.Response {
 	var finalChanges *store.GroupDelta
-	hasAccess := accesscontrol.HasAccess(srv.ac, c)
 	err := srv.xactManager.InTransaction(c.Req.Context(), func(tranCtx context.Context) error {
-		logger := srv.log.New("namespace_uid", groupKey.NamespaceUID, "group", groupKey.RuleGroup, "org_id", groupKey.OrgID, "user_id", c.UserID)
-		groupChanges, err := store.CalculateChanges(tranCtx, srv.store, groupKey, rules)
-		if err != nil {
-			return err
+		var err error
+		finalChanges, err = srv.applyRuleGroupChanges(tranCtx, c, groupKey, rules, restoredFrom)
+		return err
+	})
+	if err != nil {
+		return toRuleGroupUpdateErrorResponse(err)
+	}
+
+	if changesAffectAutogeneratedConfig(finalChanges) {
+		if err := srv.notificationSettings.UpdateAutogeneratedRoutes(c.Req.Context(), groupKey.OrgID); err != nil {
+			// the routes and inhibit rules are updated by the next synchronization of the Alertmanager configuration.
+			srv.log.Error("Failed to update the auto-generated routes and inhibit rules of alert rules", "org_id", groupKey.OrgID, "error", err)
 		}
+	}
+	return changesToResponse(finalChanges)
+}
 
-		if groupChanges.IsEmpty() {
-			finalChanges = groupChanges
-			logger.Info("No changes detected in the request. Do nothing")
-			return nil
+// applyRuleGroupChanges authorizes, validates and stores the changes to the rule group in the transaction of the context.
+func (srv RulerSrv) applyRuleGroupChanges(tranCtx context.Context, c *contextmodel.ReqContext, groupKey ngmodels.AlertRuleGroupKey, rules []*ngmodels.AlertRuleWithOptionals, restoredFrom map[string]int64) (*store.GroupDelta, error) {
+	hasAccess := accesscontrol.HasAccess(srv.ac, c)
+	logger := srv.log.New("namespace_uid", groupKey.NamespaceUID, "group", groupKey.RuleGroup, "org_id", groupKey.OrgID, "user_id", c.UserID)
+	groupChanges, err := store.CalculateChanges(tranCtx, srv.store, groupKey, rules)
+	if err != nil {
+		return nil, err
+	}
+
+	if groupChanges.IsEmpty() {
+		logger.Info("No changes detected in the request. Do nothing")
+		return groupChanges, nil
+	}
+
+	err = authorizeRuleChanges(groupChanges, func(evaluator accesscontrol.Evaluator) bool {
+		return hasAccess(evaluator)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateQueries(c.Req.Context(), groupChanges, srv.conditionValidator, c.SignedInUser); err != nil {
+		return nil, err
+	}
+
+	if err := verifyProvisionedRulesNotAffected(c.Req.Context(), srv.provenanceStore, c.SignedInUser.GetOrgID(), groupChanges); err != nil {
+		return nil, err
+	}
+
+	if err := validateNotificationSettingsChanges(c.Req.Context(), srv.notificationSettings, groupChanges); err != nil {
+		return nil, err
+	}
+
+	if err := validateRuleDependencies(tranCtx, srv.store, c.SignedInUser, groupChanges); err != nil {
+		return nil, err
+	}
+
+	finalChanges := store.UpdateCalculatedRuleFields(groupChanges)
+	logger.Debug("Updating database with the authorized changes", "add", len(finalChanges.New), "update", len(finalChanges.New), "delete", len(finalChanges.Delete))
+
+	// Delete first as this could prevent future unique constraint violations.
+	if len(finalChanges.Delete) > 0 {
+		UIDs := make([]string, 0, len(finalChanges.Delete))
+		for _, rule := range finalChanges.Delete {
+			UIDs = append(UIDs, rule.UID)
 		}
 
-		err = authorizeRuleChanges(groupChanges, func(evaluator accesscontrol.Evaluator) bool {
-			return hasAccess(evaluator)
-		})
+		if err = srv.store.DeleteAlertRulesByUID(tranCtx, c.SignedInUser.OrgID, UIDs...); err != nil {
+			return nil, fmt.Errorf("failed to delete rules: %w", err)
+		}
+	}
+
+	if len(finalChanges.Update) > 0 {
+		updates := make([]ngmodels.UpdateRule, 0, len(finalChanges.Update))
+		for _, update := range finalChanges.Update {
+			logger.Debug("Updating rule", "rule_uid", update.New.UID, "diff", update.Diff.String())
+			newRule := *update.New
+			newRule.UpdatedBy = c.SignedInUser.Login
+			updates = append(updates, ngmodels.UpdateRule{
+				Existing:     update.Existing,
+				New:          newRule,
+				RestoredFrom: restoredFrom[newRule.UID],
+			})
+		}
+		err = srv.store.UpdateAlertRules(tranCtx, updates)
 		if err != nil {
-			return err
+			return nil, fmt.Errorf("failed to update rules: %w", err)
 		}
+	}
 
-		if err := validateQueries(c.Req.Context(), groupChanges, srv.conditionValidator, c.SignedInUser); err != nil {
-			return err
+	if len(finalChanges.New) > 0 {
+		inserts := make([]ngmodels.AlertRule, 0, len(finalChanges.New))
+		for _, rule := range finalChanges.New {
+			newRule := *rule
+			newRule.UpdatedBy = c.SignedInUser.Login
+			inserts = append(inserts, newRule)
+		}
+		added, err := srv.store.InsertAlertRules(tranCtx, inserts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add rules: %w", err)
+		}
+		if len(added) != len(finalChanges.New) {
+			logger.Error("Cannot match inserted rules with final changes", "insertedCount", len(added), "changes", len(finalChanges.New))
+		} else {
+			for i, newRule := range finalChanges.New {
+				newRule.ID = added[i].ID
+				newRule.UID = added[i].UID
+			}
 		}
+	}
 
-		if err := verifyProvisionedRulesNotAffected(c.Req.Context(), srv.provenanceStore, c.SignedInUser.GetOrgID(), groupChanges); err != nil {
-			return err
+	if len(finalChanges.New) > 0 {
+		limitReached, err := srv.QuotaService.CheckQuotaReached(tranCtx, ngmodels.QuotaTargetSrv, &quota.ScopeParameters{
+			OrgID:  c.SignedInUser.GetOrgID(),
+			UserID: c.UserID,
+		}) // alert rule is table name
+		if err != nil {
+			return nil, fmt.Errorf("failed to get alert rules quota: %w", err)
+		}
+		if limitReached {
+			return nil, ngmodels.ErrQuotaReached
 		}
+	}
+	return finalChanges, nil
+}
 
-		finalChanges = store.UpdateCalculatedRuleFields(groupChanges)
-		logger.Debug("Updating database with the authorized changes", "add", len(finalChanges.New), "update", len(finalChanges.New), "delete", len(finalChanges.Delete))
+// toRuleGroupUpdateErrorResponse converts an error returned by applyRuleGroupChanges to a response.
+func toRuleGroupUpdateErrorResponse(err error) response.Response {
+	if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
+		return ErrResp(http.StatusNotFound, err, "failed to update rule group")
+	} else if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) || errors.Is(err, errProvisionedResource) {
+		return ErrResp(http.StatusBadRequest, err, "failed to update rule group")
+	} else if errors.Is(err, ngmodels.ErrQuotaReached) {
+		return ErrResp(http.StatusForbidden, err, "")
+	} else if errors.Is(err, ErrAuthorization) {
+		return ErrResp(http.StatusUnauthorized, err, "")
+	} else if errors.Is(err, store.ErrOptimisticLock) {
+		return ErrResp(http.StatusConflict, err, "")
+	}
+	return ErrResp(http.StatusInternalServerError, err, "failed to update rule group")
+}
 
-		// Delete first as this could prevent future unique constraint violations.
-		if len(finalChanges.Delete) > 0 {
-			UIDs := make([]string, 0, len(finalChanges.Delete))
-			for _, rule := range finalChanges.Delete {
-				UIDs = append(UIDs, rule.UID)
+// validateNotificationSettingsChanges checks that the receivers and mute time intervals referenced by the notification settings of
+// new and updated rules exist. Settings that were not changed are not validated again.
+func validateNotificationSettingsChanges(ctx context.Context, svc NotificationSettingsService, ch *store.GroupDelta) error {
+	validate := func(rule *ngmodels.AlertRule) error {
+		if !rule.HasNotificationSettings() {
+			return nil
+		}
+		if err := svc.ValidateNotificationSettings(ctx, rule.OrgID, rule.NotificationSettings); err != nil {
+			if errors.Is(err, ngmodels.ErrNotificationSettingsInvalid) {
+				return fmt.Errorf("%w: rule '%s': %s", ngmodels.ErrAlertRuleFailedValidation, rule.Title, err.Error())
 			}
+			return err
+		}
+		return nil
+	}
+	for _, rule := range ch.New {
+		if err := validate(rule); err != nil {
+			return err
+		}
+	}
+	for _, update := range ch.Update {
+		if update.Existing.NotificationSettings.Fingerprint() == update.New.NotificationSettings.Fingerprint() {
+			continue
+		}
+		if err := validate(update.New); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-			if err = srv.store.DeleteAlertRulesByUID(tranCtx, c.SignedInUser.OrgID, UIDs...); err != nil {
-				return fmt.Errorf("failed to delete rules: %w", err)
-			}
+// validateRuleDependencies checks that the rules that new and updated rules depend on exist in folders the user can read,
+// and that the changes do not create a cycle of dependencies between these rules. Rules in other folders are reported as
+// missing so that their existence is not disclosed. Rules that depend on deleted rules are not affected, a dependency that
+// does not exist never inhibits notifications.
+func validateRuleDependencies(ctx context.Context, ruleStore RuleStore, user *user.SignedInUser, ch *store.GroupDelta) error {
+	var changed []*ngmodels.AlertRule
+	for _, rule := range ch.New {
+		if rule.HasDependencies() {
+			changed = append(changed, rule)
+		}
+	}
+	for _, update := range ch.Update {
+		if update.New.HasDependencies() {
+			changed = append(changed, update.New)
 		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
 
-		if len(finalChanges.Update) > 0 {
-			updates := make([]ngmodels.UpdateRule, 0, len(finalChanges.Update))
-			for _, update := range finalChanges.Update {
-				logger.Debug("Updating rule", "rule_uid", update.New.UID, "diff", update.Diff.String())
-				updates = append(updates, ngmodels.UpdateRule{
-					Existing: update.Existing,
-					New:      *update.New,
-				})
-			}
-			err = srv.store.UpdateAlertRules(tranCtx, updates)
-			if err != nil {
-				return fmt.Errorf("failed to update rules: %w", err)
-			}
+	namespaces, err := ruleStore.GetUserVisibleNamespaces(ctx, user.GetOrgID(), user)
+	if err != nil {
+		return fmt.Errorf("failed to get namespaces visible to the user: %w", err)
+	}
+	var existing []*ngmodels.AlertRule
+	if len(namespaces) > 0 {
+		q := &ngmodels.ListAlertRulesQuery{OrgID: user.GetOrgID(), NamespaceUIDs: make([]string, 0, len(namespaces))}
+		for uid := range namespaces {
+			q.NamespaceUIDs = append(q.NamespaceUIDs, uid)
+		}
+		existing, err = ruleStore.ListAlertRules(ctx, q)
+		if err != nil {
+			return fmt.Errorf("failed to list alert rules: %w", err)
 		}
+	}
+	dependencies := make(map[string][]string, len(existing)+len(ch.New))
+	for _, rule := range existing {
+		dependencies[rule.UID] = rule.DependsOn
+	}
+	for _, rule := range ch.Delete {
+		delete(dependencies, rule.UID)
+	}
+	for _, update := range ch.Update {
+		dependencies[update.New.UID] = update.New.DependsOn
+	}
+	for _, rule := range ch.New {
+		if rule.UID != "" {
+			dependencies[rule.UID] = rule.DependsOn
+		}
+	}
 
-		if len(finalChanges.New) > 0 {
-			inserts := make([]ngmodels.AlertRule, 0, len(finalChanges.New))
-			for _, rule := range finalChanges.New {
-				inserts = append(inserts, *rule)
-			}
-			added, err := srv.store.InsertAlertRules(tranCtx, inserts)
-			if err != nil {
-				return fmt.Errorf("failed to add rules: %w", err)
-			}
-			if len(added) != len(finalChanges.New) {
-				logger.Error("Cannot match inserted rules with final changes", "insertedCount", len(added), "changes", len(finalChanges.New))
-			} else {
-				for i, newRule := range finalChanges.New {
-					newRule.ID = added[i].ID
-					newRule.UID = added[i].UID
-				}
+	for _, rule := range changed {
+		for _, uid := range rule.DependsOn {
+			if _, ok := dependencies[uid]; !ok {
+				return fmt.Errorf("%w: rule '%s' depends on rule '%s' that does not exist", ngmodels.ErrAlertRuleFailedValidation, rule.Title, uid)
 			}
 		}
-
-		if len(finalChanges.New) > 0 {
-			limitReached, err := srv.QuotaService.CheckQuotaReached(tranCtx, ngmodels.QuotaTargetSrv, &quota.ScopeParameters{
-				OrgID:  c.SignedInUser.GetOrgID(),
-				UserID: c.UserID,
-			}) // alert rule is table name
-			if err != nil {
-				return fmt.Errorf("failed to get alert rules quota: %w", err)
+		if rule.UID == "" {
+			// a rule without UID cannot be referenced by other rules, and therefore cannot be part of a cycle.
+			continue
+		}
+		visited := make(map[string]struct{})
+		queue := append([]string(nil), rule.DependsOn...)
+		for len(queue) > 0 {
+			uid := queue[0]
+			queue = queue[1:]
+			if uid == rule.UID {
+				return fmt.Errorf("%w: dependencies of rule '%s' create a cycle", ngmodels.ErrAlertRuleFailedValidation, rule.Title)
 			}
-			if limitReached {
-				return ngmodels.ErrQuotaReached
+			if _, ok := visited[uid]; ok {
+				continue
 			}
+			visited[uid] = struct{}{}
+			queue = append(queue, dependencies[uid]...)
 		}
-		return nil
-	})
+	}
+	return nil
+}
 
-	if err != nil {
-		if errors.Is(err, ngmodels.ErrAlertRuleNotFound) {
-			return ErrResp(http.StatusNotFound, err, "failed to update rule group")
-		} else if errors.Is(err, ngmodels.ErrAlertRuleFailedValidation) || errors.Is(err, errProvisionedResource) {
-			return ErrResp(http.StatusBadRequest, err, "failed to update rule group")
-		} else if errors.Is(err, ngmodels.ErrQuotaReached) {
-			return ErrResp(http.StatusForbidden, err, "")
-		} else if errors.Is(err, ErrAuthorization) {
-			return ErrResp(http.StatusUnauthorized, err, "")
-		} else if errors.Is(err, store.ErrOptimisticLock) {
-			return ErrResp(http.StatusConflict, err, "")
-		}
-		return ErrResp(http.StatusInternalServerError, err, "failed to update rule group")
+// changesAffectAutogeneratedConfig returns true if the changes add, update or delete rules with notification settings
+// or dependencies, which are compiled into the auto-generated routes and inhibit rules of the Alertmanager.
+func changesAffectAutogeneratedConfig(ch *store.GroupDelta) bool {
+	affects := func(rule *ngmodels.AlertRule) bool {
+		return rule.HasNotificationSettings() || rule.HasDependencies()
 	}
-	return changesToResponse(finalChanges)
+	for _, rule := range ch.New {
+		if affects(rule) {
+			return true
+		}
+	}
+	for _, rule := range ch.Delete {
+		if affects(rule) {
+			return true
+		}
+	}
+	for _, update := range ch.Update {
+		if affects(update.Existing) || affects(update.New) {
+			return true
+		}
+	}
+	return false
 }
 
 func changesToResponse(finalChanges *store.GroupDelta) response.Response {
@@ -421,22 +594,27 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, namespaceID int64, provena
 	}
 	gettableExtendedRuleNode := apimodels.GettableExtendedRuleNode{
 		GrafanaManagedAlert: &apimodels.GettableGrafanaRule{
-			ID:              r.ID,
-			OrgID:           r.OrgID,
-			Title:           r.Title,
-			Condition:       r.Condition,
-			Data:            ApiAlertQueriesFromAlertQueries(r.Data),
-			Updated:         r.Updated,
-			IntervalSeconds: r.IntervalSeconds,
-			Version:         r.Version,
-			UID:             r.UID,
-			NamespaceUID:    r.NamespaceUID,
-			NamespaceID:     namespaceID,
-			RuleGroup:       r.RuleGroup,
-			NoDataState:     apimodels.NoDataState(r.NoDataState),
-			ExecErrState:    apimodels.ExecutionErrorState(r.ExecErrState),
-			Provenance:      apimodels.Provenance(provenance),
-			IsPaused:        r.IsPaused,
+			ID:                   r.ID,
+			OrgID:                r.OrgID,
+			Title:                r.Title,
+			Condition:            r.Condition,
+			Data:                 ApiAlertQueriesFromAlertQueries(r.Data),
+			Updated:              r.Updated,
+			IntervalSeconds:      r.IntervalSeconds,
+			Version:              r.Version,
+			UID:                  r.UID,
+			NamespaceUID:         r.NamespaceUID,
+			NamespaceID:          namespaceID,
+			RuleGroup:            r.RuleGroup,
+			NoDataState:          apimodels.NoDataState(r.NoDataState),
+			ExecErrState:         apimodels.ExecutionErrorState(r.ExecErrState),
+			Provenance:           apimodels.Provenance(provenance),
+			IsPaused:             r.IsPaused,
+			NotificationSettings: ApiNotificationSettingsFromNotificationSettings(r.NotificationSettings),
+			DependsOn:            r.DependsOn,
+			UpdatedBy:            r.UpdatedBy,
+			Record:               ApiRecordFromModelRecord(r.Record),
+			FlapDetection:        ApiFlapDetectionFromFlapDetection(r.FlapDetection),
 		},
 	}
 	forDuration := model.Duration(r.For)
@@ -445,6 +623,10 @@ func toGettableExtendedRuleNode(r ngmodels.AlertRule, namespaceID int64, provena
 		Annotations: r.Annotations,
 		Labels:      r.Labels,
 	}
+	if r.KeepFiringFor > 0 {
+		keepFiringFor := model.Duration(r.KeepFiringFor)
+		gettableExtendedRuleNode.ApiRuleNode.KeepFiringFor = &keepFiringFor
+	}
 	return gettableExtendedRuleNode
 }
 
@@ -0,0 +1,355 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/expr"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/template"
+)
+
+const (
+	// importQueryRefID is the RefID of the query of the imported rules.
+	importQueryRefID = "A"
+	// importConditionRefID is the RefID of the condition of the imported alerting rules.
+	importConditionRefID = "B"
+	// importConditionExpression is true for every series returned by the query, so an alert fires for each
+	// series like in Prometheus.
+	importConditionExpression = "is_number($A) || is_nan($A) || is_inf($A)"
+	// importQueryTimeRange is the relative time range of the query of the imported rules. Prometheus evaluates
+	// rules with instant queries, which only use the end of the time range.
+	importQueryTimeRange = 10 * time.Minute
+)
+
+// RoutePostImportPrometheusRules converts the rule groups of a Prometheus rule file to Grafana-managed rule groups
+// and creates them in the folder. If the dryRun query parameter is set, the rule groups are converted and validated
+// but not created.
+func (srv RulerSrv) RoutePostImportPrometheusRules(c *contextmodel.ReqContext, body apimodels.PrometheusRulesImport, namespaceTitle string) response.Response {
+	if body.DatasourceUID == "" {
+		return ErrResp(http.StatusBadRequest, errors.New("datasourceUid is required"), "")
+	}
+	if len(body.Groups) == 0 {
+		return ErrResp(http.StatusBadRequest, errors.New("at least one rule group is required"), "")
+	}
+
+	orgID := c.SignedInUser.GetOrgID()
+	namespace, err := srv.store.GetNamespaceByTitle(c.Req.Context(), namespaceTitle, orgID, c.SignedInUser)
+	if err != nil {
+		return toNamespaceErrorResponse(err)
+	}
+
+	existing, err := srv.store.ListAlertRules(c.Req.Context(), &ngmodels.ListAlertRulesQuery{
+		OrgID:         orgID,
+		NamespaceUIDs: []string{namespace.UID},
+	})
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get the rules of the folder")
+	}
+
+	converter := newPrometheusRuleConverter(body.DatasourceUID, existing)
+	result := apimodels.PrometheusRulesImportResponse{}
+	rulesByGroup := make(map[string][]*ngmodels.AlertRuleWithOptionals, len(body.Groups))
+	for _, group := range body.Groups {
+		converted, ok := converter.convertGroup(group)
+		if !ok {
+			continue
+		}
+		rules, err := validateRuleGroup(&converted, orgID, namespace, srv.cfg)
+		if err != nil {
+			converter.blocking(group.Name, "", err.Error())
+			continue
+		}
+		for _, rule := range rules {
+			if err := srv.conditionValidator.Validate(eval.NewContext(c.Req.Context(), c.SignedInUser), rule.GetEvalCondition()); err != nil {
+				converter.blocking(group.Name, rule.Title, err.Error())
+			}
+		}
+		result.Groups = append(result.Groups, converted)
+		rulesByGroup[converted.Name] = rules
+	}
+	result.Incompatibilities = converter.incompatibilities
+
+	if converter.hasBlocking {
+		return response.JSON(http.StatusBadRequest, result)
+	}
+	if c.QueryBoolWithDefault("dryRun", false) {
+		return response.JSON(http.StatusOK, result)
+	}
+
+	// All rule groups are created in one transaction, so that a failure does not leave some of them in the folder
+	// and the file can be imported again.
+	var updateAutogeneratedConfig bool
+	err = srv.xactManager.InTransaction(c.Req.Context(), func(tranCtx context.Context) error {
+		for _, group := range result.Groups {
+			groupKey := ngmodels.AlertRuleGroupKey{
+				OrgID:        orgID,
+				NamespaceUID: namespace.UID,
+				RuleGroup:    group.Name,
+			}
+			changes, err := srv.applyRuleGroupChanges(tranCtx, c, groupKey, rulesByGroup[group.Name], nil)
+			if err != nil {
+				return fmt.Errorf("failed to create rule group %q: %w", group.Name, err)
+			}
+			updateAutogeneratedConfig = updateAutogeneratedConfig || changesAffectAutogeneratedConfig(changes)
+		}
+		return nil
+	})
+	if err != nil {
+		return toRuleGroupUpdateErrorResponse(err)
+	}
+
+	if updateAutogeneratedConfig {
+		if err := srv.notificationSettings.UpdateAutogeneratedRoutes(c.Req.Context(), orgID); err != nil {
+			// the routes and inhibit rules are updated by the next synchronization of the Alertmanager configuration.
+			srv.log.Error("Failed to update the auto-generated routes and inhibit rules of alert rules", "org_id", orgID, "error", err)
+		}
+	}
+	return response.JSON(http.StatusAccepted, result)
+}
+
+// prometheusRuleConverter converts the rule groups of a Prometheus rule file to Grafana-managed rule groups, and
+// collects the incompatibilities between them.
+type prometheusRuleConverter struct {
+	datasourceUID     string
+	existingGroups    map[string]struct{}
+	convertedGroups   map[string]struct{}
+	titles            map[string]struct{}
+	incompatibilities []apimodels.PrometheusRuleIncompatibility
+	hasBlocking       bool
+}
+
+func newPrometheusRuleConverter(datasourceUID string, existing []*ngmodels.AlertRule) *prometheusRuleConverter {
+	c := &prometheusRuleConverter{
+		datasourceUID:   datasourceUID,
+		existingGroups:  make(map[string]struct{}),
+		convertedGroups: make(map[string]struct{}),
+		titles:          make(map[string]struct{}),
+	}
+	for _, rule := range existing {
+		c.existingGroups[rule.RuleGroup] = struct{}{}
+		c.titles[rule.Title] = struct{}{}
+	}
+	return c
+}
+
+func (c *prometheusRuleConverter) blocking(group, rule, msg string) {
+	c.hasBlocking = true
+	c.incompatibilities = append(c.incompatibilities, apimodels.PrometheusRuleIncompatibility{Group: group, Rule: rule, Message: msg, Blocking: true})
+}
+
+func (c *prometheusRuleConverter) warning(group, rule, msg string) {
+	c.incompatibilities = append(c.incompatibilities, apimodels.PrometheusRuleIncompatibility{Group: group, Rule: rule, Message: msg})
+}
+
+// convertGroup converts a Prometheus rule group. It returns false if the group cannot be imported at all.
+func (c *prometheusRuleConverter) convertGroup(group apimodels.PrometheusRuleGroup) (apimodels.PostableRuleGroupConfig, bool) {
+	if group.Name == "" {
+		c.blocking(group.Name, "", "rule group name cannot be empty")
+		return apimodels.PostableRuleGroupConfig{}, false
+	}
+	if _, ok := c.existingGroups[group.Name]; ok {
+		c.blocking(group.Name, "", "the folder already has a rule group with this name")
+		return apimodels.PostableRuleGroupConfig{}, false
+	}
+	if _, ok := c.convertedGroups[group.Name]; ok {
+		c.blocking(group.Name, "", "the file has more than one rule group with this name")
+		return apimodels.PostableRuleGroupConfig{}, false
+	}
+	c.convertedGroups[group.Name] = struct{}{}
+
+	if group.Limit != 0 {
+		c.warning(group.Name, "", "limit is not supported, the number of alerts and series of the rules is not limited")
+	}
+	var queryOffset time.Duration
+	if group.QueryOffset != nil {
+		queryOffset = time.Duration(*group.QueryOffset)
+	}
+
+	result := apimodels.PostableRuleGroupConfig{
+		Name:     group.Name,
+		Interval: group.Interval,
+		Rules:    make([]apimodels.PostableExtendedRuleNode, 0, len(group.Rules)),
+	}
+	for _, rule := range group.Rules {
+		node, ok := c.convertRule(group.Name, rule, queryOffset)
+		if !ok {
+			continue
+		}
+		result.Rules = append(result.Rules, node)
+	}
+	return result, true
+}
+
+// convertRule converts a Prometheus alerting or recording rule. It returns false if the rule cannot be imported.
+func (c *prometheusRuleConverter) convertRule(group string, rule apimodels.ApiRuleNode, queryOffset time.Duration) (apimodels.PostableExtendedRuleNode, bool) {
+	name := rule.Alert
+	if rule.Record != "" {
+		name = rule.Record
+	}
+	if (rule.Alert == "") == (rule.Record == "") {
+		c.blocking(group, name, "a rule must have either alert or record")
+		return apimodels.PostableExtendedRuleNode{}, false
+	}
+	if _, err := parser.ParseExpr(rule.Expr); err != nil {
+		c.blocking(group, name, fmt.Sprintf("invalid expression: %s", err))
+		return apimodels.PostableExtendedRuleNode{}, false
+	}
+
+	model, err := json.Marshal(map[string]any{
+		"refId":   importQueryRefID,
+		"expr":    rule.Expr,
+		"instant": true,
+		"range":   false,
+	})
+	if err != nil {
+		c.blocking(group, name, err.Error())
+		return apimodels.PostableExtendedRuleNode{}, false
+	}
+
+	title := c.uniqueTitle(name)
+	if title != name {
+		msg := fmt.Sprintf("the title of the rule is %q because the folder or the file already has a rule titled %q", title, name)
+		if rule.Alert != "" {
+			msg += fmt.Sprintf(", so the alertname label of its alerts is %q", title)
+		}
+		c.warning(group, name, msg)
+	}
+
+	grafanaRule := &apimodels.PostableGrafanaRule{
+		Title: title,
+		Data: []apimodels.AlertQuery{{
+			RefID:         importQueryRefID,
+			DatasourceUID: c.datasourceUID,
+			RelativeTimeRange: apimodels.RelativeTimeRange{
+				From: apimodels.Duration(queryOffset + importQueryTimeRange),
+				To:   apimodels.Duration(queryOffset),
+			},
+			Model: model,
+		}},
+	}
+	if rule.Record != "" {
+		grafanaRule.Condition = importQueryRefID
+		grafanaRule.Record = &apimodels.Record{Metric: rule.Record, From: importQueryRefID}
+	} else {
+		condition, err := json.Marshal(map[string]any{
+			"refId":      importConditionRefID,
+			"type":       "math",
+			"expression": importConditionExpression,
+		})
+		if err != nil {
+			c.blocking(group, name, err.Error())
+			return apimodels.PostableExtendedRuleNode{}, false
+		}
+		grafanaRule.Data = append(grafanaRule.Data, apimodels.AlertQuery{
+			RefID:         importConditionRefID,
+			DatasourceUID: expr.DatasourceUID,
+			Model:         condition,
+		})
+		grafanaRule.Condition = importConditionRefID
+		// Prometheus does not fire alerts when the query returns no series.
+		grafanaRule.NoDataState = apimodels.OK
+		grafanaRule.ExecErrState = apimodels.ErrorErrState
+	}
+
+	return apimodels.PostableExtendedRuleNode{
+		ApiRuleNode: &apimodels.ApiRuleNode{
+			For:           rule.For,
+			KeepFiringFor: rule.KeepFiringFor,
+			Labels:        c.convertTemplates(group, name, "label", rule.Labels),
+			Annotations:   c.convertTemplates(group, name, "annotation", rule.Annotations),
+		},
+		GrafanaManagedAlert: grafanaRule,
+	}, true
+}
+
+// uniqueTitle returns the name, or the name with a suffix if the folder or the file already has a rule with this title.
+func (c *prometheusRuleConverter) uniqueTitle(name string) string {
+	title := name
+	for i := 2; ; i++ {
+		if _, ok := c.titles[title]; !ok {
+			break
+		}
+		title = fmt.Sprintf("%s (%d)", name, i)
+	}
+	c.titles[title] = struct{}{}
+	return title
+}
+
+// convertTemplates translates the Prometheus templates in the values of labels or annotations to Grafana templates.
+func (c *prometheusRuleConverter) convertTemplates(group, rule, kind string, templates map[string]string) map[string]string {
+	if templates == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(templates))
+	for key := range templates {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make(map[string]string, len(templates))
+	for _, key := range keys {
+		converted, warnings := convertPrometheusTemplate(templates[key], c.datasourceUID)
+		for _, w := range warnings {
+			c.warning(group, rule, fmt.Sprintf("%s %q: %s", kind, key, w))
+		}
+		result[key] = converted
+	}
+	return result
+}
+
+var (
+	templateActionRegex = regexp.MustCompile(`(?s){{.*?}}`)
+	// field references are only matched at the start of a chain, so $values.A.Value and $labels.Value are not matched.
+	valueVariableRegex       = regexp.MustCompile(`\$value\b`)
+	valueFieldRegex          = regexp.MustCompile(`(^|[^\w.$)\]])\.Value\b`)
+	externalURLVariableRegex = regexp.MustCompile(`\$externalURL\b`)
+	externalURLFieldRegex    = regexp.MustCompile(`(^|[^\w.$)\]])\.ExternalURL\b`)
+	externalLabelsRegex      = regexp.MustCompile(`\$externalLabels\b|(^|[^\w.$)\]])\.ExternalLabels\b`)
+	queryFuncRegex           = regexp.MustCompile(`(^|[\s({|])query\s`)
+)
+
+// convertPrometheusTemplate translates a Prometheus template to a Grafana template, and returns warnings
+// for the parts that work differently in Grafana.
+func convertPrometheusTemplate(tmpl, datasourceUID string) (string, []string) {
+	var usesQuery, usesExternalLabels bool
+	queryReplacement := "${1}" + template.QueryDatasourceFuncName + " " + strings.ReplaceAll(strconv.Quote(datasourceUID), "$", "$$") + " "
+	result := templateActionRegex.ReplaceAllStringFunc(tmpl, func(action string) string {
+		// $value and .Value are the value of the query in Prometheus, but a description of all values in Grafana.
+		action = valueVariableRegex.ReplaceAllLiteralString(action, "$values."+importQueryRefID+".Value")
+		action = valueFieldRegex.ReplaceAllString(action, "${1}$$values."+importQueryRefID+".Value")
+		action = externalURLVariableRegex.ReplaceAllLiteralString(action, "externalURL")
+		action = externalURLFieldRegex.ReplaceAllString(action, "${1}externalURL")
+		if externalLabelsRegex.MatchString(action) {
+			usesExternalLabels = true
+		}
+		if queryFuncRegex.MatchString(action) {
+			usesQuery = true
+			action = queryFuncRegex.ReplaceAllString(action, queryReplacement)
+		}
+		return action
+	})
+
+	var warnings []string
+	if usesQuery {
+		warnings = append(warnings, "query is replaced with queryDatasource, which only queries the data source of the rule. Its results have Labels and Value fields and cannot be passed to first, label, value, strvalue or sortByLabel")
+	}
+	if usesExternalLabels {
+		warnings = append(warnings, "external labels are not available in Grafana templates, so the template fails to expand")
+	}
+	return result, warnings
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/services/quota/quotatest"
+)
+
+func TestRoutePostImportPrometheusRules(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	forDuration := prommodel.Duration(5 * time.Minute)
+
+	rulesImport := func() apimodels.PrometheusRulesImport {
+		return apimodels.PrometheusRulesImport{
+			DatasourceUID: "prometheus",
+			Groups: []apimodels.PrometheusRuleGroup{{
+				Name:     "node",
+				Interval: prommodel.Duration(time.Minute),
+				Rules: []apimodels.ApiRuleNode{{
+					Alert: "InstanceDown",
+					Expr:  `up == 0`,
+					For:   &forDuration,
+					Labels: map[string]string{
+						"severity": "critical",
+					},
+					Annotations: map[string]string{
+						"summary": "Instance {{ $labels.instance }} is down ({{ $value }})",
+					},
+				}, {
+					Record: "job:up:sum",
+					Expr:   `sum by (job) (up)`,
+				}},
+			}},
+		}
+	}
+
+	setup := func(t *testing.T) (*fakes.RuleStore, *RulerSrv, *contextmodel.ReqContext) {
+		ruleStore := fakes.NewRuleStore(t)
+		ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+		svc := createService(ruleStore)
+		svc.cfg.RecordingRules.Enabled = true
+		svc.conditionValidator = &recordingConditionValidator{}
+		svc.QuotaService = quotatest.New(false, nil)
+		req := createRequestContextWithPerms(orgID, map[int64]map[string][]string{
+			orgID: {
+				datasources.ActionQuery:                {datasources.ScopeAll},
+				accesscontrol.ActionAlertingRuleCreate: {dashboards.ScopeFoldersProvider.GetResourceScopeUID(folder.UID)},
+			},
+		}, nil)
+		return ruleStore, svc, req
+	}
+
+	getInserted := func(ruleStore *fakes.RuleStore) []models.AlertRule {
+		var result []models.AlertRule
+		for _, cmd := range ruleStore.GetRecordedCommands(func(cmd any) (any, bool) {
+			a, ok := cmd.([]models.AlertRule)
+			return a, ok
+		}) {
+			result = append(result, cmd.([]models.AlertRule)...)
+		}
+		return result
+	}
+
+	t.Run("should create the converted rule groups", func(t *testing.T) {
+		ruleStore, svc, req := setup(t)
+
+		response := svc.RoutePostImportPrometheusRules(req, rulesImport(), folder.Title)
+		require.Equal(t, http.StatusAccepted, response.Status())
+
+		inserted := getInserted(ruleStore)
+		require.Len(t, inserted, 2)
+
+		alerting := inserted[0]
+		require.Equal(t, "InstanceDown", alerting.Title)
+		require.Equal(t, folder.UID, alerting.NamespaceUID)
+		require.Equal(t, "node", alerting.RuleGroup)
+		require.EqualValues(t, 60, alerting.IntervalSeconds)
+		require.Equal(t, 5*time.Minute, alerting.For)
+		require.Equal(t, importConditionRefID, alerting.Condition)
+		require.Equal(t, models.OK, alerting.NoDataState)
+		require.Equal(t, models.ErrorErrState, alerting.ExecErrState)
+		require.Equal(t, map[string]string{"severity": "critical"}, alerting.Labels)
+		require.Equal(t, "Instance {{ $labels.instance }} is down ({{ $values.A.Value }})", alerting.Annotations["summary"])
+		require.Len(t, alerting.Data, 2)
+		require.Equal(t, "prometheus", alerting.Data[0].DatasourceUID)
+		require.Equal(t, expr.DatasourceUID, alerting.Data[1].DatasourceUID)
+		var query map[string]any
+		require.NoError(t, json.Unmarshal(alerting.Data[0].Model, &query))
+		require.Equal(t, "up == 0", query["expr"])
+		require.Equal(t, true, query["instant"])
+
+		recording := inserted[1]
+		require.Equal(t, "job:up:sum", recording.Title)
+		require.Equal(t, models.Record{Metric: "job:up:sum", From: importQueryRefID}, recording.Record)
+		require.Len(t, recording.Data, 1)
+	})
+
+	t.Run("should create all rule groups in one transaction", func(t *testing.T) {
+		ruleStore, svc, req := setup(t)
+		xact := &recordingTransactionManager{}
+		svc.xactManager = xact
+		body := rulesImport()
+		body.Groups = append(body.Groups, apimodels.PrometheusRuleGroup{
+			Name:     "other",
+			Interval: prommodel.Duration(time.Minute),
+			Rules:    []apimodels.ApiRuleNode{{Record: "up:count", Expr: `count(up)`}},
+		})
+
+		response := svc.RoutePostImportPrometheusRules(req, body, folder.Title)
+		require.Equal(t, http.StatusAccepted, response.Status())
+		require.Equal(t, 1, xact.calls)
+		require.Len(t, getInserted(ruleStore), 3)
+	})
+
+	t.Run("should return the error if a rule group cannot be created", func(t *testing.T) {
+		_, svc, req := setup(t)
+		svc.QuotaService = quotatest.New(true, nil)
+
+		response := svc.RoutePostImportPrometheusRules(req, rulesImport(), folder.Title)
+		require.Equal(t, http.StatusForbidden, response.Status())
+		require.Contains(t, string(response.Body()), `failed to create rule group \"node\"`)
+	})
+
+	t.Run("should not create the rule groups in dry-run mode", func(t *testing.T) {
+		ruleStore, svc, req := setup(t)
+		req.Req.Form.Set("dryRun", "true")
+
+		response := svc.RoutePostImportPrometheusRules(req, rulesImport(), folder.Title)
+		require.Equal(t, http.StatusOK, response.Status())
+		require.Empty(t, getInserted(ruleStore))
+
+		var result apimodels.PrometheusRulesImportResponse
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Len(t, result.Groups, 1)
+		require.Len(t, result.Groups[0].Rules, 2)
+		require.Empty(t, result.Incompatibilities)
+	})
+
+	t.Run("should report incompatibilities", func(t *testing.T) {
+		ruleStore, svc, req := setup(t)
+		existing := models.AlertRuleGen(withOrgID(orgID), withNamespace(folder), withGroup("existing"))()
+		existing.Title = "InstanceDown"
+		ruleStore.PutRule(context.Background(), existing)
+
+		body := rulesImport()
+		body.Groups[0].Limit = 10
+		body.Groups[0].Rules[0].Annotations["description"] = `{{ query "up" | first | value }} {{ $externalLabels.cluster }}`
+		body.Groups = append(body.Groups, apimodels.PrometheusRuleGroup{Name: "existing"})
+
+		response := svc.RoutePostImportPrometheusRules(req, body, folder.Title)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+		require.Empty(t, getInserted(ruleStore))
+
+		var result apimodels.PrometheusRulesImportResponse
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.ElementsMatch(t, []apimodels.PrometheusRuleIncompatibility{
+			{Group: "node", Message: "limit is not supported, the number of alerts and series of the rules is not limited"},
+			{Group: "node", Rule: "InstanceDown", Message: `the title of the rule is "InstanceDown (2)" because the folder or the file already has a rule titled "InstanceDown", so the alertname label of its alerts is "InstanceDown (2)"`},
+			{Group: "node", Rule: "InstanceDown", Message: `annotation "description": query is replaced with queryDatasource, which only queries the data source of the rule. Its results have Labels and Value fields and cannot be passed to first, label, value, strvalue or sortByLabel`},
+			{Group: "node", Rule: "InstanceDown", Message: `annotation "description": external labels are not available in Grafana templates, so the template fails to expand`},
+			{Group: "existing", Message: "the folder already has a rule group with this name", Blocking: true},
+		}, result.Incompatibilities)
+	})
+
+	t.Run("should return 400 if an expression is invalid", func(t *testing.T) {
+		ruleStore, svc, req := setup(t)
+		body := rulesImport()
+		body.Groups[0].Rules[0].Expr = "up =="
+
+		response := svc.RoutePostImportPrometheusRules(req, body, folder.Title)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+		require.Empty(t, getInserted(ruleStore))
+
+		var result apimodels.PrometheusRulesImportResponse
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Len(t, result.Incompatibilities, 1)
+		require.True(t, result.Incompatibilities[0].Blocking)
+		require.Equal(t, "InstanceDown", result.Incompatibilities[0].Rule)
+	})
+
+	t.Run("should return 400 if the data source cannot be queried", func(t *testing.T) {
+		ruleStore, svc, req := setup(t)
+		svc.conditionValidator = &recordingConditionValidator{
+			hook: func(models.Condition) error {
+				return errors.New("data source not found")
+			},
+		}
+
+		response := svc.RoutePostImportPrometheusRules(req, rulesImport(), folder.Title)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+		require.Empty(t, getInserted(ruleStore))
+	})
+
+	t.Run("should return 400 if the data source is not set", func(t *testing.T) {
+		_, svc, req := setup(t)
+		body := rulesImport()
+		body.DatasourceUID = ""
+		response := svc.RoutePostImportPrometheusRules(req, body, folder.Title)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+}
+
+func TestConvertPrometheusTemplate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		tmpl     string
+		expected string
+		warnings int
+	}{{
+		name:     "text without templates is not changed",
+		tmpl:     "The $value of .Value",
+		expected: "The $value of .Value",
+	}, {
+		name:     "labels are not changed",
+		tmpl:     "{{ $labels.instance }} {{ .Labels.job }}",
+		expected: "{{ $labels.instance }} {{ .Labels.job }}",
+	}, {
+		name:     "$value is the value of the query",
+		tmpl:     "{{ $value | humanizePercentage }}",
+		expected: "{{ $values.A.Value | humanizePercentage }}",
+	}, {
+		name:     ".Value is the value of the query",
+		tmpl:     "{{.Value}} {{ printf \"%.2f\" .Value }}",
+		expected: "{{$values.A.Value}} {{ printf \"%.2f\" $values.A.Value }}",
+	}, {
+		name:     "$values is not changed",
+		tmpl:     "{{ $values.B.Value }} {{ $labels.Value }}",
+		expected: "{{ $values.B.Value }} {{ $labels.Value }}",
+	}, {
+		name:     "external URL",
+		tmpl:     "{{ $externalURL }}/alerts {{ .ExternalURL }}",
+		expected: "{{ externalURL }}/alerts {{ externalURL }}",
+	}, {
+		name:     "query",
+		tmpl:     `{{ range query "up == 0" }}{{ .Labels.instance }}{{ end }}`,
+		expected: `{{ range queryDatasource "prometheus" "up == 0" }}{{ .Labels.instance }}{{ end }}`,
+		warnings: 1,
+	}, {
+		name:     "external labels",
+		tmpl:     "{{ $externalLabels.cluster }}",
+		expected: "{{ $externalLabels.cluster }}",
+		warnings: 1,
+	}}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, warnings := convertPrometheusTemplate(tc.tmpl, "prometheus")
+			require.Equal(t, tc.expected, result)
+			require.Len(t, warnings, tc.warnings)
+		})
+	}
+}
+
+// recordingTransactionManager counts the transactions and runs the work without one.
+type recordingTransactionManager struct {
+	calls int
+}
+
+func (m *recordingTransactionManager) InTransaction(ctx context.Context, work func(ctx context.Context) error) error {
+	m.calls++
+	return work(ctx)
+}
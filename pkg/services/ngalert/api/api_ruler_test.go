@@ -586,6 +586,156 @@ func TestValidateQueries(t *testing.T) {
 	})
 }
 
+func TestValidateNotificationSettingsChanges(t *testing.T) {
+	withSettings := func(receiver string) func(rule *models.AlertRule) {
+		return func(rule *models.AlertRule) {
+			rule.NotificationSettings = models.NotificationSettings{Receiver: receiver}
+		}
+	}
+	unchanged := models.AlertRuleGen(withSettings("Update_Unchanged"))()
+	delta := store.GroupDelta{
+		New: []*models.AlertRule{
+			models.AlertRuleGen(withSettings("New"))(),
+			models.AlertRuleGen(func(rule *models.AlertRule) {
+				rule.NotificationSettings = models.NotificationSettings{}
+			})(),
+		},
+		Update: []store.RuleDelta{
+			{
+				Existing: models.AlertRuleGen(withSettings("Update_Existing"))(),
+				New:      models.AlertRuleGen(withSettings("Update_New"))(),
+			},
+			{
+				Existing: unchanged,
+				New:      models.CopyRule(unchanged),
+			},
+		},
+		Delete: []*models.AlertRule{
+			models.AlertRuleGen(withSettings("Deleted"))(),
+		},
+	}
+
+	t.Run("should validate changed settings of New and Updated only", func(t *testing.T) {
+		var validated []string
+		svc := &fakeNotificationSettingsService{
+			ValidateFunc: func(settings models.NotificationSettings) error {
+				validated = append(validated, settings.Receiver)
+				return nil
+			},
+		}
+		err := validateNotificationSettingsChanges(context.Background(), svc, &delta)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"New", "Update_New"}, validated)
+	})
+
+	t.Run("should return rule validate error if settings are invalid", func(t *testing.T) {
+		svc := &fakeNotificationSettingsService{
+			ValidateFunc: func(settings models.NotificationSettings) error {
+				if settings.Receiver == "Update_New" {
+					return fmt.Errorf("%w: receiver does not exist", models.ErrNotificationSettingsInvalid)
+				}
+				return nil
+			},
+		}
+		err := validateNotificationSettingsChanges(context.Background(), svc, &delta)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, delta.Update[0].New.Title)
+	})
+
+	t.Run("should detect changes that affect notification settings", func(t *testing.T) {
+		require.True(t, changesAffectAutogeneratedConfig(&delta))
+		require.True(t, changesAffectAutogeneratedConfig(&store.GroupDelta{Delete: delta.Delete}))
+		require.False(t, changesAffectAutogeneratedConfig(&store.GroupDelta{New: delta.New[1:]}))
+	})
+
+	t.Run("should detect changes that affect dependencies", func(t *testing.T) {
+		rule := models.AlertRuleGen(models.WithOrgID(1))()
+		rule.NotificationSettings = models.NotificationSettings{}
+		rule.DependsOn = []string{"cluster-down"}
+		require.True(t, changesAffectAutogeneratedConfig(&store.GroupDelta{New: []*models.AlertRule{rule}}))
+		require.True(t, changesAffectAutogeneratedConfig(&store.GroupDelta{Delete: []*models.AlertRule{rule}}))
+	})
+}
+
+func TestValidateRuleDependencies(t *testing.T) {
+	orgID := rand.Int63()
+	withDependencies := func(uids ...string) func(rule *models.AlertRule) {
+		return func(rule *models.AlertRule) {
+			rule.DependsOn = uids
+		}
+	}
+	ruleA := models.AlertRuleGen(withOrgID(orgID))()
+	ruleB := models.AlertRuleGen(withOrgID(orgID), withDependencies(ruleA.UID))()
+
+	ruleHidden := models.AlertRuleGen(withOrgID(orgID))()
+	usr := &user.SignedInUser{OrgID: orgID}
+
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.PutRule(context.Background(), ruleA, ruleB, ruleHidden)
+	// the folder of ruleHidden is not visible to the user
+	folders := ruleStore.Folders[orgID][:0]
+	for _, f := range ruleStore.Folders[orgID] {
+		if f.UID != ruleHidden.NamespaceUID {
+			folders = append(folders, f)
+		}
+	}
+	ruleStore.Folders[orgID] = folders
+
+	t.Run("should pass if dependencies exist", func(t *testing.T) {
+		delta := &store.GroupDelta{
+			New: []*models.AlertRule{
+				models.AlertRuleGen(withOrgID(orgID), withDependencies(ruleA.UID, ruleB.UID))(),
+			},
+		}
+		require.NoError(t, validateRuleDependencies(context.Background(), ruleStore, usr, delta))
+	})
+
+	t.Run("should fail if dependency does not exist", func(t *testing.T) {
+		delta := &store.GroupDelta{
+			New: []*models.AlertRule{
+				models.AlertRuleGen(withOrgID(orgID), withDependencies("missing"))(),
+			},
+		}
+		err := validateRuleDependencies(context.Background(), ruleStore, usr, delta)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+	})
+
+	t.Run("should fail if dependency is in a folder the user cannot read", func(t *testing.T) {
+		delta := &store.GroupDelta{
+			New: []*models.AlertRule{
+				models.AlertRuleGen(withOrgID(orgID), withDependencies(ruleHidden.UID))(),
+			},
+		}
+		err := validateRuleDependencies(context.Background(), ruleStore, usr, delta)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "does not exist")
+	})
+
+	t.Run("should fail if dependency is deleted by the same changes", func(t *testing.T) {
+		delta := &store.GroupDelta{
+			New: []*models.AlertRule{
+				models.AlertRuleGen(withOrgID(orgID), withDependencies(ruleA.UID))(),
+			},
+			Delete: []*models.AlertRule{ruleA},
+		}
+		err := validateRuleDependencies(context.Background(), ruleStore, usr, delta)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+	})
+
+	t.Run("should fail if update creates a cycle", func(t *testing.T) {
+		updated := models.CopyRule(ruleA)
+		updated.DependsOn = []string{ruleB.UID}
+		delta := &store.GroupDelta{
+			Update: []store.RuleDelta{
+				{Existing: ruleA, New: updated},
+			},
+		}
+		err := validateRuleDependencies(context.Background(), ruleStore, usr, delta)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "cycle")
+	})
+}
+
 func createServiceWithProvenanceStore(store *fakes.RuleStore, provenanceStore provisioning.ProvisioningStore) *RulerSrv {
 	svc := createService(store)
 	svc.provenanceStore = provenanceStore
@@ -602,7 +752,8 @@ func createService(store *fakes.RuleStore) *RulerSrv {
 		cfg: &setting.UnifiedAlertingSettings{
 			BaseInterval: 10 * time.Second,
 		},
-		ac: acimpl.ProvideAccessControl(setting.NewCfg()),
+		ac:                   acimpl.ProvideAccessControl(setting.NewCfg()),
+		notificationSettings: &fakeNotificationSettingsService{},
 	}
 }
 
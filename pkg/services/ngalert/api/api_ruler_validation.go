@@ -7,6 +7,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/prometheus/common/model"
+
 	"github.com/grafana/grafana/pkg/services/folder"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -67,34 +69,64 @@ func validateRuleNode(
 		}
 	}
 
+	record, err := validateRecord(ruleNode.GrafanaManagedAlert.Record, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+
+	condition := ruleNode.GrafanaManagedAlert.Condition
+	if !record.IsZero() {
+		// the condition of a recording rule is the query or expression whose results are recorded
+		condition = record.From
+	}
+
 	if len(ruleNode.GrafanaManagedAlert.Data) == 0 {
 		if canPatch {
-			if ruleNode.GrafanaManagedAlert.Condition != "" {
+			if condition != "" {
 				return nil, fmt.Errorf("%w: query is not specified by condition is. You must specify both query and condition to update existing alert rule", ngmodels.ErrAlertRuleFailedValidation)
 			}
 		} else {
 			return nil, fmt.Errorf("%w: no queries or expressions are found", ngmodels.ErrAlertRuleFailedValidation)
 		}
 	} else {
-		err = validateCondition(ruleNode.GrafanaManagedAlert.Condition, ruleNode.GrafanaManagedAlert.Data)
+		err = validateCondition(condition, ruleNode.GrafanaManagedAlert.Data)
 		if err != nil {
 			return nil, fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
 		}
 	}
 
+	notificationSettings, err := validateNotificationSettings(ruleNode.GrafanaManagedAlert.NotificationSettings, record)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+
+	dependsOn, err := validateDependsOn(ruleNode.GrafanaManagedAlert.DependsOn, ruleNode.GrafanaManagedAlert.UID, record)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+
+	flapDetection := FlapDetectionFromApiFlapDetection(ruleNode.GrafanaManagedAlert.FlapDetection)
+	if err := flapDetection.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+
 	queries := AlertQueriesFromApiAlertQueries(ruleNode.GrafanaManagedAlert.Data)
 
 	newAlertRule := ngmodels.AlertRule{
-		OrgID:           orgId,
-		Title:           ruleNode.GrafanaManagedAlert.Title,
-		Condition:       ruleNode.GrafanaManagedAlert.Condition,
-		Data:            queries,
-		UID:             ruleNode.GrafanaManagedAlert.UID,
-		IntervalSeconds: intervalSeconds,
-		NamespaceUID:    namespace.UID,
-		RuleGroup:       groupName,
-		NoDataState:     noDataState,
-		ExecErrState:    errorState,
+		OrgID:                orgId,
+		Title:                ruleNode.GrafanaManagedAlert.Title,
+		Condition:            condition,
+		Data:                 queries,
+		UID:                  ruleNode.GrafanaManagedAlert.UID,
+		IntervalSeconds:      intervalSeconds,
+		NamespaceUID:         namespace.UID,
+		RuleGroup:            groupName,
+		NoDataState:          noDataState,
+		ExecErrState:         errorState,
+		Record:               record,
+		NotificationSettings: notificationSettings,
+		DependsOn:            dependsOn,
+		FlapDetection:        flapDetection,
 	}
 
 	newAlertRule.For, err = validateForInterval(ruleNode)
@@ -102,6 +134,11 @@ func validateRuleNode(
 		return nil, err
 	}
 
+	newAlertRule.KeepFiringFor, err = validateKeepFiringFor(ruleNode)
+	if err != nil {
+		return nil, err
+	}
+
 	if ruleNode.ApiRuleNode != nil {
 		newAlertRule.Annotations = ruleNode.ApiRuleNode.Annotations
 		newAlertRule.Labels = ruleNode.ApiRuleNode.Labels
@@ -142,6 +179,65 @@ func validateCondition(condition string, queries []apimodels.AlertQuery) error {
 	return nil
 }
 
+// validateRecord validates the definition of a recording rule and converts it to models.Record.
+// Returns an empty record if the rule is an alerting rule.
+func validateRecord(record *apimodels.Record, cfg *setting.UnifiedAlertingSettings) (ngmodels.Record, error) {
+	if record == nil {
+		return ngmodels.Record{}, nil
+	}
+	if !cfg.RecordingRules.Enabled {
+		return ngmodels.Record{}, errors.New("recording rules are not enabled")
+	}
+	if !model.IsValidMetricName(model.LabelValue(record.Metric)) {
+		return ngmodels.Record{}, fmt.Errorf("metric name '%s' of recording rule is not a valid Prometheus metric name", record.Metric)
+	}
+	if record.From == "" {
+		return ngmodels.Record{}, errors.New("the query or expression to record cannot be empty")
+	}
+	return ngmodels.Record{Metric: record.Metric, From: record.From}, nil
+}
+
+// validateNotificationSettings validates that the notification settings are well-formed and converts them to models.NotificationSettings.
+// It does not check that the receiver and mute time intervals exist. Recording rules cannot have notification settings.
+func validateNotificationSettings(settings *apimodels.AlertRuleNotificationSettings, record ngmodels.Record) (ngmodels.NotificationSettings, error) {
+	if settings == nil {
+		return ngmodels.NotificationSettings{}, nil
+	}
+	if !record.IsZero() {
+		return ngmodels.NotificationSettings{}, errors.New("recording rules cannot have notification settings")
+	}
+	result := NotificationSettingsFromApiNotificationSettings(settings)
+	if err := result.Validate(); err != nil {
+		return ngmodels.NotificationSettings{}, err
+	}
+	return result, nil
+}
+
+// validateDependsOn validates that the list of rules the rule depends on does not contain empty or duplicated UIDs, or the rule itself.
+// It does not check that the rules exist. Recording rules cannot depend on other rules.
+func validateDependsOn(dependsOn []string, uid string, record ngmodels.Record) ([]string, error) {
+	if len(dependsOn) == 0 {
+		return nil, nil
+	}
+	if !record.IsZero() {
+		return nil, errors.New("recording rules cannot depend on other rules")
+	}
+	seen := make(map[string]struct{}, len(dependsOn))
+	for _, dependency := range dependsOn {
+		if dependency == "" {
+			return nil, errors.New("UID of a rule the rule depends on cannot be empty")
+		}
+		if uid != "" && dependency == uid {
+			return nil, errors.New("rule cannot depend on itself")
+		}
+		if _, ok := seen[dependency]; ok {
+			return nil, fmt.Errorf("rule '%s' is specified more than once in depends_on", dependency)
+		}
+		seen[dependency] = struct{}{}
+	}
+	return dependsOn, nil
+}
+
 func validateInterval(cfg *setting.UnifiedAlertingSettings, interval time.Duration) (int64, error) {
 	intervalSeconds := int64(interval.Seconds())
 
@@ -173,6 +269,21 @@ func validateForInterval(ruleNode *apimodels.PostableExtendedRuleNode) (time.Dur
 	return duration, nil
 }
 
+// validateKeepFiringFor validates ApiRuleNode.KeepFiringFor and converts it to time.Duration. If the field is not specified returns 0 if GrafanaManagedAlert.UID is empty and -1 if it is not.
+func validateKeepFiringFor(ruleNode *apimodels.PostableExtendedRuleNode) (time.Duration, error) {
+	if ruleNode.ApiRuleNode == nil || ruleNode.ApiRuleNode.KeepFiringFor == nil {
+		if ruleNode.GrafanaManagedAlert.UID != "" {
+			return -1, nil // will be patched later with the real value of the current version of the rule
+		}
+		return 0, nil
+	}
+	duration := time.Duration(*ruleNode.ApiRuleNode.KeepFiringFor)
+	if duration < 0 {
+		return 0, fmt.Errorf("field `keep_firing_for` cannot be negative [%v]. 0 or any positive duration are allowed", *ruleNode.ApiRuleNode.KeepFiringFor)
+	}
+	return duration, nil
+}
+
 // validateRuleGroup validates API model (definitions.PostableRuleGroupConfig) and converts it to a collection of models.AlertRule.
 // Returns a slice that contains all rules described by API model or error if either group specification or an alert definition is not valid.
 // It also returns a map containing current existing alerts that don't contain the is_paused field in the body of the call.
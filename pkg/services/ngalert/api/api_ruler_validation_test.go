@@ -790,3 +790,165 @@ func TestValidateRuleNodeIntervalFailures(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRuleNodeRecord(t *testing.T) {
+	cfg := config(t)
+	cfg.RecordingRules.Enabled = true
+	interval := cfg.BaseInterval * time.Duration(rand.Int63n(10)+1)
+
+	t.Run("should use the recorded query as condition", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.Condition = ""
+		r.GrafanaManagedAlert.Record = &apimodels.Record{Metric: "test_metric", From: "A"}
+
+		alert, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), cfg)
+		require.NoError(t, err)
+		require.True(t, alert.IsRecordingRule())
+		require.Equal(t, models.Record{Metric: "test_metric", From: "A"}, alert.Record)
+		require.Equal(t, "A", alert.Condition)
+	})
+
+	testCases := []struct {
+		name   string
+		record apimodels.Record
+	}{
+		{
+			name:   "fail if metric name is invalid",
+			record: apimodels.Record{Metric: "invalid metric", From: "A"},
+		},
+		{
+			name:   "fail if query is empty",
+			record: apimodels.Record{Metric: "test_metric"},
+		},
+		{
+			name:   "fail if query does not exist",
+			record: apimodels.Record{Metric: "test_metric", From: "B"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := validRule()
+			r.GrafanaManagedAlert.Record = &testCase.record
+			_, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), cfg)
+			require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		})
+	}
+
+	t.Run("fail if recording rules are disabled", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.Record = &apimodels.Record{Metric: "test_metric", From: "A"}
+		disabled := *cfg
+		disabled.RecordingRules.Enabled = false
+		_, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), &disabled)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+	})
+}
+
+func TestValidateRuleNodeDependsOn(t *testing.T) {
+	cfg := config(t)
+	interval := cfg.BaseInterval * time.Duration(rand.Int63n(10)+1)
+
+	t.Run("should copy rules the rule depends on", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.DependsOn = []string{"rule-1", "rule-2"}
+		alert, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), cfg)
+		require.NoError(t, err)
+		require.Equal(t, []string{"rule-1", "rule-2"}, alert.DependsOn)
+	})
+
+	testCases := []struct {
+		name      string
+		dependsOn []string
+	}{
+		{
+			name:      "fail if UID is empty",
+			dependsOn: []string{""},
+		},
+		{
+			name:      "fail if UID is duplicated",
+			dependsOn: []string{"rule-1", "rule-1"},
+		},
+		{
+			name:      "fail if rule depends on itself",
+			dependsOn: []string{"rule-1", "self"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := validRule()
+			r.GrafanaManagedAlert.UID = "self"
+			r.GrafanaManagedAlert.DependsOn = testCase.dependsOn
+			_, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), cfg)
+			require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		})
+	}
+}
+
+func TestValidateRuleNodeNotificationSettings(t *testing.T) {
+	cfg := config(t)
+	interval := cfg.BaseInterval * time.Duration(rand.Int63n(10)+1)
+
+	t.Run("should convert notification settings", func(t *testing.T) {
+		r := validRule()
+		groupWait := model.Duration(30 * time.Second)
+		r.GrafanaManagedAlert.NotificationSettings = &apimodels.AlertRuleNotificationSettings{
+			Receiver:          "receiver",
+			GroupBy:           []string{"alertname", "grafana_folder"},
+			GroupWait:         &groupWait,
+			MuteTimeIntervals: []string{"weekends"},
+		}
+
+		alert, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), cfg)
+		require.NoError(t, err)
+		require.Equal(t, models.NotificationSettings{
+			Receiver:          "receiver",
+			GroupBy:           []string{"alertname", "grafana_folder"},
+			GroupWait:         &groupWait,
+			MuteTimeIntervals: []string{"weekends"},
+		}, alert.NotificationSettings)
+	})
+
+	invalidInterval := model.Duration(0)
+	testCases := []struct {
+		name     string
+		settings apimodels.AlertRuleNotificationSettings
+	}{
+		{
+			name:     "fail if receiver is empty",
+			settings: apimodels.AlertRuleNotificationSettings{},
+		},
+		{
+			name:     "fail if group by contains invalid label",
+			settings: apimodels.AlertRuleNotificationSettings{Receiver: "receiver", GroupBy: []string{"invalid label"}},
+		},
+		{
+			name:     "fail if group by contains other labels with '...'",
+			settings: apimodels.AlertRuleNotificationSettings{Receiver: "receiver", GroupBy: []string{"...", "alertname"}},
+		},
+		{
+			name:     "fail if repeat interval is not positive",
+			settings: apimodels.AlertRuleNotificationSettings{Receiver: "receiver", RepeatInterval: &invalidInterval},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			r := validRule()
+			r.GrafanaManagedAlert.NotificationSettings = &testCase.settings
+			_, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), cfg)
+			require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		})
+	}
+
+	t.Run("fail if recording rule has notification settings", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.Record = &apimodels.Record{Metric: "test_metric", From: "A"}
+		r.GrafanaManagedAlert.NotificationSettings = &apimodels.AlertRuleNotificationSettings{Receiver: "receiver"}
+		enabled := *cfg
+		enabled.RecordingRules.Enabled = true
+		_, err := validateRuleNode(&r, util.GenerateShortUID(), interval, rand.Int63(), randFolder(), &enabled)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+	})
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/folder"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// alertRuleFieldsToIgnoreInVersionDiff contains fields that change with every version and therefore are not reported in the diff of two versions.
+var alertRuleFieldsToIgnoreInVersionDiff = []string{"ID", "Version", "Updated", "UpdatedBy"}
+
+// RouteGetRuleVersions returns all versions of the rule, most recent first.
+// Returns 404 if the rule does not exist, and 401 if the user is not authorized to access the rule.
+func (srv RulerSrv) RouteGetRuleVersions(c *contextmodel.ReqContext, ruleUID string) response.Response {
+	rule, namespace, versions, err := srv.getAuthorizedRuleVersions(c, ruleUID)
+	if err != nil {
+		return toRuleVersionsErrorResponse(err)
+	}
+
+	result := make(apimodels.GettableAlertRuleVersions, 0, len(versions))
+	for _, v := range versions {
+		versionedRule := v.AlertRule()
+		versionedRule.ID = rule.ID
+		result = append(result, apimodels.GettableAlertRuleVersion{
+			Version:       v.Version,
+			ParentVersion: v.ParentVersion,
+			RestoredFrom:  v.RestoredFrom,
+			Created:       v.Created,
+			UpdatedBy:     v.UpdatedBy,
+			Rule:          toGettableExtendedRuleNode(*versionedRule, namespace.ID, nil),
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// RouteGetRuleVersionsDiff returns fields of the rule that differ between versions specified by query parameters "from" and "to".
+func (srv RulerSrv) RouteGetRuleVersionsDiff(c *contextmodel.ReqContext, ruleUID string) response.Response {
+	from, err := strconv.ParseInt(c.Query("from"), 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid value of parameter 'from': %w", err), "")
+	}
+	to, err := strconv.ParseInt(c.Query("to"), 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid value of parameter 'to': %w", err), "")
+	}
+
+	_, _, versions, err := srv.getAuthorizedRuleVersions(c, ruleUID)
+	if err != nil {
+		return toRuleVersionsErrorResponse(err)
+	}
+	fromVersion, err := findRuleVersion(versions, from)
+	if err != nil {
+		return toRuleVersionsErrorResponse(err)
+	}
+	toVersion, err := findRuleVersion(versions, to)
+	if err != nil {
+		return toRuleVersionsErrorResponse(err)
+	}
+
+	diff := fromVersion.AlertRule().Diff(toVersion.AlertRule(), alertRuleFieldsToIgnoreInVersionDiff...)
+	result := apimodels.RuleVersionDiff{
+		From:    from,
+		To:      to,
+		Changes: make([]apimodels.RuleVersionChange, 0, len(diff)),
+	}
+	for _, d := range diff {
+		result.Changes = append(result.Changes, apimodels.RuleVersionChange{
+			Path: d.Path,
+			From: diffValue(d.Left),
+			To:   diffValue(d.Right),
+		})
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+// RoutePostRestoreRuleVersion replaces the definition of the rule with the one saved at the specified version.
+// The rule stays in its current folder and group. The change is validated and authorized the same way as an update of the rule group.
+func (srv RulerSrv) RoutePostRestoreRuleVersion(c *contextmodel.ReqContext, ruleUID string, versionParam string) response.Response {
+	version, err := strconv.ParseInt(versionParam, 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid version: %w", err), "")
+	}
+
+	rule, _, versions, err := srv.getAuthorizedRuleVersions(c, ruleUID)
+	if err != nil {
+		return toRuleVersionsErrorResponse(err)
+	}
+	v, err := findRuleVersion(versions, version)
+	if err != nil {
+		return toRuleVersionsErrorResponse(err)
+	}
+
+	restored := v.AlertRule()
+	// the location of the rule is not restored because it is managed by the rule group.
+	restored.ID = rule.ID
+	restored.OrgID = rule.OrgID
+	restored.NamespaceUID = rule.NamespaceUID
+	restored.RuleGroup = rule.RuleGroup
+	restored.RuleGroupIndex = rule.RuleGroupIndex
+	restored.IntervalSeconds = rule.IntervalSeconds
+	restored.Version = rule.Version
+	if err := restored.SetDashboardAndPanelFromAnnotations(); err != nil {
+		return ErrResp(http.StatusBadRequest, err, "failed to restore the rule")
+	}
+
+	groupRules, err := srv.store.GetAlertRulesGroupByRuleUID(c.Req.Context(), &ngmodels.GetAlertRulesGroupByRuleUIDQuery{
+		UID:   rule.UID,
+		OrgID: rule.OrgID,
+	})
+	if err != nil {
+		return ErrResp(http.StatusInternalServerError, err, "failed to get the rule group")
+	}
+	rules := make([]*ngmodels.AlertRuleWithOptionals, 0, len(groupRules))
+	for _, r := range groupRules {
+		if r.UID == restored.UID {
+			r = restored
+		}
+		rules = append(rules, &ngmodels.AlertRuleWithOptionals{AlertRule: *r, HasPause: true})
+	}
+
+	return srv.updateAlertRulesInGroup(c, rule.GetGroupKey(), rules, map[string]int64{rule.UID: version})
+}
+
+// getAuthorizedRuleVersions returns the rule, its folder and all its versions.
+// Returns ErrAuthorization if the user is not authorized to access the rule, and errFolderAccess if the user cannot read the folder of the rule.
+func (srv RulerSrv) getAuthorizedRuleVersions(c *contextmodel.ReqContext, ruleUID string) (ngmodels.AlertRule, *folder.Folder, []*ngmodels.AlertRuleVersion, error) {
+	rule, err := srv.getAuthorizedRuleByUid(c.Req.Context(), c, ruleUID)
+	if err != nil {
+		return ngmodels.AlertRule{}, nil, nil, err
+	}
+	namespace, err := srv.store.GetNamespaceByUID(c.Req.Context(), rule.NamespaceUID, c.SignedInUser.GetOrgID(), c.SignedInUser)
+	if err != nil {
+		return ngmodels.AlertRule{}, nil, nil, errors.Join(errFolderAccess, err)
+	}
+	versions, err := srv.store.GetAlertRuleVersions(c.Req.Context(), &ngmodels.ListAlertRuleVersionsQuery{
+		OrgID:   rule.OrgID,
+		RuleUID: rule.UID,
+	})
+	if err != nil {
+		return ngmodels.AlertRule{}, nil, nil, err
+	}
+	return rule, namespace, versions, nil
+}
+
+func findRuleVersion(versions []*ngmodels.AlertRuleVersion, version int64) (*ngmodels.AlertRuleVersion, error) {
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: version %d", ngmodels.ErrAlertRuleVersionNotFound, version)
+}
+
+func toRuleVersionsErrorResponse(err error) response.Response {
+	if errors.Is(err, ngmodels.ErrAlertRuleNotFound) || errors.Is(err, ngmodels.ErrAlertRuleVersionNotFound) {
+		return ErrResp(http.StatusNotFound, err, "")
+	}
+	return errorToResponse(err)
+}
+
+// diffValue returns the value of a field reported by the diff. Returns nil if the field does not exist in the version,
+// for example when a label is added or removed.
+func diffValue(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.CanInterface() {
+		return v.Interface()
+	}
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+)
+
+func TestRouteGetRuleVersions(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+	rule := models.AlertRuleGen(withOrgID(orgID), withNamespace(folder))()
+	ruleStore.PutRule(context.Background(), rule)
+
+	first := ruleVersionFromRule(rule, 1, "first-user")
+	second := ruleVersionFromRule(rule, 2, "second-user")
+	second.Title = "updated title"
+	second.RestoredFrom = 1
+	ruleStore.Versions = append(ruleStore.Versions, first, second)
+
+	t.Run("should return versions of the rule, most recent first", func(t *testing.T) {
+		req := createRequestContext(orgID, nil)
+		response := createService(ruleStore).RouteGetRuleVersions(req, rule.UID)
+
+		require.Equal(t, http.StatusOK, response.Status())
+		var result apimodels.GettableAlertRuleVersions
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.Len(t, result, 2)
+		require.EqualValues(t, 2, result[0].Version)
+		require.EqualValues(t, 1, result[0].RestoredFrom)
+		require.Equal(t, "second-user", result[0].UpdatedBy)
+		require.Equal(t, "updated title", result[0].Rule.GrafanaManagedAlert.Title)
+		require.Equal(t, rule.UID, result[0].Rule.GrafanaManagedAlert.UID)
+		require.EqualValues(t, 1, result[1].Version)
+		require.Equal(t, "first-user", result[1].UpdatedBy)
+		require.Equal(t, rule.Title, result[1].Rule.GrafanaManagedAlert.Title)
+	})
+
+	t.Run("should return 404 if rule does not exist", func(t *testing.T) {
+		req := createRequestContext(orgID, nil)
+		response := createService(ruleStore).RouteGetRuleVersions(req, "unknown")
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+
+	t.Run("should return 401 if user cannot query data sources of the rule", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, map[int64]map[string][]string{}, nil)
+		response := createService(ruleStore).RouteGetRuleVersions(req, rule.UID)
+		require.Equal(t, http.StatusUnauthorized, response.Status())
+	})
+}
+
+func TestRouteGetRuleVersionsDiff(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+	rule := models.AlertRuleGen(withOrgID(orgID), withNamespace(folder))()
+	ruleStore.PutRule(context.Background(), rule)
+
+	first := ruleVersionFromRule(rule, 1, "first-user")
+	first.Title = "old title"
+	second := ruleVersionFromRule(rule, 2, "second-user")
+	second.Title = "new title"
+	ruleStore.Versions = append(ruleStore.Versions, first, second)
+
+	createDiffRequest := func(from, to string) *contextmodel.ReqContext {
+		req := createRequestContext(orgID, nil)
+		req.Req.Form.Set("from", from)
+		req.Req.Form.Set("to", to)
+		return req
+	}
+
+	t.Run("should return fields that changed between versions", func(t *testing.T) {
+		req := createDiffRequest("1", "2")
+		response := createService(ruleStore).RouteGetRuleVersionsDiff(req, rule.UID)
+
+		require.Equal(t, http.StatusOK, response.Status())
+		var result apimodels.RuleVersionDiff
+		require.NoError(t, json.Unmarshal(response.Body(), &result))
+		require.EqualValues(t, 1, result.From)
+		require.EqualValues(t, 2, result.To)
+		require.Len(t, result.Changes, 1)
+		require.Equal(t, "Title", result.Changes[0].Path)
+		require.Equal(t, "old title", result.Changes[0].From)
+		require.Equal(t, "new title", result.Changes[0].To)
+	})
+
+	t.Run("should return 400 if versions are not numbers", func(t *testing.T) {
+		req := createDiffRequest("first", "2")
+		response := createService(ruleStore).RouteGetRuleVersionsDiff(req, rule.UID)
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+
+	t.Run("should return 404 if version does not exist", func(t *testing.T) {
+		req := createDiffRequest("1", "3")
+		response := createService(ruleStore).RouteGetRuleVersionsDiff(req, rule.UID)
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+}
+
+func TestRoutePostRestoreRuleVersion(t *testing.T) {
+	orgID := rand.Int63()
+	folder := randFolder()
+	ruleStore := fakes.NewRuleStore(t)
+	ruleStore.Folders[orgID] = append(ruleStore.Folders[orgID], folder)
+	groupKey := models.GenerateGroupKey(orgID)
+	groupKey.NamespaceUID = folder.UID
+	rules := models.GenerateAlertRules(3, models.AlertRuleGen(withGroupKey(groupKey), models.WithUniqueGroupIndex(), models.WithUniqueID()))
+	ruleStore.PutRule(context.Background(), rules...)
+	rule := rules[0]
+
+	old := ruleVersionFromRule(rule, 1, "first-user")
+	old.Title = "old title"
+	old.Labels = map[string]string{"severity": "critical"}
+	ruleStore.Versions = append(ruleStore.Versions, old)
+
+	permissions := createPermissionsForRules(rules, orgID)
+	permissions[orgID][accesscontrol.ActionAlertingRuleUpdate] = []string{dashboards.ScopeFoldersProvider.GetResourceScopeUID(folder.UID)}
+
+	t.Run("should update the rule with the definition of the version", func(t *testing.T) {
+		ruleStore.RecordedOps = nil
+		req := createRequestContextWithPerms(orgID, permissions, nil)
+		req.SignedInUser.Login = "restoring-user"
+		svc := createService(ruleStore)
+		svc.conditionValidator = &recordingConditionValidator{}
+
+		response := svc.RoutePostRestoreRuleVersion(req, rule.UID, "1")
+
+		require.Equal(t, http.StatusAccepted, response.Status())
+		updates := ruleStore.GetRecordedCommands(func(cmd any) (any, bool) {
+			a, ok := cmd.([]models.UpdateRule)
+			return a, ok
+		})
+		require.Len(t, updates, 1)
+		var restored *models.UpdateRule
+		for _, u := range updates[0].([]models.UpdateRule) {
+			if u.New.UID == rule.UID {
+				restored = &u
+				break
+			}
+		}
+		require.NotNil(t, restored)
+		require.Equal(t, "old title", restored.New.Title)
+		require.Equal(t, map[string]string{"severity": "critical"}, restored.New.Labels)
+		require.Equal(t, rule.RuleGroup, restored.New.RuleGroup)
+		require.Equal(t, rule.NamespaceUID, restored.New.NamespaceUID)
+		require.Equal(t, rule.ID, restored.New.ID)
+		require.Equal(t, "restoring-user", restored.New.UpdatedBy)
+		require.EqualValues(t, 1, restored.RestoredFrom)
+	})
+
+	t.Run("should return 401 if user cannot update rules in the folder", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, createPermissionsForRules(rules, orgID), nil)
+		svc := createService(ruleStore)
+		svc.conditionValidator = &recordingConditionValidator{}
+
+		response := svc.RoutePostRestoreRuleVersion(req, rule.UID, "1")
+		require.Equal(t, http.StatusUnauthorized, response.Status())
+	})
+
+	t.Run("should return 404 if version does not exist", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, permissions, nil)
+		response := createService(ruleStore).RoutePostRestoreRuleVersion(req, rule.UID, "2")
+		require.Equal(t, http.StatusNotFound, response.Status())
+	})
+
+	t.Run("should return 400 if version is not a number", func(t *testing.T) {
+		req := createRequestContextWithPerms(orgID, permissions, nil)
+		response := createService(ruleStore).RoutePostRestoreRuleVersion(req, rule.UID, "latest")
+		require.Equal(t, http.StatusBadRequest, response.Status())
+	})
+}
+
+func ruleVersionFromRule(rule *models.AlertRule, version int64, author string) *models.AlertRuleVersion {
+	return &models.AlertRuleVersion{
+		RuleOrgID:        rule.OrgID,
+		RuleUID:          rule.UID,
+		RuleNamespaceUID: rule.NamespaceUID,
+		RuleGroup:        rule.RuleGroup,
+		RuleGroupIndex:   rule.RuleGroupIndex,
+		ParentVersion:    version - 1,
+		Version:          version,
+		Created:          time.Now(),
+		Title:            rule.Title,
+		Condition:        rule.Condition,
+		Data:             rule.Data,
+		IntervalSeconds:  rule.IntervalSeconds,
+		NoDataState:      rule.NoDataState,
+		ExecErrState:     rule.ExecErrState,
+		For:              rule.For,
+		Annotations:      rule.Annotations,
+		Labels:           rule.Labels,
+		UpdatedBy:        author,
+	}
+}
@@ -39,10 +39,20 @@ func (api *API) authorize(method, path string) web.Handler {
 	case http.MethodGet + "/api/ruler/grafana/api/v1/rules",
 		http.MethodGet + "/api/ruler/grafana/api/v1/export/rules":
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
+	// the folder of the rule is not known from the path, the handlers enforce access to the folder and the data sources of the rule.
+	case http.MethodGet + "/api/ruler/grafana/api/v1/rule/{RuleUID}/versions",
+		http.MethodGet + "/api/ruler/grafana/api/v1/rule/{RuleUID}/versions/diff":
+		eval = ac.EvalPermission(ac.ActionAlertingRuleRead)
+	case http.MethodPost + "/api/ruler/grafana/api/v1/rule/{RuleUID}/versions/{Version}/restore":
+		// more granular permissions are enforced by the handler via "authorizeRuleChanges"
+		eval = ac.EvalPermission(ac.ActionAlertingRuleUpdate)
 	case http.MethodPost + "/api/ruler/grafana/api/v1/rules/{Namespace}/export":
 		scope := dashboards.ScopeFoldersProvider.GetResourceScopeName(ac.Parameter(":Namespace"))
 		// more granular permissions are enforced by the handler via "authorizeRuleChanges"
 		eval = ac.EvalPermission(ac.ActionAlertingRuleRead, scope)
+	case http.MethodPost + "/api/ruler/grafana/api/v1/import/prometheus/{Namespace}":
+		// more granular permissions are enforced by the handler via "authorizeRuleChanges"
+		eval = ac.EvalPermission(ac.ActionAlertingRuleCreate, dashboards.ScopeFoldersProvider.GetResourceScopeName(ac.Parameter(":Namespace")))
 	case http.MethodPost + "/api/ruler/grafana/api/v1/rules/{Namespace}":
 		scope := dashboards.ScopeFoldersProvider.GetResourceScopeName(ac.Parameter(":Namespace"))
 		// more granular permissions are enforced by the handler via "authorizeRuleChanges"
@@ -111,10 +121,16 @@ func (api *API) authorize(method, path string) web.Handler {
 		eval = ac.EvalPermission(ac.ActionAlertingInstanceRead)
 	case http.MethodGet + "/api/alertmanager/grafana/api/v2/alerts":
 		eval = ac.EvalPermission(ac.ActionAlertingInstanceRead)
+	case http.MethodPost + "/api/alertmanager/grafana/api/v2/alerts":
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceCreate)
 
 	// Grafana Prometheus-compatible Paths
 	case http.MethodGet + "/api/prometheus/grafana/api/v1/alerts":
 		eval = ac.EvalPermission(ac.ActionAlertingInstanceRead)
+	case http.MethodPost + "/api/prometheus/grafana/api/v1/alerts/acknowledge",
+		http.MethodPost + "/api/prometheus/grafana/api/v1/alerts/unacknowledge":
+		// access to the folder of the rule is enforced by the handler.
+		eval = ac.EvalPermission(ac.ActionAlertingInstanceUpdate)
 
 	// Silences. External AM.
 	case http.MethodDelete + "/api/alertmanager/{DatasourceUID}/api/v2/silence/{SilenceId}":
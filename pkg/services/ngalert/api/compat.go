@@ -14,46 +14,88 @@ import (
 // AlertRuleFromProvisionedAlertRule converts definitions.ProvisionedAlertRule to models.AlertRule
 func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (models.AlertRule, error) {
 	return models.AlertRule{
-		ID:           a.ID,
-		UID:          a.UID,
-		OrgID:        a.OrgID,
-		NamespaceUID: a.FolderUID,
-		RuleGroup:    a.RuleGroup,
-		Title:        a.Title,
-		Condition:    a.Condition,
-		Data:         AlertQueriesFromApiAlertQueries(a.Data),
-		Updated:      a.Updated,
-		NoDataState:  models.NoDataState(a.NoDataState),          // TODO there must be a validation
-		ExecErrState: models.ExecutionErrorState(a.ExecErrState), // TODO there must be a validation
-		For:          time.Duration(a.For),
-		Annotations:  a.Annotations,
-		Labels:       a.Labels,
-		IsPaused:     a.IsPaused,
+		ID:                   a.ID,
+		UID:                  a.UID,
+		OrgID:                a.OrgID,
+		NamespaceUID:         a.FolderUID,
+		RuleGroup:            a.RuleGroup,
+		Title:                a.Title,
+		Condition:            a.Condition,
+		Data:                 AlertQueriesFromApiAlertQueries(a.Data),
+		Updated:              a.Updated,
+		NoDataState:          models.NoDataState(a.NoDataState),          // TODO there must be a validation
+		ExecErrState:         models.ExecutionErrorState(a.ExecErrState), // TODO there must be a validation
+		For:                  time.Duration(a.For),
+		KeepFiringFor:        time.Duration(a.KeepFiringFor),
+		Annotations:          a.Annotations,
+		Labels:               a.Labels,
+		IsPaused:             a.IsPaused,
+		NotificationSettings: NotificationSettingsFromApiNotificationSettings(a.NotificationSettings),
+		DependsOn:            a.DependsOn,
+		Record:               ModelRecordFromApiRecord(a.Record),
+		FlapDetection:        FlapDetectionFromApiFlapDetection(a.FlapDetection),
 	}, nil
 }
 
 // ProvisionedAlertRuleFromAlertRule converts models.AlertRule to definitions.ProvisionedAlertRule and sets provided provenance status
 func ProvisionedAlertRuleFromAlertRule(rule models.AlertRule, provenance models.Provenance) definitions.ProvisionedAlertRule {
 	return definitions.ProvisionedAlertRule{
-		ID:           rule.ID,
-		UID:          rule.UID,
-		OrgID:        rule.OrgID,
-		FolderUID:    rule.NamespaceUID,
-		RuleGroup:    rule.RuleGroup,
-		Title:        rule.Title,
-		For:          model.Duration(rule.For),
-		Condition:    rule.Condition,
-		Data:         ApiAlertQueriesFromAlertQueries(rule.Data),
-		Updated:      rule.Updated,
-		NoDataState:  definitions.NoDataState(rule.NoDataState),          // TODO there may be a validation
-		ExecErrState: definitions.ExecutionErrorState(rule.ExecErrState), // TODO there may be a validation
-		Annotations:  rule.Annotations,
-		Labels:       rule.Labels,
-		Provenance:   definitions.Provenance(provenance), // TODO validate enum conversion?
-		IsPaused:     rule.IsPaused,
+		ID:                   rule.ID,
+		UID:                  rule.UID,
+		OrgID:                rule.OrgID,
+		FolderUID:            rule.NamespaceUID,
+		RuleGroup:            rule.RuleGroup,
+		Title:                rule.Title,
+		For:                  model.Duration(rule.For),
+		KeepFiringFor:        model.Duration(rule.KeepFiringFor),
+		Condition:            rule.Condition,
+		Data:                 ApiAlertQueriesFromAlertQueries(rule.Data),
+		Updated:              rule.Updated,
+		NoDataState:          definitions.NoDataState(rule.NoDataState),          // TODO there may be a validation
+		ExecErrState:         definitions.ExecutionErrorState(rule.ExecErrState), // TODO there may be a validation
+		Annotations:          rule.Annotations,
+		Labels:               rule.Labels,
+		Provenance:           definitions.Provenance(provenance), // TODO validate enum conversion?
+		IsPaused:             rule.IsPaused,
+		NotificationSettings: ApiNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
+		DependsOn:            rule.DependsOn,
+		Record:               ApiRecordFromModelRecord(rule.Record),
+		FlapDetection:        ApiFlapDetectionFromFlapDetection(rule.FlapDetection),
 	}
 }
 
+// ModelRecordFromApiRecord converts definitions.Record to models.Record, nil is an alerting rule
+func ModelRecordFromApiRecord(r *definitions.Record) models.Record {
+	if r == nil {
+		return models.Record{}
+	}
+	return models.Record{Metric: r.Metric, From: r.From}
+}
+
+// ApiRecordFromModelRecord converts models.Record to definitions.Record, it returns nil for alerting rules
+func ApiRecordFromModelRecord(r models.Record) *definitions.Record {
+	if r.IsZero() {
+		return nil
+	}
+	return &definitions.Record{Metric: r.Metric, From: r.From}
+}
+
+// FlapDetectionFromApiFlapDetection converts definitions.FlapDetection to models.FlapDetection, nil disables flap detection
+func FlapDetectionFromApiFlapDetection(f *definitions.FlapDetection) models.FlapDetection {
+	if f == nil {
+		return models.FlapDetection{}
+	}
+	return models.FlapDetection{Threshold: f.Threshold, Window: time.Duration(f.Window)}
+}
+
+// ApiFlapDetectionFromFlapDetection converts models.FlapDetection to definitions.FlapDetection, it returns nil if flap detection is disabled
+func ApiFlapDetectionFromFlapDetection(f models.FlapDetection) *definitions.FlapDetection {
+	if !f.IsEnabled() {
+		return nil
+	}
+	return &definitions.FlapDetection{Threshold: f.Threshold, Window: model.Duration(f.Window)}
+}
+
 // ProvisionedAlertRuleFromAlertRules converts a collection of models.AlertRule to definitions.ProvisionedAlertRules with provenance status models.ProvenanceNone
 func ProvisionedAlertRuleFromAlertRules(rules []*models.AlertRule, provenances map[string]models.Provenance) definitions.ProvisionedAlertRules {
 	result := make([]definitions.ProvisionedAlertRule, 0, len(rules))
@@ -99,6 +141,37 @@ func ApiAlertQueriesFromAlertQueries(queries []models.AlertQuery) []definitions.
 	return result
 }
 
+// NotificationSettingsFromApiNotificationSettings converts definitions.AlertRuleNotificationSettings to models.NotificationSettings
+func NotificationSettingsFromApiNotificationSettings(settings *definitions.AlertRuleNotificationSettings) models.NotificationSettings {
+	if settings == nil {
+		return models.NotificationSettings{}
+	}
+	return models.NotificationSettings{
+		Receiver:          settings.Receiver,
+		GroupBy:           settings.GroupBy,
+		GroupWait:         settings.GroupWait,
+		GroupInterval:     settings.GroupInterval,
+		RepeatInterval:    settings.RepeatInterval,
+		MuteTimeIntervals: settings.MuteTimeIntervals,
+	}
+}
+
+// ApiNotificationSettingsFromNotificationSettings converts models.NotificationSettings to definitions.AlertRuleNotificationSettings.
+// Returns nil if the rule does not have notification settings.
+func ApiNotificationSettingsFromNotificationSettings(settings models.NotificationSettings) *definitions.AlertRuleNotificationSettings {
+	if settings.IsZero() {
+		return nil
+	}
+	return &definitions.AlertRuleNotificationSettings{
+		Receiver:          settings.Receiver,
+		GroupBy:           settings.GroupBy,
+		GroupWait:         settings.GroupWait,
+		GroupInterval:     settings.GroupInterval,
+		RepeatInterval:    settings.RepeatInterval,
+		MuteTimeIntervals: settings.MuteTimeIntervals,
+	}
+}
+
 func AlertRuleGroupFromApiAlertRuleGroup(a definitions.AlertRuleGroup) (models.AlertRuleGroup, error) {
 	ruleGroup := models.AlertRuleGroup{
 		Title:     a.Title,
@@ -174,26 +247,35 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 	}
 
 	result := definitions.AlertRuleExport{
-		UID:          rule.UID,
-		Title:        rule.Title,
-		For:          model.Duration(rule.For),
-		Condition:    rule.Condition,
-		Data:         data,
-		DashboardUID: rule.DashboardUID,
-		PanelID:      rule.PanelID,
-		NoDataState:  definitions.NoDataState(rule.NoDataState),
-		ExecErrState: definitions.ExecutionErrorState(rule.ExecErrState),
-		IsPaused:     rule.IsPaused,
+		UID:                  rule.UID,
+		Title:                rule.Title,
+		For:                  model.Duration(rule.For),
+		KeepFiringFor:        model.Duration(rule.KeepFiringFor),
+		Condition:            rule.Condition,
+		Data:                 data,
+		DashboardUID:         rule.DashboardUID,
+		PanelID:              rule.PanelID,
+		NoDataState:          definitions.NoDataState(rule.NoDataState),
+		ExecErrState:         definitions.ExecutionErrorState(rule.ExecErrState),
+		IsPaused:             rule.IsPaused,
+		NotificationSettings: ApiNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
+		FlapDetection:        ApiFlapDetectionFromFlapDetection(rule.FlapDetection),
 	}
 	if rule.For.Seconds() > 0 {
 		result.ForString = util.Pointer(model.Duration(rule.For).String())
 	}
+	if rule.KeepFiringFor.Seconds() > 0 {
+		result.KeepFiringForString = util.Pointer(model.Duration(rule.KeepFiringFor).String())
+	}
 	if rule.Annotations != nil {
 		result.Annotations = &rule.Annotations
 	}
 	if rule.Labels != nil {
 		result.Labels = &rule.Labels
 	}
+	if rule.HasDependencies() {
+		result.DependsOn = &rule.DependsOn
+	}
 	return result, nil
 }
 
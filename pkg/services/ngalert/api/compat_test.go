@@ -1,11 +1,13 @@
 package api
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
 func TestToModel(t *testing.T) {
@@ -35,3 +37,33 @@ func TestToModel(t *testing.T) {
 		require.Len(t, tm.Rules, 1)
 	})
 }
+
+func TestProvisionedAlertRuleRoundTrip(t *testing.T) {
+	t.Run("recording rules keep their record", func(t *testing.T) {
+		rule := models.AlertRule{
+			UID:       "rule",
+			OrgID:     1,
+			Title:     "recording",
+			Condition: "A",
+			Data:      []models.AlertQuery{{RefID: "A", DatasourceUID: "ds", Model: json.RawMessage(`{"expr":"up"}`)}},
+			Record:    models.Record{Metric: "grafana_requests:rate5m", From: "A"},
+		}
+
+		provisioned := ProvisionedAlertRuleFromAlertRule(rule, models.ProvenanceAPI)
+		require.Equal(t, &definitions.Record{Metric: "grafana_requests:rate5m", From: "A"}, provisioned.Record)
+
+		converted, err := AlertRuleFromProvisionedAlertRule(provisioned)
+		require.NoError(t, err)
+		require.Equal(t, rule.Record, converted.Record)
+		require.True(t, converted.IsRecordingRule())
+	})
+
+	t.Run("alerting rules have no record", func(t *testing.T) {
+		provisioned := ProvisionedAlertRuleFromAlertRule(models.AlertRule{UID: "rule"}, models.ProvenanceNone)
+		require.Nil(t, provisioned.Record)
+
+		converted, err := AlertRuleFromProvisionedAlertRule(provisioned)
+		require.NoError(t, err)
+		require.False(t, converted.IsRecordingRule())
+	})
+}
@@ -151,6 +151,10 @@ func (f *AlertmanagerApiHandler) handleRouteGetGrafanaAMAlerts(ctx *contextmodel
 	return f.GrafanaSvc.RouteGetAMAlerts(ctx)
 }
 
+func (f *AlertmanagerApiHandler) handleRoutePostGrafanaAMAlerts(ctx *contextmodel.ReqContext, body apimodels.PostableAMAlerts) response.Response {
+	return f.GrafanaSvc.RoutePostAMAlerts(ctx, apimodels.PostableAlerts{PostableAlerts: body})
+}
+
 func (f *AlertmanagerApiHandler) handleRouteGetGrafanaAMAlertGroups(ctx *contextmodel.ReqContext) response.Response {
 	return f.GrafanaSvc.RouteGetAMAlertGroups(ctx)
 }
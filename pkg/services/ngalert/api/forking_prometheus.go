@@ -46,6 +46,14 @@ func (f *PrometheusApiHandler) handleRouteGetGrafanaRuleStatuses(ctx *contextmod
 	return f.GrafanaSvc.RouteGetRuleStatuses(ctx)
 }
 
+func (f *PrometheusApiHandler) handleRoutePostGrafanaAlertAcknowledgement(ctx *contextmodel.ReqContext, body apimodels.PostableAlertAcknowledgement) response.Response {
+	return f.GrafanaSvc.RoutePostAlertAcknowledgement(ctx, body)
+}
+
+func (f *PrometheusApiHandler) handleRoutePostGrafanaAlertUnacknowledgement(ctx *contextmodel.ReqContext, body apimodels.AlertInstanceRef) response.Response {
+	return f.GrafanaSvc.RoutePostAlertUnacknowledgement(ctx, body)
+}
+
 func (f *PrometheusApiHandler) getService(ctx *contextmodel.ReqContext) (*LotexProm, error) {
 	_, err := getDatasourceByUID(ctx, f.DatasourceCache, apimodels.LoTexRulerBackend)
 	if err != nil {
@@ -101,6 +101,10 @@ func (f *RulerApiHandler) handleRoutePostNameGrafanaRulesConfig(ctx *contextmode
 	return f.GrafanaRuler.RoutePostNameRulesConfig(ctx, conf, namespace)
 }
 
+func (f *RulerApiHandler) handleRoutePostImportPrometheusRules(ctx *contextmodel.ReqContext, conf apimodels.PrometheusRulesImport, namespace string) response.Response {
+	return f.GrafanaRuler.RoutePostImportPrometheusRules(ctx, conf, namespace)
+}
+
 func (f *RulerApiHandler) handleRoutePostRulesGroupForExport(ctx *contextmodel.ReqContext, conf apimodels.PostableRuleGroupConfig, namespace string) response.Response {
 	payloadType := conf.Type()
 	if payloadType != apimodels.GrafanaBackend {
@@ -113,6 +117,18 @@ func (f *RulerApiHandler) handleRouteGetRulesForExport(ctx *contextmodel.ReqCont
 	return f.GrafanaRuler.ExportRules(ctx)
 }
 
+func (f *RulerApiHandler) handleRouteGetRuleVersions(ctx *contextmodel.ReqContext, ruleUID string) response.Response {
+	return f.GrafanaRuler.RouteGetRuleVersions(ctx, ruleUID)
+}
+
+func (f *RulerApiHandler) handleRouteGetRuleVersionsDiff(ctx *contextmodel.ReqContext, ruleUID string) response.Response {
+	return f.GrafanaRuler.RouteGetRuleVersionsDiff(ctx, ruleUID)
+}
+
+func (f *RulerApiHandler) handleRoutePostRestoreRuleVersion(ctx *contextmodel.ReqContext, ruleUID, version string) response.Response {
+	return f.GrafanaRuler.RoutePostRestoreRuleVersion(ctx, ruleUID, version)
+}
+
 func (f *RulerApiHandler) getService(ctx *contextmodel.ReqContext) (*LotexRuler, error) {
 	_, err := getDatasourceByUID(ctx, f.DatasourceCache, apimodels.LoTexRulerBackend)
 	if err != nil {
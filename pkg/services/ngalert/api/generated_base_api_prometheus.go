@@ -14,6 +14,7 @@ import (
 	"github.com/grafana/grafana/pkg/middleware"
 	"github.com/grafana/grafana/pkg/middleware/requestmeta"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	"github.com/grafana/grafana/pkg/web"
 )
@@ -23,6 +24,8 @@ type PrometheusApi interface {
 	RouteGetGrafanaAlertStatuses(*contextmodel.ReqContext) response.Response
 	RouteGetGrafanaRuleStatuses(*contextmodel.ReqContext) response.Response
 	RouteGetRuleStatuses(*contextmodel.ReqContext) response.Response
+	RoutePostGrafanaAlertAcknowledgement(*contextmodel.ReqContext) response.Response
+	RoutePostGrafanaAlertUnacknowledgement(*contextmodel.ReqContext) response.Response
 }
 
 func (f *PrometheusApiHandler) RouteGetAlertStatuses(ctx *contextmodel.ReqContext) response.Response {
@@ -41,6 +44,22 @@ func (f *PrometheusApiHandler) RouteGetRuleStatuses(ctx *contextmodel.ReqContext
 	datasourceUIDParam := web.Params(ctx.Req)[":DatasourceUID"]
 	return f.handleRouteGetRuleStatuses(ctx, datasourceUIDParam)
 }
+func (f *PrometheusApiHandler) RoutePostGrafanaAlertAcknowledgement(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Request Body
+	conf := apimodels.PostableAlertAcknowledgement{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePostGrafanaAlertAcknowledgement(ctx, conf)
+}
+func (f *PrometheusApiHandler) RoutePostGrafanaAlertUnacknowledgement(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Request Body
+	conf := apimodels.AlertInstanceRef{}
+	if err := web.Bind(ctx.Req, &conf); err != nil {
+		return response.Error(http.StatusBadRequest, "bad request data", err)
+	}
+	return f.handleRoutePostGrafanaAlertUnacknowledgement(ctx, conf)
+}
 
 func (api *API) RegisterPrometheusApiEndpoints(srv PrometheusApi, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
@@ -92,5 +111,27 @@ func (api *API) RegisterPrometheusApiEndpoints(srv PrometheusApi, m *metrics.API
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/prometheus/grafana/api/v1/alerts/acknowledge"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			api.authorize(http.MethodPost, "/api/prometheus/grafana/api/v1/alerts/acknowledge"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/prometheus/grafana/api/v1/alerts/acknowledge",
+				api.Hooks.Wrap(srv.RoutePostGrafanaAlertAcknowledgement),
+				m,
+			),
+		)
+		group.Post(
+			toMacaronPath("/api/prometheus/grafana/api/v1/alerts/unacknowledge"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			api.authorize(http.MethodPost, "/api/prometheus/grafana/api/v1/alerts/unacknowledge"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/prometheus/grafana/api/v1/alerts/unacknowledge",
+				api.Hooks.Wrap(srv.RoutePostGrafanaAlertUnacknowledgement),
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
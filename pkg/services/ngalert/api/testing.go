@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/auth/identity"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/user"
 )
@@ -20,6 +21,7 @@ type fakeAlertInstanceManager struct {
 	mtx sync.Mutex
 	// orgID -> RuleID -> States
 	states map[int64]map[string][]*state.State
+	acks   map[models.AlertInstanceKey]models.AlertInstanceAcknowledgement
 }
 
 func NewFakeAlertInstanceManager(t *testing.T) *fakeAlertInstanceManager {
@@ -27,6 +29,7 @@ func NewFakeAlertInstanceManager(t *testing.T) *fakeAlertInstanceManager {
 
 	return &fakeAlertInstanceManager{
 		states: map[int64]map[string][]*state.State{},
+		acks:   map[models.AlertInstanceKey]models.AlertInstanceAcknowledgement{},
 	}
 }
 
@@ -50,6 +53,40 @@ func (f *fakeAlertInstanceManager) GetStatesForRuleUID(orgID int64, alertRuleUID
 	return f.states[orgID][alertRuleUID]
 }
 
+// GetAlertInstanceKey finds the alert instance in the states, which stand for the stored alert instances.
+func (f *fakeAlertInstanceManager) GetAlertInstanceKey(_ context.Context, orgID int64, alertRuleUID string, labels data.Labels) (models.AlertInstanceKey, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, s := range f.states[orgID][alertRuleUID] {
+		if labels.Equals(s.Labels) || labels.Equals(s.GetLabels(models.WithoutInternalLabels())) {
+			return s.GetAlertInstanceKey()
+		}
+	}
+	return models.AlertInstanceKey{}, state.ErrAlertInstanceNotFound
+}
+
+// GetAcknowledgement returns the saved acknowledgement of the alert instance.
+func (f *fakeAlertInstanceManager) GetAcknowledgement(key models.AlertInstanceKey) (models.AlertInstanceAcknowledgement, bool) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	ack, ok := f.acks[key]
+	return ack, ok
+}
+
+func (f *fakeAlertInstanceManager) Acknowledge(_ context.Context, ack models.AlertInstanceAcknowledgement) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.acks[ack.AlertInstanceKey] = ack
+	return nil
+}
+
+func (f *fakeAlertInstanceManager) Unacknowledge(_ context.Context, key models.AlertInstanceKey) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	delete(f.acks, key)
+	return nil
+}
+
 // forEachState represents the callback used when generating alert instances that allows us to modify the generated result
 type forEachState func(s *state.State) *state.State
 
@@ -139,3 +176,22 @@ func (a *recordingAccessControlFake) IsDisabled() bool {
 }
 
 var _ accesscontrol.AccessControl = &recordingAccessControlFake{}
+
+type fakeNotificationSettingsService struct {
+	ValidateFunc  func(settings models.NotificationSettings) error
+	UpdatedRoutes []int64
+}
+
+func (f *fakeNotificationSettingsService) ValidateNotificationSettings(_ context.Context, _ int64, settings models.NotificationSettings) error {
+	if f.ValidateFunc == nil {
+		return settings.Validate()
+	}
+	return f.ValidateFunc(settings)
+}
+
+func (f *fakeNotificationSettingsService) UpdateAutogeneratedRoutes(_ context.Context, orgID int64) error {
+	f.UpdatedRoutes = append(f.UpdatedRoutes, orgID)
+	return nil
+}
+
+var _ NotificationSettingsService = &fakeNotificationSettingsService{}
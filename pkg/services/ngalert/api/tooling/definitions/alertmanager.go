@@ -127,6 +127,15 @@ import (
 //       400: ValidationError
 //       404: NotFound
 
+// swagger:route POST /api/alertmanager/grafana/api/v2/alerts alertmanager RoutePostGrafanaAMAlerts
+//
+// create alerts in the Grafana Alertmanager
+//
+//     Responses:
+//       200: Ack
+//       400: ValidationError
+//       404: NotFound
+
 // swagger:route GET /api/alertmanager/grafana/api/v2/alerts/groups alertmanager RouteGetGrafanaAMAlertGroups
 //
 // get alertmanager alerts
@@ -517,6 +526,25 @@ type PostableAlerts struct {
 	PostableAlerts []amv2.PostableAlert `yaml:"" json:""`
 }
 
+// swagger:parameters RoutePostGrafanaAMAlerts
+type PostableGrafanaAMAlertsParams struct {
+	// in:body
+	Body PostableAMAlerts
+}
+
+// PostableAMAlerts is the list of alerts in the body of requests to the alerts endpoint of the Alertmanager API.
+type PostableAMAlerts []amv2.PostableAlert
+
+// NotifierTokenHeader is the header with the token that authenticates the alerts that instances that only run
+// the alert rule scheduler send to the instances that run the notifier.
+const NotifierTokenHeader = "X-Grafana-Alerting-Notifier-Token"
+
+// NotifierAlertsPath returns the path of the endpoint through which instances that run the notifier receive
+// the alerts of the organization from instances that only run the alert rule scheduler.
+func NotifierAlertsPath(orgID int64) string {
+	return fmt.Sprintf("/api/alerting/notifier/orgs/%d/api/v2/alerts", orgID)
+}
+
 // swagger:parameters RoutePostAlertingConfig RoutePostGrafanaAlertingConfig
 type BodyAlertingConfig struct {
 	// in:body
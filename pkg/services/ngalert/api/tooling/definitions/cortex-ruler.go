@@ -66,6 +66,56 @@ import (
 //       200: AlertingFileExport
 //       404: description: Not found.
 
+// swagger:route Get /api/ruler/grafana/api/v1/rule/{RuleUID}/versions ruler RouteGetRuleVersions
+//
+// List versions of a rule, most recent first
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: GettableAlertRuleVersions
+//       404: description: Not found.
+
+// swagger:route Get /api/ruler/grafana/api/v1/rule/{RuleUID}/versions/diff ruler RouteGetRuleVersionsDiff
+//
+// Compare two versions of a rule
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: RuleVersionDiff
+//       400: ValidationError
+//       404: description: Not found.
+
+// swagger:route POST /api/ruler/grafana/api/v1/rule/{RuleUID}/versions/{Version}/restore ruler RoutePostRestoreRuleVersion
+//
+// Restores the definition of a rule to a previous version
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       202: UpdateRuleGroupResponse
+//       400: ValidationError
+//       404: description: Not found.
+
+// swagger:route POST /api/ruler/grafana/api/v1/import/prometheus/{Namespace} ruler RoutePostImportPrometheusRules
+//
+// Converts the rule groups of a Prometheus rule file to Grafana-managed rule groups and creates them in the folder
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       202: PrometheusRulesImportResponse
+//       400: PrometheusRulesImportResponse
+//       404: description: Not found.
+
 // swagger:route POST /api/ruler/{DatasourceUID}/api/v1/rules/{Namespace} ruler RoutePostNameRulesConfig
 //
 // Creates or updates a rule group
@@ -172,6 +222,79 @@ type PathRouleGroupConfig struct {
 	Groupname string
 }
 
+// swagger:parameters RouteGetRuleVersions RouteGetRuleVersionsDiff RoutePostRestoreRuleVersion
+type PathRuleVersionsParams struct {
+	// in: path
+	RuleUID string
+}
+
+// swagger:parameters RouteGetRuleVersionsDiff
+type RuleVersionsDiffParams struct {
+	// Version to compare from
+	// in: query
+	// required: true
+	From int64 `json:"from"`
+	// Version to compare to
+	// in: query
+	// required: true
+	To int64 `json:"to"`
+}
+
+// swagger:parameters RoutePostImportPrometheusRules
+type PrometheusRulesImportParams struct {
+	// in: path
+	Namespace string
+	// Convert the rules and report the incompatibilities without creating the rule groups.
+	// in: query
+	// required: false
+	DryRun bool `json:"dryRun"`
+	// in: body
+	Body PrometheusRulesImport
+}
+
+// swagger:model
+type PrometheusRulesImport struct {
+	// UID of the Prometheus data source that the imported rules query.
+	// required: true
+	DatasourceUID string `json:"datasourceUid" yaml:"datasourceUid"`
+	// Rule groups of a Prometheus rule file.
+	// required: true
+	Groups []PrometheusRuleGroup `json:"groups" yaml:"groups"`
+}
+
+// PrometheusRuleGroup is a rule group of a Prometheus rule file.
+type PrometheusRuleGroup struct {
+	Name        string          `json:"name" yaml:"name"`
+	Interval    model.Duration  `json:"interval,omitempty" yaml:"interval,omitempty"`
+	QueryOffset *model.Duration `json:"query_offset,omitempty" yaml:"query_offset,omitempty"`
+	Limit       int             `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Rules       []ApiRuleNode   `json:"rules" yaml:"rules"`
+}
+
+// swagger:model
+type PrometheusRulesImportResponse struct {
+	// Grafana-managed rule groups that the Prometheus rule groups are converted to.
+	Groups []PostableRuleGroupConfig `json:"groups"`
+	// Differences between the Prometheus rules and the converted rules.
+	Incompatibilities []PrometheusRuleIncompatibility `json:"incompatibilities,omitempty"`
+}
+
+// PrometheusRuleIncompatibility is a difference between a Prometheus rule and the Grafana-managed rule it is converted to.
+type PrometheusRuleIncompatibility struct {
+	Group string `json:"group"`
+	// Name of the alerting or recording rule, if the incompatibility is specific to a rule.
+	Rule    string `json:"rule,omitempty"`
+	Message string `json:"message"`
+	// Blocking incompatibilities prevent the rule groups from being imported.
+	Blocking bool `json:"blocking"`
+}
+
+// swagger:parameters RoutePostRestoreRuleVersion
+type PathRestoreRuleVersionParams struct {
+	// in: path
+	Version int64
+}
+
 // swagger:parameters RouteGetRulesConfig RouteGetGrafanaRulesConfig
 type PathGetRulesParams struct {
 	// in: query
@@ -398,6 +521,14 @@ type PostableGrafanaRule struct {
 	NoDataState  NoDataState         `json:"no_data_state" yaml:"no_data_state"`
 	ExecErrState ExecutionErrorState `json:"exec_err_state" yaml:"exec_err_state"`
 	IsPaused     *bool               `json:"is_paused" yaml:"is_paused"`
+	Record       *Record             `json:"record,omitempty" yaml:"record,omitempty"`
+	// FlapDetection marks the alerts of the rule as flapping when they change between firing and resolved too often.
+	FlapDetection *FlapDetection `json:"flap_detection,omitempty" yaml:"flap_detection,omitempty"`
+	// NotificationSettings route the alerts of the rule directly to a contact point instead of the notification policy tree.
+	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
+	// UIDs of the rules of the same organization this rule depends on. Notifications of the rule are
+	// suppressed while any of these rules is firing.
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
 }
 
 // swagger:model
@@ -418,6 +549,69 @@ type GettableGrafanaRule struct {
 	ExecErrState    ExecutionErrorState `json:"exec_err_state" yaml:"exec_err_state"`
 	Provenance      Provenance          `json:"provenance,omitempty" yaml:"provenance,omitempty"`
 	IsPaused        bool                `json:"is_paused" yaml:"is_paused"`
+	Record          *Record             `json:"record,omitempty" yaml:"record,omitempty"`
+	// FlapDetection marks the alerts of the rule as flapping when they change between firing and resolved too often.
+	FlapDetection *FlapDetection `json:"flap_detection,omitempty" yaml:"flap_detection,omitempty"`
+	// NotificationSettings route the alerts of the rule directly to a contact point instead of the notification policy tree.
+	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
+	// UIDs of the rules of the same organization this rule depends on. Notifications of the rule are
+	// suppressed while any of these rules is firing.
+	DependsOn []string `json:"depends_on,omitempty" yaml:"depends_on,omitempty"`
+	// Login of the user who made the latest change to the rule, or "__provisioning__" for changes made by file provisioning.
+	UpdatedBy string `json:"updated_by,omitempty" yaml:"updated_by,omitempty"`
+}
+
+// AlertRuleNotificationSettings define how the alerts of a rule are notified without going through the
+// notification policy tree. Fields that are not set are inherited from the default notification policy.
+// swagger:model
+type AlertRuleNotificationSettings struct {
+	// Name of the receiver to send notifications to.
+	// required: true
+	// example: grafana-default-email
+	Receiver string `json:"receiver" yaml:"receiver"`
+	// Labels used to group the alerts into notifications, '...' groups by all labels.
+	// example: ["alertname", "grafana_folder"]
+	GroupBy []string `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	// How long to initially wait to send a notification for a group of alerts.
+	// example: 30s
+	GroupWait *model.Duration `json:"group_wait,omitempty" yaml:"group_wait,omitempty"`
+	// How long to wait before sending a notification about new alerts that are added to a group of alerts.
+	// example: 5m
+	GroupInterval *model.Duration `json:"group_interval,omitempty" yaml:"group_interval,omitempty"`
+	// How long to wait before sending a notification again if it has already been sent successfully.
+	// example: 4h
+	RepeatInterval *model.Duration `json:"repeat_interval,omitempty" yaml:"repeat_interval,omitempty"`
+	// Names of the mute time intervals that mute the notifications.
+	// example: ["weekends"]
+	MuteTimeIntervals []string `json:"mute_time_intervals,omitempty" yaml:"mute_time_intervals,omitempty"`
+}
+
+// Record defines a recording rule. The results of the query or expression From are written
+// as samples of Metric, with the labels of the rule.
+// swagger:model
+type Record struct {
+	// Name of the recorded metric.
+	// required: true
+	// example: grafana_requests:rate5m
+	Metric string `json:"metric" yaml:"metric"`
+	// Which expression node should be used as the input for the recorded metric.
+	// required: true
+	// example: A
+	From string `json:"from" yaml:"from"`
+}
+
+// FlapDetection marks the alerts of a rule as flapping when they change between firing and resolved
+// at least Threshold times within Window.
+// swagger:model
+type FlapDetection struct {
+	// Number of changes between firing and resolved after which an alert is flapping.
+	// required: true
+	// example: 4
+	Threshold int `json:"threshold" yaml:"threshold"`
+	// Time window in which the changes are counted.
+	// required: true
+	// example: 1h
+	Window model.Duration `json:"window" yaml:"window"`
 }
 
 // AlertQuery represents a single query associated with an alert definition.
@@ -494,3 +688,37 @@ type UpdateRuleGroupResponse struct {
 	Updated []string `json:"updated,omitempty"`
 	Deleted []string `json:"deleted,omitempty"`
 }
+
+// swagger:model
+type GettableAlertRuleVersions []GettableAlertRuleVersion
+
+// GettableAlertRuleVersion is the definition of a rule as it was saved at a specific version.
+// swagger:model
+type GettableAlertRuleVersion struct {
+	Version       int64     `json:"version"`
+	ParentVersion int64     `json:"parent_version"`
+	RestoredFrom  int64     `json:"restored_from,omitempty"`
+	Created       time.Time `json:"created"`
+	// Login of the user who saved this version, or "__provisioning__" for versions saved by file provisioning.
+	// Empty for versions saved before the author was recorded.
+	UpdatedBy string                   `json:"updated_by,omitempty"`
+	Rule      GettableExtendedRuleNode `json:"rule"`
+}
+
+// RuleVersionDiff lists the fields of a rule that differ between two versions.
+// swagger:model
+type RuleVersionDiff struct {
+	From    int64               `json:"from"`
+	To      int64               `json:"to"`
+	Changes []RuleVersionChange `json:"changes"`
+}
+
+// RuleVersionChange is a single difference between two versions of a rule.
+type RuleVersionChange struct {
+	// Path to the field, for example Labels[team] or Data[0].Model.
+	Path string `json:"path"`
+	// Value in version From. Not set if the value was added.
+	From any `json:"from,omitempty"`
+	// Value in version To. Not set if the value was removed.
+	To any `json:"to,omitempty"`
+}
@@ -39,6 +39,78 @@ import (
 //       200: AlertResponse
 //       404: NotFound
 
+// swagger:route POST /api/prometheus/grafana/api/v1/alerts/acknowledge prometheus RoutePostGrafanaAlertAcknowledgement
+//
+// acknowledges an alert instance that is firing, has no data or has an error
+//
+//     Consumes:
+//     - application/json
+//
+//     Produces:
+//     - application/json
+//
+//     Responses:
+//       200: AlertAcknowledgement
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:route POST /api/prometheus/grafana/api/v1/alerts/unacknowledge prometheus RoutePostGrafanaAlertUnacknowledgement
+//
+// removes the acknowledgement of an alert instance
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: Ack
+//       400: ValidationError
+//       404: NotFound
+
+// swagger:parameters RoutePostGrafanaAlertAcknowledgement
+type PostableAlertAcknowledgementParams struct {
+	// in:body
+	Body PostableAlertAcknowledgement
+}
+
+// swagger:parameters RoutePostGrafanaAlertUnacknowledgement
+type AlertInstanceRefParams struct {
+	// in:body
+	Body AlertInstanceRef
+}
+
+// AlertInstanceRef identifies an alert instance by the UID of its rule and its labels.
+// The labels can be given with or without the internal labels.
+// swagger:model
+type AlertInstanceRef struct {
+	// required: true
+	RuleUID string `json:"ruleUid"`
+	// required: true
+	Labels map[string]string `json:"labels"`
+}
+
+// swagger:model
+type PostableAlertAcknowledgement struct {
+	AlertInstanceRef
+	Comment string `json:"comment,omitempty"`
+	// ExpiresAt is the time at which the acknowledgement stops applying. If it is not set
+	// the acknowledgement applies until the alert instance resolves.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	// SuppressNotifications stops repeat notifications for the alert instance while the acknowledgement applies.
+	SuppressNotifications bool `json:"suppressNotifications,omitempty"`
+}
+
+// AlertAcknowledgement describes who is working on an alert instance.
+// swagger:model
+type AlertAcknowledgement struct {
+	// required: true
+	AcknowledgedBy string `json:"acknowledgedBy"`
+	// required: true
+	AcknowledgedAt        time.Time  `json:"acknowledgedAt"`
+	Comment               string     `json:"comment,omitempty"`
+	ExpiresAt             *time.Time `json:"expiresAt,omitempty"`
+	SuppressNotifications bool       `json:"suppressNotifications"`
+}
+
 // swagger:model
 type RuleResponse struct {
 	// in: body
@@ -138,6 +210,11 @@ type AlertingRule struct {
 	Alerts         []Alert          `json:"alerts,omitempty"`
 	Totals         map[string]int64 `json:"totals,omitempty"`
 	TotalsFiltered map[string]int64 `json:"totalsFiltered,omitempty"`
+	// UIDs of the rules this rule depends on.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// UIDs of the rules this rule depends on that are firing. Notifications of the rule are suppressed
+	// while it is not empty.
+	InhibitedBy []string `json:"inhibitedBy,omitempty"`
 	Rule
 }
 
@@ -170,6 +247,8 @@ type Alert struct {
 	ActiveAt *time.Time `json:"activeAt"`
 	// required: true
 	Value string `json:"value"`
+	// Acknowledgement is set if a user acknowledged the alert.
+	Acknowledgement *AlertAcknowledgement `json:"acknowledgement,omitempty"`
 }
 
 type StateByImportance int
@@ -148,6 +148,14 @@ type ProvisionedAlertRule struct {
 	ExecErrState ExecutionErrorState `json:"execErrState"`
 	// required: true
 	For model.Duration `json:"for"`
+	// example: 5m
+	KeepFiringFor        model.Duration                 `json:"keepFiringFor,omitempty"`
+	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty"`
+	// example: ["cluster_down_rule_uid"]
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// Record is set for recording rules, whose results are written as a new metric instead of creating alerts.
+	Record        *Record        `json:"record,omitempty"`
+	FlapDetection *FlapDetection `json:"flapDetection,omitempty"`
 	// example: {"runbook_url": "https://supercoolrunbook.com/page/13"}
 	Annotations map[string]string `json:"annotations,omitempty"`
 	// example: {"team": "sre-team-1"}
@@ -246,10 +254,16 @@ type AlertRuleExport struct {
 	// ForString is used to:
 	// - Only export the for field for HCL if it is non-zero.
 	// - Format the Prometheus model.Duration type properly for HCL.
-	ForString   *string            `json:"-" yaml:"-" hcl:"for"`
-	Annotations *map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty" hcl:"annotations"`
-	Labels      *map[string]string `json:"labels,omitempty" yaml:"labels,omitempty" hcl:"labels"`
-	IsPaused    bool               `json:"isPaused" yaml:"isPaused" hcl:"is_paused"`
+	ForString     *string        `json:"-" yaml:"-" hcl:"for"`
+	KeepFiringFor model.Duration `json:"keepFiringFor,omitempty" yaml:"keepFiringFor,omitempty"`
+	// KeepFiringForString is used to export the keep firing for field for HCL only if it is non-zero.
+	KeepFiringForString  *string                        `json:"-" yaml:"-" hcl:"keep_firing_for"`
+	Annotations          *map[string]string             `json:"annotations,omitempty" yaml:"annotations,omitempty" hcl:"annotations"`
+	Labels               *map[string]string             `json:"labels,omitempty" yaml:"labels,omitempty" hcl:"labels"`
+	IsPaused             bool                           `json:"isPaused" yaml:"isPaused" hcl:"is_paused"`
+	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
+	DependsOn            *[]string                      `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty" hcl:"depends_on"`
+	FlapDetection        *FlapDetection                 `json:"flapDetection,omitempty" yaml:"flapDetection,omitempty"`
 }
 
 // AlertQueryExport is the provisioned export of models.AlertQuery.
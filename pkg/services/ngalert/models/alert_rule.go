@@ -28,6 +28,8 @@ var (
 	ErrAlertRuleFailedValidation          = errors.New("invalid alert rule")
 	ErrAlertRuleUniqueConstraintViolation = errors.New("a conflicting alert rule is found: rule title under the same organisation and folder should be unique")
 	ErrQuotaReached                       = errors.New("quota has been exceeded")
+	// ErrAlertRuleVersionNotFound is an error for an unknown version of an alert rule.
+	ErrAlertRuleVersionNotFound = errors.New("could not find alert rule version")
 	// ErrNoDashboard is returned when the alert rule does not have a Dashboard UID
 	// in its annotations or the dashboard does not exist.
 	ErrNoDashboard = errors.New("no dashboard")
@@ -104,6 +106,21 @@ const (
 
 	// StateReasonAnnotation is the name of the annotation that explains the difference between evaluation state and alert state (i.e. changing state when NoData or Error).
 	StateReasonAnnotation = GrafanaReservedLabelPrefix + "state_reason"
+
+	// InhibitedByAnnotation is the name of the annotation that lists the UIDs of the firing rules an alert rule depends on.
+	// It is added to the alerts of the rule while they are inhibited by these rules.
+	InhibitedByAnnotation = GrafanaReservedLabelPrefix + "inhibited_by"
+
+	// FlappingAnnotation is the name of the annotation that is added to alert instances that change between firing and resolved too often.
+	FlappingAnnotation = GrafanaReservedLabelPrefix + "flapping"
+
+	// AcknowledgedByAnnotation, AcknowledgementCommentAnnotation and AcknowledgedUntilAnnotation describe the
+	// acknowledgement of an alert instance. AcknowledgedUntilAnnotation is omitted if the acknowledgement does not expire.
+	AcknowledgedByAnnotation         = GrafanaReservedLabelPrefix + "acknowledged_by"
+	AcknowledgementCommentAnnotation = GrafanaReservedLabelPrefix + "acknowledgement_comment"
+	AcknowledgedUntilAnnotation      = GrafanaReservedLabelPrefix + "acknowledged_until"
+	// AcknowledgementSuppressAnnotation is set on acknowledged alert instances whose repeat notifications are suppressed.
+	AcknowledgementSuppressAnnotation = GrafanaReservedLabelPrefix + "acknowledgement_suppresses_notifications"
 )
 
 const (
@@ -113,6 +130,7 @@ const (
 	StateReasonPaused        = "Paused"
 	StateReasonUpdated       = "Updated"
 	StateReasonRuleDeleted   = "RuleDeleted"
+	StateReasonKeepFiring    = "KeepFiring"
 )
 
 var (
@@ -201,10 +219,103 @@ type AlertRule struct {
 	ExecErrState    ExecutionErrorState
 	// ideally this field should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
-	For         time.Duration
-	Annotations map[string]string
-	Labels      map[string]string
-	IsPaused    bool
+	For time.Duration
+	// KeepFiringFor is how long an alerting instance keeps firing after its condition stopped being met.
+	KeepFiringFor time.Duration
+	// FlapDetection is set if the alert instances of the rule are marked as flapping when they
+	// change between firing and resolved too often.
+	FlapDetection FlapDetection `xorm:"flap_detection"`
+	Annotations   map[string]string
+	Labels        map[string]string
+	IsPaused      bool
+	// Record is set for recording rules, whose results are written as a new metric
+	// instead of creating alerts.
+	Record Record `xorm:"record"`
+	// NotificationSettings is set if the alerts of the rule are routed directly to a receiver
+	// instead of the notification policy tree.
+	NotificationSettings NotificationSettings `xorm:"notification_settings"`
+	// DependsOn contains the UIDs of the rules of the same organization this rule depends on.
+	// Notifications of the rule are suppressed while any of these rules is firing.
+	DependsOn []string `xorm:"depends_on"`
+	// UpdatedBy is the login of the user who made the last change to the rule, or FileProvisioningAuthor
+	// if the rule was changed by file provisioning.
+	UpdatedBy string `xorm:"updated_by"`
+}
+
+// FileProvisioningAuthor is recorded as the author of the changes made by file provisioning.
+const FileProvisioningAuthor = "__provisioning__"
+
+// Record is the configuration of a recording rule.
+type Record struct {
+	// Metric is the name of the metric the results of the rule are written to.
+	Metric string `json:"metric"`
+	// From is the RefID of the query or expression whose results are recorded.
+	// It is also the condition of the rule.
+	From string `json:"from"`
+}
+
+// IsZero returns true if the rule is not a recording rule.
+func (r Record) IsZero() bool {
+	return r.Metric == "" && r.From == ""
+}
+
+// FromDB loads the record from its JSON representation, an empty value is an alerting rule.
+func (r *Record) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*r = Record{}
+		return nil
+	}
+	return json.Unmarshal(data, r)
+}
+
+// ToDB returns the JSON representation of the record, or an empty value for alerting rules.
+func (r *Record) ToDB() ([]byte, error) {
+	if r.IsZero() {
+		return nil, nil
+	}
+	return json.Marshal(r)
+}
+
+// FlapDetection configures the detection of alert instances that change between firing and resolved too often.
+type FlapDetection struct {
+	// Threshold is the number of changes between firing and resolved within Window after which
+	// an alert instance is flapping.
+	Threshold int `json:"threshold"`
+	// Window is the time window in which the changes are counted.
+	Window time.Duration `json:"window"`
+}
+
+// IsEnabled returns true if the alert instances of the rule are checked for flapping.
+func (f FlapDetection) IsEnabled() bool {
+	return f.Threshold > 0
+}
+
+// Validate checks that the window is set if flap detection is enabled.
+func (f FlapDetection) Validate() error {
+	if f.Threshold < 0 {
+		return errors.New("flap detection threshold cannot be negative")
+	}
+	if f.IsEnabled() && f.Window <= 0 {
+		return errors.New("flap detection window must be greater than 0")
+	}
+	return nil
+}
+
+// FromDB loads the settings from their JSON representation, an empty value disables flap detection.
+func (f *FlapDetection) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*f = FlapDetection{}
+		return nil
+	}
+	return json.Unmarshal(data, f)
+}
+
+// ToDB returns the JSON representation of the settings, or an empty value if flap detection is disabled.
+func (f *FlapDetection) ToDB() ([]byte, error) {
+	if !f.IsEnabled() {
+		return nil, nil
+	}
+	return json.Marshal(f)
 }
 
 // AlertRuleWithOptionals This is to avoid having to pass in additional arguments deep in the call stack. Alert rule
@@ -288,6 +399,21 @@ func (alertRule *AlertRule) GetLabels(opts ...LabelOption) map[string]string {
 	return labels
 }
 
+// HasDependencies returns true if the notifications of the rule depend on the state of other rules.
+func (alertRule *AlertRule) HasDependencies() bool {
+	return len(alertRule.DependsOn) > 0
+}
+
+// HasNotificationSettings returns true if the alerts of the rule are routed directly to a receiver.
+func (alertRule *AlertRule) HasNotificationSettings() bool {
+	return !alertRule.NotificationSettings.IsZero()
+}
+
+// IsRecordingRule returns true if the results of the rule are recorded as a metric instead of creating alerts.
+func (alertRule *AlertRule) IsRecordingRule() bool {
+	return !alertRule.Record.IsZero()
+}
+
 func (alertRule *AlertRule) GetEvalCondition() Condition {
 	return Condition{
 		Condition: alertRule.Condition,
@@ -456,10 +582,52 @@ type AlertRuleVersion struct {
 	ExecErrState    ExecutionErrorState
 	// ideally this field should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
-	For         time.Duration
-	Annotations map[string]string
-	Labels      map[string]string
-	IsPaused    bool
+	For                  time.Duration
+	KeepFiringFor        time.Duration
+	FlapDetection        FlapDetection `xorm:"flap_detection"`
+	Annotations          map[string]string
+	Labels               map[string]string
+	IsPaused             bool
+	Record               Record               `xorm:"record"`
+	NotificationSettings NotificationSettings `xorm:"notification_settings"`
+	DependsOn            []string             `xorm:"depends_on"`
+	UpdatedBy            string               `xorm:"updated_by"`
+}
+
+// AlertRule returns the definition of the alert rule at this version. Fields that are not versioned, such as the
+// ID of the rule and the dashboard and panel it is linked to, are not set.
+func (v *AlertRuleVersion) AlertRule() *AlertRule {
+	return &AlertRule{
+		OrgID:                v.RuleOrgID,
+		UID:                  v.RuleUID,
+		NamespaceUID:         v.RuleNamespaceUID,
+		RuleGroup:            v.RuleGroup,
+		RuleGroupIndex:       v.RuleGroupIndex,
+		Version:              v.Version,
+		Updated:              v.Created,
+		Title:                v.Title,
+		Condition:            v.Condition,
+		Data:                 v.Data,
+		IntervalSeconds:      v.IntervalSeconds,
+		NoDataState:          v.NoDataState,
+		ExecErrState:         v.ExecErrState,
+		For:                  v.For,
+		KeepFiringFor:        v.KeepFiringFor,
+		FlapDetection:        v.FlapDetection,
+		Annotations:          v.Annotations,
+		Labels:               v.Labels,
+		IsPaused:             v.IsPaused,
+		Record:               v.Record,
+		NotificationSettings: v.NotificationSettings,
+		DependsOn:            v.DependsOn,
+		UpdatedBy:            v.UpdatedBy,
+	}
+}
+
+// ListAlertRuleVersionsQuery is the query for retrieving the versions of an alert rule, most recent first.
+type ListAlertRuleVersionsQuery struct {
+	OrgID   int64
+	RuleUID string
 }
 
 // GetAlertRuleByUIDQuery is the query for retrieving/deleting an alert rule by UID and organisation ID.
@@ -475,6 +643,11 @@ type GetAlertRulesGroupByRuleUIDQuery struct {
 }
 
 // ListAlertRulesQuery is the query for listing alert rules
+// ListRuleDependenciesQuery is the query for retrieving the dependencies of the alert rules of an organization.
+type ListRuleDependenciesQuery struct {
+	OrgID int64
+}
+
 type ListAlertRulesQuery struct {
 	OrgID         int64
 	NamespaceUIDs []string
@@ -523,6 +696,8 @@ type ListOrgRuleGroupsQuery struct {
 type UpdateRule struct {
 	Existing *AlertRule
 	New      AlertRule
+	// RestoredFrom is the version of the rule New was restored from, if any.
+	RestoredFrom int64
 }
 
 // Condition contains backend expressions and queries and the RefID
@@ -546,7 +721,7 @@ func (c Condition) IsValid() bool {
 // There are several exceptions:
 // 1. Following fields are not patched and therefore will be ignored: AlertRule.ID, AlertRule.OrgID, AlertRule.Updated, AlertRule.Version, AlertRule.UID, AlertRule.DashboardUID, AlertRule.PanelID, AlertRule.Annotations and AlertRule.Labels
 // 2. There are fields that are patched together:
-//   - AlertRule.Condition and AlertRule.Data
+//   - AlertRule.Condition, AlertRule.Data and AlertRule.Record
 //
 // If either of the pair is specified, neither is patched.
 func PatchPartialAlertRule(existingRule *AlertRule, ruleToPatch *AlertRuleWithOptionals) {
@@ -556,6 +731,7 @@ func PatchPartialAlertRule(existingRule *AlertRule, ruleToPatch *AlertRuleWithOp
 	if ruleToPatch.Condition == "" || len(ruleToPatch.Data) == 0 {
 		ruleToPatch.Condition = existingRule.Condition
 		ruleToPatch.Data = existingRule.Data
+		ruleToPatch.Record = existingRule.Record
 	}
 	if ruleToPatch.IntervalSeconds == 0 {
 		ruleToPatch.IntervalSeconds = existingRule.IntervalSeconds
@@ -575,6 +751,9 @@ func PatchPartialAlertRule(existingRule *AlertRule, ruleToPatch *AlertRuleWithOp
 	if ruleToPatch.For == -1 {
 		ruleToPatch.For = existingRule.For
 	}
+	if ruleToPatch.KeepFiringFor == -1 {
+		ruleToPatch.KeepFiringFor = existingRule.KeepFiringFor
+	}
 	if !ruleToPatch.HasPause {
 		ruleToPatch.IsPaused = existingRule.IsPaused
 	}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -14,6 +15,28 @@ type AlertInstance struct {
 	CurrentStateSince time.Time
 	CurrentStateEnd   time.Time
 	LastEvalTime      time.Time
+	// FiringChanges are the times of the recent changes between firing and not firing, used for flap detection.
+	FiringChanges FiringChanges
+}
+
+// FiringChanges are the times of the recent changes of an alert instance between firing and not firing.
+type FiringChanges []time.Time
+
+// FromDB loads the changes from their JSON representation.
+func (c *FiringChanges) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*c = nil
+		return nil
+	}
+	return json.Unmarshal(data, c)
+}
+
+// ToDB returns the JSON representation of the changes, or an empty value if there are none.
+func (c *FiringChanges) ToDB() ([]byte, error) {
+	if len(*c) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(c)
 }
 
 type AlertInstanceKey struct {
@@ -22,6 +45,27 @@ type AlertInstanceKey struct {
 	LabelsHash string
 }
 
+// AlertInstanceAcknowledgement records that a user is working on an alert instance. Unlike a silence,
+// it does not hide the alert instance. It is removed when the alert instance resolves.
+type AlertInstanceAcknowledgement struct {
+	AlertInstanceKey `xorm:"extends"`
+	UserID           int64
+	Login            string
+	Comment          string
+	CreatedAt        time.Time
+	// ExpiresAt is the time at which the acknowledgement stops applying. It applies until
+	// the alert instance resolves if ExpiresAt is zero.
+	ExpiresAt time.Time
+	// SuppressNotifications stops repeat notifications for the alert instance while the
+	// acknowledgement applies.
+	SuppressNotifications bool
+}
+
+// IsActive returns true if the acknowledgement applies at the time t.
+func (a AlertInstanceAcknowledgement) IsActive(t time.Time) bool {
+	return a.ExpiresAt.IsZero() || t.Before(a.ExpiresAt)
+}
+
 // InstanceStateType is an enum for instance states.
 type InstanceStateType string
 
@@ -54,6 +98,12 @@ type ListAlertInstancesQuery struct {
 	RuleOrgID int64 `json:"-"`
 }
 
+// ListAlertInstanceAcknowledgementsQuery is the query to list the acknowledgements of alert instances.
+type ListAlertInstanceAcknowledgementsQuery struct {
+	RuleOrgID int64
+	RuleUID   string
+}
+
 // ValidateAlertInstance validates that the alert instance contains an alert rule id,
 // and state.
 func ValidateAlertInstance(alertInstance AlertInstance) error {
@@ -71,3 +121,29 @@ func ValidateAlertInstance(alertInstance AlertInstance) error {
 
 	return nil
 }
+
+// ValidateAlertInstanceAcknowledgement validates that the acknowledgement refers to an alert instance,
+// has a user and does not expire before it is created.
+func ValidateAlertInstanceAcknowledgement(ack AlertInstanceAcknowledgement) error {
+	if ack.RuleOrgID == 0 {
+		return fmt.Errorf("acknowledgement is invalid due to missing alert rule organisation")
+	}
+
+	if ack.RuleUID == "" {
+		return fmt.Errorf("acknowledgement is invalid due to missing alert rule uid")
+	}
+
+	if ack.LabelsHash == "" {
+		return fmt.Errorf("acknowledgement is invalid due to missing alert instance labels hash")
+	}
+
+	if ack.Login == "" {
+		return fmt.Errorf("acknowledgement is invalid due to missing user")
+	}
+
+	if !ack.ExpiresAt.IsZero() && !ack.ExpiresAt.After(ack.CreatedAt) {
+		return fmt.Errorf("acknowledgement is invalid because it expires before it is created")
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package models
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// AutogeneratedRouteLabel is the label that is added to alert instances of rules with notification settings.
+	// Such instances are routed by the auto-generated routes instead of the notification policy tree.
+	AutogeneratedRouteLabel = "__grafana_autogenerated__"
+	// AutogeneratedRouteReceiverNameLabel contains the name of the receiver of the notification settings.
+	AutogeneratedRouteReceiverNameLabel = "__grafana_receiver__"
+	// AutogeneratedRouteSettingsHashLabel contains the fingerprint of the notification settings.
+	AutogeneratedRouteSettingsHashLabel = "__grafana_route_settings_hash__"
+)
+
+var (
+	ErrNotificationSettingsInvalid = errors.New("invalid notification settings")
+)
+
+// NotificationSettings route the alerts of a rule directly to a receiver, bypassing the notification policy tree.
+// Fields that are not set are inherited from the root of the notification policy tree.
+type NotificationSettings struct {
+	Receiver          string          `json:"receiver"`
+	GroupBy           []string        `json:"group_by,omitempty"`
+	GroupWait         *model.Duration `json:"group_wait,omitempty"`
+	GroupInterval     *model.Duration `json:"group_interval,omitempty"`
+	RepeatInterval    *model.Duration `json:"repeat_interval,omitempty"`
+	MuteTimeIntervals []string        `json:"mute_time_intervals,omitempty"`
+}
+
+// IsZero returns true if the rule does not have notification settings.
+func (s NotificationSettings) IsZero() bool {
+	return s.Receiver == "" && len(s.GroupBy) == 0 && s.GroupWait == nil && s.GroupInterval == nil &&
+		s.RepeatInterval == nil && len(s.MuteTimeIntervals) == 0
+}
+
+// Validate checks that the settings are well-formed. It does not check that the receiver and mute time intervals exist.
+func (s NotificationSettings) Validate() error {
+	if s.Receiver == "" {
+		return fmt.Errorf("%w: receiver must be specified", ErrNotificationSettingsInvalid)
+	}
+	for _, label := range s.GroupBy {
+		if label == "..." {
+			if len(s.GroupBy) > 1 {
+				return fmt.Errorf("%w: group_by cannot contain other labels when it contains '...'", ErrNotificationSettingsInvalid)
+			}
+			continue
+		}
+		if !model.LabelName(label).IsValid() {
+			return fmt.Errorf("%w: invalid label name '%s' in group_by", ErrNotificationSettingsInvalid, label)
+		}
+	}
+	if s.GroupWait != nil && *s.GroupWait < 0 {
+		return fmt.Errorf("%w: group_wait cannot be negative", ErrNotificationSettingsInvalid)
+	}
+	if s.GroupInterval != nil && *s.GroupInterval <= 0 {
+		return fmt.Errorf("%w: group_interval must be positive", ErrNotificationSettingsInvalid)
+	}
+	if s.RepeatInterval != nil && *s.RepeatInterval <= 0 {
+		return fmt.Errorf("%w: repeat_interval must be positive", ErrNotificationSettingsInvalid)
+	}
+	return nil
+}
+
+// Fingerprint returns a hash of the settings that identifies the auto-generated route of the settings.
+// The order of labels in GroupBy and of mute time intervals does not change the fingerprint.
+func (s NotificationSettings) Fingerprint() string {
+	h := fnv.New64()
+	tmp := make([]byte, 8)
+
+	writeString := func(str string) {
+		_, _ = h.Write([]byte(str))
+		// add a byte sequence that cannot happen in UTF-8 strings.
+		_, _ = h.Write([]byte{255})
+	}
+	writeDuration := func(d *model.Duration) {
+		if d == nil {
+			_, _ = h.Write([]byte{255})
+			return
+		}
+		binary.LittleEndian.PutUint64(tmp, uint64(*d))
+		_, _ = h.Write(tmp)
+	}
+	writeSorted := func(values []string) {
+		sorted := make([]string, len(values))
+		copy(sorted, values)
+		sort.Strings(sorted)
+		for _, v := range sorted {
+			writeString(v)
+		}
+		// separate the lists
+		_, _ = h.Write([]byte{255, 255})
+	}
+
+	writeString(s.Receiver)
+	writeSorted(s.GroupBy)
+	writeDuration(s.GroupWait)
+	writeDuration(s.GroupInterval)
+	writeDuration(s.RepeatInterval)
+	writeSorted(s.MuteTimeIntervals)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// FromDB loads the settings from their JSON representation, an empty value means that the rule does not have notification settings.
+func (s *NotificationSettings) FromDB(data []byte) error {
+	if len(data) == 0 {
+		*s = NotificationSettings{}
+		return nil
+	}
+	return json.Unmarshal(data, s)
+}
+
+// ToDB returns the JSON representation of the settings, or an empty value if the rule does not have notification settings.
+func (s *NotificationSettings) ToDB() ([]byte, error) {
+	if s.IsZero() {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// ListNotificationSettingsQuery is the query for retrieving the notification settings of the alert rules of an organization.
+type ListNotificationSettingsQuery struct {
+	OrgID int64
+}
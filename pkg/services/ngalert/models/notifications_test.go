@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSettingsFingerprint(t *testing.T) {
+	d := func(v time.Duration) *model.Duration {
+		r := model.Duration(v)
+		return &r
+	}
+	base := NotificationSettings{
+		Receiver:          "receiver",
+		GroupBy:           []string{"alertname", "grafana_folder"},
+		GroupWait:         d(time.Second),
+		GroupInterval:     d(time.Minute),
+		RepeatInterval:    d(time.Hour),
+		MuteTimeIntervals: []string{"weekends", "holidays"},
+	}
+
+	t.Run("should not depend on the order of labels and mute time intervals", func(t *testing.T) {
+		other := base
+		other.GroupBy = []string{"grafana_folder", "alertname"}
+		other.MuteTimeIntervals = []string{"holidays", "weekends"}
+		require.Equal(t, base.Fingerprint(), other.Fingerprint())
+	})
+
+	t.Run("should change when a field changes", func(t *testing.T) {
+		changes := []func(s *NotificationSettings){
+			func(s *NotificationSettings) { s.Receiver = "other" },
+			func(s *NotificationSettings) { s.GroupBy = []string{"alertname"} },
+			func(s *NotificationSettings) { s.GroupWait = nil },
+			func(s *NotificationSettings) { s.GroupInterval = d(2 * time.Minute) },
+			func(s *NotificationSettings) { s.RepeatInterval = nil },
+			func(s *NotificationSettings) { s.MuteTimeIntervals = nil },
+			func(s *NotificationSettings) {
+				// moving a value from a list to another should change the fingerprint
+				s.GroupBy = []string{"alertname", "grafana_folder", "weekends"}
+				s.MuteTimeIntervals = []string{"holidays"}
+			},
+		}
+		for _, change := range changes {
+			other := base
+			change(&other)
+			require.NotEqual(t, base.Fingerprint(), other.Fingerprint())
+		}
+	})
+}
+
+func TestNotificationSettingsDB(t *testing.T) {
+	t.Run("empty settings should be stored as empty value", func(t *testing.T) {
+		s := NotificationSettings{}
+		data, err := s.ToDB()
+		require.NoError(t, err)
+		require.Empty(t, data)
+
+		var loaded NotificationSettings
+		require.NoError(t, loaded.FromDB(data))
+		require.True(t, loaded.IsZero())
+	})
+
+	t.Run("settings should be loaded as they were stored", func(t *testing.T) {
+		wait := model.Duration(time.Minute)
+		s := NotificationSettings{Receiver: "receiver", GroupBy: []string{"..."}, GroupWait: &wait}
+		data, err := s.ToDB()
+		require.NoError(t, err)
+
+		var loaded NotificationSettings
+		require.NoError(t, loaded.FromDB(data))
+		require.Equal(t, s, loaded)
+	})
+}
@@ -345,6 +345,21 @@ func CopyRule(r *AlertRule) *AlertRule {
 		NoDataState:     r.NoDataState,
 		ExecErrState:    r.ExecErrState,
 		For:             r.For,
+		KeepFiringFor:   r.KeepFiringFor,
+		FlapDetection:   r.FlapDetection,
+		IsPaused:        r.IsPaused,
+		Record:          r.Record,
+		UpdatedBy:       r.UpdatedBy,
+	}
+
+	if !r.NotificationSettings.IsZero() {
+		result.NotificationSettings = r.NotificationSettings
+		result.NotificationSettings.GroupBy = append([]string(nil), r.NotificationSettings.GroupBy...)
+		result.NotificationSettings.MuteTimeIntervals = append([]string(nil), r.NotificationSettings.MuteTimeIntervals...)
+	}
+
+	if r.DependsOn != nil {
+		result.DependsOn = append([]string(nil), r.DependsOn...)
 	}
 
 	if r.DashboardUID != nil {
@@ -17,6 +17,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/modules"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/annotations"
 	"github.com/grafana/grafana/pkg/services/dashboards"
@@ -39,6 +40,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
 	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
 	"github.com/grafana/grafana/pkg/services/quota"
@@ -194,8 +196,17 @@ func (ng *AlertNG) init() error {
 	ng.ImageService = imageService
 
 	// Let's make sure we're able to complete an initial sync of Alertmanagers before we start the alerting components.
-	if err := ng.MultiOrgAlertmanager.LoadAndSyncAlertmanagersForOrgs(initCtx); err != nil {
-		return fmt.Errorf("failed to initialize alerting because multiorg alertmanager manager failed to warm up: %w", err)
+	// Instances that do not run the notifier have no Alertmanagers, so they send alerts to the instances that run it.
+	var notifierURLs []string
+	if _, runNotifier := modules.AlertingComponents(ng.Cfg.Target, ng.Cfg.UnifiedAlerting.ExecuteAlerts); runNotifier {
+		if err := ng.MultiOrgAlertmanager.LoadAndSyncAlertmanagersForOrgs(initCtx); err != nil {
+			return fmt.Errorf("failed to initialize alerting because multiorg alertmanager manager failed to warm up: %w", err)
+		}
+	} else {
+		if len(ng.Cfg.UnifiedAlerting.NotifierURLs) == 0 || ng.Cfg.UnifiedAlerting.NotifierToken == "" {
+			return fmt.Errorf("failed to initialize alerting because the %s target requires the notifier_urls and notifier_token settings", modules.AlertingScheduler)
+		}
+		notifierURLs = ng.Cfg.UnifiedAlerting.NotifierURLs
 	}
 
 	appUrl, err := url.Parse(ng.Cfg.AppURL)
@@ -207,7 +218,7 @@ func (ng *AlertNG) init() error {
 	clk := clock.New()
 
 	alertsRouter := sender.NewAlertsRouter(ng.MultiOrgAlertmanager, ng.store, clk, appUrl, ng.Cfg.UnifiedAlerting.DisabledOrgs,
-		ng.Cfg.UnifiedAlerting.AdminConfigPollInterval, ng.DataSourceService, ng.SecretsService)
+		ng.Cfg.UnifiedAlerting.AdminConfigPollInterval, ng.DataSourceService, ng.SecretsService, notifierURLs, ng.Cfg.UnifiedAlerting.NotifierToken)
 
 	// Make sure we sync at least once as Grafana starts to get the router up and running before we start sending any alerts.
 	if err := alertsRouter.SyncAndApplyConfigFromDatabase(); err != nil {
@@ -217,6 +228,10 @@ func (ng *AlertNG) init() error {
 	ng.AlertsRouter = alertsRouter
 
 	evalFactory := eval.NewEvaluatorFactory(ng.Cfg.UnifiedAlerting, ng.DataSourceCache, ng.ExpressionService, ng.pluginsStore)
+	recordingWriter, err := createRecordingWriter(ng.Cfg.UnifiedAlerting.RecordingRules, ng.Log)
+	if err != nil {
+		return fmt.Errorf("failed to initialize recording rules writer: %w", err)
+	}
 	schedCfg := schedule.SchedulerCfg{
 		MaxAttempts:          ng.Cfg.UnifiedAlerting.MaxAttempts,
 		C:                    clk,
@@ -230,6 +245,7 @@ func (ng *AlertNG) init() error {
 		AlertSender:          alertsRouter,
 		Tracer:               ng.tracer,
 		Log:                  log.New("ngalert.scheduler"),
+		RecordingWriter:      recordingWriter,
 	}
 
 	// There are a set of feature toggles available that act as short-circuits for common configurations.
@@ -249,6 +265,7 @@ func (ng *AlertNG) init() error {
 		DoNotSaveNormalState:           ng.FeatureToggles.IsEnabled(featuremgmt.FlagAlertingNoNormalState),
 		MaxStateSaveConcurrency:        ng.Cfg.UnifiedAlerting.MaxStateSaveConcurrency,
 		ApplyNoDataAndErrorToAllStates: ng.FeatureToggles.IsEnabled(featuremgmt.FlagAlertingNoDataErrorExecution),
+		TemplateQuery:                  schedule.NewTemplateQuery(evalFactory),
 		Tracer:                         ng.tracer,
 		Log:                            log.New("ngalert.state.manager"),
 	}
@@ -283,6 +300,7 @@ func (ng *AlertNG) init() error {
 		RuleStore:            ng.store,
 		AlertingStore:        ng.store,
 		AdminConfigStore:     ng.store,
+		OrgStore:             ng.store,
 		ProvenanceStore:      ng.store,
 		MultiOrgAlertmanager: ng.MultiOrgAlertmanager,
 		StateManager:         ng.stateManager,
@@ -348,7 +366,8 @@ func (ng *AlertNG) shouldRun() bool {
 	return ng.Cfg.UnifiedAlerting.IsEnabled()
 }
 
-// Run starts the scheduler and Alertmanager.
+// Run starts the scheduler and Alertmanager. Instances that run only one of the alerting-scheduler and
+// alerting-notifier targets start only the corresponding component.
 func (ng *AlertNG) Run(ctx context.Context) error {
 	if !ng.shouldRun() {
 		return nil
@@ -359,14 +378,17 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 
 	children, subCtx := errgroup.WithContext(ctx)
 
-	children.Go(func() error {
-		return ng.MultiOrgAlertmanager.Run(subCtx)
-	})
+	runScheduler, runNotifier := modules.AlertingComponents(ng.Cfg.Target, ng.Cfg.UnifiedAlerting.ExecuteAlerts)
+	if runNotifier {
+		children.Go(func() error {
+			return ng.MultiOrgAlertmanager.Run(subCtx)
+		})
+	}
 	children.Go(func() error {
 		return ng.AlertsRouter.Run(subCtx)
 	})
 
-	if ng.Cfg.UnifiedAlerting.ExecuteAlerts {
+	if runScheduler {
 		children.Go(func() error {
 			return ng.schedule.Run(subCtx)
 		})
@@ -419,6 +441,15 @@ type Historian interface {
 	state.Historian
 }
 
+// createRecordingWriter returns the writer of the results of recording rules, which drops them if recording rules are disabled.
+func createRecordingWriter(cfg setting.RecordingRuleSettings, l log.Logger) (writer.Writer, error) {
+	if !cfg.Enabled {
+		return writer.NoopWriter{}, nil
+	}
+	l.Info("Recording rules are enabled")
+	return writer.NewPrometheusWriter(cfg, l.New("component", "recording-writer"))
+}
+
 func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingStateHistorySettings, ar annotations.Repository, ds dashboards.DashboardService, rs historian.RuleStore, met *metrics.Historian, l log.Logger) (Historian, error) {
 	if !cfg.Enabled {
 		met.Info.WithLabelValues("noop").Set(0)
@@ -0,0 +1,41 @@
+package notifier
+
+import (
+	"context"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// acknowledgementFilter is a notifier that drops the firing alerts whose acknowledgement suppresses
+// notifications before passing the remaining alerts to the integration. Resolved alerts are always sent.
+type acknowledgementFilter struct {
+	integration *alertingNotify.Integration
+}
+
+func (f acknowledgementFilter) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	filtered := make([]*types.Alert, 0, len(alerts))
+	for _, a := range alerts {
+		if !a.Resolved() && a.Annotations[model.LabelName(ngmodels.AcknowledgementSuppressAnnotation)] == "true" {
+			continue
+		}
+		filtered = append(filtered, a)
+	}
+	if len(filtered) == 0 {
+		return false, nil
+	}
+	return f.integration.Notify(ctx, filtered...)
+}
+
+// withAcknowledgementFilter wraps the integrations of the receiver so that they do not notify about
+// acknowledged alerts that suppress notifications.
+func withAcknowledgementFilter(receiverName string, integrations []*alertingNotify.Integration) []*alertingNotify.Integration {
+	result := make([]*alertingNotify.Integration, 0, len(integrations))
+	for _, i := range integrations {
+		result = append(result, alertingNotify.NewIntegration(acknowledgementFilter{integration: i}, i, i.Name(), i.Index(), receiverName))
+	}
+	return result
+}
@@ -0,0 +1,66 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type recordingNotifier struct {
+	alerts []*types.Alert
+}
+
+func (n *recordingNotifier) Notify(_ context.Context, alerts ...*types.Alert) (bool, error) {
+	n.alerts = append(n.alerts, alerts...)
+	return false, nil
+}
+
+func (n *recordingNotifier) SendResolved() bool {
+	return true
+}
+
+func TestAcknowledgementFilter(t *testing.T) {
+	newAlert := func(name string, suppressed bool, endsAt time.Time) *types.Alert {
+		a := &types.Alert{Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": model.LabelValue(name)},
+			Annotations: model.LabelSet{},
+			StartsAt:    time.Now().Add(-time.Hour),
+			EndsAt:      endsAt,
+		}}
+		if suppressed {
+			a.Annotations[model.LabelName(ngmodels.AcknowledgementSuppressAnnotation)] = "true"
+		}
+		return a
+	}
+	firing := time.Now().Add(time.Hour)
+	resolved := time.Now().Add(-time.Minute)
+
+	n := &recordingNotifier{}
+	integrations := withAcknowledgementFilter("receiver", []*alertingNotify.Integration{alertingNotify.NewIntegration(n, n, "webhook", 2, "receiver")})
+	require.Len(t, integrations, 1)
+	require.Equal(t, "webhook", integrations[0].Name())
+	require.Equal(t, 2, integrations[0].Index())
+
+	_, err := integrations[0].Notify(context.Background(), newAlert("suppressed", true, firing))
+	require.NoError(t, err)
+	require.Empty(t, n.alerts, "notifier must not be called when all alerts are suppressed")
+
+	_, err = integrations[0].Notify(context.Background(),
+		newAlert("suppressed", true, firing),
+		newAlert("firing", false, firing),
+		newAlert("resolved", true, resolved),
+	)
+	require.NoError(t, err)
+	names := make([]string, 0, len(n.alerts))
+	for _, a := range n.alerts {
+		names = append(names, string(a.Labels["alertname"]))
+	}
+	require.Equal(t, []string{"firing", "resolved"}, names)
+}
@@ -21,6 +21,7 @@ import (
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/setting"
@@ -42,6 +43,7 @@ var silenceMaintenanceInterval = 15 * time.Minute
 type AlertingStore interface {
 	store.AlertingStore
 	store.ImageStore
+	autogenRuleStore
 }
 
 type alertmanager struct {
@@ -179,7 +181,7 @@ func (am *alertmanager) SaveAndApplyDefaultConfig(ctx context.Context) error {
 		}
 
 		err = am.Store.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error {
-			_, err := am.applyConfig(cfg, []byte(am.Settings.UnifiedAlerting.DefaultConfiguration))
+			_, err := am.applyConfig(ctx, cfg, []byte(am.Settings.UnifiedAlerting.DefaultConfiguration))
 			return err
 		})
 		if err != nil {
@@ -209,7 +211,7 @@ func (am *alertmanager) SaveAndApplyConfig(ctx context.Context, cfg *apimodels.P
 		}
 
 		err = am.Store.SaveAlertmanagerConfigurationWithCallback(ctx, cmd, func() error {
-			_, err := am.applyConfig(cfg, rawConfig)
+			_, err := am.applyConfig(ctx, cfg, rawConfig)
 			return err
 		})
 		if err != nil {
@@ -281,7 +283,17 @@ func (am *alertmanager) aggregateInhibitMatchers(rules []config.InhibitRule, amu
 // applyConfig applies a new configuration by re-initializing all components using the configuration provided.
 // It returns a boolean indicating whether the user config was changed and an error.
 // It is not safe to call concurrently.
-func (am *alertmanager) applyConfig(cfg *apimodels.PostableUserConfig, rawConfig []byte) (bool, error) {
+func (am *alertmanager) applyConfig(ctx context.Context, cfg *apimodels.PostableUserConfig, rawConfig []byte) (bool, error) {
+	autogenerated, err := addAutogenConfig(ctx, am.logger, am.Store, am.orgID, &cfg.AlertmanagerConfig)
+	if err != nil {
+		return false, err
+	}
+	if autogenerated {
+		// The auto-generated routes are not part of the raw configuration,
+		// so the hash must be calculated from the configuration that includes them.
+		rawConfig = nil
+	}
+
 	// First, let's make sure this config is not already loaded
 	var amConfigChanged bool
 	if rawConfig == nil {
@@ -332,7 +344,7 @@ func (am *alertmanager) applyConfig(cfg *apimodels.PostableUserConfig, rawConfig
 
 // applyAndMarkConfig applies a configuration and marks it as applied if no errors occur.
 func (am *alertmanager) applyAndMarkConfig(ctx context.Context, hash string, cfg *apimodels.PostableUserConfig, rawConfig []byte) error {
-	configChanged, err := am.applyConfig(cfg, rawConfig)
+	configChanged, err := am.applyConfig(ctx, cfg, rawConfig)
 	if err != nil {
 		return err
 	}
@@ -353,8 +365,14 @@ func (am *alertmanager) AppURL() string {
 }
 
 // buildReceiverIntegrations builds a list of integration notifiers off of a receiver config.
+// Integrations implemented in Grafana are built by the package channels, the rest are built by the alerting module.
 func (am *alertmanager) buildReceiverIntegrations(receiver *alertingNotify.APIReceiver, tmpl *alertingTemplates.Template) ([]*alertingNotify.Integration, error) {
-	receiverCfg, err := alertingNotify.BuildReceiverConfiguration(context.Background(), receiver, am.decryptFn)
+	upstream, own := channels.SplitReceiver(receiver)
+	receiverCfg, err := alertingNotify.BuildReceiverConfiguration(context.Background(), upstream, am.decryptFn)
+	if err != nil {
+		return nil, err
+	}
+	ownCfg, err := channels.BuildReceiverConfiguration(context.Background(), own, am.decryptFn)
 	if err != nil {
 		return nil, err
 	}
@@ -377,7 +395,8 @@ func (am *alertmanager) buildReceiverIntegrations(receiver *alertingNotify.APIRe
 	if err != nil {
 		return nil, err
 	}
-	return integrations, nil
+	integrations = append(integrations, channels.BuildReceiverIntegrations(ownCfg, tmpl, s, LoggerFactory)...)
+	return withAcknowledgementFilter(receiver.Name, integrations), nil
 }
 
 // PutAlerts receives the alerts and then sends them through the corresponding route based on whenever the alert has a receiver embedded or not
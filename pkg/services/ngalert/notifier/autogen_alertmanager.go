@@ -0,0 +1,243 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	alertingModels "github.com/grafana/alerting/models"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+// autogenRuleStore lists the notification settings and the dependencies of alert rules that are compiled into
+// auto-generated routes and inhibit rules.
+type autogenRuleStore interface {
+	ListNotificationSettings(ctx context.Context, q models.ListNotificationSettingsQuery) (map[models.AlertRuleKey]models.NotificationSettings, error)
+	ListRuleDependencies(ctx context.Context, q models.ListRuleDependenciesQuery) (map[models.AlertRuleKey][]string, error)
+}
+
+// addAutogenConfig adds the routes generated from the notification settings and the inhibit rules generated from
+// the dependencies of the alert rules of the organization to the configuration. They are never saved.
+// Returns true if the configuration was changed.
+func addAutogenConfig(ctx context.Context, logger log.Logger, store autogenRuleStore, orgID int64, cfg *apimodels.PostableApiAlertingConfig) (bool, error) {
+	routesAdded, err := addAutogenRoutes(ctx, logger, store, orgID, cfg)
+	if err != nil {
+		return false, err
+	}
+	inhibitRulesAdded, err := addAutogenInhibitRules(ctx, store, orgID, cfg)
+	if err != nil {
+		return false, err
+	}
+	return routesAdded || inhibitRulesAdded, nil
+}
+
+// addAutogenRoutes adds the routes generated from the notification settings of the alert rules of the organization
+// to the configuration. The routes are added as the first child of the root route.
+// Notification settings that reference receivers that do not exist are skipped, and their alerts are sent to the
+// receiver of the root route. Mute time intervals that do not exist are ignored.
+func addAutogenRoutes(ctx context.Context, logger log.Logger, store autogenRuleStore, orgID int64, cfg *apimodels.PostableApiAlertingConfig) (bool, error) {
+	if cfg.Route == nil {
+		return false, nil
+	}
+	settings, err := store.ListNotificationSettings(ctx, models.ListNotificationSettingsQuery{OrgID: orgID})
+	if err != nil {
+		return false, fmt.Errorf("failed to list notification settings of alert rules: %w", err)
+	}
+	if len(settings) == 0 {
+		return false, nil
+	}
+
+	receivers := make(map[string]struct{}, len(cfg.Receivers))
+	for _, r := range cfg.Receivers {
+		receivers[r.Name] = struct{}{}
+	}
+	muteTimeIntervals := make(map[string]struct{}, len(cfg.MuteTimeIntervals))
+	for _, mt := range cfg.MuteTimeIntervals {
+		muteTimeIntervals[mt.Name] = struct{}{}
+	}
+
+	// group the settings by receiver and deduplicate them by fingerprint
+	byReceiver := make(map[string]map[string]models.NotificationSettings)
+	for key, s := range settings {
+		if _, ok := receivers[s.Receiver]; !ok {
+			logger.Warn("Alert rule references a receiver that does not exist, its alerts are sent to the default receiver", "rule_uid", key.UID, "receiver", s.Receiver)
+			continue
+		}
+		fingerprints, ok := byReceiver[s.Receiver]
+		if !ok {
+			fingerprints = make(map[string]models.NotificationSettings)
+			byReceiver[s.Receiver] = fingerprints
+		}
+		fingerprints[s.Fingerprint()] = s
+	}
+
+	autogenRoute := &apimodels.Route{
+		Receiver:       cfg.Route.Receiver,
+		ObjectMatchers: apimodels.ObjectMatchers{mustMatcher(labels.MatchEqual, models.AutogeneratedRouteLabel, "true")},
+	}
+	for _, receiver := range sortedKeys(byReceiver) {
+		receiverRoute := &apimodels.Route{
+			Receiver:       receiver,
+			ObjectMatchers: apimodels.ObjectMatchers{mustMatcher(labels.MatchEqual, models.AutogeneratedRouteReceiverNameLabel, receiver)},
+		}
+		fingerprints := byReceiver[receiver]
+		for _, fingerprint := range sortedKeys(fingerprints) {
+			receiverRoute.Routes = append(receiverRoute.Routes, newSettingsRoute(logger, fingerprint, fingerprints[fingerprint], muteTimeIntervals))
+		}
+		autogenRoute.Routes = append(autogenRoute.Routes, receiverRoute)
+	}
+
+	root := *cfg.Route
+	root.Routes = append([]*apimodels.Route{autogenRoute}, cfg.Route.Routes...)
+	cfg.Route = &root
+	return true, nil
+}
+
+// addAutogenInhibitRules adds an inhibit rule for each dependency of the alert rules of the organization to the
+// configuration, so that the alerts of a rule are inhibited while the rules it depends on have firing alerts.
+func addAutogenInhibitRules(ctx context.Context, store autogenRuleStore, orgID int64, cfg *apimodels.PostableApiAlertingConfig) (bool, error) {
+	dependencies, err := store.ListRuleDependencies(ctx, models.ListRuleDependenciesQuery{OrgID: orgID})
+	if err != nil {
+		return false, fmt.Errorf("failed to list dependencies of alert rules: %w", err)
+	}
+	if len(dependencies) == 0 {
+		return false, nil
+	}
+
+	// sort the rules so that the configuration, and therefore its hash, does not change if the dependencies do not
+	byUID := make(map[string][]string, len(dependencies))
+	for key, uids := range dependencies {
+		byUID[key.UID] = uids
+	}
+	inhibitRules := append([]config.InhibitRule(nil), cfg.InhibitRules...)
+	for _, uid := range sortedKeys(byUID) {
+		for _, dependency := range byUID[uid] {
+			inhibitRules = append(inhibitRules, config.InhibitRule{
+				SourceMatchers: config.Matchers{mustMatcher(labels.MatchEqual, alertingModels.RuleUIDLabel, dependency)},
+				TargetMatchers: config.Matchers{mustMatcher(labels.MatchEqual, alertingModels.RuleUIDLabel, uid)},
+			})
+		}
+	}
+	cfg.InhibitRules = inhibitRules
+	return true, nil
+}
+
+// ValidateNotificationSettings checks that the notification settings are well-formed, and that their receiver and
+// mute time intervals exist in the latest Alertmanager configuration of the organization.
+func (moa *MultiOrgAlertmanager) ValidateNotificationSettings(ctx context.Context, orgID int64, s models.NotificationSettings) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+
+	rawConfig := moa.settings.UnifiedAlerting.DefaultConfiguration
+	amConfig, err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, &models.GetLatestAlertmanagerConfigurationQuery{OrgID: orgID})
+	if err != nil {
+		if !errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+			return fmt.Errorf("failed to get latest configuration: %w", err)
+		}
+	} else {
+		rawConfig = amConfig.AlertmanagerConfiguration
+	}
+	cfg, err := Load([]byte(rawConfig))
+	if err != nil {
+		return fmt.Errorf("failed to parse Alertmanager configuration: %w", err)
+	}
+
+	receiverExists := false
+	for _, r := range cfg.AlertmanagerConfig.Receivers {
+		if r.Name == s.Receiver {
+			receiverExists = true
+			break
+		}
+	}
+	if !receiverExists {
+		return fmt.Errorf("%w: receiver '%s' does not exist", models.ErrNotificationSettingsInvalid, s.Receiver)
+	}
+
+	muteTimeIntervals := make(map[string]struct{}, len(cfg.AlertmanagerConfig.MuteTimeIntervals))
+	for _, mt := range cfg.AlertmanagerConfig.MuteTimeIntervals {
+		muteTimeIntervals[mt.Name] = struct{}{}
+	}
+	for _, mt := range s.MuteTimeIntervals {
+		if _, ok := muteTimeIntervals[mt]; !ok {
+			return fmt.Errorf("%w: mute time interval '%s' does not exist", models.ErrNotificationSettingsInvalid, mt)
+		}
+	}
+	return nil
+}
+
+// UpdateAutogeneratedRoutes applies the latest configuration of the organization again in order to update the
+// routes generated from the notification settings of alert rules. It does nothing if the Alertmanager of the
+// organization is not ready, the routes are updated by the next synchronization instead.
+func (moa *MultiOrgAlertmanager) UpdateAutogeneratedRoutes(ctx context.Context, orgID int64) error {
+	am, err := moa.AlertmanagerFor(orgID)
+	if err != nil {
+		if errors.Is(err, ErrAlertmanagerNotReady) || errors.Is(err, ErrNoAlertmanagerForOrg) {
+			return nil
+		}
+		return err
+	}
+
+	dbConfig, err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, &models.GetLatestAlertmanagerConfigurationQuery{OrgID: orgID})
+	if err != nil {
+		if errors.Is(err, store.ErrNoAlertmanagerConfiguration) {
+			return nil
+		}
+		return fmt.Errorf("failed to get latest configuration: %w", err)
+	}
+	return am.ApplyConfig(ctx, dbConfig)
+}
+
+// newSettingsRoute creates the route of the alerts with the notification settings s.
+func newSettingsRoute(logger log.Logger, fingerprint string, s models.NotificationSettings, muteTimeIntervals map[string]struct{}) *apimodels.Route {
+	route := &apimodels.Route{
+		Receiver:       s.Receiver,
+		ObjectMatchers: apimodels.ObjectMatchers{mustMatcher(labels.MatchEqual, models.AutogeneratedRouteSettingsHashLabel, fingerprint)},
+		GroupWait:      s.GroupWait,
+		GroupInterval:  s.GroupInterval,
+		RepeatInterval: s.RepeatInterval,
+	}
+	if len(s.GroupBy) > 0 {
+		route.GroupByStr = s.GroupBy
+		for _, l := range s.GroupBy {
+			if l == "..." {
+				route.GroupByAll = true
+				break
+			}
+			route.GroupBy = append(route.GroupBy, model.LabelName(l))
+		}
+	}
+	for _, mt := range s.MuteTimeIntervals {
+		if _, ok := muteTimeIntervals[mt]; !ok {
+			logger.Warn("Notification settings reference a mute time interval that does not exist, ignoring it", "receiver", s.Receiver, "mute_time_interval", mt)
+			continue
+		}
+		route.MuteTimeIntervals = append(route.MuteTimeIntervals, mt)
+	}
+	return route
+}
+
+func mustMatcher(t labels.MatchType, name, value string) *labels.Matcher {
+	m, err := labels.NewMatcher(t, name, value)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
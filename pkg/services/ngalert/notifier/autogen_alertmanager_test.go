@@ -0,0 +1,125 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	alertingModels "github.com/grafana/alerting/models"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestAddAutogenConfig(t *testing.T) {
+	const orgID = 1
+	repeatInterval := model.Duration(time.Hour)
+	newConfig := func() *apimodels.PostableApiAlertingConfig {
+		return &apimodels.PostableApiAlertingConfig{
+			Config: apimodels.Config{
+				Route: &apimodels.Route{
+					Receiver: "default",
+					Routes: []*apimodels.Route{
+						{Receiver: "receiver-1"},
+					},
+				},
+				MuteTimeIntervals: []config.MuteTimeInterval{{Name: "weekends"}},
+			},
+			Receivers: []*apimodels.PostableApiReceiver{
+				{Receiver: config.Receiver{Name: "default"}},
+				{Receiver: config.Receiver{Name: "receiver-1"}},
+				{Receiver: config.Receiver{Name: "receiver-2"}},
+			},
+		}
+	}
+
+	t.Run("should not change the configuration if rules do not have notification settings", func(t *testing.T) {
+		cfg := newConfig()
+		changed, err := addAutogenConfig(context.Background(), log.NewNopLogger(), &fakeConfigStore{}, orgID, cfg)
+		require.NoError(t, err)
+		require.False(t, changed)
+		require.Len(t, cfg.Route.Routes, 1)
+	})
+
+	t.Run("should add a route per receiver and per distinct settings", func(t *testing.T) {
+		settings1 := models.NotificationSettings{Receiver: "receiver-1"}
+		settings2 := models.NotificationSettings{
+			Receiver:          "receiver-2",
+			GroupBy:           []string{"alertname", "grafana_folder"},
+			RepeatInterval:    &repeatInterval,
+			MuteTimeIntervals: []string{"weekends", "missing"},
+		}
+		store := &fakeConfigStore{
+			notificationSettings: map[int64]map[models.AlertRuleKey]models.NotificationSettings{
+				orgID: {
+					{OrgID: orgID, UID: "rule-1"}: settings1,
+					{OrgID: orgID, UID: "rule-2"}: settings1,
+					{OrgID: orgID, UID: "rule-3"}: settings2,
+					{OrgID: orgID, UID: "rule-4"}: {Receiver: "missing"},
+				},
+			},
+		}
+		cfg := newConfig()
+		original := cfg.Route
+
+		changed, err := addAutogenConfig(context.Background(), log.NewNopLogger(), store, orgID, cfg)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Len(t, original.Routes, 1, "the original route should not be modified")
+
+		require.Len(t, cfg.Route.Routes, 2)
+		autogen := cfg.Route.Routes[0]
+		require.Equal(t, "default", autogen.Receiver)
+		require.Equal(t, models.AutogeneratedRouteLabel+`="true"`, autogen.ObjectMatchers[0].String())
+		require.Equal(t, "receiver-1", cfg.Route.Routes[1].Receiver)
+
+		require.Len(t, autogen.Routes, 2)
+		r1 := autogen.Routes[0]
+		require.Equal(t, "receiver-1", r1.Receiver)
+		require.Equal(t, models.AutogeneratedRouteReceiverNameLabel+`="receiver-1"`, r1.ObjectMatchers[0].String())
+		require.Len(t, r1.Routes, 1)
+		require.Equal(t, models.AutogeneratedRouteSettingsHashLabel+`="`+settings1.Fingerprint()+`"`, r1.Routes[0].ObjectMatchers[0].String())
+
+		r2 := autogen.Routes[1]
+		require.Equal(t, "receiver-2", r2.Receiver)
+		require.Len(t, r2.Routes, 1)
+		require.Equal(t, []model.LabelName{"alertname", "grafana_folder"}, r2.Routes[0].GroupBy)
+		require.Equal(t, &repeatInterval, r2.Routes[0].RepeatInterval)
+		require.Equal(t, []string{"weekends"}, r2.Routes[0].MuteTimeIntervals)
+	})
+
+	t.Run("should add an inhibit rule per dependency", func(t *testing.T) {
+		store := &fakeConfigStore{
+			ruleDependencies: map[int64]map[models.AlertRuleKey][]string{
+				orgID: {
+					{OrgID: orgID, UID: "rule-2"}: {"rule-1"},
+					{OrgID: orgID, UID: "rule-1"}: {"cluster-down", "network-down"},
+				},
+			},
+		}
+		cfg := newConfig()
+		cfg.InhibitRules = []config.InhibitRule{{Equal: model.LabelNames{"cluster"}}}
+		original := cfg.InhibitRules
+
+		changed, err := addAutogenConfig(context.Background(), log.NewNopLogger(), store, orgID, cfg)
+		require.NoError(t, err)
+		require.True(t, changed)
+		require.Len(t, original, 1, "the original inhibit rules should not be modified")
+		require.Len(t, cfg.Route.Routes, 1, "routes should not be added without notification settings")
+
+		require.Len(t, cfg.InhibitRules, 4)
+		require.Equal(t, original[0], cfg.InhibitRules[0])
+		expected := [][2]string{{"cluster-down", "rule-1"}, {"network-down", "rule-1"}, {"rule-1", "rule-2"}}
+		for i, e := range expected {
+			r := cfg.InhibitRules[i+1]
+			require.Len(t, r.SourceMatchers, 1)
+			require.Equal(t, alertingModels.RuleUIDLabel+`="`+e[0]+`"`, r.SourceMatchers[0].String())
+			require.Len(t, r.TargetMatchers, 1)
+			require.Equal(t, alertingModels.RuleUIDLabel+`="`+e[1]+`"`, r.TargetMatchers[0].String())
+		}
+	})
+}
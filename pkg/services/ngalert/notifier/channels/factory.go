@@ -0,0 +1,182 @@
+// Package channels contains contact point integrations that are implemented in Grafana rather than in the alerting module.
+// Integrations of these types are separated from the rest of a receiver before the receiver is passed to the alerting module,
+// and are built by BuildReceiverIntegrations in the same way the alerting module builds the other integrations.
+package channels
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	alertingLogging "github.com/grafana/alerting/logging"
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/grafana/alerting/receivers"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	MatrixType     = "matrix"
+	MattermostType = "mattermost"
+	ZulipType      = "zulip"
+	NtfyType       = "ntfy"
+	SNMPType       = "snmp"
+)
+
+// IsSupported returns true if the integration type is implemented in this package.
+func IsSupported(integrationType string) bool {
+	switch strings.ToLower(integrationType) {
+	case MatrixType, MattermostType, ZulipType, NtfyType, SNMPType:
+		return true
+	}
+	return false
+}
+
+// SplitReceiver separates the integrations of the receiver that are implemented in this package from the ones implemented by the alerting module.
+// Both returned receivers have the same name as the original one.
+func SplitReceiver(receiver *alertingNotify.APIReceiver) (upstream *alertingNotify.APIReceiver, own *alertingNotify.APIReceiver) {
+	upstream = &alertingNotify.APIReceiver{ConfigReceiver: receiver.ConfigReceiver}
+	own = &alertingNotify.APIReceiver{ConfigReceiver: receiver.ConfigReceiver}
+	for _, integration := range receiver.Integrations {
+		if IsSupported(integration.Type) {
+			own.Integrations = append(own.Integrations, integration)
+			continue
+		}
+		upstream.Integrations = append(upstream.Integrations, integration)
+	}
+	return upstream, own
+}
+
+// ReceiverConfig contains the parsed and decrypted settings of the integrations of a receiver that are implemented in this package.
+type ReceiverConfig struct {
+	Name              string
+	MatrixConfigs     []*alertingNotify.NotifierConfig[MatrixConfig]
+	MattermostConfigs []*alertingNotify.NotifierConfig[MattermostConfig]
+	ZulipConfigs      []*alertingNotify.NotifierConfig[ZulipConfig]
+	NtfyConfigs       []*alertingNotify.NotifierConfig[NtfyConfig]
+	SNMPConfigs       []*alertingNotify.NotifierConfig[SNMPConfig]
+}
+
+// BuildReceiverConfiguration parses, decrypts and validates the integrations of the receiver. Integrations of types that are not
+// implemented in this package are not allowed, use SplitReceiver first. Returns alertingNotify.IntegrationValidationError if an integration is not valid.
+func BuildReceiverConfiguration(ctx context.Context, api *alertingNotify.APIReceiver, decrypt alertingNotify.GetDecryptedValueFn) (ReceiverConfig, error) {
+	result := ReceiverConfig{
+		Name: api.Name,
+	}
+	for _, integration := range api.Integrations {
+		if err := parseNotifier(ctx, &result, integration, decrypt); err != nil {
+			return ReceiverConfig{}, alertingNotify.IntegrationValidationError{
+				Integration: integration,
+				Err:         err,
+			}
+		}
+	}
+	return result, nil
+}
+
+func parseNotifier(ctx context.Context, result *ReceiverConfig, integration *alertingNotify.GrafanaIntegrationConfig, decrypt alertingNotify.GetDecryptedValueFn) error {
+	secureSettings := make(map[string][]byte, len(integration.SecureSettings))
+	for key, value := range integration.SecureSettings {
+		d, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("failed to decode secure settings key %s: %w", key, err)
+		}
+		secureSettings[key] = d
+	}
+	decryptFn := func(key string, fallback string) string {
+		return decrypt(ctx, secureSettings, key, fallback)
+	}
+
+	switch strings.ToLower(integration.Type) {
+	case MatrixType:
+		cfg, err := NewMatrixConfig(integration.Settings, decryptFn)
+		if err != nil {
+			return err
+		}
+		result.MatrixConfigs = append(result.MatrixConfigs, newNotifierConfig(integration, cfg))
+	case MattermostType:
+		cfg, err := NewMattermostConfig(integration.Settings, decryptFn)
+		if err != nil {
+			return err
+		}
+		result.MattermostConfigs = append(result.MattermostConfigs, newNotifierConfig(integration, cfg))
+	case ZulipType:
+		cfg, err := NewZulipConfig(integration.Settings, decryptFn)
+		if err != nil {
+			return err
+		}
+		result.ZulipConfigs = append(result.ZulipConfigs, newNotifierConfig(integration, cfg))
+	case NtfyType:
+		cfg, err := NewNtfyConfig(integration.Settings, decryptFn)
+		if err != nil {
+			return err
+		}
+		result.NtfyConfigs = append(result.NtfyConfigs, newNotifierConfig(integration, cfg))
+	case SNMPType:
+		cfg, err := NewSNMPConfig(integration.Settings, decryptFn)
+		if err != nil {
+			return err
+		}
+		result.SNMPConfigs = append(result.SNMPConfigs, newNotifierConfig(integration, cfg))
+	default:
+		return fmt.Errorf("notifier %s is not supported", integration.Type)
+	}
+	return nil
+}
+
+func newNotifierConfig[T any](integration *alertingNotify.GrafanaIntegrationConfig, settings T) *alertingNotify.NotifierConfig[T] {
+	return &alertingNotify.NotifierConfig[T]{
+		Metadata: receivers.Metadata{
+			UID:                   integration.UID,
+			Name:                  integration.Name,
+			Type:                  integration.Type,
+			DisableResolveMessage: integration.DisableResolveMessage,
+		},
+		Settings: settings,
+	}
+}
+
+// BuildReceiverIntegrations creates an integration for each integration configured in the receiver.
+func BuildReceiverIntegrations(
+	receiver ReceiverConfig,
+	tmpl *alertingTemplates.Template,
+	sender receivers.WebhookSender,
+	logger alertingLogging.LoggerFactory,
+) []*alertingNotify.Integration {
+	type notificationChannel interface {
+		notify.Notifier
+		notify.ResolvedSender
+	}
+	var integrations []*alertingNotify.Integration
+	nl := func(meta receivers.Metadata) alertingLogging.Logger {
+		return logger("ngalert.notifier."+meta.Type, "notifierUID", meta.UID)
+	}
+	ci := func(idx int, meta receivers.Metadata, n notificationChannel) {
+		integrations = append(integrations, notify.NewIntegration(n, n, meta.Type, idx, meta.Name))
+	}
+	for i, cfg := range receiver.MatrixConfigs {
+		ci(i, cfg.Metadata, NewMatrixNotifier(cfg.Settings, cfg.Metadata, tmpl, sender, nl(cfg.Metadata)))
+	}
+	for i, cfg := range receiver.MattermostConfigs {
+		ci(i, cfg.Metadata, NewMattermostNotifier(cfg.Settings, cfg.Metadata, tmpl, sender, nl(cfg.Metadata)))
+	}
+	for i, cfg := range receiver.ZulipConfigs {
+		ci(i, cfg.Metadata, NewZulipNotifier(cfg.Settings, cfg.Metadata, tmpl, sender, nl(cfg.Metadata)))
+	}
+	for i, cfg := range receiver.NtfyConfigs {
+		ci(i, cfg.Metadata, NewNtfyNotifier(cfg.Settings, cfg.Metadata, tmpl, sender, nl(cfg.Metadata)))
+	}
+	for i, cfg := range receiver.SNMPConfigs {
+		ci(i, cfg.Metadata, NewSNMPNotifier(cfg.Settings, cfg.Metadata, tmpl, nl(cfg.Metadata)))
+	}
+	return integrations
+}
+
+// templateText returns a function that expands templates against the alerts. The first error of templating is stored in tmplErr,
+// and all following calls of the function return an empty string.
+func templateText(ctx context.Context, tmpl *alertingTemplates.Template, alerts []*types.Alert, logger alertingLogging.Logger, tmplErr *error) func(string) string {
+	fn, _ := alertingTemplates.TmplText(ctx, tmpl, alerts, logger, tmplErr)
+	return fn
+}
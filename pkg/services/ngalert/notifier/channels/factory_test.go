@@ -0,0 +1,236 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/grafana/alerting/logging"
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitReceiver(t *testing.T) {
+	receiver := &alertingNotify.APIReceiver{
+		ConfigReceiver: alertingNotify.ConfigReceiver{Name: "test"},
+		GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
+			Integrations: []*alertingNotify.GrafanaIntegrationConfig{
+				{UID: "1", Type: "slack"},
+				{UID: "2", Type: MatrixType},
+				{UID: "3", Type: "email"},
+				{UID: "4", Type: "SNMP"},
+			},
+		},
+	}
+
+	upstream, own := SplitReceiver(receiver)
+
+	require.Equal(t, "test", upstream.Name)
+	require.Equal(t, "test", own.Name)
+	require.Equal(t, []*alertingNotify.GrafanaIntegrationConfig{receiver.Integrations[0], receiver.Integrations[2]}, upstream.Integrations)
+	require.Equal(t, []*alertingNotify.GrafanaIntegrationConfig{receiver.Integrations[1], receiver.Integrations[3]}, own.Integrations)
+}
+
+func TestBuildReceiverConfiguration(t *testing.T) {
+	decrypt := func(_ context.Context, sjd map[string][]byte, key string, fallback string) string {
+		if v, ok := sjd[key]; ok {
+			return string(v)
+		}
+		return fallback
+	}
+	secure := func(v string) string {
+		return base64.StdEncoding.EncodeToString([]byte(v))
+	}
+
+	t.Run("should build configuration of all integrations and decrypt secure settings", func(t *testing.T) {
+		receiver := &alertingNotify.APIReceiver{
+			ConfigReceiver: alertingNotify.ConfigReceiver{Name: "test"},
+			GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
+				Integrations: []*alertingNotify.GrafanaIntegrationConfig{
+					{
+						UID:            "matrix-uid",
+						Name:           "matrix",
+						Type:           MatrixType,
+						Settings:       json.RawMessage(`{"homeserverUrl": "https://matrix.example.com", "roomId": "!room:example.com"}`),
+						SecureSettings: map[string]string{"accessToken": secure("matrix-token")},
+					},
+					{
+						UID:                   "mattermost-uid",
+						Name:                  "mattermost",
+						Type:                  MattermostType,
+						DisableResolveMessage: true,
+						Settings:              json.RawMessage(`{"url": "https://mattermost.example.com", "channelId": "channel"}`),
+						SecureSettings:        map[string]string{"botToken": secure("mattermost-token")},
+					},
+					{
+						Type:           ZulipType,
+						Settings:       json.RawMessage(`{"url": "https://zulip.example.com", "botEmail": "bot@example.com", "stream": "alerts"}`),
+						SecureSettings: map[string]string{"apiKey": secure("zulip-key")},
+					},
+					{
+						Type:     NtfyType,
+						Settings: json.RawMessage(`{"topic": "alerts"}`),
+					},
+					{
+						Type:     SNMPType,
+						Settings: json.RawMessage(`{"address": "127.0.0.1"}`),
+					},
+				},
+			},
+		}
+
+		cfg, err := BuildReceiverConfiguration(context.Background(), receiver, decrypt)
+		require.NoError(t, err)
+
+		require.Equal(t, "test", cfg.Name)
+		require.Len(t, cfg.MatrixConfigs, 1)
+		require.Equal(t, receivers.Metadata{UID: "matrix-uid", Name: "matrix", Type: MatrixType}, cfg.MatrixConfigs[0].Metadata)
+		require.Equal(t, "matrix-token", cfg.MatrixConfigs[0].Settings.AccessToken)
+		require.Len(t, cfg.MattermostConfigs, 1)
+		require.True(t, cfg.MattermostConfigs[0].DisableResolveMessage)
+		require.Equal(t, "mattermost-token", cfg.MattermostConfigs[0].Settings.BotToken)
+		require.Len(t, cfg.ZulipConfigs, 1)
+		require.Equal(t, "zulip-key", cfg.ZulipConfigs[0].Settings.APIKey)
+		require.Len(t, cfg.NtfyConfigs, 1)
+		require.Equal(t, ntfyDefaultURL, cfg.NtfyConfigs[0].Settings.URL)
+		require.Len(t, cfg.SNMPConfigs, 1)
+		require.Equal(t, "127.0.0.1:162", cfg.SNMPConfigs[0].Settings.Address)
+
+		integrations := BuildReceiverIntegrations(cfg, templates.ForTests(t), receivers.MockNotificationService(), func(_ string, _ ...any) logging.Logger {
+			return &logging.FakeLogger{}
+		})
+		require.Len(t, integrations, 5)
+		require.Equal(t, MatrixType, integrations[0].Name())
+		require.True(t, integrations[0].SendResolved())
+		require.Equal(t, MattermostType, integrations[1].Name())
+		require.False(t, integrations[1].SendResolved())
+	})
+
+	t.Run("should return validation error if integration is not valid", func(t *testing.T) {
+		receiver := &alertingNotify.APIReceiver{
+			GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
+				Integrations: []*alertingNotify.GrafanaIntegrationConfig{
+					{
+						Type:     ZulipType,
+						Settings: json.RawMessage(`{"url": "https://zulip.example.com"}`),
+					},
+				},
+			},
+		}
+		_, err := BuildReceiverConfiguration(context.Background(), receiver, decrypt)
+		var validationErr alertingNotify.IntegrationValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, receiver.Integrations[0], validationErr.Integration)
+	})
+
+	t.Run("should fail if integration is not implemented in the package", func(t *testing.T) {
+		receiver := &alertingNotify.APIReceiver{
+			GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
+				Integrations: []*alertingNotify.GrafanaIntegrationConfig{
+					{
+						Type:     "slack",
+						Settings: json.RawMessage(`{}`),
+					},
+				},
+			},
+		}
+		_, err := BuildReceiverConfiguration(context.Background(), receiver, decrypt)
+		require.ErrorContains(t, err, "notifier slack is not supported")
+	})
+}
+
+// httpSender is a minimal receivers.WebhookSender that sends requests as the notification service does.
+type httpSender struct{}
+
+func (httpSender) SendWebhook(ctx context.Context, cmd *receivers.SendWebhookSettings) error {
+	req, err := http.NewRequestWithContext(ctx, cmd.HTTPMethod, cmd.URL, bytes.NewBufferString(cmd.Body))
+	if err != nil {
+		return err
+	}
+	contentType := cmd.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	if cmd.User != "" && cmd.Password != "" {
+		req.SetBasicAuth(cmd.User, cmd.Password)
+	}
+	for k, v := range cmd.HTTPHeader {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook response status %v", resp.Status)
+	}
+	return nil
+}
+
+func (httpSender) SendEmail(context.Context, *receivers.SendEmailSettings) error {
+	return nil
+}
+
+// recordedRequest is a request received by the stand-in of a server started by newRecordingServer.
+type recordedRequest struct {
+	Method string
+	Path   string
+	Header http.Header
+	Body   []byte
+}
+
+// newRecordingServer starts an HTTP server that responds with the status and sends every received request to the returned channel.
+func newRecordingServer(t *testing.T, status int) (*httptest.Server, <-chan recordedRequest) {
+	t.Helper()
+	requests := make(chan recordedRequest, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		requests <- recordedRequest{
+			Method: r.Method,
+			Path:   r.URL.EscapedPath(),
+			Header: r.Header.Clone(),
+			Body:   body,
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, requests
+}
+
+func testTemplate(t *testing.T) *templates.Template {
+	t.Helper()
+	tmpl := templates.ForTests(t)
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+	return tmpl
+}
+
+func testAlerts() []*types.Alert {
+	return []*types.Alert{
+		{
+			Alert: model.Alert{
+				Labels:      model.LabelSet{"__alert_rule_uid__": "rule uid", "alertname": "alert1", "lbl1": "val1"},
+				Annotations: model.LabelSet{"ann1": "annv1"},
+			},
+		},
+	}
+}
+
+func noDecrypt(_ string, fallback string) string {
+	return fallback
+}
@@ -0,0 +1,141 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	alertingLogging "github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	matrixMsgTypeText   = "m.text"
+	matrixMsgTypeNotice = "m.notice"
+
+	// matrixMaxMessageLenBytes is the limit of the size of an event in Matrix minus the space that is needed for the rest of the event.
+	matrixMaxMessageLenBytes = 60000
+)
+
+// MatrixConfig contains the settings of a Matrix integration that sends messages to a room.
+type MatrixConfig struct {
+	HomeserverURL string `json:"homeserverUrl,omitempty" yaml:"homeserverUrl,omitempty"`
+	RoomID        string `json:"roomId,omitempty" yaml:"roomId,omitempty"`
+	AccessToken   string `json:"accessToken,omitempty" yaml:"accessToken,omitempty"`
+	MessageType   string `json:"messageType,omitempty" yaml:"messageType,omitempty"`
+	Title         string `json:"title,omitempty" yaml:"title,omitempty"`
+	Message       string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// NewMatrixConfig is the constructor for the Matrix integration settings.
+func NewMatrixConfig(jsonData json.RawMessage, decryptFn receivers.DecryptFunc) (MatrixConfig, error) {
+	settings := MatrixConfig{}
+	if err := json.Unmarshal(jsonData, &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	if settings.HomeserverURL == "" {
+		return settings, errors.New("could not find homeserver URL in settings")
+	}
+	u, err := url.Parse(settings.HomeserverURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return settings, fmt.Errorf("invalid homeserver URL %q", settings.HomeserverURL)
+	}
+	settings.HomeserverURL = strings.TrimSuffix(u.String(), "/")
+	if settings.RoomID == "" {
+		return settings, errors.New("could not find room ID in settings")
+	}
+	settings.AccessToken = decryptFn("accessToken", settings.AccessToken)
+	if settings.AccessToken == "" {
+		return settings, errors.New("could not find access token in settings")
+	}
+	switch settings.MessageType {
+	case "":
+		settings.MessageType = matrixMsgTypeText
+	case matrixMsgTypeText, matrixMsgTypeNotice:
+	default:
+		return settings, fmt.Errorf("invalid message type %q, must be %q or %q", settings.MessageType, matrixMsgTypeText, matrixMsgTypeNotice)
+	}
+	if settings.Title == "" {
+		settings.Title = alertingTemplates.DefaultMessageTitleEmbed
+	}
+	if settings.Message == "" {
+		settings.Message = alertingTemplates.DefaultMessageEmbed
+	}
+	return settings, nil
+}
+
+// MatrixNotifier sends alert notifications as messages to a Matrix room using the client-server API.
+type MatrixNotifier struct {
+	*receivers.Base
+	ns       receivers.WebhookSender
+	log      alertingLogging.Logger
+	tmpl     *alertingTemplates.Template
+	settings MatrixConfig
+}
+
+func NewMatrixNotifier(cfg MatrixConfig, meta receivers.Metadata, template *alertingTemplates.Template, sender receivers.WebhookSender, logger alertingLogging.Logger) *MatrixNotifier {
+	return &MatrixNotifier{
+		Base:     receivers.NewBase(meta),
+		ns:       sender,
+		log:      logger,
+		tmpl:     template,
+		settings: cfg,
+	}
+}
+
+// matrixMessage is the content of the m.room.message event.
+type matrixMessage struct {
+	MsgType string `json:"msgtype"`
+	Body    string `json:"body"`
+}
+
+// Notify sends the message to the room. Each notification uses a new transaction ID because the same alerts
+// are expected to be sent again at the next repeat interval.
+func (mn *MatrixNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl := templateText(ctx, mn.tmpl, as, mn.log, &tmplErr)
+
+	title := tmpl(mn.settings.Title)
+	message := tmpl(mn.settings.Message)
+	if tmplErr != nil {
+		mn.log.Warn("Failed to template Matrix message", "error", tmplErr.Error())
+	}
+	body, truncated := receivers.TruncateInBytes(strings.TrimSpace(title+"\n\n"+message), matrixMaxMessageLenBytes)
+	if truncated {
+		mn.log.Warn("Truncated Matrix message", "maxBytes", matrixMaxMessageLenBytes)
+	}
+
+	b, err := json.Marshal(matrixMessage{
+		MsgType: mn.settings.MessageType,
+		Body:    body,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	txnID := fmt.Sprintf("grafana-%s-%d", mn.UID, time.Now().UnixNano())
+	cmd := &receivers.SendWebhookSettings{
+		URL: fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+			mn.settings.HomeserverURL, url.PathEscape(mn.settings.RoomID), url.PathEscape(txnID)),
+		Body:       string(b),
+		HTTPMethod: http.MethodPut,
+		HTTPHeader: map[string]string{
+			"Authorization": "Bearer " + mn.settings.AccessToken,
+		},
+	}
+	if err := mn.ns.SendWebhook(ctx, cmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (mn *MatrixNotifier) SendResolved() bool {
+	return !mn.GetDisableResolveMessage()
+}
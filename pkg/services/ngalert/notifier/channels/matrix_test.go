@@ -0,0 +1,132 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMatrixConfig(t *testing.T) {
+	cases := []struct {
+		name        string
+		settings    string
+		secrets     map[string]string
+		expected    MatrixConfig
+		expectedErr string
+	}{
+		{
+			name:     "minimal valid configuration",
+			settings: `{"homeserverUrl": "https://matrix.example.com/", "roomId": "!room:example.com"}`,
+			secrets:  map[string]string{"accessToken": "token"},
+			expected: MatrixConfig{
+				HomeserverURL: "https://matrix.example.com",
+				RoomID:        "!room:example.com",
+				AccessToken:   "token",
+				MessageType:   matrixMsgTypeText,
+				Title:         templates.DefaultMessageTitleEmbed,
+				Message:       templates.DefaultMessageEmbed,
+			},
+		},
+		{
+			name:     "all settings",
+			settings: `{"homeserverUrl": "https://matrix.example.com", "roomId": "!room:example.com", "accessToken": "plain", "messageType": "m.notice", "title": "title", "message": "message"}`,
+			expected: MatrixConfig{
+				HomeserverURL: "https://matrix.example.com",
+				RoomID:        "!room:example.com",
+				AccessToken:   "plain",
+				MessageType:   matrixMsgTypeNotice,
+				Title:         "title",
+				Message:       "message",
+			},
+		},
+		{
+			name:        "missing homeserver URL",
+			settings:    `{"roomId": "!room:example.com"}`,
+			secrets:     map[string]string{"accessToken": "token"},
+			expectedErr: "could not find homeserver URL in settings",
+		},
+		{
+			name:        "invalid homeserver URL",
+			settings:    `{"homeserverUrl": "matrix.example.com", "roomId": "!room:example.com"}`,
+			secrets:     map[string]string{"accessToken": "token"},
+			expectedErr: `invalid homeserver URL "matrix.example.com"`,
+		},
+		{
+			name:        "missing room ID",
+			settings:    `{"homeserverUrl": "https://matrix.example.com"}`,
+			secrets:     map[string]string{"accessToken": "token"},
+			expectedErr: "could not find room ID in settings",
+		},
+		{
+			name:        "missing access token",
+			settings:    `{"homeserverUrl": "https://matrix.example.com", "roomId": "!room:example.com"}`,
+			expectedErr: "could not find access token in settings",
+		},
+		{
+			name:        "invalid message type",
+			settings:    `{"homeserverUrl": "https://matrix.example.com", "roomId": "!room:example.com", "messageType": "m.emote"}`,
+			secrets:     map[string]string{"accessToken": "token"},
+			expectedErr: `invalid message type "m.emote"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := NewMatrixConfig(json.RawMessage(c.settings), decryptFromMap(c.secrets))
+			if c.expectedErr != "" {
+				require.ErrorContains(t, err, c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestMatrixNotifier(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusOK)
+	cfg := MatrixConfig{
+		HomeserverURL: server.URL,
+		RoomID:        "!room:example.com",
+		AccessToken:   "token",
+		MessageType:   matrixMsgTypeNotice,
+		Title:         "{{ len .Alerts.Firing }} firing",
+		Message:       "{{ range .Alerts }}{{ .Labels.alertname }}{{ end }}",
+	}
+	n := NewMatrixNotifier(cfg, receivers.Metadata{UID: "uid"}, testTemplate(t), httpSender{}, &logging.FakeLogger{})
+
+	ok, err := n.Notify(context.Background(), testAlerts()...)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	req := <-requests
+	require.Equal(t, http.MethodPut, req.Method)
+	require.True(t, strings.HasPrefix(req.Path, "/_matrix/client/v3/rooms/%21room:example.com/send/m.room.message/grafana-uid-"), req.Path)
+	require.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	require.JSONEq(t, `{"msgtype": "m.notice", "body": "1 firing\n\nalert1"}`, string(req.Body))
+
+	t.Run("should fail if homeserver rejects the message", func(t *testing.T) {
+		server, _ := newRecordingServer(t, http.StatusForbidden)
+		cfg.HomeserverURL = server.URL
+		n := NewMatrixNotifier(cfg, receivers.Metadata{}, testTemplate(t), httpSender{}, &logging.FakeLogger{})
+		ok, err := n.Notify(context.Background(), testAlerts()...)
+		require.Error(t, err)
+		require.False(t, ok)
+	})
+}
+
+func decryptFromMap(secrets map[string]string) receivers.DecryptFunc {
+	return func(key string, fallback string) string {
+		if v, ok := secrets[key]; ok {
+			return v
+		}
+		return fallback
+	}
+}
@@ -0,0 +1,146 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	alertingLogging "github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// mattermostMaxMessageLenRunes is the maximum length of a post in Mattermost.
+const mattermostMaxMessageLenRunes = 16383
+
+// MattermostConfig contains the settings of a Mattermost integration that creates posts in a channel using the REST API of Mattermost.
+// Unlike Slack-compatible incoming webhooks, it does not require incoming webhooks to be enabled on the server.
+type MattermostConfig struct {
+	URL       string `json:"url,omitempty" yaml:"url,omitempty"`
+	ChannelID string `json:"channelId,omitempty" yaml:"channelId,omitempty"`
+	BotToken  string `json:"botToken,omitempty" yaml:"botToken,omitempty"`
+	Title     string `json:"title,omitempty" yaml:"title,omitempty"`
+	Message   string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// NewMattermostConfig is the constructor for the Mattermost integration settings.
+func NewMattermostConfig(jsonData json.RawMessage, decryptFn receivers.DecryptFunc) (MattermostConfig, error) {
+	settings := MattermostConfig{}
+	if err := json.Unmarshal(jsonData, &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	if settings.URL == "" {
+		return settings, errors.New("could not find Mattermost URL in settings")
+	}
+	u, err := url.Parse(settings.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return settings, fmt.Errorf("invalid Mattermost URL %q", settings.URL)
+	}
+	settings.URL = strings.TrimSuffix(u.String(), "/")
+	if settings.ChannelID == "" {
+		return settings, errors.New("could not find channel ID in settings")
+	}
+	settings.BotToken = decryptFn("botToken", settings.BotToken)
+	if settings.BotToken == "" {
+		return settings, errors.New("could not find bot token in settings")
+	}
+	if settings.Title == "" {
+		settings.Title = alertingTemplates.DefaultMessageTitleEmbed
+	}
+	if settings.Message == "" {
+		settings.Message = alertingTemplates.DefaultMessageEmbed
+	}
+	return settings, nil
+}
+
+// MattermostNotifier sends alert notifications as posts to a Mattermost channel.
+type MattermostNotifier struct {
+	*receivers.Base
+	ns       receivers.WebhookSender
+	log      alertingLogging.Logger
+	tmpl     *alertingTemplates.Template
+	settings MattermostConfig
+}
+
+func NewMattermostNotifier(cfg MattermostConfig, meta receivers.Metadata, template *alertingTemplates.Template, sender receivers.WebhookSender, logger alertingLogging.Logger) *MattermostNotifier {
+	return &MattermostNotifier{
+		Base:     receivers.NewBase(meta),
+		ns:       sender,
+		log:      logger,
+		tmpl:     template,
+		settings: cfg,
+	}
+}
+
+type mattermostPost struct {
+	ChannelID string              `json:"channel_id"`
+	Props     mattermostPostProps `json:"props"`
+}
+
+type mattermostPostProps struct {
+	Attachments []mattermostAttachment `json:"attachments"`
+}
+
+type mattermostAttachment struct {
+	Fallback  string `json:"fallback"`
+	Color     string `json:"color"`
+	Title     string `json:"title"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text"`
+}
+
+// Notify creates a post with an attachment colored according to the status of the alerts.
+func (mn *MattermostNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl := templateText(ctx, mn.tmpl, as, mn.log, &tmplErr)
+
+	title := tmpl(mn.settings.Title)
+	message, truncated := receivers.TruncateInRunes(tmpl(mn.settings.Message), mattermostMaxMessageLenRunes)
+	if truncated {
+		mn.log.Warn("Truncated Mattermost message", "maxRunes", mattermostMaxMessageLenRunes)
+	}
+	if tmplErr != nil {
+		mn.log.Warn("Failed to template Mattermost message", "error", tmplErr.Error())
+	}
+
+	ruleURL := receivers.JoinURLPath(mn.tmpl.ExternalURL.String(), "/alerting/list", mn.log)
+	b, err := json.Marshal(mattermostPost{
+		ChannelID: mn.settings.ChannelID,
+		Props: mattermostPostProps{
+			Attachments: []mattermostAttachment{
+				{
+					Fallback:  title,
+					Color:     receivers.GetAlertStatusColor(types.Alerts(as...).Status()),
+					Title:     title,
+					TitleLink: ruleURL,
+					Text:      message,
+				},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	cmd := &receivers.SendWebhookSettings{
+		URL:        mn.settings.URL + "/api/v4/posts",
+		Body:       string(b),
+		HTTPMethod: http.MethodPost,
+		HTTPHeader: map[string]string{
+			"Authorization": "Bearer " + mn.settings.BotToken,
+		},
+	}
+	if err := mn.ns.SendWebhook(ctx, cmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (mn *MattermostNotifier) SendResolved() bool {
+	return !mn.GetDisableResolveMessage()
+}
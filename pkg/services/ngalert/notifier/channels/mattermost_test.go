@@ -0,0 +1,98 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMattermostConfig(t *testing.T) {
+	cases := []struct {
+		name        string
+		settings    string
+		secrets     map[string]string
+		expected    MattermostConfig
+		expectedErr string
+	}{
+		{
+			name:     "minimal valid configuration",
+			settings: `{"url": "https://mattermost.example.com/", "channelId": "channel"}`,
+			secrets:  map[string]string{"botToken": "token"},
+			expected: MattermostConfig{
+				URL:       "https://mattermost.example.com",
+				ChannelID: "channel",
+				BotToken:  "token",
+				Title:     templates.DefaultMessageTitleEmbed,
+				Message:   templates.DefaultMessageEmbed,
+			},
+		},
+		{
+			name:        "missing URL",
+			settings:    `{"channelId": "channel"}`,
+			secrets:     map[string]string{"botToken": "token"},
+			expectedErr: "could not find Mattermost URL in settings",
+		},
+		{
+			name:        "missing channel ID",
+			settings:    `{"url": "https://mattermost.example.com"}`,
+			secrets:     map[string]string{"botToken": "token"},
+			expectedErr: "could not find channel ID in settings",
+		},
+		{
+			name:        "missing bot token",
+			settings:    `{"url": "https://mattermost.example.com", "channelId": "channel"}`,
+			expectedErr: "could not find bot token in settings",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := NewMattermostConfig(json.RawMessage(c.settings), decryptFromMap(c.secrets))
+			if c.expectedErr != "" {
+				require.ErrorContains(t, err, c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestMattermostNotifier(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusCreated)
+	cfg := MattermostConfig{
+		URL:       server.URL,
+		ChannelID: "channel",
+		BotToken:  "token",
+		Title:     "{{ len .Alerts.Firing }} firing",
+		Message:   "{{ range .Alerts }}{{ .Labels.alertname }}{{ end }}",
+	}
+	n := NewMattermostNotifier(cfg, receivers.Metadata{}, testTemplate(t), httpSender{}, &logging.FakeLogger{})
+
+	ok, err := n.Notify(context.Background(), testAlerts()...)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	req := <-requests
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, "/api/v4/posts", req.Path)
+	require.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+	require.JSONEq(t, `{
+		"channel_id": "channel",
+		"props": {
+			"attachments": [{
+				"fallback": "1 firing",
+				"color": "#D63232",
+				"title": "1 firing",
+				"title_link": "http://localhost/alerting/list",
+				"text": "alert1"
+			}]
+		}
+	}`, string(req.Body))
+}
@@ -0,0 +1,182 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	alertingLogging "github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	ntfyDefaultURL = "https://ntfy.sh"
+
+	// ntfyMaxMessageLenBytes is the maximum size of a message that ntfy.sh accepts without converting it to an attachment.
+	ntfyMaxMessageLenBytes = 4096
+)
+
+// NtfyConfig contains the settings of an ntfy integration that publishes messages to a topic.
+// The server is authenticated either with an access token or with a username and password.
+type NtfyConfig struct {
+	URL      string   `json:"url,omitempty" yaml:"url,omitempty"`
+	Topic    string   `json:"topic,omitempty" yaml:"topic,omitempty"`
+	Token    string   `json:"token,omitempty" yaml:"token,omitempty"`
+	Username string   `json:"username,omitempty" yaml:"username,omitempty"`
+	Password string   `json:"password,omitempty" yaml:"password,omitempty"`
+	Priority int      `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Tags     []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Title    string   `json:"title,omitempty" yaml:"title,omitempty"`
+	Message  string   `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// NewNtfyConfig is the constructor for the ntfy integration settings.
+func NewNtfyConfig(jsonData json.RawMessage, decryptFn receivers.DecryptFunc) (NtfyConfig, error) {
+	var raw struct {
+		NtfyConfig
+		Priority json.Number `json:"priority,omitempty"`
+		Tags     any         `json:"tags,omitempty"`
+	}
+	if err := json.Unmarshal(jsonData, &raw); err != nil {
+		return NtfyConfig{}, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	settings := raw.NtfyConfig
+
+	if settings.URL == "" {
+		settings.URL = ntfyDefaultURL
+	}
+	u, err := url.Parse(settings.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return settings, fmt.Errorf("invalid ntfy URL %q", settings.URL)
+	}
+	settings.URL = strings.TrimSuffix(u.String(), "/")
+	if settings.Topic == "" {
+		return settings, errors.New("could not find topic in settings")
+	}
+	settings.Token = decryptFn("token", settings.Token)
+	settings.Password = decryptFn("password", settings.Password)
+	if settings.Token != "" && settings.Username != "" {
+		return settings, errors.New("both token and username are set, only one authentication method can be used")
+	}
+	if settings.Username == "" && settings.Password != "" {
+		return settings, errors.New("password is set but username is not")
+	}
+
+	// the priority and tags can be set in UI as strings
+	if raw.Priority != "" {
+		p, err := strconv.Atoi(raw.Priority.String())
+		if err != nil || p < 1 || p > 5 {
+			return settings, fmt.Errorf("invalid priority %q, must be a number from 1 to 5", raw.Priority)
+		}
+		settings.Priority = p
+	}
+	switch tags := raw.Tags.(type) {
+	case nil:
+	case string:
+		settings.Tags = splitCommaDelimitedString(tags)
+	case []any:
+		settings.Tags = make([]string, 0, len(tags))
+		for _, t := range tags {
+			s, ok := t.(string)
+			if !ok {
+				return settings, fmt.Errorf("invalid tag %v, must be a string", t)
+			}
+			settings.Tags = append(settings.Tags, s)
+		}
+	default:
+		return settings, errors.New("invalid tags, must be a list or a comma-separated string")
+	}
+
+	if settings.Title == "" {
+		settings.Title = alertingTemplates.DefaultMessageTitleEmbed
+	}
+	if settings.Message == "" {
+		settings.Message = alertingTemplates.DefaultMessageEmbed
+	}
+	return settings, nil
+}
+
+// NtfyNotifier publishes alert notifications to an ntfy topic.
+type NtfyNotifier struct {
+	*receivers.Base
+	ns       receivers.WebhookSender
+	log      alertingLogging.Logger
+	tmpl     *alertingTemplates.Template
+	settings NtfyConfig
+}
+
+func NewNtfyNotifier(cfg NtfyConfig, meta receivers.Metadata, template *alertingTemplates.Template, sender receivers.WebhookSender, logger alertingLogging.Logger) *NtfyNotifier {
+	return &NtfyNotifier{
+		Base:     receivers.NewBase(meta),
+		ns:       sender,
+		log:      logger,
+		tmpl:     template,
+		settings: cfg,
+	}
+}
+
+// Notify publishes the message to the topic. The title, priority and tags are passed in headers, as expected by ntfy.
+// The link to the alert list is attached as the click action of the notification.
+func (nn *NtfyNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl := templateText(ctx, nn.tmpl, as, nn.log, &tmplErr)
+
+	title := tmpl(nn.settings.Title)
+	message, truncated := receivers.TruncateInBytes(tmpl(nn.settings.Message), ntfyMaxMessageLenBytes)
+	if truncated {
+		nn.log.Warn("Truncated ntfy message", "maxBytes", ntfyMaxMessageLenBytes)
+	}
+	if tmplErr != nil {
+		nn.log.Warn("Failed to template ntfy message", "error", tmplErr.Error())
+	}
+
+	headers := map[string]string{
+		"Title": title,
+		"Click": receivers.JoinURLPath(nn.tmpl.ExternalURL.String(), "/alerting/list", nn.log),
+	}
+	if nn.settings.Priority > 0 {
+		headers["Priority"] = strconv.Itoa(nn.settings.Priority)
+	}
+	if len(nn.settings.Tags) > 0 {
+		headers["Tags"] = strings.Join(nn.settings.Tags, ",")
+	}
+	if nn.settings.Token != "" {
+		headers["Authorization"] = "Bearer " + nn.settings.Token
+	}
+
+	cmd := &receivers.SendWebhookSettings{
+		URL:         nn.settings.URL + "/" + url.PathEscape(nn.settings.Topic),
+		User:        nn.settings.Username,
+		Password:    nn.settings.Password,
+		Body:        message,
+		HTTPMethod:  http.MethodPost,
+		HTTPHeader:  headers,
+		ContentType: "text/plain; charset=utf-8",
+	}
+	if err := nn.ns.SendWebhook(ctx, cmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (nn *NtfyNotifier) SendResolved() bool {
+	return !nn.GetDisableResolveMessage()
+}
+
+func splitCommaDelimitedString(str string) []string {
+	split := strings.Split(str, ",")
+	res := make([]string, 0, len(split))
+	for _, s := range split {
+		if s = strings.TrimSpace(s); s != "" {
+			res = append(res, s)
+		}
+	}
+	return res
+}
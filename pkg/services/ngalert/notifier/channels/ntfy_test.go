@@ -0,0 +1,152 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNtfyConfig(t *testing.T) {
+	cases := []struct {
+		name        string
+		settings    string
+		secrets     map[string]string
+		expected    NtfyConfig
+		expectedErr string
+	}{
+		{
+			name:     "minimal valid configuration",
+			settings: `{"topic": "alerts"}`,
+			expected: NtfyConfig{
+				URL:     ntfyDefaultURL,
+				Topic:   "alerts",
+				Title:   templates.DefaultMessageTitleEmbed,
+				Message: templates.DefaultMessageEmbed,
+			},
+		},
+		{
+			name:     "priority and tags as strings",
+			settings: `{"url": "https://ntfy.example.com/", "topic": "alerts", "priority": "4", "tags": "warning, skull"}`,
+			secrets:  map[string]string{"token": "token"},
+			expected: NtfyConfig{
+				URL:      "https://ntfy.example.com",
+				Topic:    "alerts",
+				Token:    "token",
+				Priority: 4,
+				Tags:     []string{"warning", "skull"},
+				Title:    templates.DefaultMessageTitleEmbed,
+				Message:  templates.DefaultMessageEmbed,
+			},
+		},
+		{
+			name:     "priority and tags as JSON types",
+			settings: `{"topic": "alerts", "username": "user", "priority": 5, "tags": ["warning"]}`,
+			secrets:  map[string]string{"password": "password"},
+			expected: NtfyConfig{
+				URL:      ntfyDefaultURL,
+				Topic:    "alerts",
+				Username: "user",
+				Password: "password",
+				Priority: 5,
+				Tags:     []string{"warning"},
+				Title:    templates.DefaultMessageTitleEmbed,
+				Message:  templates.DefaultMessageEmbed,
+			},
+		},
+		{
+			name:        "missing topic",
+			settings:    `{}`,
+			expectedErr: "could not find topic in settings",
+		},
+		{
+			name:        "token and username",
+			settings:    `{"topic": "alerts", "username": "user"}`,
+			secrets:     map[string]string{"token": "token"},
+			expectedErr: "both token and username are set",
+		},
+		{
+			name:        "password without username",
+			settings:    `{"topic": "alerts"}`,
+			secrets:     map[string]string{"password": "password"},
+			expectedErr: "password is set but username is not",
+		},
+		{
+			name:        "priority out of range",
+			settings:    `{"topic": "alerts", "priority": 6}`,
+			expectedErr: `invalid priority "6"`,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := NewNtfyConfig(json.RawMessage(c.settings), decryptFromMap(c.secrets))
+			if c.expectedErr != "" {
+				require.ErrorContains(t, err, c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestNtfyNotifier(t *testing.T) {
+	t.Run("should publish message with token", func(t *testing.T) {
+		server, requests := newRecordingServer(t, http.StatusOK)
+		cfg := NtfyConfig{
+			URL:      server.URL,
+			Topic:    "alerts",
+			Token:    "token",
+			Priority: 4,
+			Tags:     []string{"warning", "skull"},
+			Title:    "{{ len .Alerts.Firing }} firing",
+			Message:  "{{ range .Alerts }}{{ .Labels.alertname }}{{ end }}",
+		}
+		n := NewNtfyNotifier(cfg, receivers.Metadata{}, testTemplate(t), httpSender{}, &logging.FakeLogger{})
+
+		ok, err := n.Notify(context.Background(), testAlerts()...)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		req := <-requests
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, "/alerts", req.Path)
+		require.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		require.Equal(t, "1 firing", req.Header.Get("Title"))
+		require.Equal(t, "4", req.Header.Get("Priority"))
+		require.Equal(t, "warning,skull", req.Header.Get("Tags"))
+		require.Equal(t, "http://localhost/alerting/list", req.Header.Get("Click"))
+		require.Equal(t, "alert1", string(req.Body))
+	})
+
+	t.Run("should publish message with basic authentication", func(t *testing.T) {
+		server, requests := newRecordingServer(t, http.StatusOK)
+		cfg := NtfyConfig{
+			URL:      server.URL,
+			Topic:    "alerts",
+			Username: "user",
+			Password: "password",
+			Title:    "title",
+			Message:  "message",
+		}
+		n := NewNtfyNotifier(cfg, receivers.Metadata{}, testTemplate(t), httpSender{}, &logging.FakeLogger{})
+
+		ok, err := n.Notify(context.Background(), testAlerts()...)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		req := <-requests
+		user, password, ok := (&http.Request{Header: req.Header}).BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "user", user)
+		require.Equal(t, "password", password)
+		require.Empty(t, req.Header.Get("Priority"))
+		require.Empty(t, req.Header.Get("Tags"))
+	})
+}
@@ -0,0 +1,410 @@
+package channels
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	alertingLogging "github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	snmpVersion2c = "v2c"
+	snmpVersion3  = "v3"
+
+	snmpAuthMD5 = "MD5"
+	snmpAuthSHA = "SHA"
+	snmpPrivAES = "AES"
+
+	snmpDefaultPort      = "162"
+	snmpDefaultCommunity = "public"
+	// snmpDefaultTrapOID is netSnmpPlaypen from NET-SNMP-MIB, the subtree that is reserved for experiments.
+	// Users that have a MIB for Grafana alerts are expected to set their own OID.
+	snmpDefaultTrapOID = "1.3.6.1.4.1.8072.9999.9999"
+
+	// snmpMaxStringLenBytes is the maximum size of a string varbind.
+	// Traps are sent in a single UDP datagram, and receivers are not required to accept datagrams larger than 484 bytes,
+	// however, all common implementations accept datagrams of any size.
+	snmpMaxStringLenBytes = 4096
+	// snmpMaxMessageSize is the maximum size of the payload of a UDP datagram.
+	snmpMaxMessageSize = 65507
+)
+
+var (
+	snmpSysUpTimeOID  = []uint32{1, 3, 6, 1, 2, 1, 1, 3, 0}
+	snmpTrapOIDOID    = []uint32{1, 3, 6, 1, 6, 3, 1, 1, 4, 1, 0}
+	snmpNotifierStart = time.Now()
+	// snmpEngineTimeEpoch is the point in time from which the engine time of SNMPv3 messages is counted.
+	// Grafana does not persist the number of engine boots, therefore, the time must not go back after restart.
+	snmpEngineTimeEpoch = time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// SNMPConfig contains the settings of an SNMP integration that sends traps to a network management system.
+type SNMPConfig struct {
+	Address   string `json:"address,omitempty" yaml:"address,omitempty"`
+	Version   string `json:"version,omitempty" yaml:"version,omitempty"`
+	Community string `json:"community,omitempty" yaml:"community,omitempty"`
+	// Settings of the user-based security model of SNMPv3.
+	SecurityName string `json:"securityName,omitempty" yaml:"securityName,omitempty"`
+	EngineID     string `json:"engineId,omitempty" yaml:"engineId,omitempty"`
+	AuthProtocol string `json:"authProtocol,omitempty" yaml:"authProtocol,omitempty"`
+	AuthPassword string `json:"authPassword,omitempty" yaml:"authPassword,omitempty"`
+	PrivProtocol string `json:"privProtocol,omitempty" yaml:"privProtocol,omitempty"`
+	PrivPassword string `json:"privPassword,omitempty" yaml:"privPassword,omitempty"`
+	TrapOID      string `json:"trapOid,omitempty" yaml:"trapOid,omitempty"`
+	Title        string `json:"title,omitempty" yaml:"title,omitempty"`
+	Message      string `json:"message,omitempty" yaml:"message,omitempty"`
+
+	trapOID  []uint32
+	engineID []byte
+}
+
+// NewSNMPConfig is the constructor for the SNMP integration settings.
+func NewSNMPConfig(jsonData json.RawMessage, decryptFn receivers.DecryptFunc) (SNMPConfig, error) {
+	settings := SNMPConfig{}
+	if err := json.Unmarshal(jsonData, &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	if settings.Address == "" {
+		return settings, errors.New("could not find address in settings")
+	}
+	if _, _, err := net.SplitHostPort(settings.Address); err != nil {
+		settings.Address = net.JoinHostPort(settings.Address, snmpDefaultPort)
+	}
+	if _, _, err := net.SplitHostPort(settings.Address); err != nil {
+		return settings, fmt.Errorf("invalid address %q: %w", settings.Address, err)
+	}
+
+	if settings.TrapOID == "" {
+		settings.TrapOID = snmpDefaultTrapOID
+	}
+	oid, err := parseOID(settings.TrapOID)
+	if err != nil {
+		return settings, fmt.Errorf("invalid trap OID %q: %w", settings.TrapOID, err)
+	}
+	settings.trapOID = oid
+
+	switch strings.ToLower(settings.Version) {
+	case "", snmpVersion2c:
+		settings.Version = snmpVersion2c
+		settings.Community = decryptFn("community", settings.Community)
+		if settings.Community == "" {
+			settings.Community = snmpDefaultCommunity
+		}
+	case snmpVersion3:
+		settings.Version = snmpVersion3
+		if err := settings.validateUSM(decryptFn); err != nil {
+			return settings, err
+		}
+	default:
+		return settings, fmt.Errorf("invalid SNMP version %q, must be %q or %q", settings.Version, snmpVersion2c, snmpVersion3)
+	}
+
+	if settings.Title == "" {
+		settings.Title = alertingTemplates.DefaultMessageTitleEmbed
+	}
+	if settings.Message == "" {
+		settings.Message = alertingTemplates.DefaultMessageEmbed
+	}
+	return settings, nil
+}
+
+func (s *SNMPConfig) validateUSM(decryptFn receivers.DecryptFunc) error {
+	if s.SecurityName == "" {
+		return errors.New("could not find security name in settings")
+	}
+	engineID, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(s.EngineID), "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid engine ID %q, must be a hex string: %w", s.EngineID, err)
+	}
+	// RFC 3411 limits the size of the engine ID to 5-32 bytes.
+	if len(engineID) < 5 || len(engineID) > 32 {
+		return fmt.Errorf("invalid engine ID %q, must be from 5 to 32 bytes long", s.EngineID)
+	}
+	s.engineID = engineID
+
+	s.AuthPassword = decryptFn("authPassword", s.AuthPassword)
+	s.PrivPassword = decryptFn("privPassword", s.PrivPassword)
+	switch strings.ToUpper(s.AuthProtocol) {
+	case "":
+		if s.PrivProtocol != "" {
+			return errors.New("privacy protocol requires an authentication protocol")
+		}
+		return nil
+	case snmpAuthMD5, snmpAuthSHA:
+		s.AuthProtocol = strings.ToUpper(s.AuthProtocol)
+	default:
+		return fmt.Errorf("invalid authentication protocol %q, must be %q or %q", s.AuthProtocol, snmpAuthMD5, snmpAuthSHA)
+	}
+	// RFC 3414 requires passwords to be at least 8 characters long.
+	if len(s.AuthPassword) < 8 {
+		return errors.New("authentication password must be at least 8 characters long")
+	}
+	switch strings.ToUpper(s.PrivProtocol) {
+	case "":
+		return nil
+	case snmpPrivAES:
+		s.PrivProtocol = snmpPrivAES
+	default:
+		return fmt.Errorf("invalid privacy protocol %q, must be %q", s.PrivProtocol, snmpPrivAES)
+	}
+	if len(s.PrivPassword) < 8 {
+		return errors.New("privacy password must be at least 8 characters long")
+	}
+	return nil
+}
+
+// SNMPNotifier sends alert notifications as SNMPv2c or SNMPv3 traps over UDP.
+// Traps are not acknowledged by the receiver, therefore, a notification is considered successful once it is sent.
+type SNMPNotifier struct {
+	*receivers.Base
+	log      alertingLogging.Logger
+	tmpl     *alertingTemplates.Template
+	settings SNMPConfig
+	authKey  []byte
+	privKey  []byte
+}
+
+func NewSNMPNotifier(cfg SNMPConfig, meta receivers.Metadata, template *alertingTemplates.Template, logger alertingLogging.Logger) *SNMPNotifier {
+	n := &SNMPNotifier{
+		Base:     receivers.NewBase(meta),
+		log:      logger,
+		tmpl:     template,
+		settings: cfg,
+	}
+	if cfg.Version == snmpVersion3 && cfg.AuthProtocol != "" {
+		// keys are derived once because the derivation hashes a megabyte of data.
+		n.authKey = snmpLocalizeKey(cfg.AuthProtocol, cfg.AuthPassword, cfg.engineID)
+		if cfg.PrivProtocol != "" {
+			n.privKey = snmpLocalizeKey(cfg.AuthProtocol, cfg.PrivPassword, cfg.engineID)[:16]
+		}
+	}
+	return n
+}
+
+// Notify sends a trap with the title, the message and the status of the alerts. They are sent as varbinds
+// with OIDs <trapOid>.1, <trapOid>.2 and <trapOid>.3 respectively.
+func (sn *SNMPNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl := templateText(ctx, sn.tmpl, as, sn.log, &tmplErr)
+
+	title, truncated := receivers.TruncateInBytes(tmpl(sn.settings.Title), snmpMaxStringLenBytes)
+	if truncated {
+		sn.log.Warn("Truncated SNMP trap title", "maxBytes", snmpMaxStringLenBytes)
+	}
+	message, truncated := receivers.TruncateInBytes(tmpl(sn.settings.Message), snmpMaxStringLenBytes)
+	if truncated {
+		sn.log.Warn("Truncated SNMP trap message", "maxBytes", snmpMaxStringLenBytes)
+	}
+	if tmplErr != nil {
+		sn.log.Warn("Failed to template SNMP trap", "error", tmplErr.Error())
+	}
+
+	pdu, err := sn.buildTrapPDU(title, message, string(types.Alerts(as...).Status()))
+	if err != nil {
+		return false, err
+	}
+	var packet []byte
+	if sn.settings.Version == snmpVersion3 {
+		packet, err = sn.buildV3Message(pdu)
+	} else {
+		packet = berSequence(berTagSequence,
+			berInteger(berTagInteger, 1), // version-2c(1)
+			berOctetString([]byte(sn.settings.Community)),
+			pdu,
+		)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", sn.settings.Address)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to SNMP receiver: %w", err)
+	}
+	defer func() {
+		if err := conn.Close(); err != nil {
+			sn.log.Warn("Failed to close connection", "error", err)
+		}
+	}()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return false, err
+		}
+	}
+	if _, err := conn.Write(packet); err != nil {
+		return false, fmt.Errorf("failed to send SNMP trap: %w", err)
+	}
+	return true, nil
+}
+
+func (sn *SNMPNotifier) SendResolved() bool {
+	return !sn.GetDisableResolveMessage()
+}
+
+func (sn *SNMPNotifier) buildTrapPDU(title, message, status string) ([]byte, error) {
+	requestID, err := snmpRandomInt32()
+	if err != nil {
+		return nil, err
+	}
+	oid := sn.settings.trapOID
+	varbind := func(oid []uint32, value []byte) []byte {
+		return berSequence(berTagSequence, berOID(oid), value)
+	}
+	uptime := time.Since(snmpNotifierStart) / (10 * time.Millisecond)
+	return berSequence(berTagTrapV2PDU,
+		berInteger(berTagInteger, int64(requestID)),
+		berInteger(berTagInteger, 0), // error-status
+		berInteger(berTagInteger, 0), // error-index
+		berSequence(berTagSequence,
+			varbind(snmpSysUpTimeOID, berUnsigned(berTagTimeTicks, uint32(uptime))),
+			varbind(snmpTrapOIDOID, berOID(oid)),
+			varbind(append(oid[:len(oid):len(oid)], 1), berOctetString([]byte(title))),
+			varbind(append(oid[:len(oid):len(oid)], 2), berOctetString([]byte(message))),
+			varbind(append(oid[:len(oid):len(oid)], 3), berOctetString([]byte(status))),
+		),
+	), nil
+}
+
+// buildV3Message wraps the PDU into an SNMPv3 message secured according to the user-based security model (RFC 3414).
+// Grafana is the authoritative engine of the traps it sends, therefore, the engine ID is the one from the settings.
+func (sn *SNMPNotifier) buildV3Message(pdu []byte) ([]byte, error) {
+	msgID, err := snmpRandomInt32()
+	if err != nil {
+		return nil, err
+	}
+	engineBoots := int64(1)
+	engineTime := int64(time.Since(snmpEngineTimeEpoch) / time.Second)
+
+	var flags byte
+	authParams, privParams := []byte{}, []byte{}
+	if sn.authKey != nil {
+		flags |= 0x01
+		authParams = make([]byte, 12)
+	}
+
+	scopedPDU := berSequence(berTagSequence,
+		berOctetString(sn.settings.engineID), // contextEngineID
+		berOctetString(nil),                  // contextName
+		pdu,
+	)
+	msgData := scopedPDU
+	if sn.privKey != nil {
+		flags |= 0x02
+		privParams = make([]byte, 8)
+		if _, err := rand.Read(privParams); err != nil {
+			return nil, err
+		}
+		iv := make([]byte, 0, aes.BlockSize)
+		iv = binary.BigEndian.AppendUint32(iv, uint32(engineBoots))
+		iv = binary.BigEndian.AppendUint32(iv, uint32(engineTime))
+		iv = append(iv, privParams...)
+		encrypted, err := snmpEncryptAESCFB(sn.privKey, iv, scopedPDU)
+		if err != nil {
+			return nil, err
+		}
+		msgData = berOctetString(encrypted)
+	}
+
+	usmPrefix := concat(
+		berOctetString(sn.settings.engineID),
+		berInteger(berTagInteger, engineBoots),
+		berInteger(berTagInteger, engineTime),
+		berOctetString([]byte(sn.settings.SecurityName)),
+	)
+	usmSuffix := concat(
+		berOctetString(authParams),
+		berOctetString(privParams),
+	)
+	usm := berTLV(berTagSequence, concat(usmPrefix, usmSuffix))
+
+	header := concat(
+		berInteger(berTagInteger, 3), // msgVersion
+		berSequence(berTagSequence,
+			berInteger(berTagInteger, int64(msgID)),
+			berInteger(berTagInteger, snmpMaxMessageSize),
+			berOctetString([]byte{flags}),
+			berInteger(berTagInteger, 3), // msgSecurityModel: USM
+		),
+	)
+	securityParams := berOctetString(usm)
+	content := concat(header, securityParams, msgData)
+	message := berTLV(berTagSequence, content)
+
+	if sn.authKey != nil {
+		// the authentication parameters are calculated over the whole message with the parameters set to zeros.
+		// Find their position by the size of everything that precedes them.
+		offset := len(message) - len(content) + len(header)                   // the tag and the length of the message, and the header
+		offset += len(securityParams) - len(usm)                              // the tag and the length of the security parameters
+		offset += len(usm) - len(usmPrefix) - len(usmSuffix) + len(usmPrefix) // the sequence of USM up to the authentication parameters
+		offset += 2                                                           // the tag and the length of the authentication parameters
+		mac := hmac.New(snmpHashFunc(sn.settings.AuthProtocol), sn.authKey)
+		mac.Write(message)
+		copy(message[offset:offset+12], mac.Sum(nil)[:12])
+	}
+	return message, nil
+}
+
+func snmpHashFunc(protocol string) func() hash.Hash {
+	if protocol == snmpAuthMD5 {
+		return md5.New
+	}
+	return sha1.New
+}
+
+// snmpLocalizeKey derives the key from the password and localizes it to the engine as described in RFC 3414 A.2.
+func snmpLocalizeKey(protocol, password string, engineID []byte) []byte {
+	h := snmpHashFunc(protocol)()
+	const total = 1 << 20
+	buf := make([]byte, 64)
+	for written := 0; written < total; written += len(buf) {
+		for i := range buf {
+			buf[i] = password[(written+i)%len(password)]
+		}
+		h.Write(buf)
+	}
+	key := h.Sum(nil)
+
+	h.Reset()
+	h.Write(key)
+	h.Write(engineID)
+	h.Write(key)
+	return h.Sum(nil)
+}
+
+// snmpEncryptAESCFB encrypts the data with AES in 128-bit cipher feedback mode as required by RFC 3826.
+func snmpEncryptAESCFB(key, iv, data []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, len(data))
+	cipher.NewCFBEncrypter(block, iv).XORKeyStream(out, data)
+	return out, nil
+}
+
+func snmpRandomInt32() (int32, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return 0, err
+	}
+	return int32(binary.BigEndian.Uint32(b) & math.MaxInt32), nil
+}
@@ -0,0 +1,102 @@
+package channels
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Tags of the BER types that are used in SNMP messages.
+const (
+	berTagInteger     byte = 0x02
+	berTagOctetString byte = 0x04
+	berTagOID         byte = 0x06
+	berTagSequence    byte = 0x30
+	berTagTimeTicks   byte = 0x43
+	berTagTrapV2PDU   byte = 0xA7
+)
+
+// berTLV encodes the value with the tag and the definite length.
+func berTLV(tag byte, value []byte) []byte {
+	header := append([]byte{tag}, berLength(len(value))...)
+	return append(header, value...)
+}
+
+func berLength(l int) []byte {
+	if l < 0x80 {
+		return []byte{byte(l)}
+	}
+	var b []byte
+	for ; l > 0; l >>= 8 {
+		b = append([]byte{byte(l)}, b...)
+	}
+	return append([]byte{0x80 | byte(len(b))}, b...)
+}
+
+func berSequence(tag byte, elements ...[]byte) []byte {
+	return berTLV(tag, concat(elements...))
+}
+
+// berInteger encodes the integer in the minimal number of bytes of two's complement.
+func berInteger(tag byte, v int64) []byte {
+	b := []byte{byte(v)}
+	for v > 0x7f || v < -0x80 {
+		v >>= 8
+		b = append([]byte{byte(v)}, b...)
+	}
+	return berTLV(tag, b)
+}
+
+// berUnsigned encodes unsigned 32-bit values such as TimeTicks and Counter32.
+func berUnsigned(tag byte, v uint32) []byte {
+	return berInteger(tag, int64(v))
+}
+
+func berOctetString(v []byte) []byte {
+	return berTLV(berTagOctetString, v)
+}
+
+func berOID(oid []uint32) []byte {
+	value := base128(oid[0]*40 + oid[1])
+	for _, arc := range oid[2:] {
+		value = append(value, base128(arc)...)
+	}
+	return berTLV(berTagOID, value)
+}
+
+func base128(v uint32) []byte {
+	b := []byte{byte(v & 0x7f)}
+	for v >>= 7; v > 0; v >>= 7 {
+		b = append([]byte{byte(v&0x7f) | 0x80}, b...)
+	}
+	return b
+}
+
+// parseOID parses the object identifier in dotted notation, for example "1.3.6.1.4.1".
+func parseOID(s string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimPrefix(s, "."), ".")
+	if len(parts) < 2 {
+		return nil, errors.New("object identifier must have at least two arcs")
+	}
+	oid := make([]uint32, 0, len(parts))
+	for _, p := range parts {
+		arc, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid arc %q", p)
+		}
+		oid = append(oid, uint32(arc))
+	}
+	if oid[0] > 2 || (oid[0] < 2 && oid[1] > 39) {
+		return nil, errors.New("invalid first two arcs")
+	}
+	return oid, nil
+}
+
+func concat(parts ...[]byte) []byte {
+	var result []byte
+	for _, p := range parts {
+		result = append(result, p...)
+	}
+	return result
+}
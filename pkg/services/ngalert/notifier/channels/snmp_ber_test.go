@@ -0,0 +1,48 @@
+package channels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBerEncoding(t *testing.T) {
+	t.Run("length", func(t *testing.T) {
+		require.Equal(t, []byte{0x7f}, berLength(127))
+		require.Equal(t, []byte{0x81, 0x80}, berLength(128))
+		require.Equal(t, []byte{0x82, 0x01, 0x00}, berLength(256))
+	})
+
+	t.Run("integer", func(t *testing.T) {
+		require.Equal(t, []byte{0x02, 0x01, 0x00}, berInteger(berTagInteger, 0))
+		require.Equal(t, []byte{0x02, 0x01, 0x7f}, berInteger(berTagInteger, 127))
+		require.Equal(t, []byte{0x02, 0x02, 0x00, 0x80}, berInteger(berTagInteger, 128))
+		require.Equal(t, []byte{0x02, 0x01, 0xff}, berInteger(berTagInteger, -1))
+		require.Equal(t, []byte{0x02, 0x02, 0xff, 0x7f}, berInteger(berTagInteger, -129))
+		require.Equal(t, []byte{0x43, 0x05, 0x00, 0xff, 0xff, 0xff, 0xff}, berUnsigned(berTagTimeTicks, 0xffffffff))
+	})
+
+	t.Run("object identifier", func(t *testing.T) {
+		require.Equal(t, []byte{0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x03, 0x00}, berOID(snmpSysUpTimeOID))
+		require.Equal(t, []byte{0x06, 0x03, 0x2b, 0x86, 0x48}, berOID([]uint32{1, 3, 840}))
+	})
+
+	t.Run("sequence", func(t *testing.T) {
+		require.Equal(t, []byte{0x30, 0x05, 0x02, 0x01, 0x01, 0x04, 0x00}, berSequence(berTagSequence, berInteger(berTagInteger, 1), berOctetString(nil)))
+	})
+}
+
+func TestParseOID(t *testing.T) {
+	oid, err := parseOID("1.3.6.1.4.1.8072.9999.9999")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 3, 6, 1, 4, 1, 8072, 9999, 9999}, oid)
+
+	oid, err = parseOID(".1.3.6")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1, 3, 6}, oid)
+
+	for _, invalid := range []string{"", "1", "1.3.a", "1..3", "3.1", "1.40", "1.3.4294967296"} {
+		_, err := parseOID(invalid)
+		require.Errorf(t, err, "OID %q", invalid)
+	}
+}
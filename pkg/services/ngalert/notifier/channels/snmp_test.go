@@ -0,0 +1,280 @@
+package channels
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSNMPConfig(t *testing.T) {
+	cases := []struct {
+		name        string
+		settings    string
+		secrets     map[string]string
+		expected    SNMPConfig
+		expectedErr string
+	}{
+		{
+			name:     "minimal valid configuration",
+			settings: `{"address": "snmp.example.com"}`,
+			expected: SNMPConfig{
+				Address:   "snmp.example.com:162",
+				Version:   snmpVersion2c,
+				Community: snmpDefaultCommunity,
+				TrapOID:   snmpDefaultTrapOID,
+				Title:     templates.DefaultMessageTitleEmbed,
+				Message:   templates.DefaultMessageEmbed,
+				trapOID:   []uint32{1, 3, 6, 1, 4, 1, 8072, 9999, 9999},
+			},
+		},
+		{
+			name:     "v3 with authentication and privacy",
+			settings: `{"address": "[::1]:1162", "version": "v3", "securityName": "grafana", "engineId": "0x8000000001020304", "authProtocol": "sha", "privProtocol": "aes", "trapOid": "1.3.6.1.4.1.1"}`,
+			secrets:  map[string]string{"authPassword": "auth-password", "privPassword": "priv-password"},
+			expected: SNMPConfig{
+				Address:      "[::1]:1162",
+				Version:      snmpVersion3,
+				SecurityName: "grafana",
+				EngineID:     "0x8000000001020304",
+				AuthProtocol: snmpAuthSHA,
+				AuthPassword: "auth-password",
+				PrivProtocol: snmpPrivAES,
+				PrivPassword: "priv-password",
+				TrapOID:      "1.3.6.1.4.1.1",
+				Title:        templates.DefaultMessageTitleEmbed,
+				Message:      templates.DefaultMessageEmbed,
+				trapOID:      []uint32{1, 3, 6, 1, 4, 1, 1},
+				engineID:     []byte{0x80, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04},
+			},
+		},
+		{
+			name:        "missing address",
+			settings:    `{}`,
+			expectedErr: "could not find address in settings",
+		},
+		{
+			name:        "invalid version",
+			settings:    `{"address": "snmp.example.com", "version": "v1"}`,
+			expectedErr: `invalid SNMP version "v1"`,
+		},
+		{
+			name:        "invalid trap OID",
+			settings:    `{"address": "snmp.example.com", "trapOid": "enterprises.1"}`,
+			expectedErr: `invalid trap OID "enterprises.1"`,
+		},
+		{
+			name:        "v3 without security name",
+			settings:    `{"address": "snmp.example.com", "version": "v3", "engineId": "8000000001020304"}`,
+			expectedErr: "could not find security name in settings",
+		},
+		{
+			name:        "v3 with short engine ID",
+			settings:    `{"address": "snmp.example.com", "version": "v3", "securityName": "grafana", "engineId": "0102"}`,
+			expectedErr: "must be from 5 to 32 bytes long",
+		},
+		{
+			name:        "v3 with privacy but without authentication",
+			settings:    `{"address": "snmp.example.com", "version": "v3", "securityName": "grafana", "engineId": "8000000001020304", "privProtocol": "AES"}`,
+			secrets:     map[string]string{"privPassword": "priv-password"},
+			expectedErr: "privacy protocol requires an authentication protocol",
+		},
+		{
+			name:        "v3 with short authentication password",
+			settings:    `{"address": "snmp.example.com", "version": "v3", "securityName": "grafana", "engineId": "8000000001020304", "authProtocol": "MD5"}`,
+			secrets:     map[string]string{"authPassword": "short"},
+			expectedErr: "authentication password must be at least 8 characters long",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := NewSNMPConfig(json.RawMessage(c.settings), decryptFromMap(c.secrets))
+			if c.expectedErr != "" {
+				require.ErrorContains(t, err, c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestSNMPNotifier(t *testing.T) {
+	t.Run("should send v2c trap", func(t *testing.T) {
+		conn, address := listenUDP(t)
+		cfg, err := NewSNMPConfig(json.RawMessage(`{"address": "`+address+`", "community": "secret", "title": "{{ len .Alerts.Firing }} firing", "message": "{{ range .Alerts }}{{ .Labels.alertname }}{{ end }}"}`), noDecrypt)
+		require.NoError(t, err)
+		n := NewSNMPNotifier(cfg, receivers.Metadata{}, testTemplate(t), &logging.FakeLogger{})
+
+		ok, err := n.Notify(context.Background(), testAlerts()...)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		message := readBerSequence(t, berTagSequence, receiveUDP(t, conn))
+		require.Len(t, message, 3)
+		require.Equal(t, []byte{0x01}, message[0].value)
+		require.Equal(t, "secret", string(message[1].value))
+		requireTrapPDU(t, message[2])
+	})
+
+	t.Run("should send v3 trap with authentication and privacy", func(t *testing.T) {
+		conn, address := listenUDP(t)
+		cfg, err := NewSNMPConfig(json.RawMessage(`{"address": "`+address+`", "version": "v3", "securityName": "grafana", "engineId": "8000000001020304", "authProtocol": "SHA", "privProtocol": "AES", "title": "{{ len .Alerts.Firing }} firing", "message": "{{ range .Alerts }}{{ .Labels.alertname }}{{ end }}"}`),
+			decryptFromMap(map[string]string{"authPassword": "auth-password", "privPassword": "priv-password"}))
+		require.NoError(t, err)
+		n := NewSNMPNotifier(cfg, receivers.Metadata{}, testTemplate(t), &logging.FakeLogger{})
+
+		ok, err := n.Notify(context.Background(), testAlerts()...)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		packet := receiveUDP(t, conn)
+		message := readBerSequence(t, berTagSequence, packet)
+		require.Len(t, message, 4)
+		require.Equal(t, []byte{0x03}, message[0].value)
+		globalData := readBerSequence(t, berTagSequence, message[1].raw)
+		require.Equal(t, []byte{0x03}, globalData[2].value, "flags must be authPriv and not reportable")
+		require.Equal(t, []byte{0x03}, globalData[3].value, "security model must be USM")
+
+		usm := readBerSequence(t, berTagSequence, message[2].value)
+		require.Len(t, usm, 6)
+		engineID := []byte{0x80, 0x00, 0x00, 0x00, 0x01, 0x02, 0x03, 0x04}
+		require.Equal(t, engineID, usm[0].value)
+		require.Equal(t, "grafana", string(usm[3].value))
+		authParams, privParams := usm[4].value, usm[5].value
+		require.Len(t, authParams, 12)
+		require.Len(t, privParams, 8)
+
+		// verify the message as a trap receiver would.
+		authKey := snmpLocalizeKey(snmpAuthSHA, "auth-password", engineID)
+		zeroed := bytes.Replace(packet, authParams, make([]byte, 12), 1)
+		mac := hmac.New(snmpHashFunc(snmpAuthSHA), authKey)
+		mac.Write(zeroed)
+		require.Equal(t, mac.Sum(nil)[:12], authParams)
+
+		privKey := snmpLocalizeKey(snmpAuthSHA, "priv-password", engineID)[:16]
+		iv := make([]byte, 0, aes.BlockSize)
+		iv = binary.BigEndian.AppendUint32(iv, uint32(readBerInteger(usm[1].value)))
+		iv = binary.BigEndian.AppendUint32(iv, uint32(readBerInteger(usm[2].value)))
+		iv = append(iv, privParams...)
+		block, err := aes.NewCipher(privKey)
+		require.NoError(t, err)
+		scopedPDU := make([]byte, len(message[3].value))
+		cipher.NewCFBDecrypter(block, iv).XORKeyStream(scopedPDU, message[3].value)
+
+		scoped := readBerSequence(t, berTagSequence, scopedPDU)
+		require.Len(t, scoped, 3)
+		require.Equal(t, engineID, scoped[0].value)
+		requireTrapPDU(t, scoped[2])
+	})
+}
+
+func TestSNMPLocalizeKey(t *testing.T) {
+	// test vectors from RFC 3414 A.3.
+	engineID := []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}
+	require.Equal(t, "526f5eed9fcce26f8964c2930787d82b", hex.EncodeToString(snmpLocalizeKey(snmpAuthMD5, "maplesyrup", engineID)))
+	require.Equal(t, "6695febc9288e36282235fc7151f128497b38f3f", hex.EncodeToString(snmpLocalizeKey(snmpAuthSHA, "maplesyrup", engineID)))
+}
+
+func requireTrapPDU(t *testing.T, pdu berElement) {
+	t.Helper()
+	require.Equal(t, berTagTrapV2PDU, pdu.tag)
+	fields := readBerSequence(t, berTagTrapV2PDU, pdu.raw)
+	require.Len(t, fields, 4)
+	varbinds := readBerSequence(t, berTagSequence, fields[3].raw)
+	require.Len(t, varbinds, 5)
+
+	values := make(map[string]string, len(varbinds))
+	for _, vb := range varbinds {
+		elements := readBerSequence(t, berTagSequence, vb.raw)
+		require.Len(t, elements, 2)
+		values[string(elements[0].raw)] = string(elements[1].raw)
+	}
+	oid := []uint32{1, 3, 6, 1, 4, 1, 8072, 9999, 9999}
+	require.Contains(t, values, string(berOID(snmpSysUpTimeOID)))
+	require.Equal(t, string(berOID(oid)), values[string(berOID(snmpTrapOIDOID))])
+	require.Equal(t, string(berOctetString([]byte("1 firing"))), values[string(berOID(append(oid, 1)))])
+	require.Equal(t, string(berOctetString([]byte("alert1"))), values[string(berOID(append(oid, 2)))])
+	require.Equal(t, string(berOctetString([]byte("firing"))), values[string(berOID(append(oid, 3)))])
+}
+
+type berElement struct {
+	tag   byte
+	value []byte
+	// raw is the whole encoded element.
+	raw []byte
+}
+
+// readBerSequence decodes the elements of the sequence. Only lengths of up to 2 bytes are supported.
+func readBerSequence(t *testing.T, tag byte, data []byte) []berElement {
+	t.Helper()
+	seq, rest := readBerElement(t, data)
+	require.Empty(t, rest)
+	require.Equal(t, tag, seq.tag)
+	var elements []berElement
+	for content := seq.value; len(content) > 0; {
+		var e berElement
+		e, content = readBerElement(t, content)
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+func readBerElement(t *testing.T, data []byte) (berElement, []byte) {
+	t.Helper()
+	require.GreaterOrEqual(t, len(data), 2)
+	length, header := int(data[1]), 2
+	switch data[1] {
+	case 0x81:
+		length, header = int(data[2]), 3
+	case 0x82:
+		length, header = int(binary.BigEndian.Uint16(data[2:4])), 4
+	}
+	require.GreaterOrEqual(t, len(data), header+length)
+	return berElement{
+		tag:   data[0],
+		value: data[header : header+length],
+		raw:   data[:header+length],
+	}, data[header+length:]
+}
+
+func readBerInteger(value []byte) int64 {
+	var v int64
+	if len(value) > 0 && value[0]&0x80 != 0 {
+		v = -1
+	}
+	for _, b := range value {
+		v = v<<8 | int64(b)
+	}
+	return v
+}
+
+func listenUDP(t *testing.T) (net.PacketConn, string) {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn, conn.LocalAddr().String()
+}
+
+func receiveUDP(t *testing.T, conn net.PacketConn) []byte {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, snmpMaxMessageSize)
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	return buf[:n]
+}
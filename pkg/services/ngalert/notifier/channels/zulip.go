@@ -0,0 +1,126 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	alertingLogging "github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/types"
+)
+
+const (
+	// zulipMaxTopicLenRunes is the maximum length of a topic name in Zulip.
+	zulipMaxTopicLenRunes = 60
+	// zulipMaxMessageLenBytes is the maximum size of a message in Zulip.
+	zulipMaxMessageLenBytes = 10000
+)
+
+// ZulipConfig contains the settings of a Zulip integration that sends messages to a topic of a stream on behalf of a bot.
+type ZulipConfig struct {
+	URL      string `json:"url,omitempty" yaml:"url,omitempty"`
+	BotEmail string `json:"botEmail,omitempty" yaml:"botEmail,omitempty"`
+	APIKey   string `json:"apiKey,omitempty" yaml:"apiKey,omitempty"`
+	Stream   string `json:"stream,omitempty" yaml:"stream,omitempty"`
+	Topic    string `json:"topic,omitempty" yaml:"topic,omitempty"`
+	Message  string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// NewZulipConfig is the constructor for the Zulip integration settings.
+func NewZulipConfig(jsonData json.RawMessage, decryptFn receivers.DecryptFunc) (ZulipConfig, error) {
+	settings := ZulipConfig{}
+	if err := json.Unmarshal(jsonData, &settings); err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	if settings.URL == "" {
+		return settings, errors.New("could not find Zulip URL in settings")
+	}
+	u, err := url.Parse(settings.URL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return settings, fmt.Errorf("invalid Zulip URL %q", settings.URL)
+	}
+	settings.URL = strings.TrimSuffix(u.String(), "/")
+	if settings.BotEmail == "" {
+		return settings, errors.New("could not find bot email in settings")
+	}
+	settings.APIKey = decryptFn("apiKey", settings.APIKey)
+	if settings.APIKey == "" {
+		return settings, errors.New("could not find API key in settings")
+	}
+	if settings.Stream == "" {
+		return settings, errors.New("could not find stream in settings")
+	}
+	if settings.Topic == "" {
+		settings.Topic = alertingTemplates.DefaultMessageTitleEmbed
+	}
+	if settings.Message == "" {
+		settings.Message = alertingTemplates.DefaultMessageEmbed
+	}
+	return settings, nil
+}
+
+// ZulipNotifier sends alert notifications as messages to a Zulip stream.
+type ZulipNotifier struct {
+	*receivers.Base
+	ns       receivers.WebhookSender
+	log      alertingLogging.Logger
+	tmpl     *alertingTemplates.Template
+	settings ZulipConfig
+}
+
+func NewZulipNotifier(cfg ZulipConfig, meta receivers.Metadata, template *alertingTemplates.Template, sender receivers.WebhookSender, logger alertingLogging.Logger) *ZulipNotifier {
+	return &ZulipNotifier{
+		Base:     receivers.NewBase(meta),
+		ns:       sender,
+		log:      logger,
+		tmpl:     template,
+		settings: cfg,
+	}
+}
+
+// Notify sends the message to the topic of the stream. The topic is created by Zulip if it does not exist.
+func (zn *ZulipNotifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl := templateText(ctx, zn.tmpl, as, zn.log, &tmplErr)
+
+	topic, truncated := receivers.TruncateInRunes(tmpl(zn.settings.Topic), zulipMaxTopicLenRunes)
+	if truncated {
+		zn.log.Warn("Truncated Zulip topic", "maxRunes", zulipMaxTopicLenRunes)
+	}
+	message, truncated := receivers.TruncateInBytes(tmpl(zn.settings.Message), zulipMaxMessageLenBytes)
+	if truncated {
+		zn.log.Warn("Truncated Zulip message", "maxBytes", zulipMaxMessageLenBytes)
+	}
+	if tmplErr != nil {
+		zn.log.Warn("Failed to template Zulip message", "error", tmplErr.Error())
+	}
+
+	form := url.Values{}
+	form.Set("type", "stream")
+	form.Set("to", zn.settings.Stream)
+	form.Set("topic", topic)
+	form.Set("content", message)
+
+	cmd := &receivers.SendWebhookSettings{
+		URL:         zn.settings.URL + "/api/v1/messages",
+		User:        zn.settings.BotEmail,
+		Password:    zn.settings.APIKey,
+		Body:        form.Encode(),
+		HTTPMethod:  http.MethodPost,
+		ContentType: "application/x-www-form-urlencoded",
+	}
+	if err := zn.ns.SendWebhook(ctx, cmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (zn *ZulipNotifier) SendResolved() bool {
+	return !zn.GetDisableResolveMessage()
+}
@@ -0,0 +1,101 @@
+package channels
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewZulipConfig(t *testing.T) {
+	cases := []struct {
+		name        string
+		settings    string
+		secrets     map[string]string
+		expected    ZulipConfig
+		expectedErr string
+	}{
+		{
+			name:     "minimal valid configuration",
+			settings: `{"url": "https://zulip.example.com", "botEmail": "bot@example.com", "stream": "alerts"}`,
+			secrets:  map[string]string{"apiKey": "key"},
+			expected: ZulipConfig{
+				URL:      "https://zulip.example.com",
+				BotEmail: "bot@example.com",
+				APIKey:   "key",
+				Stream:   "alerts",
+				Topic:    templates.DefaultMessageTitleEmbed,
+				Message:  templates.DefaultMessageEmbed,
+			},
+		},
+		{
+			name:        "missing bot email",
+			settings:    `{"url": "https://zulip.example.com", "stream": "alerts"}`,
+			secrets:     map[string]string{"apiKey": "key"},
+			expectedErr: "could not find bot email in settings",
+		},
+		{
+			name:        "missing API key",
+			settings:    `{"url": "https://zulip.example.com", "botEmail": "bot@example.com", "stream": "alerts"}`,
+			expectedErr: "could not find API key in settings",
+		},
+		{
+			name:        "missing stream",
+			settings:    `{"url": "https://zulip.example.com", "botEmail": "bot@example.com"}`,
+			secrets:     map[string]string{"apiKey": "key"},
+			expectedErr: "could not find stream in settings",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := NewZulipConfig(json.RawMessage(c.settings), decryptFromMap(c.secrets))
+			if c.expectedErr != "" {
+				require.ErrorContains(t, err, c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, actual)
+		})
+	}
+}
+
+func TestZulipNotifier(t *testing.T) {
+	server, requests := newRecordingServer(t, http.StatusOK)
+	cfg := ZulipConfig{
+		URL:      server.URL,
+		BotEmail: "bot@example.com",
+		APIKey:   "key",
+		Stream:   "alerts",
+		Topic:    strings.Repeat("t", 100),
+		Message:  "{{ range .Alerts }}{{ .Labels.alertname }}{{ end }}",
+	}
+	n := NewZulipNotifier(cfg, receivers.Metadata{}, testTemplate(t), httpSender{}, &logging.FakeLogger{})
+
+	ok, err := n.Notify(context.Background(), testAlerts()...)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	req := <-requests
+	require.Equal(t, http.MethodPost, req.Method)
+	require.Equal(t, "/api/v1/messages", req.Path)
+	require.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+	user, password, ok := (&http.Request{Header: req.Header}).BasicAuth()
+	require.True(t, ok)
+	require.Equal(t, "bot@example.com", user)
+	require.Equal(t, "key", password)
+
+	form, err := url.ParseQuery(string(req.Body))
+	require.NoError(t, err)
+	require.Equal(t, "stream", form.Get("type"))
+	require.Equal(t, "alerts", form.Get("to"))
+	require.Equal(t, strings.Repeat("t", zulipMaxTopicLenRunes-1)+"…", form.Get("topic"))
+	require.Equal(t, "alert1", form.Get("content"))
+}
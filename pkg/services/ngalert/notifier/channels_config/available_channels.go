@@ -1339,6 +1339,422 @@ func GetAvailableNotifiers() []*NotifierPlugin {
 				},
 			},
 		},
+		{
+			Type:        "matrix",
+			Name:        "Matrix",
+			Description: "Sends notifications to a Matrix room",
+			Heading:     "Matrix settings",
+			Info:        "The user of the access token must be a member of the room",
+			Options: []NotifierOption{
+				{
+					Label:        "Homeserver URL",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "https://matrix.example.com",
+					PropertyName: "homeserverUrl",
+					Required:     true,
+				},
+				{
+					Label:        "Room ID",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "!abcdefghijklmnop:example.com",
+					PropertyName: "roomId",
+					Required:     true,
+				},
+				{
+					Label:        "Access Token",
+					Description:  "Access token of the user that sends messages to the room.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "accessToken",
+					Required:     true,
+					Secure:       true,
+				},
+				{
+					Label:   "Message Type",
+					Element: ElementTypeSelect,
+					SelectOptions: []SelectOption{
+						{
+							Value: "m.text",
+							Label: "Text",
+						},
+						{
+							Value: "m.notice",
+							Label: "Notice",
+						},
+					},
+					Description:  "Notices are not expected to be answered, and bots do not react to them.",
+					PropertyName: "messageType",
+				},
+				{
+					Label:        "Title",
+					Description:  "Templated title of the message.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  alertingTemplates.DefaultMessageTitleEmbed,
+					PropertyName: "title",
+				},
+				{
+					Label:        "Message",
+					Description:  "Custom message. You can use template variables.",
+					Element:      ElementTypeTextArea,
+					Placeholder:  alertingTemplates.DefaultMessageEmbed,
+					PropertyName: "message",
+				},
+			},
+		},
+		{
+			Type:        "mattermost",
+			Name:        "Mattermost",
+			Description: "Sends notifications to a Mattermost channel using a bot account",
+			Heading:     "Mattermost settings",
+			Info:        "The bot must be a member of the channel",
+			Options: []NotifierOption{
+				{
+					Label:        "Mattermost URL",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "https://mattermost.example.com",
+					PropertyName: "url",
+					Required:     true,
+				},
+				{
+					Label:        "Channel ID",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "channelId",
+					Required:     true,
+				},
+				{
+					Label:        "Bot Token",
+					Description:  "Access token of the bot account that creates posts.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "botToken",
+					Required:     true,
+					Secure:       true,
+				},
+				{
+					Label:        "Title",
+					Description:  "Templated title of the message.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  alertingTemplates.DefaultMessageTitleEmbed,
+					PropertyName: "title",
+				},
+				{
+					Label:        "Message",
+					Description:  "Custom message. You can use template variables. Markdown is supported.",
+					Element:      ElementTypeTextArea,
+					Placeholder:  alertingTemplates.DefaultMessageEmbed,
+					PropertyName: "message",
+				},
+			},
+		},
+		{
+			Type:        "zulip",
+			Name:        "Zulip",
+			Description: "Sends notifications to a Zulip stream",
+			Heading:     "Zulip settings",
+			Options: []NotifierOption{
+				{
+					Label:        "Zulip URL",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "https://example.zulipchat.com",
+					PropertyName: "url",
+					Required:     true,
+				},
+				{
+					Label:        "Bot Email",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "alerts-bot@example.zulipchat.com",
+					PropertyName: "botEmail",
+					Required:     true,
+				},
+				{
+					Label:        "API Key",
+					Description:  "API key of the bot.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "apiKey",
+					Required:     true,
+					Secure:       true,
+				},
+				{
+					Label:        "Stream",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "stream",
+					Required:     true,
+				},
+				{
+					Label:        "Topic",
+					Description:  "Templated topic of the message. The topic is created if it does not exist.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  alertingTemplates.DefaultMessageTitleEmbed,
+					PropertyName: "topic",
+				},
+				{
+					Label:        "Message",
+					Description:  "Custom message. You can use template variables. Markdown is supported.",
+					Element:      ElementTypeTextArea,
+					Placeholder:  alertingTemplates.DefaultMessageEmbed,
+					PropertyName: "message",
+				},
+			},
+		},
+		{
+			Type:        "ntfy",
+			Name:        "ntfy",
+			Description: "Sends push notifications to an ntfy topic",
+			Heading:     "ntfy settings",
+			Options: []NotifierOption{
+				{
+					Label:        "Server URL",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "https://ntfy.sh",
+					PropertyName: "url",
+				},
+				{
+					Label:        "Topic",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "topic",
+					Required:     true,
+				},
+				{
+					Label:        "Access Token",
+					Description:  "Access token of the user that publishes messages. Only one of access token or username and password can be set.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "token",
+					Secure:       true,
+				},
+				{
+					Label:        "Username",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "username",
+				},
+				{
+					Label:        "Password",
+					Element:      ElementTypeInput,
+					InputType:    InputTypePassword,
+					PropertyName: "password",
+					Secure:       true,
+				},
+				{
+					Label:   "Priority",
+					Element: ElementTypeSelect,
+					SelectOptions: []SelectOption{
+						{
+							Value: "1",
+							Label: "Min",
+						},
+						{
+							Value: "2",
+							Label: "Low",
+						},
+						{
+							Value: "3",
+							Label: "Default",
+						},
+						{
+							Value: "4",
+							Label: "High",
+						},
+						{
+							Value: "5",
+							Label: "Max",
+						},
+					},
+					PropertyName: "priority",
+				},
+				{
+					Label:        "Tags",
+					Description:  "Tags or emoji shortcodes that are shown with the notification.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "comma-separated list",
+					PropertyName: "tags",
+				},
+				{
+					Label:        "Title",
+					Description:  "Templated title of the notification.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  alertingTemplates.DefaultMessageTitleEmbed,
+					PropertyName: "title",
+				},
+				{
+					Label:        "Message",
+					Description:  "Custom message. You can use template variables.",
+					Element:      ElementTypeTextArea,
+					Placeholder:  alertingTemplates.DefaultMessageEmbed,
+					PropertyName: "message",
+				},
+			},
+		},
+		{
+			Type:        "snmp",
+			Name:        "SNMP",
+			Description: "Sends SNMP traps to a network management system",
+			Heading:     "SNMP settings",
+			Info:        "The title, the message and the status of the alerts are sent as varbinds <Trap OID>.1, <Trap OID>.2 and <Trap OID>.3",
+			Options: []NotifierOption{
+				{
+					Label:        "Address",
+					Description:  "Host and port of the trap receiver. Default port is 162.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "snmp.example.com:162",
+					PropertyName: "address",
+					Required:     true,
+				},
+				{
+					Label:   "Version",
+					Element: ElementTypeSelect,
+					SelectOptions: []SelectOption{
+						{
+							Value: "v2c",
+							Label: "v2c",
+						},
+						{
+							Value: "v3",
+							Label: "v3",
+						},
+					},
+					PropertyName: "version",
+				},
+				{
+					Label:        "Community",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "public",
+					PropertyName: "community",
+					Secure:       true,
+					ShowWhen: ShowWhen{
+						Field: "version",
+						Is:    "v2c",
+					},
+				},
+				{
+					Label:        "Security Name",
+					Description:  "Name of the USM user.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "securityName",
+					ShowWhen: ShowWhen{
+						Field: "version",
+						Is:    "v3",
+					},
+				},
+				{
+					Label:        "Engine ID",
+					Description:  "Hex-encoded ID of the engine that sends traps. The trap receiver must know the user by this engine ID.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "0x8000000001020304",
+					PropertyName: "engineId",
+					ShowWhen: ShowWhen{
+						Field: "version",
+						Is:    "v3",
+					},
+				},
+				{
+					Label:   "Authentication Protocol",
+					Element: ElementTypeSelect,
+					SelectOptions: []SelectOption{
+						{
+							Value: "",
+							Label: "None",
+						},
+						{
+							Value: "MD5",
+							Label: "MD5",
+						},
+						{
+							Value: "SHA",
+							Label: "SHA",
+						},
+					},
+					PropertyName: "authProtocol",
+					ShowWhen: ShowWhen{
+						Field: "version",
+						Is:    "v3",
+					},
+				},
+				{
+					Label:        "Authentication Password",
+					Element:      ElementTypeInput,
+					InputType:    InputTypePassword,
+					PropertyName: "authPassword",
+					Secure:       true,
+					ShowWhen: ShowWhen{
+						Field: "version",
+						Is:    "v3",
+					},
+				},
+				{
+					Label:   "Privacy Protocol",
+					Element: ElementTypeSelect,
+					SelectOptions: []SelectOption{
+						{
+							Value: "",
+							Label: "None",
+						},
+						{
+							Value: "AES",
+							Label: "AES",
+						},
+					},
+					PropertyName: "privProtocol",
+					ShowWhen: ShowWhen{
+						Field: "version",
+						Is:    "v3",
+					},
+				},
+				{
+					Label:        "Privacy Password",
+					Element:      ElementTypeInput,
+					InputType:    InputTypePassword,
+					PropertyName: "privPassword",
+					Secure:       true,
+					ShowWhen: ShowWhen{
+						Field: "version",
+						Is:    "v3",
+					},
+				},
+				{
+					Label:        "Trap OID",
+					Description:  "Object identifier of the trap.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  "1.3.6.1.4.1.8072.9999.9999",
+					PropertyName: "trapOid",
+				},
+				{
+					Label:        "Title",
+					Description:  "Templated title of the trap.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					Placeholder:  alertingTemplates.DefaultMessageTitleEmbed,
+					PropertyName: "title",
+				},
+				{
+					Label:        "Message",
+					Description:  "Custom message. You can use template variables.",
+					Element:      ElementTypeTextArea,
+					Placeholder:  alertingTemplates.DefaultMessageEmbed,
+					PropertyName: "message",
+				},
+			},
+		},
 	}
 }
 
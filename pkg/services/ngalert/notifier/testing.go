@@ -19,6 +19,20 @@ type fakeConfigStore struct {
 
 	// historicConfigs stores configs by orgID.
 	historicConfigs map[int64][]*models.HistoricAlertConfiguration
+
+	// notificationSettings stores the notification settings of alert rules by orgID.
+	notificationSettings map[int64]map[models.AlertRuleKey]models.NotificationSettings
+
+	// ruleDependencies stores the dependencies of alert rules by orgID.
+	ruleDependencies map[int64]map[models.AlertRuleKey][]string
+}
+
+func (f *fakeConfigStore) ListNotificationSettings(_ context.Context, q models.ListNotificationSettingsQuery) (map[models.AlertRuleKey]models.NotificationSettings, error) {
+	return f.notificationSettings[q.OrgID], nil
+}
+
+func (f *fakeConfigStore) ListRuleDependencies(_ context.Context, q models.ListRuleDependenciesQuery) (map[models.AlertRuleKey][]string, error) {
+	return f.ruleDependencies[q.OrgID], nil
 }
 
 // Saves the image or returns an error.
@@ -538,6 +538,26 @@ func TestCreateAlertRule(t *testing.T) {
 		require.Equal(t, models.ProvenanceAPI, provenance)
 	})
 
+	t.Run("should record file provisioning as the author", func(t *testing.T) {
+		rule, err := ruleService.CreateAlertRule(context.Background(), dummyRule("test#4", orgID), models.ProvenanceFile, 0)
+		require.NoError(t, err)
+
+		stored, _, err := ruleService.GetAlertRule(context.Background(), orgID, rule.UID)
+		require.NoError(t, err)
+		require.Equal(t, models.FileProvisioningAuthor, stored.UpdatedBy)
+	})
+
+	t.Run("should keep the author set by the caller", func(t *testing.T) {
+		r := dummyRule("test#5", orgID)
+		r.UpdatedBy = "admin"
+		rule, err := ruleService.CreateAlertRule(context.Background(), r, models.ProvenanceAPI, 0)
+		require.NoError(t, err)
+
+		stored, _, err := ruleService.GetAlertRule(context.Background(), orgID, rule.UID)
+		require.NoError(t, err)
+		require.Equal(t, "admin", stored.UpdatedBy)
+	})
+
 	t.Run("when UID is specified", func(t *testing.T) {
 		t.Run("return error if it is not valid UID", func(t *testing.T) {
 			rule := dummyRule("test#3", orgID)
@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels"
 	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels_config"
 	"github.com/grafana/grafana/pkg/services/secrets"
 	"github.com/grafana/grafana/pkg/services/user"
@@ -520,11 +521,16 @@ func ValidateContactPoint(ctx context.Context, e apimodels.EmbeddedContactPoint,
 	if err != nil {
 		return err
 	}
-	_, err = alertingNotify.BuildReceiverConfiguration(ctx, &alertingNotify.APIReceiver{
+	upstream, own := channels.SplitReceiver(&alertingNotify.APIReceiver{
 		GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
 			Integrations: []*alertingNotify.GrafanaIntegrationConfig{&integration},
 		},
-	}, decryptFunc)
+	})
+	_, err = alertingNotify.BuildReceiverConfiguration(ctx, upstream, decryptFunc)
+	if err != nil {
+		return err
+	}
+	_, err = channels.BuildReceiverConfiguration(ctx, own, decryptFunc)
 	if err != nil {
 		return err
 	}
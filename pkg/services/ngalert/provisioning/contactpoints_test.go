@@ -119,6 +119,24 @@ func TestContactPointService(t *testing.T) {
 		require.ErrorIs(t, err, ErrValidation)
 	})
 
+	t.Run("create validates contact points implemented in Grafana", func(t *testing.T) {
+		sut := createContactPointServiceSut(t, secretsService)
+		settings, _ := simplejson.NewJson([]byte(`{"homeserverUrl":"https://matrix.example.com","roomId":"!room:example.com","accessToken":"token"}`))
+		newCp := definitions.EmbeddedContactPoint{
+			Name:     "matrix-contact-point",
+			Type:     "matrix",
+			Settings: settings,
+		}
+
+		created, err := sut.CreateContactPoint(context.Background(), 1, newCp, models.ProvenanceAPI)
+		require.NoError(t, err)
+		require.Equal(t, definitions.RedactedValue, created.Settings.Get("accessToken").MustString())
+
+		newCp.Settings, _ = simplejson.NewJson([]byte(`{"homeserverUrl":"https://matrix.example.com","accessToken":"token"}`))
+		_, err = sut.CreateContactPoint(context.Background(), 1, newCp, models.ProvenanceAPI)
+		require.ErrorIs(t, err, ErrValidation)
+	})
+
 	t.Run("update rejects contact points with no settings", func(t *testing.T) {
 		sut := createContactPointServiceSut(t, secretsService)
 		newCp := createTestContactPoint()
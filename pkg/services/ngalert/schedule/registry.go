@@ -329,6 +329,14 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 	writeLabels(rule.Labels)
 	writeString(rule.Condition)
 	writeQuery()
+	writeString(rule.Record.Metric)
+	writeString(rule.Record.From)
+	if rule.HasNotificationSettings() {
+		writeString(rule.NotificationSettings.Fingerprint())
+	}
+	for _, uid := range rule.DependsOn {
+		writeString(uid)
+	}
 
 	if rule.IsPaused {
 		writeInt(1)
@@ -342,6 +350,9 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 	writeInt(rule.OrgID)
 	writeInt(rule.IntervalSeconds)
 	writeInt(int64(rule.For))
+	writeInt(int64(rule.KeepFiringFor))
+	writeInt(int64(rule.FlapDetection.Threshold))
+	writeInt(int64(rule.FlapDetection.Window))
 	writeLabels(rule.Annotations)
 	if rule.DashboardUID != nil {
 		writeString(*rule.DashboardUID)
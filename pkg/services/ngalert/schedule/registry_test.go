@@ -408,6 +408,8 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			NoDataState:     "test-nodata",
 			ExecErrState:    "test-err",
 			For:             12,
+			KeepFiringFor:   13,
+			FlapDetection:   models.FlapDetection{Threshold: 3, Window: time.Hour},
 			Annotations: map[string]string{
 				"key-annotation": "value-annotation",
 			},
@@ -415,6 +417,14 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 				"key-label": "value-label",
 			},
 			IsPaused: false,
+			Record: models.Record{
+				Metric: "test_metric",
+				From:   "A",
+			},
+			NotificationSettings: models.NotificationSettings{
+				Receiver: "receiver",
+			},
+			DependsOn: []string{"dependency-uid"},
 		}
 		r2 := &models.AlertRule{
 			ID:        2,
@@ -443,6 +453,8 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			NoDataState:     "test-nodata2",
 			ExecErrState:    "test-err2",
 			For:             1141,
+			KeepFiringFor:   1142,
+			FlapDetection:   models.FlapDetection{Threshold: 5, Window: 2 * time.Hour},
 			Annotations: map[string]string{
 				"key-annotation2": "value-annotation",
 			},
@@ -450,11 +462,20 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 				"key-label": "value-label23",
 			},
 			IsPaused: true,
+			Record: models.Record{
+				Metric: "test_metric_2",
+				From:   "B",
+			},
+			NotificationSettings: models.NotificationSettings{
+				Receiver: "receiver-2",
+			},
+			DependsOn: []string{"dependency-uid-2"},
 		}
 
 		excludedFields := map[string]struct{}{
-			"Version": {},
-			"Updated": {},
+			"Version":   {},
+			"Updated":   {},
+			"UpdatedBy": {},
 		}
 
 		tp := reflect.TypeOf(rule).Elem()
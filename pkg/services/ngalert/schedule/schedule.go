@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
@@ -21,6 +24,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/ngalert/writer"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/util/ticker"
@@ -91,6 +95,8 @@ type schedule struct {
 	schedulableAlertRules alertRulesRegistry
 
 	tracer tracing.Tracer
+
+	recordingWriter writer.Writer
 }
 
 // SchedulerCfg is the scheduler configuration.
@@ -107,6 +113,8 @@ type SchedulerCfg struct {
 	AlertSender          AlertsSender
 	Tracer               tracing.Tracer
 	Log                  log.Logger
+	// RecordingWriter writes the results of recording rules.
+	RecordingWriter writer.Writer
 }
 
 // NewScheduler returns a new schedule.
@@ -127,6 +135,7 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		schedulableAlertRules: alertRulesRegistry{rules: make(map[ngmodels.AlertRuleKey]*ngmodels.AlertRule)},
 		alertsSender:          cfg.AlertSender,
 		tracer:                cfg.Tracer,
+		recordingWriter:       cfg.RecordingWriter,
 	}
 
 	return &sch
@@ -432,6 +441,17 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key ngmodels.AlertR
 
 		start = sch.clock.Now()
 		alerts := state.FromStateTransitionToPostableAlerts(processedStates, sch.stateManager, sch.appURL)
+		if inhibitedBy := state.GetFiringDependencies(sch.stateManager, e.rule); len(inhibitedBy) > 0 {
+			// the alerts are still sent so that the Alertmanager keeps track of them, it suppresses their notifications
+			// with the inhibit rules generated from the dependencies of the rule.
+			logger.Debug("Marking alerts as inhibited because rules the rule depends on are firing", "inhibited_by", inhibitedBy)
+			span.AddEvent("alerts inhibited", trace.WithAttributes(
+				attribute.StringSlice("inhibited_by", inhibitedBy),
+			))
+			for i := range alerts.PostableAlerts {
+				alerts.PostableAlerts[i].Annotations[ngmodels.InhibitedByAnnotation] = strings.Join(inhibitedBy, ",")
+			}
+		}
 		span.AddEvent("results processed", trace.WithAttributes(
 			attribute.Int64("state_transitions", int64(len(processedStates))),
 			attribute.Int64("alerts_to_send", int64(len(alerts.PostableAlerts))),
@@ -442,6 +462,46 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key ngmodels.AlertR
 		sendDuration.Observe(sch.clock.Now().Sub(start).Seconds())
 	}
 
+	record := func(ctx context.Context, attempt int64, e *evaluation, span trace.Span) {
+		logger := logger.New("version", e.rule.Version, "attempt", attempt, "now", e.scheduledAt, "metric", e.rule.Record.Metric).FromContext(ctx)
+		start := sch.clock.Now()
+
+		evalCtx := eval.NewContext(ctx, SchedulerUserFor(e.rule.OrgID))
+		ruleEval, err := sch.evaluatorFactory.Create(evalCtx, e.rule.GetEvalCondition())
+		var frames data.Frames
+		if err == nil {
+			var resp *backend.QueryDataResponse
+			resp, err = ruleEval.EvaluateRaw(ctx, e.scheduledAt)
+			if err == nil {
+				frames, err = recordedFrames(resp, e.rule.Record.From)
+			}
+		}
+		dur := sch.clock.Now().Sub(start)
+
+		evalTotal.Inc()
+		evalDuration.Observe(dur.Seconds())
+
+		if err == nil {
+			if ctx.Err() != nil { // the evaluation can be a long-running task
+				logger.Debug("Skip writing the results because the context has been cancelled")
+				return
+			}
+			err = sch.recordingWriter.Write(ctx, e.rule.Record.Metric, e.scheduledAt, frames, e.rule.Labels)
+		}
+		if err != nil {
+			evalTotalFailures.Inc()
+			logger.Error("Failed to record rule", "error", err, "duration", dur)
+			span.SetStatus(codes.Error, "rule recording failed")
+			span.RecordError(err)
+			return
+		}
+
+		logger.Debug("Recording rule evaluated", "frames", len(frames), "duration", dur)
+		span.AddEvent("rule recorded", trace.WithAttributes(
+			attribute.Int64("frames", int64(len(frames))),
+		))
+	}
+
 	retryIfError := func(f func(attempt int64) error) error {
 		var attempt int64
 		var err error
@@ -520,6 +580,10 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key ngmodels.AlertR
 					))
 					defer span.End()
 
+					if ctx.rule.IsRecordingRule() {
+						record(tracingCtx, attempt, ctx, span)
+						return nil
+					}
 					evaluate(tracingCtx, f, attempt, ctx, span)
 					return nil
 				})
@@ -544,6 +608,18 @@ func (sch *schedule) ruleRoutine(grafanaCtx context.Context, key ngmodels.AlertR
 	}
 }
 
+// recordedFrames returns the frames of the query or expression recorded by a recording rule.
+func recordedFrames(resp *backend.QueryDataResponse, refID string) (data.Frames, error) {
+	dr, ok := resp.Responses[refID]
+	if !ok {
+		return nil, fmt.Errorf("no results for query or expression %s", refID)
+	}
+	if dr.Error != nil {
+		return nil, dr.Error
+	}
+	return dr.Frames, nil
+}
+
 // evalApplied is only used on tests.
 func (sch *schedule) evalApplied(alertDefKey ngmodels.AlertRuleKey, now time.Time) {
 	if sch.evalAppliedFunc == nil {
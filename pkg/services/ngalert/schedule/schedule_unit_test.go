@@ -788,6 +788,47 @@ func TestSchedule_ruleRoutine(t *testing.T) {
 
 			require.Len(t, args.PostableAlerts, 1)
 		})
+
+		t.Run("it should mark alerts as inhibited if a rule it depends on is firing", func(t *testing.T) {
+			rule := models.AlertRuleGen(withQueryForState(t, eval.Alerting))()
+			dependency := models.AlertRuleGen(models.WithOrgID(rule.OrgID))()
+			rule.DependsOn = []string{dependency.UID}
+
+			evalChan := make(chan *evaluation)
+			evalAppliedChan := make(chan time.Time)
+
+			sender := AlertsSenderMock{}
+			sender.EXPECT().Send(mock.Anything, rule.GetKey(), mock.Anything).Return()
+
+			sch, ruleStore, _, _ := createSchedule(evalAppliedChan, &sender)
+			ruleStore.PutRule(context.Background(), rule)
+			sch.stateManager.Put([]*state.State{{
+				OrgID:        dependency.OrgID,
+				AlertRuleUID: dependency.UID,
+				CacheID:      "test",
+				State:        eval.Alerting,
+			}})
+
+			go func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				t.Cleanup(cancel)
+				_ = sch.ruleRoutine(ctx, rule.GetKey(), evalChan, make(chan ruleVersionAndPauseStatus))
+			}()
+
+			evalChan <- &evaluation{
+				scheduledAt: sch.clock.Now(),
+				rule:        rule,
+			}
+
+			waitForTimeChannel(t, evalAppliedChan)
+
+			sender.AssertNumberOfCalls(t, "Send", 1)
+			args, ok := sender.Calls[0].Arguments[2].(definitions.PostableAlerts)
+			require.Truef(t, ok, fmt.Sprintf("expected argument of function was supposed to be 'definitions.PostableAlerts' but got %T", sender.Calls[0].Arguments[2]))
+
+			require.Len(t, args.PostableAlerts, 1)
+			require.Equal(t, dependency.UID, args.PostableAlerts[0].Annotations[models.InhibitedByAnnotation])
+		})
 	})
 
 	t.Run("when there are no alerts to send it should not call notifiers", func(t *testing.T) {
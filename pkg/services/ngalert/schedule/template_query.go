@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/template"
+)
+
+const (
+	templateQueryRefID = "A"
+	// templateQueryLookback is the relative time range of template queries. Datasources that
+	// support instant queries only use the end of it.
+	templateQueryLookback = 10 * time.Minute
+	// templateQueryTimeout is the maximum duration of a template query, so that slow datasources
+	// do not hold up the evaluation of the alert rule.
+	templateQueryTimeout = 10 * time.Second
+)
+
+// NewTemplateQuery returns a function that runs the instant queries of templates with the
+// same permissions as the scheduler. The state manager only passes it the datasources of the
+// alert rule being evaluated. The query is sent to the datasource as its expr, so only
+// Prometheus-compatible datasources such as Prometheus and Loki are supported.
+func NewTemplateQuery(factory eval.EvaluatorFactory) state.TemplateQueryFunc {
+	return func(ctx context.Context, orgID int64, datasourceUID, query string, ts time.Time) ([]template.Value, error) {
+		ctx, cancel := context.WithTimeout(ctx, templateQueryTimeout)
+		defer cancel()
+		model, err := json.Marshal(map[string]any{
+			"refId":   templateQueryRefID,
+			"expr":    query,
+			"instant": true,
+			"range":   false,
+		})
+		if err != nil {
+			return nil, err
+		}
+		condition := models.Condition{
+			Condition: templateQueryRefID,
+			Data: []models.AlertQuery{{
+				RefID:             templateQueryRefID,
+				DatasourceUID:     datasourceUID,
+				Model:             model,
+				RelativeTimeRange: models.RelativeTimeRange{From: models.Duration(templateQueryLookback)},
+			}},
+		}
+
+		evaluator, err := factory.Create(eval.NewContext(ctx, SchedulerUserFor(orgID)), condition)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build query: %w", err)
+		}
+		resp, err := evaluator.EvaluateRaw(ctx, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to execute query: %w", err)
+		}
+		return templateQueryValues(resp)
+	}
+}
+
+// templateQueryValues returns the labels and the last value of each numeric field in the response.
+func templateQueryValues(resp *backend.QueryDataResponse) ([]template.Value, error) {
+	res, ok := resp.Responses[templateQueryRefID]
+	if !ok {
+		return nil, nil
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	var values []template.Value
+	for _, frame := range res.Frames {
+		for _, field := range frame.Fields {
+			if !field.Type().Numeric() {
+				continue
+			}
+			for i := field.Len() - 1; i >= 0; i-- {
+				v, err := field.NullableFloatAt(i)
+				if err != nil {
+					return nil, err
+				}
+				if v != nil {
+					values = append(values, template.Value{Labels: template.Labels(field.Labels), Value: *v})
+					break
+				}
+			}
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,82 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval/eval_mocks"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/template"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type recordingEvaluatorFactory struct {
+	eval.EvaluatorFactory
+	ctx       eval.EvaluationContext
+	condition models.Condition
+}
+
+func (f *recordingEvaluatorFactory) Create(ctx eval.EvaluationContext, condition models.Condition) (eval.ConditionEvaluator, error) {
+	f.ctx, f.condition = ctx, condition
+	return f.EvaluatorFactory.Create(ctx, condition)
+}
+
+func TestTemplateQuery(t *testing.T) {
+	ts := time.Now()
+
+	t.Run("should return the last value of each series", func(t *testing.T) {
+		evaluator := eval_mocks.NewConditionEvaluatorMock(t)
+		hasDeadline := mock.MatchedBy(func(ctx context.Context) bool {
+			_, ok := ctx.Deadline()
+			return ok
+		})
+		evaluator.EXPECT().EvaluateRaw(hasDeadline, ts).Return(&backend.QueryDataResponse{
+			Responses: backend.Responses{
+				"A": {Frames: data.Frames{
+					data.NewFrame("",
+						data.NewField("time", nil, []time.Time{ts, ts}),
+						data.NewField("value", data.Labels{"instance": "a"}, []*float64{util.Pointer(1.0), nil}),
+						data.NewField("value", data.Labels{"instance": "b"}, []int64{2, 3}),
+					),
+				}},
+			},
+		}, nil)
+		factory := &recordingEvaluatorFactory{EvaluatorFactory: eval_mocks.NewEvaluatorFactory(evaluator)}
+
+		values, err := NewTemplateQuery(factory)(context.Background(), 2, "prometheus", "up", ts)
+		require.NoError(t, err)
+		require.Equal(t, []template.Value{
+			{Labels: template.Labels{"instance": "a"}, Value: 1},
+			{Labels: template.Labels{"instance": "b"}, Value: 3},
+		}, values)
+
+		require.Equal(t, int64(2), factory.ctx.User.OrgID)
+		require.Equal(t, "A", factory.condition.Condition)
+		require.Len(t, factory.condition.Data, 1)
+		require.Equal(t, "prometheus", factory.condition.Data[0].DatasourceUID)
+		require.JSONEq(t, `{"refId": "A", "expr": "up", "instant": true, "range": false}`, string(factory.condition.Data[0].Model))
+	})
+
+	t.Run("should return the error of the query", func(t *testing.T) {
+		evaluator := eval_mocks.NewConditionEvaluatorMock(t)
+		evaluator.EXPECT().EvaluateRaw(mock.Anything, ts).Return(&backend.QueryDataResponse{
+			Responses: backend.Responses{"A": {Error: errors.New("bad query")}},
+		}, nil)
+
+		_, err := NewTemplateQuery(eval_mocks.NewEvaluatorFactory(evaluator))(context.Background(), 1, "prometheus", "up{", ts)
+		require.EqualError(t, err, "bad query")
+	})
+
+	t.Run("should return an error if the query cannot be built", func(t *testing.T) {
+		_, err := NewTemplateQuery(eval_mocks.NewFailingEvaluatorFactory(errors.New("unknown datasource")))(context.Background(), 1, "unknown", "up", ts)
+		require.EqualError(t, err, "failed to build query: unknown datasource")
+	})
+}
@@ -0,0 +1,107 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+// notifierInstances sends alerts to the Grafana instances that run the notifier, when this instance
+// only runs the alert rule scheduler. Alertmanager does not share alerts between the members of a cluster,
+// so the alerts are sent to every instance and the cluster deduplicates the notifications.
+type notifierInstances struct {
+	logger log.Logger
+	client *http.Client
+	urls   []string
+	token  string
+}
+
+func newNotifierInstances(urls []string, token string) *notifierInstances {
+	return &notifierInstances{
+		logger: log.New("ngalert.sender.notifier-instances"),
+		client: &http.Client{Timeout: defaultTimeout},
+		urls:   urls,
+		token:  token,
+	}
+}
+
+// Send posts the alerts of the organization to all notifier instances. It returns an error only if
+// none of the instances accepted the alerts.
+func (n *notifierInstances) Send(ctx context.Context, orgID int64, alerts definitions.PostableAlerts) error {
+	b, err := json.Marshal(alerts.PostableAlerts)
+	if err != nil {
+		return fmt.Errorf("failed to encode alerts: %w", err)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		mtx     sync.Mutex
+		success int
+		lastErr error
+	)
+	for _, u := range n.urls {
+		wg.Add(1)
+		go func(u string) {
+			defer wg.Done()
+			err := n.sendOne(ctx, u, orgID, b)
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				n.logger.Error("Failed to send alerts to the notifier instance", "url", redactedURL(u), "org", orgID, "count", len(alerts.PostableAlerts), "error", err)
+				lastErr = err
+				return
+			}
+			success++
+		}(u)
+	}
+	wg.Wait()
+
+	if success == 0 {
+		return fmt.Errorf("no notifier instance accepted the alerts: %w", lastErr)
+	}
+	return nil
+}
+
+func (n *notifierInstances) sendOne(ctx context.Context, u string, orgID int64, b []byte) error {
+	alertsURL, err := url.JoinPath(u, definitions.NotifierAlertsPath(orgID))
+	if err != nil {
+		return fmt.Errorf("invalid notifier URL: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, alertsURL, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Content-Type", contentTypeJSON)
+	req.Header.Set(definitions.NotifierTokenHeader, n.token)
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("bad response status %s", resp.Status)
+	}
+	return nil
+}
+
+func redactedURL(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	return parsed.Redacted()
+}
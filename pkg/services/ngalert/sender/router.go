@@ -41,6 +41,9 @@ type AlertsRouter struct {
 
 	multiOrgNotifier *notifier.MultiOrgAlertmanager
 
+	// notifierInstances is set when this instance only runs the alert rule scheduler.
+	notifierInstances *notifierInstances
+
 	appURL                  *url.URL
 	disabledOrgs            map[int64]struct{}
 	adminConfigPollInterval time.Duration
@@ -49,9 +52,11 @@ type AlertsRouter struct {
 	secretService     secrets.Service
 }
 
+// NewAlertsRouter returns an AlertsRouter. If notifierURLs is not empty, the alerts that would be handled
+// by the internal Alertmanager are sent to each of the URLs instead, authenticated with notifierToken.
 func NewAlertsRouter(multiOrgNotifier *notifier.MultiOrgAlertmanager, store store.AdminConfigurationStore,
 	clk clock.Clock, appURL *url.URL, disabledOrgs map[int64]struct{}, configPollInterval time.Duration,
-	datasourceService datasources.DataSourceService, secretService secrets.Service) *AlertsRouter {
+	datasourceService datasources.DataSourceService, secretService secrets.Service, notifierURLs []string, notifierToken string) *AlertsRouter {
 	d := &AlertsRouter{
 		logger:           log.New("ngalert.sender.router"),
 		clock:            clk,
@@ -71,6 +76,9 @@ func NewAlertsRouter(multiOrgNotifier *notifier.MultiOrgAlertmanager, store stor
 		datasourceService: datasourceService,
 		secretService:     secretService,
 	}
+	if len(notifierURLs) > 0 {
+		d.notifierInstances = newNotifierInstances(notifierURLs, notifierToken)
+	}
 	return d
 }
 
@@ -299,6 +307,13 @@ func (d *AlertsRouter) Send(ctx context.Context, key models.AlertRuleKey, alerts
 	var localNotifierExist, externalNotifierExist bool
 	if d.sendAlertsTo[key.OrgID] == models.ExternalAlertmanagers && len(d.AlertmanagersFor(key.OrgID)) > 0 {
 		logger.Debug("All alerts for the given org should be routed to external notifiers only. skipping the internal notifier.")
+	} else if d.notifierInstances != nil {
+		// This instance does not run the notifier, the instances that run it handle the alerts instead.
+		logger.Info("Sending alerts to the notifier instances", "count", len(alerts.PostableAlerts))
+		localNotifierExist = true
+		if err := d.notifierInstances.Send(ctx, key.OrgID, alerts); err != nil {
+			logger.Error("Failed to send alerts to the notifier instances", "count", len(alerts.PostableAlerts), "error", err)
+		}
 	} else {
 		logger.Info("Sending alerts to local notifier", "count", len(alerts.PostableAlerts))
 		n, err := d.multiOrgNotifier.AlertmanagerFor(key.OrgID)
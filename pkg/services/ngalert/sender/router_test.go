@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync"
 	"testing"
 	"time"
 
@@ -63,7 +66,7 @@ func TestIntegrationSendingToExternalAlertmanager(t *testing.T) {
 		}),
 	}
 	alertsRouter := NewAlertsRouter(moa, fakeAdminConfigStore, mockedClock, appUrl, map[int64]struct{}{}, 10*time.Minute,
-		&fake_ds.FakeDataSourceService{DataSources: []*datasources.DataSource{&ds1}}, fake_secrets.NewFakeSecretsService())
+		&fake_ds.FakeDataSourceService{DataSources: []*datasources.DataSource{&ds1}}, fake_secrets.NewFakeSecretsService(), nil, "")
 
 	mockedGetAdminConfigurations.Return([]*models.AdminConfiguration{
 		{OrgID: ruleKey.OrgID, SendAlertsTo: models.AllAlertmanagers},
@@ -134,7 +137,7 @@ func TestIntegrationSendingToExternalAlertmanager_WithMultipleOrgs(t *testing.T)
 	}
 	fakeDs := &fake_ds.FakeDataSourceService{DataSources: []*datasources.DataSource{&ds1}}
 	alertsRouter := NewAlertsRouter(moa, fakeAdminConfigStore, mockedClock, appUrl, map[int64]struct{}{}, 10*time.Minute,
-		fakeDs, fake_secrets.NewFakeSecretsService())
+		fakeDs, fake_secrets.NewFakeSecretsService(), nil, "")
 
 	mockedGetAdminConfigurations.Return([]*models.AdminConfiguration{
 		{OrgID: ruleKey1.OrgID, SendAlertsTo: models.AllAlertmanagers},
@@ -293,7 +296,7 @@ func TestChangingAlertmanagersChoice(t *testing.T) {
 		}),
 	}
 	alertsRouter := NewAlertsRouter(moa, fakeAdminConfigStore, mockedClock, appUrl, map[int64]struct{}{},
-		10*time.Minute, &fake_ds.FakeDataSourceService{DataSources: []*datasources.DataSource{&ds}}, fake_secrets.NewFakeSecretsService())
+		10*time.Minute, &fake_ds.FakeDataSourceService{DataSources: []*datasources.DataSource{&ds}}, fake_secrets.NewFakeSecretsService(), nil, "")
 
 	mockedGetAdminConfigurations.Return([]*models.AdminConfiguration{
 		{OrgID: ruleKey.OrgID, SendAlertsTo: models.AllAlertmanagers},
@@ -356,6 +359,45 @@ func TestChangingAlertmanagersChoice(t *testing.T) {
 	require.Len(t, actualAlerts, len(expected))
 }
 
+func TestSendingToNotifierInstances(t *testing.T) {
+	ruleKey := models.GenerateRuleKey(2)
+	mockedClock := clock.NewMock()
+
+	type request struct {
+		path  string
+		token string
+	}
+	var (
+		mtx      sync.Mutex
+		requests = map[string][]request{}
+	)
+	newNotifier := func(name string) *httptest.Server {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			requests[name] = append(requests[name], request{path: r.URL.Path, token: r.Header.Get(definitions.NotifierTokenHeader)})
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(srv.Close)
+		return srv
+	}
+	notifier1, notifier2 := newNotifier("notifier-1"), newNotifier("notifier-2")
+
+	// The instance does not run the notifier, so it has no multi-org Alertmanager.
+	alertsRouter := NewAlertsRouter(nil, &store.AdminConfigurationStoreMock{}, mockedClock, nil, map[int64]struct{}{}, 10*time.Minute,
+		&fake_ds.FakeDataSourceService{}, fake_secrets.NewFakeSecretsService(), []string{notifier1.URL, notifier2.URL + "/grafana/"}, "secret")
+
+	alerts := definitions.PostableAlerts{PostableAlerts: []models2.PostableAlert{generatePostableAlert(t, mockedClock)}}
+	alertsRouter.Send(context.Background(), ruleKey, alerts)
+
+	mtx.Lock()
+	defer mtx.Unlock()
+	require.Len(t, requests["notifier-1"], 1)
+	require.Len(t, requests["notifier-2"], 1)
+	require.Equal(t, request{path: "/api/alerting/notifier/orgs/2/api/v2/alerts", token: "secret"}, requests["notifier-1"][0])
+	require.Equal(t, request{path: "/grafana/api/alerting/notifier/orgs/2/api/v2/alerts", token: "secret"}, requests["notifier-2"][0])
+}
+
 func assertAlertmanagersStatusForOrg(t *testing.T, alertsRouter *AlertsRouter, orgID int64, active, dropped int) {
 	t.Helper()
 	require.Eventuallyf(t, func() bool {
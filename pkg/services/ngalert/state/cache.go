@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"net/url"
 	"strings"
@@ -72,11 +73,11 @@ func (c *cache) countAlertsBy(state eval.State) float64 {
 	return count
 }
 
-func (c *cache) getOrCreate(ctx context.Context, log log.Logger, alertRule *ngModels.AlertRule, result eval.Result, extraLabels data.Labels, externalURL *url.URL) *State {
+func (c *cache) getOrCreate(ctx context.Context, log log.Logger, alertRule *ngModels.AlertRule, result eval.Result, extraLabels data.Labels, externalURL *url.URL, query template.QueryFunc) *State {
 	// Calculation of state ID involves label and annotation expansion, which may be resource intensive operations, and doing it in the context guarded by mtxStates may create a lot of contention.
 	// Instead of just calculating ID we create an entire state - a candidate. If rule states already hold a state with this ID, this candidate will be discarded and the existing one will be returned.
 	// Otherwise, this candidate will be added to the rule states and returned.
-	stateCandidate := calculateState(ctx, log, alertRule, result, extraLabels, externalURL)
+	stateCandidate := calculateState(ctx, log, alertRule, result, extraLabels, externalURL, query)
 
 	c.mtxStates.Lock()
 	defer c.mtxStates.Unlock()
@@ -120,15 +121,15 @@ func (rs *ruleStates) getOrAdd(stateCandidate State) *State {
 	return state
 }
 
-func calculateState(ctx context.Context, log log.Logger, alertRule *ngModels.AlertRule, result eval.Result, extraLabels data.Labels, externalURL *url.URL) State {
+func calculateState(ctx context.Context, log log.Logger, alertRule *ngModels.AlertRule, result eval.Result, extraLabels data.Labels, externalURL *url.URL, query template.QueryFunc) State {
 	// Merge both the extra labels and the labels from the evaluation into a common set
 	// of labels that can be expanded in custom labels and annotations.
 	templateData := template.NewData(mergeLabels(extraLabels, result.Instance), result)
 
 	// For now, do nothing with these errors as they are already logged in expand.
 	// In the future, we want to show these errors to the user somehow.
-	labels, _ := expand(ctx, log, alertRule.Title, alertRule.Labels, templateData, externalURL, result.EvaluatedAt)
-	annotations, _ := expand(ctx, log, alertRule.Title, alertRule.Annotations, templateData, externalURL, result.EvaluatedAt)
+	labels, _ := expand(ctx, log, alertRule.Title, alertRule.Labels, templateData, externalURL, result.EvaluatedAt, query)
+	annotations, _ := expand(ctx, log, alertRule.Title, alertRule.Annotations, templateData, externalURL, result.EvaluatedAt, query)
 
 	values := make(map[string]float64)
 	for refID, v := range result.Values {
@@ -194,17 +195,62 @@ func calculateState(ctx context.Context, log log.Logger, alertRule *ngModels.Ale
 	return newState
 }
 
+// ruleTemplateQuery returns the function that runs the queries of the templates of the alert rule during one evaluation.
+// The queries can only use the datasources of the alert rule, which the user that saved the rule is allowed to query,
+// and each distinct query is run once no matter how many alert instances expand it.
+func ruleTemplateQuery(query TemplateQueryFunc, alertRule *ngModels.AlertRule) template.QueryFunc {
+	if query == nil {
+		return nil
+	}
+	type queryKey struct {
+		datasourceUID string
+		query         string
+	}
+	type queryResult struct {
+		values []template.Value
+		err    error
+	}
+	var (
+		mtx     sync.Mutex
+		results = make(map[queryKey]queryResult)
+	)
+	return func(ctx context.Context, datasourceUID, q string, ts time.Time) ([]template.Value, error) {
+		if !ruleUsesDatasource(alertRule, datasourceUID) {
+			return nil, fmt.Errorf("datasource %s is not queried by the alert rule", datasourceUID)
+		}
+		mtx.Lock()
+		defer mtx.Unlock()
+		key := queryKey{datasourceUID: datasourceUID, query: q}
+		if r, ok := results[key]; ok {
+			return r.values, r.err
+		}
+		values, err := query(ctx, alertRule.OrgID, datasourceUID, q, ts)
+		results[key] = queryResult{values: values, err: err}
+		return values, err
+	}
+}
+
+// ruleUsesDatasource returns true if one of the queries of the alert rule uses the datasource.
+func ruleUsesDatasource(alertRule *ngModels.AlertRule, datasourceUID string) bool {
+	for _, q := range alertRule.Data {
+		if isExpr, _ := q.IsExpression(); !isExpr && q.DatasourceUID == datasourceUID {
+			return true
+		}
+	}
+	return false
+}
+
 // expand returns the expanded templates of all annotations or labels for the template data.
 // If a template cannot be expanded due to an error in the template the original template is
 // maintained and an error is added to the multierror. All errors in the multierror are
 // template.ExpandError errors.
-func expand(ctx context.Context, log log.Logger, name string, original map[string]string, data template.Data, externalURL *url.URL, evaluatedAt time.Time) (map[string]string, error) {
+func expand(ctx context.Context, log log.Logger, name string, original map[string]string, data template.Data, externalURL *url.URL, evaluatedAt time.Time, query template.QueryFunc) (map[string]string, error) {
 	var (
 		errs     error
 		expanded = make(map[string]string, len(original))
 	)
 	for k, v := range original {
-		result, err := template.Expand(ctx, name, v, data, externalURL, evaluatedAt)
+		result, err := template.Expand(ctx, name, v, data, externalURL, evaluatedAt, query)
 		if err != nil {
 			log.Error("Error in expanding template", "error", err)
 			errs = errors.Join(errs, err)
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -27,7 +28,7 @@ func Test_expand(t *testing.T) {
 	// If the expand function forgets to use ErrorOrNil() then the error returned will
 	// be non-nil even if no errors have been added to the multierror.
 	t.Run("err is nil if there are no errors", func(t *testing.T) {
-		result, err := expand(ctx, logger, "test", map[string]string{}, template.Data{}, nil, time.Now())
+		result, err := expand(ctx, logger, "test", map[string]string{}, template.Data{}, nil, time.Now(), nil)
 		require.NoError(t, err)
 		require.Len(t, result, 0)
 	})
@@ -36,7 +37,7 @@ func Test_expand(t *testing.T) {
 		original := map[string]string{"Summary": `Instance {{ $labels.instance }} has been down for more than 5 minutes`}
 		expected := map[string]string{"Summary": "Instance host1 has been down for more than 5 minutes"}
 		data := template.Data{Labels: map[string]string{"instance": "host1"}}
-		results, err := expand(ctx, logger, "test", original, data, nil, time.Now())
+		results, err := expand(ctx, logger, "test", original, data, nil, time.Now(), nil)
 		require.NoError(t, err)
 		require.Equal(t, expected, results)
 	})
@@ -46,7 +47,7 @@ func Test_expand(t *testing.T) {
 			"Summary": `Instance {{ $labels. }} has been down for more than 5 minutes`,
 		}
 		data := template.Data{Labels: map[string]string{"instance": "host1"}}
-		results, err := expand(ctx, logger, "test", original, data, nil, time.Now())
+		results, err := expand(ctx, logger, "test", original, data, nil, time.Now(), nil)
 		require.NotNil(t, err)
 		require.Equal(t, original, results)
 
@@ -62,7 +63,7 @@ func Test_expand(t *testing.T) {
 			"Description": "The instance has been down for {{ $value minutes, please check the instance is online",
 		}
 		data := template.Data{Labels: map[string]string{"instance": "host1"}}
-		results, err := expand(ctx, logger, "test", original, data, nil, time.Now())
+		results, err := expand(ctx, logger, "test", original, data, nil, time.Now(), nil)
 		require.NotNil(t, err)
 		require.Equal(t, original, results)
 
@@ -99,7 +100,7 @@ func Test_expand(t *testing.T) {
 			"Description": "The instance has been down for {{ $value minutes, please check the instance is online",
 		}
 		data := template.Data{Labels: map[string]string{"instance": "host1"}}
-		results, err := expand(ctx, logger, "test", original, data, nil, time.Now())
+		results, err := expand(ctx, logger, "test", original, data, nil, time.Now(), nil)
 		require.NotNil(t, err)
 		require.Equal(t, expected, results)
 
@@ -134,7 +135,7 @@ func Test_getOrCreate(t *testing.T) {
 		result := eval.Result{
 			Instance: models.GenerateAlertLabels(5, "result-"),
 		}
-		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url)
+		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url, nil)
 		for key, expected := range extraLabels {
 			require.Equal(t, expected, state.Labels[key])
 		}
@@ -162,7 +163,7 @@ func Test_getOrCreate(t *testing.T) {
 			result.Instance[key] = "result-" + util.GenerateShortUID()
 		}
 
-		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url)
+		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url, nil)
 		for key, expected := range extraLabels {
 			require.Equal(t, expected, state.Labels[key])
 		}
@@ -178,7 +179,7 @@ func Test_getOrCreate(t *testing.T) {
 		for key := range rule.Labels {
 			result.Instance[key] = "result-" + util.GenerateShortUID()
 		}
-		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url)
+		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url, nil)
 		for key, expected := range rule.Labels {
 			require.Equal(t, expected, state.Labels[key])
 		}
@@ -200,7 +201,7 @@ func Test_getOrCreate(t *testing.T) {
 		}
 		rule.Labels = labelTemplates
 
-		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url)
+		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url, nil)
 		for key, expected := range extraLabels {
 			assert.Equal(t, expected, state.Labels["rule-"+key])
 		}
@@ -227,7 +228,7 @@ func Test_getOrCreate(t *testing.T) {
 		}
 		rule.Annotations = annotationTemplates
 
-		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url)
+		state := c.getOrCreate(context.Background(), l, rule, result, extraLabels, url, nil)
 		for key, expected := range extraLabels {
 			assert.Equal(t, expected, state.Annotations["rule-"+key])
 		}
@@ -246,7 +247,7 @@ func Test_getOrCreate(t *testing.T) {
 		}
 		rule := generateRule()
 
-		state := c.getOrCreate(context.Background(), l, rule, result, nil, url)
+		state := c.getOrCreate(context.Background(), l, rule, result, nil, url, nil)
 		assert.Equal(t, map[string]float64{"A": 1, "B": 2}, state.Values)
 	})
 
@@ -260,7 +261,7 @@ func Test_getOrCreate(t *testing.T) {
 		}
 		rule := generateRule()
 
-		state := c.getOrCreate(context.Background(), l, rule, result, nil, url)
+		state := c.getOrCreate(context.Background(), l, rule, result, nil, url, nil)
 		assert.Equal(t, map[string]float64{"B0": 1, "B1": 2}, state.Values)
 	})
 }
@@ -297,3 +298,50 @@ func Test_mergeLabels(t *testing.T) {
 		}
 	})
 }
+
+func Test_ruleTemplateQuery(t *testing.T) {
+	ctx := context.Background()
+	ts := time.Now()
+	rule := models.AlertRuleGen(models.WithOrgID(2))()
+	rule.Data = []models.AlertQuery{
+		{RefID: "A", DatasourceUID: "prometheus"},
+		{RefID: "B", DatasourceUID: expr.DatasourceUID},
+	}
+
+	t.Run("should return nil if queries are not configured", func(t *testing.T) {
+		require.Nil(t, ruleTemplateQuery(nil, rule))
+	})
+
+	t.Run("should run each distinct query once", func(t *testing.T) {
+		var calls []string
+		query := ruleTemplateQuery(func(_ context.Context, orgID int64, datasourceUID, q string, _ time.Time) ([]template.Value, error) {
+			require.Equal(t, int64(2), orgID)
+			calls = append(calls, q)
+			if q == "up{" {
+				return nil, errors.New("bad query")
+			}
+			return []template.Value{{Value: float64(len(calls))}}, nil
+		}, rule)
+
+		for i := 0; i < 2; i++ {
+			values, err := query(ctx, "prometheus", "up", ts)
+			require.NoError(t, err)
+			require.Equal(t, []template.Value{{Value: 1}}, values)
+			_, err = query(ctx, "prometheus", "up{", ts)
+			require.EqualError(t, err, "bad query")
+		}
+		require.Equal(t, []string{"up", "up{"}, calls)
+	})
+
+	t.Run("should fail if the datasource is not queried by the rule", func(t *testing.T) {
+		query := ruleTemplateQuery(func(context.Context, int64, string, string, time.Time) ([]template.Value, error) {
+			require.Fail(t, "the query should not run")
+			return nil, nil
+		}, rule)
+
+		for _, uid := range []string{"other", expr.DatasourceUID} {
+			_, err := query(ctx, uid, "up", ts)
+			require.EqualError(t, err, fmt.Sprintf("datasource %s is not queried by the alert rule", uid))
+		}
+	})
+}
@@ -56,6 +56,10 @@ func StateToPostableAlert(alertState *State, appURL *url.URL) *models.PostableAl
 		nA[alertingModels.StateReasonAnnotation] = alertState.StateReason
 	}
 
+	if alertState.Flapping {
+		nA[ngModels.FlappingAnnotation] = "true"
+	}
+
 	if alertState.OrgID != 0 {
 		nA[alertingModels.OrgIDAnnotation] = strconv.FormatInt(alertState.OrgID, 10)
 	}
@@ -157,6 +157,15 @@ func Test_StateToPostableAlert(t *testing.T) {
 				require.Equal(t, alertState.StateReason, result.Annotations[ngModels.StateReasonAnnotation])
 			})
 
+			t.Run("should add flapping annotation if flapping", func(t *testing.T) {
+				alertState := randomState(tc.state)
+				require.NotContains(t, StateToPostableAlert(alertState, appURL).Annotations, ngModels.FlappingAnnotation)
+
+				alertState.Flapping = true
+				result := StateToPostableAlert(alertState, appURL)
+				require.Equal(t, "true", result.Annotations[ngModels.FlappingAnnotation])
+			})
+
 			switch tc.state {
 			case eval.NoData:
 				t.Run("should keep existing labels and change name", func(t *testing.T) {
@@ -2,6 +2,7 @@ package state
 
 import (
 	"context"
+	"errors"
 	"net/url"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	ngModels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/state/template"
 )
 
 var (
@@ -27,8 +29,22 @@ var (
 type AlertInstanceManager interface {
 	GetAll(orgID int64) []*State
 	GetStatesForRuleUID(orgID int64, alertRuleUID string) []*State
+	// GetAlertInstanceKey returns the key of the stored alert instance of the rule with the labels. The labels
+	// can include or omit the internal labels.
+	GetAlertInstanceKey(ctx context.Context, orgID int64, alertRuleUID string, labels data.Labels) (ngModels.AlertInstanceKey, error)
+	// Acknowledge creates or replaces the acknowledgement of an alert instance that is not Normal or Pending.
+	Acknowledge(ctx context.Context, ack ngModels.AlertInstanceAcknowledgement) error
+	// Unacknowledge removes the acknowledgement of an alert instance.
+	Unacknowledge(ctx context.Context, key ngModels.AlertInstanceKey) error
 }
 
+var (
+	// ErrAlertInstanceNotFound is returned when acknowledging an alert instance that is not stored.
+	ErrAlertInstanceNotFound = errors.New("alert instance not found")
+	// ErrAlertInstanceNotActive is returned when acknowledging a Normal or Pending alert instance.
+	ErrAlertInstanceNotActive = errors.New("only firing, NoData and Error alert instances can be acknowledged")
+)
+
 type Manager struct {
 	log     log.Logger
 	metrics *metrics.State
@@ -42,12 +58,17 @@ type Manager struct {
 	images        ImageCapturer
 	historian     Historian
 	externalURL   *url.URL
+	templateQuery TemplateQueryFunc
 
 	doNotSaveNormalState           bool
 	maxStateSaveConcurrency        int
 	applyNoDataAndErrorToAllStates bool
 }
 
+// TemplateQueryFunc runs an instant query against a datasource in the organization on behalf of
+// the templates of an alert rule.
+type TemplateQueryFunc func(ctx context.Context, orgID int64, datasourceUID, query string, ts time.Time) ([]template.Value, error)
+
 type ManagerCfg struct {
 	Metrics       *metrics.State
 	ExternalURL   *url.URL
@@ -64,6 +85,11 @@ type ManagerCfg struct {
 	// to all states when corresponding execution in the rule definition is set to either `Alerting` or `OK`
 	ApplyNoDataAndErrorToAllStates bool
 
+	// TemplateQuery runs the queries of the queryDatasource function in label and annotation templates.
+	// The queries are limited to the datasources of the alert rule and cached for the duration of one
+	// evaluation. The function returns an error if it is nil.
+	TemplateQuery TemplateQueryFunc
+
 	Tracer tracing.Tracer
 	Log    log.Logger
 }
@@ -88,6 +114,7 @@ func NewManager(cfg ManagerCfg) *Manager {
 		doNotSaveNormalState:           cfg.DoNotSaveNormalState,
 		maxStateSaveConcurrency:        cfg.MaxStateSaveConcurrency,
 		applyNoDataAndErrorToAllStates: cfg.ApplyNoDataAndErrorToAllStates,
+		templateQuery:                  cfg.TemplateQuery,
 		tracer:                         cfg.Tracer,
 	}
 
@@ -170,6 +197,7 @@ func (st *Manager) Warm(ctx context.Context, rulesReader RuleReader) {
 				EndsAt:               entry.CurrentStateEnd,
 				LastEvaluationTime:   entry.LastEvalTime,
 				Annotations:          ruleForEntry.Annotations,
+				FiringChanges:        entry.FiringChanges,
 			}
 			statesCount++
 		}
@@ -223,6 +251,7 @@ func (st *Manager) DeleteStateByRuleUID(ctx context.Context, ruleKey ngModels.Al
 			logger.Error("Failed to delete states that belong to a rule from database", "error", err)
 		}
 	}
+	st.updateAcknowledgements(ctx, logger, ruleKey, now, transitions)
 	logger.Info("Rules state was reset", "states", len(states))
 
 	return transitions
@@ -277,10 +306,12 @@ func (st *Manager) ProcessEvalResults(ctx context.Context, evaluatedAt time.Time
 		))
 	}
 
+	allChanges := append(states, staleStates...)
+	st.updateAcknowledgements(tracingCtx, logger, alertRule.GetKey(), evaluatedAt, allChanges)
+
 	st.saveAlertStates(tracingCtx, logger, states...)
 	span.AddEvent("updated database")
 
-	allChanges := append(states, staleStates...)
 	if st.historian != nil {
 		st.historian.Record(tracingCtx, history_model.NewRuleMeta(alertRule, logger), allChanges)
 	}
@@ -302,9 +333,10 @@ func (st *Manager) setNextStateForRule(ctx context.Context, alertRule *ngModels.
 			return transitions // if there are no current states for the rule. Create ones for each result
 		}
 	}
+	query := ruleTemplateQuery(st.templateQuery, alertRule)
 	transitions := make([]StateTransition, 0, len(results))
 	for _, result := range results {
-		currentState := st.cache.getOrCreate(ctx, logger, alertRule, result, extraLabels, st.externalURL)
+		currentState := st.cache.getOrCreate(ctx, logger, alertRule, result, extraLabels, st.externalURL, query)
 		s := st.setNextState(ctx, alertRule, currentState, result, logger)
 		transitions = append(transitions, s)
 	}
@@ -385,6 +417,19 @@ func (st *Manager) setNextState(ctx context.Context, alertRule *ngModels.AlertRu
 		result.State != eval.Normal &&
 		result.State != eval.Alerting {
 		currentState.StateReason = result.State.String()
+	} else if currentState.State == eval.Alerting && !currentState.KeepFiringSince.IsZero() {
+		currentState.StateReason = ngModels.StateReasonKeepFiring
+	}
+
+	if alertRule.FlapDetection.IsEnabled() {
+		wasFlapping := currentState.Flapping
+		currentState.UpdateFlapping(oldState, result.EvaluatedAt, alertRule.FlapDetection.Window, alertRule.FlapDetection.Threshold)
+		if currentState.Flapping != wasFlapping {
+			logger.Debug("Flapping changed", "flapping", currentState.Flapping, "changes", len(currentState.FiringChanges))
+		}
+	} else {
+		currentState.FiringChanges = nil
+		currentState.Flapping = false
 	}
 
 	// Set Resolved property so the scheduler knows to send a postable alert
@@ -426,6 +471,132 @@ func (st *Manager) GetStatesForRuleUID(orgID int64, alertRuleUID string) []*Stat
 	return st.cache.getStatesForRuleUID(orgID, alertRuleUID, st.doNotSaveNormalState)
 }
 
+func (st *Manager) GetAlertInstanceKey(ctx context.Context, orgID int64, alertRuleUID string, labels data.Labels) (ngModels.AlertInstanceKey, error) {
+	instances, err := st.listAlertInstances(ctx, orgID, alertRuleUID)
+	if err != nil {
+		return ngModels.AlertInstanceKey{}, err
+	}
+	for _, instance := range instances {
+		lbls := data.Labels(instance.Labels)
+		if labels.Equals(lbls) {
+			return instance.AlertInstanceKey, nil
+		}
+		withoutInternal := lbls.Copy()
+		ngModels.WithoutInternalLabels()(withoutInternal)
+		if labels.Equals(withoutInternal) {
+			return instance.AlertInstanceKey, nil
+		}
+	}
+	return ngModels.AlertInstanceKey{}, ErrAlertInstanceNotFound
+}
+
+// Acknowledge saves the acknowledgement if the stored alert instance is not Normal or Pending. The acknowledgement
+// applies from the next evaluation of the rule, on whichever instance of Grafana evaluates it.
+func (st *Manager) Acknowledge(ctx context.Context, ack ngModels.AlertInstanceAcknowledgement) error {
+	if err := ngModels.ValidateAlertInstanceAcknowledgement(ack); err != nil {
+		return err
+	}
+	instances, err := st.listAlertInstances(ctx, ack.RuleOrgID, ack.RuleUID)
+	if err != nil {
+		return err
+	}
+	var found *ngModels.AlertInstance
+	for _, instance := range instances {
+		if instance.AlertInstanceKey == ack.AlertInstanceKey {
+			found = instance
+			break
+		}
+	}
+	if found == nil {
+		return ErrAlertInstanceNotFound
+	}
+	if found.CurrentState == ngModels.InstanceStateNormal || found.CurrentState == ngModels.InstanceStatePending {
+		return ErrAlertInstanceNotActive
+	}
+	return st.instanceStore.SaveAlertInstanceAcknowledgement(ctx, ack)
+}
+
+func (st *Manager) Unacknowledge(ctx context.Context, key ngModels.AlertInstanceKey) error {
+	if st.instanceStore == nil {
+		return nil
+	}
+	return st.instanceStore.DeleteAlertInstanceAcknowledgements(ctx, key)
+}
+
+// listAlertInstances returns the stored alert instances of the rule. Alert instances are not stored,
+// and cannot be acknowledged, if the manager does not have an instance store.
+func (st *Manager) listAlertInstances(ctx context.Context, orgID int64, alertRuleUID string) ([]*ngModels.AlertInstance, error) {
+	if st.instanceStore == nil {
+		return nil, nil
+	}
+	return st.instanceStore.ListAlertInstances(ctx, &ngModels.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: alertRuleUID})
+}
+
+// updateAcknowledgements reads the acknowledgements of the rule from the instance store, sets the ones that apply
+// at the time t on the states, and deletes the acknowledgements of states that resolved. Acknowledgements are read
+// on every evaluation so that the ones created or removed through any instance of Grafana apply.
+func (st *Manager) updateAcknowledgements(ctx context.Context, logger log.Logger, ruleKey ngModels.AlertRuleKey, t time.Time, transitions []StateTransition) {
+	if st.instanceStore == nil || len(transitions) == 0 {
+		return
+	}
+	stored, err := st.instanceStore.ListAlertInstanceAcknowledgements(ctx, &ngModels.ListAlertInstanceAcknowledgementsQuery{
+		RuleOrgID: ruleKey.OrgID,
+		RuleUID:   ruleKey.UID,
+	})
+	if err != nil {
+		// keep the acknowledgements of the previous evaluation.
+		logger.Error("Failed to fetch acknowledgements", "error", err)
+		return
+	}
+	acks := make(map[ngModels.AlertInstanceKey]ngModels.AlertInstanceAcknowledgement, len(stored))
+	for _, ack := range stored {
+		acks[ack.AlertInstanceKey] = *ack
+	}
+
+	var resolved []ngModels.AlertInstanceKey
+	for _, s := range transitions {
+		key, err := s.GetAlertInstanceKey()
+		if err != nil {
+			continue
+		}
+		ack, ok := acks[key]
+		switch {
+		case !ok:
+			s.SetAcknowledgement(nil)
+		case s.State.State == eval.Normal:
+			s.SetAcknowledgement(nil)
+			resolved = append(resolved, key)
+		case ack.IsActive(t):
+			s.SetAcknowledgement(&ack)
+		default:
+			s.SetAcknowledgement(nil)
+		}
+	}
+
+	if len(resolved) == 0 {
+		return
+	}
+	logger.Debug("Deleting acknowledgements of resolved states", "count", len(resolved))
+	if err := st.instanceStore.DeleteAlertInstanceAcknowledgements(ctx, resolved...); err != nil {
+		logger.Error("Failed to delete acknowledgements of resolved states", "error", err)
+	}
+}
+
+// GetFiringDependencies returns the UIDs of the rules alertRule depends on that have at least one firing alert instance.
+// Notifications of alertRule are suppressed while the result is not empty.
+func GetFiringDependencies(manager AlertInstanceManager, alertRule *ngModels.AlertRule) []string {
+	var result []string
+	for _, uid := range alertRule.DependsOn {
+		for _, s := range manager.GetStatesForRuleUID(alertRule.OrgID, uid) {
+			if s.State == eval.Alerting {
+				result = append(result, uid)
+				break
+			}
+		}
+	}
+	return result
+}
+
 func (st *Manager) Put(states []*State) {
 	for _, s := range states {
 		st.cache.set(s)
@@ -458,6 +629,7 @@ func (st *Manager) saveAlertStates(ctx context.Context, logger log.Logger, state
 			LastEvalTime:      s.LastEvaluationTime,
 			CurrentStateSince: s.StartsAt,
 			CurrentStateEnd:   s.EndsAt,
+			FiringChanges:     s.FiringChanges,
 		}
 
 		err = st.instanceStore.SaveAlertInstance(ctx, instance)
@@ -1953,3 +1953,126 @@ func mergeLabels(a, b data.Labels) data.Labels {
 	}
 	return result
 }
+
+func TestAcknowledgements(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock()
+	store := &state.FakeInstanceStore{}
+	newManager := func() *state.Manager {
+		return state.NewManager(state.ManagerCfg{
+			Metrics:                 metrics.NewNGAlert(prometheus.NewPedanticRegistry()).GetStateMetrics(),
+			ExternalURL:             nil,
+			InstanceStore:           store,
+			Images:                  &state.NoopImageService{},
+			Clock:                   clk,
+			Historian:               &state.FakeHistorian{},
+			MaxStateSaveConcurrency: 1,
+			Tracer:                  tracing.InitializeTracerForTest(),
+			Log:                     log.New("ngalert.state.manager"),
+		})
+	}
+	st := newManager()
+	// other stands for another instance of Grafana that shares the database but does not evaluate the rule.
+	other := newManager()
+
+	rule := models.AlertRuleGen(models.WithFor(0))()
+	result := eval.ResultGen(eval.WithState(eval.Alerting), eval.WithEvaluatedAt(clk.Now()))()
+	processed := st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil)
+	require.Len(t, processed, 1)
+	key, err := processed[0].GetAlertInstanceKey()
+	require.NoError(t, err)
+
+	ack := models.AlertInstanceAcknowledgement{
+		AlertInstanceKey:      key,
+		UserID:                1,
+		Login:                 "editor",
+		Comment:               "looking into it",
+		CreatedAt:             clk.Now(),
+		ExpiresAt:             clk.Now().Add(time.Hour),
+		SuppressNotifications: true,
+	}
+	listAcks := func() []*models.AlertInstanceAcknowledgement {
+		acks, err := store.ListAlertInstanceAcknowledgements(ctx, &models.ListAlertInstanceAcknowledgementsQuery{RuleOrgID: rule.OrgID, RuleUID: rule.UID})
+		require.NoError(t, err)
+		return acks
+	}
+
+	t.Run("should find the stored alert instance by its labels", func(t *testing.T) {
+		actual, err := other.GetAlertInstanceKey(ctx, rule.OrgID, rule.UID, processed[0].Labels)
+		require.NoError(t, err)
+		require.Equal(t, key, actual)
+
+		actual, err = other.GetAlertInstanceKey(ctx, rule.OrgID, rule.UID, processed[0].GetLabels(models.WithoutInternalLabels()))
+		require.NoError(t, err)
+		require.Equal(t, key, actual)
+
+		_, err = other.GetAlertInstanceKey(ctx, rule.OrgID, rule.UID, data.Labels{"unknown": "label"})
+		require.ErrorIs(t, err, state.ErrAlertInstanceNotFound)
+	})
+
+	t.Run("should not acknowledge unknown alert instances", func(t *testing.T) {
+		unknown := ack
+		unknown.LabelsHash = "unknown"
+		require.ErrorIs(t, other.Acknowledge(ctx, unknown), state.ErrAlertInstanceNotFound)
+	})
+
+	t.Run("should save the acknowledgement of a stored alert instance", func(t *testing.T) {
+		require.NoError(t, other.Acknowledge(ctx, ack))
+		require.Equal(t, []*models.AlertInstanceAcknowledgement{&ack}, listAcks())
+	})
+
+	t.Run("should add annotations on the next evaluation", func(t *testing.T) {
+		clk.Add(time.Minute)
+		result.EvaluatedAt = clk.Now()
+		processed = st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil)
+		require.Len(t, processed, 1)
+		require.Equal(t, &ack, processed[0].Acknowledgement)
+		require.Equal(t, "editor", processed[0].Annotations[models.AcknowledgedByAnnotation])
+		require.Equal(t, "looking into it", processed[0].Annotations[models.AcknowledgementCommentAnnotation])
+		require.Equal(t, ack.ExpiresAt.UTC().Format(time.RFC3339), processed[0].Annotations[models.AcknowledgedUntilAnnotation])
+		require.Equal(t, "true", processed[0].Annotations[models.AcknowledgementSuppressAnnotation])
+	})
+
+	t.Run("should remove annotations when the acknowledgement expires", func(t *testing.T) {
+		clk.Add(time.Hour)
+		result.EvaluatedAt = clk.Now()
+		processed = st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil)
+		require.Len(t, processed, 1)
+		require.Nil(t, processed[0].Acknowledgement)
+		require.NotContains(t, processed[0].Annotations, models.AcknowledgedByAnnotation)
+	})
+
+	t.Run("should remove annotations when the acknowledgement is removed", func(t *testing.T) {
+		ack.ExpiresAt = time.Time{}
+		require.NoError(t, other.Acknowledge(ctx, ack))
+		clk.Add(time.Minute)
+		result.EvaluatedAt = clk.Now()
+		processed = st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil)
+		require.Equal(t, "editor", processed[0].Annotations[models.AcknowledgedByAnnotation])
+
+		require.NoError(t, other.Unacknowledge(ctx, key))
+		clk.Add(time.Minute)
+		result.EvaluatedAt = clk.Now()
+		processed = st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{result}, nil)
+		require.Nil(t, processed[0].Acknowledgement)
+		require.NotContains(t, processed[0].Annotations, models.AcknowledgedByAnnotation)
+	})
+
+	t.Run("should delete the acknowledgement when the alert instance resolves", func(t *testing.T) {
+		require.NoError(t, other.Acknowledge(ctx, ack))
+
+		clk.Add(time.Minute)
+		resolved := result
+		resolved.State = eval.Normal
+		resolved.EvaluatedAt = clk.Now()
+		processed = st.ProcessEvalResults(ctx, clk.Now(), rule, eval.Results{resolved}, nil)
+		require.Len(t, processed, 1)
+		require.Nil(t, processed[0].Acknowledgement)
+		require.NotContains(t, processed[0].Annotations, models.AcknowledgedByAnnotation)
+		require.Empty(t, listAcks())
+	})
+
+	t.Run("should not acknowledge Normal alert instances", func(t *testing.T) {
+		require.ErrorIs(t, other.Acknowledge(ctx, ack), state.ErrAlertInstanceNotActive)
+	})
+}
@@ -14,6 +14,10 @@ type InstanceStore interface {
 	SaveAlertInstance(ctx context.Context, instance models.AlertInstance) error
 	DeleteAlertInstances(ctx context.Context, keys ...models.AlertInstanceKey) error
 	DeleteAlertInstancesByRule(ctx context.Context, key models.AlertRuleKey) error
+
+	ListAlertInstanceAcknowledgements(ctx context.Context, query *models.ListAlertInstanceAcknowledgementsQuery) ([]*models.AlertInstanceAcknowledgement, error)
+	SaveAlertInstanceAcknowledgement(ctx context.Context, ack models.AlertInstanceAcknowledgement) error
+	DeleteAlertInstanceAcknowledgements(ctx context.Context, keys ...models.AlertInstanceKey) error
 }
 
 // RuleReader represents the ability to fetch alert rules.
@@ -61,6 +61,19 @@ type State struct {
 	// conditions.
 	Values map[string]float64
 
+	// KeepFiringSince is the time of the first evaluation that stopped meeting the condition of a state
+	// that keeps firing because of the KeepFiringFor duration of the rule. It is zero otherwise.
+	KeepFiringSince time.Time
+
+	// Flapping is set to true if the state changed between firing and not firing too often within the flap detection window.
+	Flapping bool
+
+	// FiringChanges contains the times of recent changes between firing and not firing. It is used for flap detection.
+	FiringChanges []time.Time
+
+	// Acknowledgement is the acknowledgement of the state that applied at the last evaluation, if any.
+	Acknowledgement *models.AlertInstanceAcknowledgement
+
 	StartsAt             time.Time
 	EndsAt               time.Time
 	LastSentAt           time.Time
@@ -187,7 +200,29 @@ func NewEvaluationValues(m map[string]eval.NumberValueCapture) map[string]*float
 	return result
 }
 
-func resultNormal(state *State, _ *models.AlertRule, result eval.Result, logger log.Logger) {
+func resultNormal(state *State, rule *models.AlertRule, result eval.Result, logger log.Logger) {
+	if state.State == eval.Alerting && rule.KeepFiringFor > 0 {
+		if state.KeepFiringSince.IsZero() {
+			state.KeepFiringSince = result.EvaluatedAt
+		}
+		// If the state is Alerting then it keeps firing until the KeepFiringFor duration has been observed
+		if result.EvaluatedAt.Sub(state.KeepFiringSince) < rule.KeepFiringFor {
+			prevEndsAt := state.EndsAt
+			state.Maintain(rule.IntervalSeconds, result.EvaluatedAt)
+			logger.Debug("Keeping state",
+				"state",
+				state.State,
+				"keep_firing_since",
+				state.KeepFiringSince,
+				"previous_ends_at",
+				prevEndsAt,
+				"next_ends_at",
+				state.EndsAt)
+			return
+		}
+	}
+	state.KeepFiringSince = time.Time{}
+
 	if state.State == eval.Normal {
 		logger.Debug("Keeping state", "state", state.State)
 	} else {
@@ -207,6 +242,9 @@ func resultNormal(state *State, _ *models.AlertRule, result eval.Result, logger
 }
 
 func resultAlerting(state *State, rule *models.AlertRule, result eval.Result, logger log.Logger) {
+	// The condition is met again, so the state no longer keeps firing because of KeepFiringFor
+	state.KeepFiringSince = time.Time{}
+
 	switch state.State {
 	case eval.Alerting:
 		prevEndsAt := state.EndsAt
@@ -390,6 +428,47 @@ func (a *State) Equals(b *State) bool {
 		data.Labels(a.Annotations).String() == data.Labels(b.Annotations).String()
 }
 
+// UpdateFlapping records a change between firing and not firing at time t if the state changed from previousState,
+// forgets the changes older than window, and marks the state as flapping if the number of the remaining changes
+// is at least threshold. The alerts of flapping states are sent with the annotation models.FlappingAnnotation.
+func (a *State) UpdateFlapping(previousState eval.State, t time.Time, window time.Duration, threshold int) {
+	if (previousState == eval.Alerting) != (a.State == eval.Alerting) {
+		a.FiringChanges = append(a.FiringChanges, t)
+	}
+
+	idx := 0
+	for idx < len(a.FiringChanges) && t.Sub(a.FiringChanges[idx]) > window {
+		idx++
+	}
+	a.FiringChanges = a.FiringChanges[idx:]
+
+	a.Flapping = len(a.FiringChanges) >= threshold
+}
+
+// SetAcknowledgement sets or, if ack is nil, removes the acknowledgement of the state and its annotations.
+func (a *State) SetAcknowledgement(ack *models.AlertInstanceAcknowledgement) {
+	a.Acknowledgement = ack
+	for _, k := range []string{models.AcknowledgedByAnnotation, models.AcknowledgementCommentAnnotation, models.AcknowledgedUntilAnnotation, models.AcknowledgementSuppressAnnotation} {
+		delete(a.Annotations, k)
+	}
+	if ack == nil {
+		return
+	}
+	if a.Annotations == nil {
+		a.Annotations = make(map[string]string)
+	}
+	a.Annotations[models.AcknowledgedByAnnotation] = ack.Login
+	if ack.Comment != "" {
+		a.Annotations[models.AcknowledgementCommentAnnotation] = ack.Comment
+	}
+	if !ack.ExpiresAt.IsZero() {
+		a.Annotations[models.AcknowledgedUntilAnnotation] = ack.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	if ack.SuppressNotifications {
+		a.Annotations[models.AcknowledgementSuppressAnnotation] = "true"
+	}
+}
+
 func (a *State) TrimResults(alertRule *models.AlertRule) {
 	numBuckets := int64(alertRule.For.Seconds()) / alertRule.IntervalSeconds
 	if numBuckets == 0 {
@@ -487,5 +566,12 @@ func GetRuleExtraLabels(rule *models.AlertRule, folderTitle string, includeFolde
 	if includeFolder {
 		extraLabels[models.FolderTitleLabel] = folderTitle
 	}
+
+	if rule.HasNotificationSettings() {
+		// the alerts are routed by the auto-generated routes of the notification settings
+		extraLabels[models.AutogeneratedRouteLabel] = "true"
+		extraLabels[models.AutogeneratedRouteReceiverNameLabel] = rule.NotificationSettings.Receiver
+		extraLabels[models.AutogeneratedRouteSettingsHashLabel] = rule.NotificationSettings.Fingerprint()
+	}
 	return extraLabels
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/infra/log/logtest"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/screenshot"
@@ -548,6 +549,61 @@ func TestResolve(t *testing.T) {
 	assert.Equal(t, expected, s)
 }
 
+func TestResultNormalKeepFiringFor(t *testing.T) {
+	logger := &logtest.Fake{}
+	now := time.Now()
+	rule := &ngmodels.AlertRule{IntervalSeconds: 10, KeepFiringFor: time.Minute}
+
+	s := State{State: eval.Alerting, StartsAt: now.Add(-time.Minute)}
+
+	// the state keeps firing while KeepFiringFor has not been observed
+	resultNormal(&s, rule, eval.Result{State: eval.Normal, EvaluatedAt: now}, logger)
+	assert.Equal(t, eval.Alerting, s.State)
+	assert.Equal(t, now, s.KeepFiringSince)
+
+	resultNormal(&s, rule, eval.Result{State: eval.Normal, EvaluatedAt: now.Add(50 * time.Second)}, logger)
+	assert.Equal(t, eval.Alerting, s.State)
+	assert.Equal(t, now, s.KeepFiringSince)
+
+	// the condition is met again, so the state no longer keeps firing
+	resultAlerting(&s, rule, eval.Result{State: eval.Alerting, EvaluatedAt: now.Add(60 * time.Second)}, logger)
+	assert.Equal(t, eval.Alerting, s.State)
+	assert.True(t, s.KeepFiringSince.IsZero())
+
+	resultNormal(&s, rule, eval.Result{State: eval.Normal, EvaluatedAt: now.Add(70 * time.Second)}, logger)
+	assert.Equal(t, eval.Alerting, s.State)
+	assert.Equal(t, now.Add(70*time.Second), s.KeepFiringSince)
+
+	// the state is resolved once KeepFiringFor has been observed
+	resultNormal(&s, rule, eval.Result{State: eval.Normal, EvaluatedAt: now.Add(130 * time.Second)}, logger)
+	assert.Equal(t, eval.Normal, s.State)
+	assert.True(t, s.KeepFiringSince.IsZero())
+}
+
+func TestUpdateFlapping(t *testing.T) {
+	now := time.Now()
+	window := 10 * time.Minute
+
+	s := State{State: eval.Normal}
+	states := []eval.State{eval.Alerting, eval.Normal, eval.Alerting, eval.Normal}
+	for i, next := range states {
+		previous := s.State
+		s.State = next
+		s.UpdateFlapping(previous, now.Add(time.Duration(i)*time.Minute), window, 4)
+	}
+	assert.True(t, s.Flapping)
+	assert.Len(t, s.FiringChanges, 4)
+
+	// the state does not change, so no change is recorded
+	s.UpdateFlapping(eval.Normal, now.Add(5*time.Minute), window, 4)
+	assert.True(t, s.Flapping)
+
+	// the oldest change is outside of the window
+	s.UpdateFlapping(eval.Normal, now.Add(10*time.Minute+time.Second), window, 4)
+	assert.False(t, s.Flapping)
+	assert.Len(t, s.FiringChanges, 3)
+}
+
 func TestShouldTakeImage(t *testing.T) {
 	tests := []struct {
 		name          string
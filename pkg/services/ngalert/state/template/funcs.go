@@ -3,8 +3,10 @@ package template
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -24,6 +26,10 @@ const (
 	RemoveLabelsReFuncName   = "removeLabelsRe"
 	TableLinkFuncName        = "tableLink"
 	MergeLabelValuesFuncName = "mergeLabelValues"
+
+	HumanizeBytesFuncName      = "humanizeBytes"
+	HumanizeDurationFuncName   = "humanizeDuration"
+	HumanizePercentageFuncName = "humanizePercentage"
 )
 
 var (
@@ -35,6 +41,22 @@ var (
 		RemoveLabelsReFuncName:   removeLabelsReFunc,
 		TableLinkFuncName:        tableLinkFunc,
 		MergeLabelValuesFuncName: mergeLabelValuesFunc,
+
+		HumanizeBytesFuncName:      humanizeBytesFunc,
+		HumanizeDurationFuncName:   humanizeDurationFunc,
+		HumanizePercentageFuncName: humanizePercentageFunc,
+	}
+
+	// durationUnits are the units accepted by humanizeDuration and their length in seconds.
+	durationUnits = map[string]float64{
+		"ns": 1e-9,
+		"us": 1e-6,
+		"µs": 1e-6,
+		"ms": 1e-3,
+		"s":  1,
+		"m":  60,
+		"h":  60 * 60,
+		"d":  24 * 60 * 60,
 	}
 )
 
@@ -132,3 +154,139 @@ func mergeLabelValuesFunc(values map[string]Value) Labels {
 	}
 	return res
 }
+
+// humanizeBytesFunc humanizes a number of bytes using IEC units, for example 1.5 MiB.
+func humanizeBytesFunc(i interface{}) (string, error) {
+	v, err := toFloat64(i)
+	if err != nil {
+		return "", err
+	}
+	if math.Abs(v) < 1024 || math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g B", v), nil
+	}
+	prefix := ""
+	for _, p := range []string{"Ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi"} {
+		if math.Abs(v) < 1024 {
+			break
+		}
+		prefix = p
+		v /= 1024
+	}
+	return fmt.Sprintf("%.4g %sB", v, prefix), nil
+}
+
+// humanizeDurationFunc humanizes a duration. It accepts either the duration in seconds,
+// or a unit followed by the duration in that unit so it can be used in pipelines:
+// {{ humanizeDuration "ms" $value }} and {{ $value | humanizeDuration "ms" }}.
+func humanizeDurationFunc(args ...interface{}) (string, error) {
+	unit, i, err := unitAndValue("s", args)
+	if err != nil {
+		return "", err
+	}
+	scale, ok := durationUnits[unit]
+	if !ok {
+		return "", fmt.Errorf("unknown duration unit %q", unit)
+	}
+	v, err := toFloat64(i)
+	if err != nil {
+		return "", err
+	}
+	v *= scale
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return fmt.Sprintf("%.4g", v), nil
+	}
+	if v == 0 {
+		return fmt.Sprintf("%.4gs", v), nil
+	}
+	if math.Abs(v) >= 1 {
+		sign := ""
+		if v < 0 {
+			sign = "-"
+			v = -v
+		}
+		duration := int64(v)
+		seconds := duration % 60
+		minutes := (duration / 60) % 60
+		hours := (duration / 60 / 60) % 24
+		days := duration / 60 / 60 / 24
+		// For days to minutes, we display seconds as an integer.
+		if days != 0 {
+			return fmt.Sprintf("%s%dd %dh %dm %ds", sign, days, hours, minutes, seconds), nil
+		}
+		if hours != 0 {
+			return fmt.Sprintf("%s%dh %dm %ds", sign, hours, minutes, seconds), nil
+		}
+		if minutes != 0 {
+			return fmt.Sprintf("%s%dm %ds", sign, minutes, seconds), nil
+		}
+		// For seconds, we display 4 significant digits.
+		return fmt.Sprintf("%s%.4gs", sign, v), nil
+	}
+	prefix := ""
+	for _, p := range []string{"m", "u", "n", "p", "f", "a", "z", "y"} {
+		if math.Abs(v) >= 1 {
+			break
+		}
+		prefix = p
+		v *= 1000
+	}
+	return fmt.Sprintf("%.4g%ss", v, prefix), nil
+}
+
+// humanizePercentageFunc humanizes a ratio (the default) or a percentage. Like humanizeDuration,
+// the unit is optional and comes before the value: {{ humanizePercentage "percent" $value }}.
+func humanizePercentageFunc(args ...interface{}) (string, error) {
+	unit, i, err := unitAndValue("ratio", args)
+	if err != nil {
+		return "", err
+	}
+	v, err := toFloat64(i)
+	if err != nil {
+		return "", err
+	}
+	switch unit {
+	case "ratio":
+		v *= 100
+	case "percent":
+	default:
+		return "", fmt.Errorf("unknown percentage unit %q", unit)
+	}
+	return fmt.Sprintf("%.4g%%", v), nil
+}
+
+// unitAndValue returns the unit and value from the arguments of a function that accepts
+// an optional unit before the value.
+func unitAndValue(defaultUnit string, args []interface{}) (string, interface{}, error) {
+	switch len(args) {
+	case 1:
+		return defaultUnit, args[0], nil
+	case 2:
+		unit, ok := args[0].(string)
+		if !ok {
+			return "", nil, fmt.Errorf("unit must be a string, got %T", args[0])
+		}
+		return unit, args[1], nil
+	default:
+		return "", nil, fmt.Errorf("expected a value and an optional unit, got %d arguments", len(args))
+	}
+}
+
+// toFloat64 converts the value to a float64 in the same way as the humanize functions from Prometheus.
+func toFloat64(i interface{}) (float64, error) {
+	switch v := i.(type) {
+	case float64:
+		return v, nil
+	case string:
+		return strconv.ParseFloat(v, 64)
+	case int:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	default:
+		return 0, fmt.Errorf("can't convert %T to float", v)
+	}
+}
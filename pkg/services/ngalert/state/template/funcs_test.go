@@ -49,3 +49,72 @@ func TestDeduplicateLabelsFuncNoDuplicates(t *testing.T) {
 	}
 	assert.Equal(t, Labels{"foo": "bar", "bar": "baz"}, mergeLabelValuesFunc(v))
 }
+
+func TestHumanizeBytesFunc(t *testing.T) {
+	for _, c := range []struct {
+		value    interface{}
+		expected string
+	}{
+		{0.0, "0 B"},
+		{512, "512 B"},
+		{1024.0, "1 KiB"},
+		{"1572864", "1.5 MiB"},
+		{int64(5 * 1024 * 1024 * 1024), "5 GiB"},
+		{-2048.0, "-2 KiB"},
+	} {
+		actual, err := humanizeBytesFunc(c.value)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+
+	_, err := humanizeBytesFunc("invalid")
+	assert.EqualError(t, err, `strconv.ParseFloat: parsing "invalid": invalid syntax`)
+}
+
+func TestHumanizeDurationFunc(t *testing.T) {
+	for _, c := range []struct {
+		args     []interface{}
+		expected string
+	}{
+		{[]interface{}{90.0}, "1m 30s"},
+		{[]interface{}{"s", 90.0}, "1m 30s"},
+		{[]interface{}{"ms", 1500.0}, "1.5s"},
+		{[]interface{}{"ms", "250"}, "250ms"},
+		{[]interface{}{"us", 42}, "42us"},
+		{[]interface{}{"µs", 42}, "42us"},
+		{[]interface{}{"ns", 1e9}, "1s"},
+		{[]interface{}{"m", 90}, "1h 30m 0s"},
+		{[]interface{}{"h", 25}, "1d 1h 0m 0s"},
+		{[]interface{}{"d", -2}, "-2d 0h 0m 0s"},
+	} {
+		actual, err := humanizeDurationFunc(c.args...)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+
+	_, err := humanizeDurationFunc("weeks", 1)
+	assert.EqualError(t, err, `unknown duration unit "weeks"`)
+	_, err = humanizeDurationFunc(1, 1)
+	assert.EqualError(t, err, "unit must be a string, got int")
+	_, err = humanizeDurationFunc()
+	assert.EqualError(t, err, "expected a value and an optional unit, got 0 arguments")
+}
+
+func TestHumanizePercentageFunc(t *testing.T) {
+	for _, c := range []struct {
+		args     []interface{}
+		expected string
+	}{
+		{[]interface{}{0.5}, "50%"},
+		{[]interface{}{"ratio", 0.123456}, "12.35%"},
+		{[]interface{}{"percent", 12.3456}, "12.35%"},
+		{[]interface{}{"percent", "99"}, "99%"},
+	} {
+		actual, err := humanizePercentageFunc(c.args...)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+
+	_, err := humanizePercentageFunc("permille", 1)
+	assert.EqualError(t, err, `unknown percentage unit "permille"`)
+}
@@ -2,18 +2,21 @@ package template
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net/url"
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/timestamp"
 	"github.com/prometheus/prometheus/promql"
-	"github.com/prometheus/prometheus/template"
+	promtemplate "github.com/prometheus/prometheus/template"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 )
@@ -95,7 +98,24 @@ func (e ExpandError) Error() string {
 	return fmt.Sprintf("failed to expand template '%s': %s", e.Tmpl, e.Err)
 }
 
-func Expand(ctx context.Context, name, tmpl string, data Data, externalURL *url.URL, evaluatedAt time.Time) (string, error) {
+const (
+	QueryDatasourceFuncName = "queryDatasource"
+	DashboardURLFuncName    = "dashboardURL"
+	PanelURLFuncName        = "panelURL"
+	ExploreURLFuncName      = "exploreURL"
+
+	// defaultURLWindow is the time range of dashboard, panel and Explore URLs when no window is given.
+	defaultURLWindow = time.Hour
+)
+
+// ErrQueryNotConfigured is returned from queryDatasource when templates cannot run queries.
+var ErrQueryNotConfigured = errors.New("queries are not configured")
+
+// QueryFunc runs an instant query against the datasource at the time ts and returns
+// the labels and value of each series in the result.
+type QueryFunc func(ctx context.Context, datasourceUID, query string, ts time.Time) ([]Value, error)
+
+func Expand(ctx context.Context, name, tmpl string, data Data, externalURL *url.URL, evaluatedAt time.Time, query QueryFunc) (string, error) {
 	if !strings.Contains(tmpl, "{{") { // If it is not a template, skip expanding it.
 		return tmpl, nil
 	}
@@ -112,8 +132,9 @@ func Expand(ctx context.Context, name, tmpl string, data Data, externalURL *url.
 	// Use missingkey=invalid so missing data shows <no value> instead of the type's default value
 	options := []string{"missingkey=invalid"}
 
-	expander := template.NewTemplateExpander(ctx, tmpl, name, data, tm, queryFunc, externalURL, options)
+	expander := promtemplate.NewTemplateExpander(ctx, tmpl, name, data, tm, queryFunc, externalURL, options)
 	expander.Funcs(defaultFuncs)
+	expander.Funcs(contextFuncs(ctx, externalURL, evaluatedAt, query))
 
 	result, err := expander.Expand()
 	if err != nil {
@@ -125,3 +146,89 @@ func Expand(ctx context.Context, name, tmpl string, data Data, externalURL *url.
 	result = strings.ReplaceAll(result, "<no value>", "[no value]")
 	return result, nil
 }
+
+// contextFuncs returns the functions that depend on the rule being evaluated.
+func contextFuncs(ctx context.Context, externalURL *url.URL, evaluatedAt time.Time, query QueryFunc) template.FuncMap {
+	return template.FuncMap{
+		QueryDatasourceFuncName: func(datasourceUID, q string) ([]Value, error) {
+			if query == nil {
+				return nil, ErrQueryNotConfigured
+			}
+			return query(ctx, datasourceUID, q, evaluatedAt)
+		},
+		DashboardURLFuncName: func(dashboardUID string, window ...string) (string, error) {
+			from, to, err := timeRange(evaluatedAt, window)
+			if err != nil {
+				return "", err
+			}
+			u := grafanaURL(externalURL, "d", dashboardUID)
+			u.RawQuery = url.Values{"from": {from}, "to": {to}}.Encode()
+			return u.String(), nil
+		},
+		PanelURLFuncName: func(dashboardUID string, panelID int, window ...string) (string, error) {
+			from, to, err := timeRange(evaluatedAt, window)
+			if err != nil {
+				return "", err
+			}
+			u := grafanaURL(externalURL, "d", dashboardUID)
+			u.RawQuery = url.Values{"from": {from}, "to": {to}, "viewPanel": {strconv.Itoa(panelID)}}.Encode()
+			return u.String(), nil
+		},
+		ExploreURLFuncName: func(datasourceUID, expr string, window ...string) (string, error) {
+			from, to, err := timeRange(evaluatedAt, window)
+			if err != nil {
+				return "", err
+			}
+			type datasource struct {
+				UID string `json:"uid"`
+			}
+			left, err := json.Marshal(struct {
+				Datasource string `json:"datasource"`
+				Queries    []any  `json:"queries"`
+				Range      any    `json:"range"`
+			}{
+				Datasource: datasourceUID,
+				Queries: []any{struct {
+					RefID      string     `json:"refId"`
+					Datasource datasource `json:"datasource"`
+					Expr       string     `json:"expr"`
+				}{RefID: "A", Datasource: datasource{UID: datasourceUID}, Expr: expr}},
+				Range: map[string]string{"from": from, "to": to},
+			})
+			if err != nil {
+				return "", err
+			}
+			u := grafanaURL(externalURL, "explore")
+			u.RawQuery = url.Values{"left": {string(left)}}.Encode()
+			return u.String(), nil
+		},
+	}
+}
+
+// timeRange returns the start and end of the window ending at evaluatedAt in milliseconds.
+// The window is optional and uses the Prometheus duration format, for example 30m.
+func timeRange(evaluatedAt time.Time, window []string) (string, string, error) {
+	d := defaultURLWindow
+	switch len(window) {
+	case 0:
+	case 1:
+		w, err := model.ParseDuration(window[0])
+		if err != nil {
+			return "", "", fmt.Errorf("invalid window: %w", err)
+		}
+		d = time.Duration(w)
+	default:
+		return "", "", fmt.Errorf("expected at most one window, got %d", len(window))
+	}
+	from := strconv.FormatInt(evaluatedAt.Add(-d).UnixMilli(), 10)
+	to := strconv.FormatInt(evaluatedAt.UnixMilli(), 10)
+	return from, to, nil
+}
+
+// grafanaURL returns the path elements joined to the external URL of the Grafana server.
+func grafanaURL(externalURL *url.URL, elem ...string) *url.URL {
+	if externalURL == nil {
+		return (&url.URL{Path: "/"}).JoinPath(elem...)
+	}
+	return externalURL.JoinPath(elem...)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/stretchr/testify/assert"
@@ -339,6 +340,14 @@ func TestExpandTemplate(t *testing.T) {
 		name:          "humanizePercentage - string with error",
 		text:          `{{ "invalid" | humanizePercentage }}`,
 		expectedError: errors.New(`failed to expand template '{{- $labels := .Labels -}}{{- $values := .Values -}}{{- $value := .Value -}}{{ "invalid" | humanizePercentage }}': error executing template __alert_test: template: __alert_test:1:91: executing "__alert_test" at <humanizePercentage>: error calling humanizePercentage: strconv.ParseFloat: parsing "invalid": invalid syntax`),
+	}, {
+		name:     "humanizeDuration and humanizePercentage with units",
+		text:     `{{ humanizeDuration "ms" 1500 }}:{{ 90 | humanizeDuration "m" }}:{{ 42.5 | humanizePercentage "percent" }}`,
+		expected: "1.5s:1h 30m 0s:42.5%",
+	}, {
+		name:     "humanizeBytes",
+		text:     "{{ humanizeBytes 1536 }}",
+		expected: "1.5 KiB",
 	}, {
 		name:     "humanizeTimestamp - float64",
 		text:     "{{ 1435065584.128 | humanizeTimestamp }}",
@@ -436,7 +445,7 @@ func TestExpandTemplate(t *testing.T) {
 
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
-			v, err := Expand(context.Background(), "test", c.text, NewData(c.labels, c.alertInstance), externalURL, c.alertInstance.EvaluatedAt)
+			v, err := Expand(context.Background(), "test", c.text, NewData(c.labels, c.alertInstance), externalURL, c.alertInstance.EvaluatedAt, nil)
 			if c.expectedError != nil {
 				require.NotNil(t, err)
 				require.EqualError(t, c.expectedError, err.Error())
@@ -447,3 +456,75 @@ func TestExpandTemplate(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandContextFuncs(t *testing.T) {
+	externalURL, err := url.Parse("http://localhost/path/prefix")
+	require.NoError(t, err)
+	evaluatedAt := time.UnixMilli(1700000000000)
+
+	query := func(ctx context.Context, datasourceUID, q string, ts time.Time) ([]Value, error) {
+		require.Equal(t, "prometheus", datasourceUID)
+		require.Equal(t, evaluatedAt, ts)
+		if q == "invalid" {
+			return nil, errors.New("bad query")
+		}
+		return []Value{
+			{Labels: Labels{"instance": "a"}, Value: 1},
+			{Labels: Labels{"instance": "b"}, Value: 2.5},
+		}, nil
+	}
+
+	cases := []struct {
+		name          string
+		text          string
+		query         QueryFunc
+		expected      string
+		expectedError string
+	}{{
+		name:     "queryDatasource returns the values of each series",
+		text:     `{{ range queryDatasource "prometheus" "up" }}{{ .Labels.instance }}={{ . }} {{ end }}`,
+		query:    query,
+		expected: "a=1 b=2.5 ",
+	}, {
+		name:          "queryDatasource returns the error from the query",
+		text:          `{{ queryDatasource "prometheus" "invalid" }}`,
+		query:         query,
+		expectedError: "error calling queryDatasource: bad query",
+	}, {
+		name:          "queryDatasource returns an error when queries are not configured",
+		text:          `{{ queryDatasource "prometheus" "up" }}`,
+		expectedError: "error calling queryDatasource: queries are not configured",
+	}, {
+		name:     "dashboardURL uses the last hour by default",
+		text:     `{{ dashboardURL "abc" }}`,
+		expected: "http://localhost/path/prefix/d/abc?from=1699996400000&to=1700000000000",
+	}, {
+		name:     "dashboardURL with window",
+		text:     `{{ dashboardURL "abc" "30m" }}`,
+		expected: "http://localhost/path/prefix/d/abc?from=1699998200000&to=1700000000000",
+	}, {
+		name:          "dashboardURL with invalid window",
+		text:          `{{ dashboardURL "abc" "1 hour" }}`,
+		expectedError: `error calling dashboardURL: invalid window: unknown unit " hour" in duration "1 hour"`,
+	}, {
+		name:     "panelURL",
+		text:     `{{ panelURL "abc" 4 "5m" }}`,
+		expected: "http://localhost/path/prefix/d/abc?from=1699999700000&to=1700000000000&viewPanel=4",
+	}, {
+		name:     "exploreURL",
+		text:     `{{ exploreURL "prometheus" "rate(errors[5m]) > 0" }}`,
+		expected: "http://localhost/path/prefix/explore?left=" + url.QueryEscape(`{"datasource":"prometheus","queries":[{"refId":"A","datasource":{"uid":"prometheus"},"expr":"rate(errors[5m]) \u003e 0"}],"range":{"from":"1699996400000","to":"1700000000000"}}`),
+	}}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			v, err := Expand(context.Background(), "test", c.text, Data{}, externalURL, evaluatedAt, c.query)
+			if c.expectedError != "" {
+				require.ErrorContains(t, err, c.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, c.expected, v)
+		})
+	}
+}
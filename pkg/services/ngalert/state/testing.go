@@ -14,6 +14,8 @@ var _ InstanceStore = &FakeInstanceStore{}
 type FakeInstanceStore struct {
 	mtx         sync.Mutex
 	RecordedOps []any
+	instances   map[models.AlertInstanceKey]models.AlertInstance
+	acks        map[models.AlertInstanceKey]models.AlertInstanceAcknowledgement
 }
 
 type FakeInstanceStoreOp struct {
@@ -25,13 +27,24 @@ func (f *FakeInstanceStore) ListAlertInstances(_ context.Context, q *models.List
 	f.mtx.Lock()
 	defer f.mtx.Unlock()
 	f.RecordedOps = append(f.RecordedOps, *q)
-	return nil, nil
+	var result []*models.AlertInstance
+	for _, instance := range f.instances {
+		if instance.RuleOrgID == q.RuleOrgID && (q.RuleUID == "" || instance.RuleUID == q.RuleUID) {
+			instance := instance
+			result = append(result, &instance)
+		}
+	}
+	return result, nil
 }
 
 func (f *FakeInstanceStore) SaveAlertInstance(_ context.Context, q models.AlertInstance) error {
 	f.mtx.Lock()
 	defer f.mtx.Unlock()
 	f.RecordedOps = append(f.RecordedOps, q)
+	if f.instances == nil {
+		f.instances = make(map[models.AlertInstanceKey]models.AlertInstance)
+	}
+	f.instances[q.AlertInstanceKey] = q
 	return nil
 }
 
@@ -46,10 +59,59 @@ func (f *FakeInstanceStore) DeleteAlertInstances(ctx context.Context, q ...model
 			q,
 		},
 	})
+	for _, k := range q {
+		delete(f.instances, k)
+	}
 	return nil
 }
 
 func (f *FakeInstanceStore) DeleteAlertInstancesByRule(ctx context.Context, key models.AlertRuleKey) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for k := range f.instances {
+		if k.RuleOrgID == key.OrgID && k.RuleUID == key.UID {
+			delete(f.instances, k)
+		}
+	}
+	return nil
+}
+
+func (f *FakeInstanceStore) ListAlertInstanceAcknowledgements(_ context.Context, q *models.ListAlertInstanceAcknowledgementsQuery) ([]*models.AlertInstanceAcknowledgement, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var result []*models.AlertInstanceAcknowledgement
+	for _, ack := range f.acks {
+		if ack.RuleOrgID == q.RuleOrgID && (q.RuleUID == "" || ack.RuleUID == q.RuleUID) {
+			ack := ack
+			result = append(result, &ack)
+		}
+	}
+	return result, nil
+}
+
+func (f *FakeInstanceStore) SaveAlertInstanceAcknowledgement(_ context.Context, ack models.AlertInstanceAcknowledgement) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.RecordedOps = append(f.RecordedOps, ack)
+	if f.acks == nil {
+		f.acks = make(map[models.AlertInstanceKey]models.AlertInstanceAcknowledgement)
+	}
+	f.acks[ack.AlertInstanceKey] = ack
+	return nil
+}
+
+func (f *FakeInstanceStore) DeleteAlertInstanceAcknowledgements(ctx context.Context, keys ...models.AlertInstanceKey) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.RecordedOps = append(f.RecordedOps, FakeInstanceStoreOp{
+		Name: "DeleteAlertInstanceAcknowledgements", Args: []any{
+			ctx,
+			keys,
+		},
+	})
+	for _, k := range keys {
+		delete(f.acks, k)
+	}
 	return nil
 }
 
@@ -16,6 +16,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/search/model"
 	"github.com/grafana/grafana/pkg/services/sqlstore"
 	"github.com/grafana/grafana/pkg/services/sqlstore/searchstore"
+	entityevents "github.com/grafana/grafana/pkg/services/store"
 	"github.com/grafana/grafana/pkg/services/store/entity"
 	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/util"
@@ -49,6 +50,14 @@ func getAlertRuleByUID(sess *db.Session, alertRuleUID string, orgID int64) (*ngm
 func (st DBstore) DeleteAlertRulesByUID(ctx context.Context, orgID int64, ruleUID ...string) error {
 	logger := st.Logger.New("org_id", orgID, "rule_uids", ruleUID)
 	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		// the folders of the rules are kept in the delete events, so the events can be authorized after the rules are gone
+		deleted := make([]ngmodels.AlertRule, 0, len(ruleUID))
+		if entityevents.EntityEventsEnabled(st.FeatureToggles) {
+			if err := sess.Table("alert_rule").Cols("org_id", "uid", "namespace_uid").Where("org_id = ?", orgID).In("uid", ruleUID).Find(&deleted); err != nil {
+				return err
+			}
+		}
+
 		rows, err := sess.Table("alert_rule").Where("org_id = ?", orgID).In("uid", ruleUID).Delete(ngmodels.AlertRule{})
 		if err != nil {
 			return err
@@ -66,7 +75,8 @@ func (st DBstore) DeleteAlertRulesByUID(ctx context.Context, orgID int64, ruleUI
 			return err
 		}
 		logger.Debug("Deleted alert instances", "count", rows)
-		return nil
+
+		return st.insertEntityEvents(sess, entityevents.EntityEventTypeDelete, deleted...)
 	})
 }
 
@@ -115,6 +125,20 @@ func (st DBstore) GetAlertRulesGroupByRuleUID(ctx context.Context, query *ngmode
 	return result, err
 }
 
+// GetAlertRuleVersions returns the versions of an alert rule, most recent first.
+func (st DBstore) GetAlertRuleVersions(ctx context.Context, query *ngmodels.ListAlertRuleVersionsQuery) (result []*ngmodels.AlertRuleVersion, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var versions []*ngmodels.AlertRuleVersion
+		err := sess.Table("alert_rule_version").Where("rule_org_id = ? AND rule_uid = ?", query.OrgID, query.RuleUID).Desc("id").Find(&versions)
+		if err != nil {
+			return err
+		}
+		result = versions
+		return nil
+	})
+	return result, err
+}
+
 // InsertAlertRules is a handler for creating/updating alert rules.
 // Returns the UID and ID of rules that were created in the same order as the input rules.
 func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRule) ([]ngmodels.AlertRuleKeyWithId, error) {
@@ -140,22 +164,30 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 			}
 			newRules = append(newRules, r)
 			ruleVersions = append(ruleVersions, ngmodels.AlertRuleVersion{
-				RuleUID:          r.UID,
-				RuleOrgID:        r.OrgID,
-				RuleNamespaceUID: r.NamespaceUID,
-				RuleGroup:        r.RuleGroup,
-				ParentVersion:    0,
-				Version:          r.Version,
-				Created:          r.Updated,
-				Condition:        r.Condition,
-				Title:            r.Title,
-				Data:             r.Data,
-				IntervalSeconds:  r.IntervalSeconds,
-				NoDataState:      r.NoDataState,
-				ExecErrState:     r.ExecErrState,
-				For:              r.For,
-				Annotations:      r.Annotations,
-				Labels:           r.Labels,
+				RuleUID:              r.UID,
+				RuleOrgID:            r.OrgID,
+				RuleNamespaceUID:     r.NamespaceUID,
+				RuleGroup:            r.RuleGroup,
+				RuleGroupIndex:       r.RuleGroupIndex,
+				ParentVersion:        0,
+				Version:              r.Version,
+				Created:              r.Updated,
+				Condition:            r.Condition,
+				Title:                r.Title,
+				Data:                 r.Data,
+				IntervalSeconds:      r.IntervalSeconds,
+				NoDataState:          r.NoDataState,
+				ExecErrState:         r.ExecErrState,
+				For:                  r.For,
+				KeepFiringFor:        r.KeepFiringFor,
+				FlapDetection:        r.FlapDetection,
+				Annotations:          r.Annotations,
+				Labels:               r.Labels,
+				IsPaused:             r.IsPaused,
+				Record:               r.Record,
+				NotificationSettings: r.NotificationSettings,
+				DependsOn:            r.DependsOn,
+				UpdatedBy:            r.UpdatedBy,
 			})
 		}
 		if len(newRules) > 0 {
@@ -180,6 +212,9 @@ func (st DBstore) InsertAlertRules(ctx context.Context, rules []ngmodels.AlertRu
 				return fmt.Errorf("failed to create new rule versions: %w", err)
 			}
 		}
+		if err := st.insertEntityEvents(sess, entityevents.EntityEventTypeCreate, newRules...); err != nil {
+			return err
+		}
 		return nil
 	})
 }
@@ -203,8 +238,11 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []ngmodels.UpdateR
 			if err := (&r.New).PreSave(TimeNow); err != nil {
 				return err
 			}
-			// no way to update multiple rules at once
-			if updated, err := sess.ID(r.Existing.ID).AllCols().Update(r.New); err != nil || updated == 0 {
+			// no way to update multiple rules at once.
+			// xorm uses the conversion of fields such as Record only if they are addressable, therefore the rule is passed by pointer.
+			// It is a copy because xorm increments the version of the bean, and r.New.Version is used below.
+			updatedRule := r.New
+			if updated, err := sess.ID(r.Existing.ID).AllCols().Update(&updatedRule); err != nil || updated == 0 {
 				if err != nil {
 					if st.SQLStore.GetDialect().IsUniqueConstraintViolation(err) {
 						return ngmodels.ErrAlertRuleUniqueConstraintViolation
@@ -215,23 +253,31 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []ngmodels.UpdateR
 			}
 			parentVersion = r.Existing.Version
 			ruleVersions = append(ruleVersions, ngmodels.AlertRuleVersion{
-				RuleOrgID:        r.New.OrgID,
-				RuleUID:          r.New.UID,
-				RuleNamespaceUID: r.New.NamespaceUID,
-				RuleGroup:        r.New.RuleGroup,
-				RuleGroupIndex:   r.New.RuleGroupIndex,
-				ParentVersion:    parentVersion,
-				Version:          r.New.Version + 1,
-				Created:          r.New.Updated,
-				Condition:        r.New.Condition,
-				Title:            r.New.Title,
-				Data:             r.New.Data,
-				IntervalSeconds:  r.New.IntervalSeconds,
-				NoDataState:      r.New.NoDataState,
-				ExecErrState:     r.New.ExecErrState,
-				For:              r.New.For,
-				Annotations:      r.New.Annotations,
-				Labels:           r.New.Labels,
+				RuleOrgID:            r.New.OrgID,
+				RuleUID:              r.New.UID,
+				RuleNamespaceUID:     r.New.NamespaceUID,
+				RuleGroup:            r.New.RuleGroup,
+				RuleGroupIndex:       r.New.RuleGroupIndex,
+				ParentVersion:        parentVersion,
+				RestoredFrom:         r.RestoredFrom,
+				Version:              r.New.Version + 1,
+				Created:              r.New.Updated,
+				Condition:            r.New.Condition,
+				Title:                r.New.Title,
+				Data:                 r.New.Data,
+				IntervalSeconds:      r.New.IntervalSeconds,
+				NoDataState:          r.New.NoDataState,
+				ExecErrState:         r.New.ExecErrState,
+				For:                  r.New.For,
+				KeepFiringFor:        r.New.KeepFiringFor,
+				FlapDetection:        r.New.FlapDetection,
+				Annotations:          r.New.Annotations,
+				Labels:               r.New.Labels,
+				Record:               r.New.Record,
+				IsPaused:             r.New.IsPaused,
+				NotificationSettings: r.New.NotificationSettings,
+				DependsOn:            r.New.DependsOn,
+				UpdatedBy:            r.New.UpdatedBy,
 			})
 		}
 		if len(ruleVersions) > 0 {
@@ -239,10 +285,32 @@ func (st DBstore) UpdateAlertRules(ctx context.Context, rules []ngmodels.UpdateR
 				return fmt.Errorf("failed to create new rule versions: %w", err)
 			}
 		}
+		updated := make([]ngmodels.AlertRule, 0, len(rules))
+		for _, r := range rules {
+			updated = append(updated, r.New)
+		}
+		if err := st.insertEntityEvents(sess, entityevents.EntityEventTypeUpdate, updated...); err != nil {
+			return err
+		}
 		return nil
 	})
 }
 
+// insertEntityEvents records the rule changes so they can be streamed to external consumers
+func (st DBstore) insertEntityEvents(sess *db.Session, eventType entityevents.EntityEventType, rules ...ngmodels.AlertRule) error {
+	if !entityevents.EntityEventsEnabled(st.FeatureToggles) {
+		return nil
+	}
+	for _, r := range rules {
+		ev := entityevents.NewDatabaseEntityEvent(eventType, r.UID, r.OrgID, entityevents.EntityTypeAlertRule)
+		ev.FolderUID = r.NamespaceUID
+		if _, err := sess.Insert(ev); err != nil {
+			return fmt.Errorf("failed to insert entity event: %w", err)
+		}
+	}
+	return nil
+}
+
 // preventIntermediateUniqueConstraintViolations prevents unique constraint violations caused by an intermediate update.
 // The uniqueness constraint for titles within an org+folder is enforced on every update within a transaction
 // instead of on commit (deferred constraint). This means that there could be a set of updates that will throw
@@ -386,6 +454,52 @@ func (st DBstore) ListAlertRules(ctx context.Context, query *ngmodels.ListAlertR
 	return result, err
 }
 
+// ListNotificationSettings returns the notification settings of the alert rules of an organization that have them.
+func (st DBstore) ListNotificationSettings(ctx context.Context, q ngmodels.ListNotificationSettingsQuery) (map[ngmodels.AlertRuleKey]ngmodels.NotificationSettings, error) {
+	var rules []ngmodels.AlertRule
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table(ngmodels.AlertRule{}).
+			Select("uid, org_id, notification_settings").
+			Where("org_id = ?", q.OrgID).
+			And("notification_settings IS NOT NULL").
+			Find(&rules)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[ngmodels.AlertRuleKey]ngmodels.NotificationSettings, len(rules))
+	for _, rule := range rules {
+		if !rule.HasNotificationSettings() {
+			continue
+		}
+		result[rule.GetKey()] = rule.NotificationSettings
+	}
+	return result, nil
+}
+
+// ListRuleDependencies returns the UIDs of the rules that the alert rules of an organization depend on, for the rules that have dependencies.
+func (st DBstore) ListRuleDependencies(ctx context.Context, q ngmodels.ListRuleDependenciesQuery) (map[ngmodels.AlertRuleKey][]string, error) {
+	var rules []ngmodels.AlertRule
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table(ngmodels.AlertRule{}).
+			Select("uid, org_id, depends_on").
+			Where("org_id = ?", q.OrgID).
+			And("depends_on IS NOT NULL").
+			Find(&rules)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[ngmodels.AlertRuleKey][]string, len(rules))
+	for _, rule := range rules {
+		if !rule.HasDependencies() {
+			continue
+		}
+		result[rule.GetKey()] = rule.DependsOn
+	}
+	return result, nil
+}
+
 // Count returns either the number of the alert rules under a specific org (if orgID is not zero)
 // or the number of all the alert rules
 func (st DBstore) Count(ctx context.Context, orgID int64) (int64, error) {
@@ -677,5 +791,25 @@ func (st DBstore) validateAlertRule(alertRule ngmodels.AlertRule) error {
 	if alertRule.For < 0 {
 		return fmt.Errorf("%w: field `for` cannot be negative", ngmodels.ErrAlertRuleFailedValidation)
 	}
+
+	if err := alertRule.FlapDetection.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+
+	if alertRule.HasNotificationSettings() {
+		if err := alertRule.NotificationSettings.Validate(); err != nil {
+			return fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+		}
+	}
+
+	if alertRule.KeepFiringFor < 0 {
+		return fmt.Errorf("%w: field `keep_firing_for` cannot be negative", ngmodels.ErrAlertRuleFailedValidation)
+	}
+
+	for _, uid := range alertRule.DependsOn {
+		if uid == alertRule.UID {
+			return fmt.Errorf("%w: alert rule cannot depend on itself", ngmodels.ErrAlertRuleFailedValidation)
+		}
+	}
 	return nil
 }
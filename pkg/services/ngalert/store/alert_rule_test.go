@@ -83,6 +83,29 @@ func TestIntegrationUpdateAlertRules(t *testing.T) {
 
 		require.ErrorIs(t, err, ErrOptimisticLock)
 	})
+
+	t.Run("should record the version with its author", func(t *testing.T) {
+		rule := createRule(t, store, generator)
+		newRule := models.CopyRule(rule)
+		newRule.Title = util.GenerateShortUID()
+		newRule.UpdatedBy = "test-user"
+		err := store.UpdateAlertRules(context.Background(), []models.UpdateRule{{
+			Existing:     rule,
+			New:          *newRule,
+			RestoredFrom: rule.Version,
+		},
+		})
+		require.NoError(t, err)
+
+		versions, err := store.GetAlertRuleVersions(context.Background(), &models.ListAlertRuleVersionsQuery{OrgID: rule.OrgID, RuleUID: rule.UID})
+		require.NoError(t, err)
+		require.NotEmpty(t, versions)
+		require.Equal(t, rule.Version+1, versions[0].Version)
+		require.Equal(t, rule.Version, versions[0].ParentVersion)
+		require.Equal(t, rule.Version, versions[0].RestoredFrom)
+		require.Equal(t, newRule.Title, versions[0].Title)
+		require.Equal(t, "test-user", versions[0].UpdatedBy)
+	})
 }
 
 func TestIntegrationUpdateAlertRulesWithUniqueConstraintViolation(t *testing.T) {
@@ -54,12 +54,16 @@ func (st DBstore) SaveAlertInstance(ctx context.Context, alertInstance models.Al
 		if err != nil {
 			return err
 		}
-		params := append(make([]any, 0), alertInstance.RuleOrgID, alertInstance.RuleUID, labelTupleJSON, alertInstance.LabelsHash, alertInstance.CurrentState, alertInstance.CurrentReason, alertInstance.CurrentStateSince.Unix(), alertInstance.CurrentStateEnd.Unix(), alertInstance.LastEvalTime.Unix())
+		firingChanges, err := alertInstance.FiringChanges.ToDB()
+		if err != nil {
+			return err
+		}
+		params := append(make([]any, 0), alertInstance.RuleOrgID, alertInstance.RuleUID, labelTupleJSON, alertInstance.LabelsHash, alertInstance.CurrentState, alertInstance.CurrentReason, alertInstance.CurrentStateSince.Unix(), alertInstance.CurrentStateEnd.Unix(), alertInstance.LastEvalTime.Unix(), string(firingChanges))
 
 		upsertSQL := st.SQLStore.GetDialect().UpsertSQL(
 			"alert_instance",
 			[]string{"rule_org_id", "rule_uid", "labels_hash"},
-			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time"})
+			[]string{"rule_org_id", "rule_uid", "labels", "labels_hash", "current_state", "current_reason", "current_state_since", "current_state_end", "last_eval_time", "firing_changes"})
 		_, err = sess.SQL(upsertSQL, params...).Query()
 		if err != nil {
 			return err
@@ -197,3 +201,52 @@ func (st DBstore) DeleteAlertInstancesByRule(ctx context.Context, key models.Ale
 		return err
 	})
 }
+
+// ListAlertInstanceAcknowledgements returns the acknowledgements of alert instances in the organisation,
+// and of the rule if the query has a rule UID.
+func (st DBstore) ListAlertInstanceAcknowledgements(ctx context.Context, query *models.ListAlertInstanceAcknowledgementsQuery) ([]*models.AlertInstanceAcknowledgement, error) {
+	result := make([]*models.AlertInstanceAcknowledgement, 0)
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		sql := "SELECT * FROM alert_instance_acknowledgement WHERE rule_org_id = ?"
+		params := []any{query.RuleOrgID}
+		if query.RuleUID != "" {
+			sql += " AND rule_uid = ?"
+			params = append(params, query.RuleUID)
+		}
+		return sess.SQL(sql, params...).Find(&result)
+	})
+	return result, err
+}
+
+// SaveAlertInstanceAcknowledgement creates or replaces the acknowledgement of an alert instance.
+func (st DBstore) SaveAlertInstanceAcknowledgement(ctx context.Context, ack models.AlertInstanceAcknowledgement) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		if err := models.ValidateAlertInstanceAcknowledgement(ack); err != nil {
+			return err
+		}
+
+		params := append(make([]any, 0), ack.RuleOrgID, ack.RuleUID, ack.LabelsHash, ack.UserID, ack.Login, ack.Comment, ack.CreatedAt.Unix(), ack.ExpiresAt.Unix(), ack.SuppressNotifications)
+
+		upsertSQL := st.SQLStore.GetDialect().UpsertSQL(
+			"alert_instance_acknowledgement",
+			[]string{"rule_org_id", "rule_uid", "labels_hash"},
+			[]string{"rule_org_id", "rule_uid", "labels_hash", "user_id", "login", "comment", "created_at", "expires_at", "suppress_notifications"})
+		_, err := sess.SQL(upsertSQL, params...).Query()
+		return err
+	})
+}
+
+// DeleteAlertInstanceAcknowledgements deletes the acknowledgements of the alert instances with the provided keys.
+func (st DBstore) DeleteAlertInstanceAcknowledgements(ctx context.Context, keys ...models.AlertInstanceKey) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		for _, k := range keys {
+			if _, err := sess.Exec("DELETE FROM alert_instance_acknowledgement WHERE rule_org_id = ? AND rule_uid = ? AND labels_hash = ?", k.RuleOrgID, k.RuleUID, k.LabelsHash); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -97,6 +98,7 @@ func TestIntegrationAlertInstanceOperations(t *testing.T) {
 			CurrentState:  models.InstanceStateFiring,
 			CurrentReason: string(models.InstanceStateError),
 			Labels:        labels,
+			FiringChanges: models.FiringChanges{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 5, 0, 0, time.UTC)},
 		}
 		err := dbstore.SaveAlertInstance(ctx, instance)
 		require.NoError(t, err)
@@ -113,6 +115,7 @@ func TestIntegrationAlertInstanceOperations(t *testing.T) {
 		require.Equal(t, alertRule1.OrgID, alerts[0].RuleOrgID)
 		require.Equal(t, alertRule1.UID, alerts[0].RuleUID)
 		require.Equal(t, instance.CurrentReason, alerts[0].CurrentReason)
+		require.Equal(t, instance.FiringChanges, alerts[0].FiringChanges)
 	})
 
 	t.Run("can save and read new alert instance with no labels", func(t *testing.T) {
@@ -294,3 +297,90 @@ func TestIntegrationAlertInstanceOperations(t *testing.T) {
 		require.Equal(t, instance2.CurrentState, alerts[0].CurrentState)
 	})
 }
+
+func TestIntegrationAlertInstanceAcknowledgementOperations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	const mainOrgID int64 = 1
+	alertRule := tests.CreateTestAlertRule(t, ctx, dbstore, 60, mainOrgID)
+
+	newAck := func(value string) models.AlertInstanceAcknowledgement {
+		labels := models.InstanceLabels{"test": value}
+		_, hash, _ := labels.StringAndHash()
+		return models.AlertInstanceAcknowledgement{
+			AlertInstanceKey: models.AlertInstanceKey{
+				RuleOrgID:  alertRule.OrgID,
+				RuleUID:    alertRule.UID,
+				LabelsHash: hash,
+			},
+			UserID:    1,
+			Login:     "admin",
+			Comment:   "looking into it",
+			CreatedAt: time.Unix(1700000000, 0),
+		}
+	}
+
+	t.Run("can save, replace and read acknowledgements", func(t *testing.T) {
+		ack1 := newAck("a")
+		require.NoError(t, dbstore.SaveAlertInstanceAcknowledgement(ctx, ack1))
+
+		ack2 := newAck("b")
+		ack2.ExpiresAt = ack2.CreatedAt.Add(time.Hour)
+		ack2.SuppressNotifications = true
+		require.NoError(t, dbstore.SaveAlertInstanceAcknowledgement(ctx, ack2))
+		ack2.Comment = "still looking"
+		require.NoError(t, dbstore.SaveAlertInstanceAcknowledgement(ctx, ack2))
+
+		acks, err := dbstore.ListAlertInstanceAcknowledgements(ctx, &models.ListAlertInstanceAcknowledgementsQuery{RuleOrgID: mainOrgID})
+		require.NoError(t, err)
+		require.Len(t, acks, 2)
+		byHash := map[string]*models.AlertInstanceAcknowledgement{}
+		for _, ack := range acks {
+			byHash[ack.LabelsHash] = ack
+		}
+
+		actual := byHash[ack1.LabelsHash]
+		require.Equal(t, ack1.AlertInstanceKey, actual.AlertInstanceKey)
+		require.Equal(t, "admin", actual.Login)
+		require.Equal(t, "looking into it", actual.Comment)
+		require.Equal(t, ack1.CreatedAt.Unix(), actual.CreatedAt.Unix())
+		require.True(t, actual.ExpiresAt.IsZero())
+		require.False(t, actual.SuppressNotifications)
+
+		actual = byHash[ack2.LabelsHash]
+		require.Equal(t, "still looking", actual.Comment)
+		require.Equal(t, ack2.ExpiresAt.Unix(), actual.ExpiresAt.Unix())
+		require.True(t, actual.SuppressNotifications)
+
+		acks, err = dbstore.ListAlertInstanceAcknowledgements(ctx, &models.ListAlertInstanceAcknowledgementsQuery{RuleOrgID: mainOrgID, RuleUID: alertRule.UID})
+		require.NoError(t, err)
+		require.Len(t, acks, 2)
+
+		acks, err = dbstore.ListAlertInstanceAcknowledgements(ctx, &models.ListAlertInstanceAcknowledgementsQuery{RuleOrgID: mainOrgID, RuleUID: "other"})
+		require.NoError(t, err)
+		require.Empty(t, acks)
+
+		acks, err = dbstore.ListAlertInstanceAcknowledgements(ctx, &models.ListAlertInstanceAcknowledgementsQuery{RuleOrgID: mainOrgID + 1})
+		require.NoError(t, err)
+		require.Empty(t, acks)
+	})
+
+	t.Run("should reject invalid acknowledgements", func(t *testing.T) {
+		ack := newAck("c")
+		ack.ExpiresAt = ack.CreatedAt.Add(-time.Minute)
+		require.Error(t, dbstore.SaveAlertInstanceAcknowledgement(ctx, ack))
+	})
+
+	t.Run("can delete acknowledgements", func(t *testing.T) {
+		require.NoError(t, dbstore.DeleteAlertInstanceAcknowledgements(ctx, newAck("a").AlertInstanceKey, newAck("missing").AlertInstanceKey))
+
+		acks, err := dbstore.ListAlertInstanceAcknowledgements(ctx, &models.ListAlertInstanceAcknowledgementsQuery{RuleOrgID: mainOrgID})
+		require.NoError(t, err)
+		require.Len(t, acks, 1)
+		require.Equal(t, newAck("b").LabelsHash, acks[0].LabelsHash)
+	})
+}
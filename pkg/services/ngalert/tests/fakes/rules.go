@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"testing"
 	"time"
@@ -25,6 +26,8 @@ type RuleStore struct {
 	Hook        func(cmd any) error // use Hook if you need to intercept some query and return an error
 	RecordedOps []any
 	Folders     map[int64][]*folder.Folder
+	// Versions contains the history of rules returned by GetAlertRuleVersions.
+	Versions []*models.AlertRuleVersion
 }
 
 type GenericRecordedQuery struct {
@@ -271,6 +274,25 @@ func (f *RuleStore) GetNamespaceByUID(_ context.Context, uid string, orgID int64
 	return nil, fmt.Errorf("not found")
 }
 
+func (f *RuleStore) GetAlertRuleVersions(_ context.Context, q *models.ListAlertRuleVersionsQuery) ([]*models.AlertRuleVersion, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.RecordedOps = append(f.RecordedOps, *q)
+	if err := f.Hook(*q); err != nil {
+		return nil, err
+	}
+	result := make([]*models.AlertRuleVersion, 0)
+	for _, v := range f.Versions {
+		if v.RuleOrgID == q.OrgID && v.RuleUID == q.RuleUID {
+			result = append(result, v)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version > result[j].Version
+	})
+	return result, nil
+}
+
 func (f *RuleStore) UpdateAlertRules(_ context.Context, q []models.UpdateRule) error {
 	f.mtx.Lock()
 	defer f.mtx.Unlock()
@@ -0,0 +1,197 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/prompb"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/live/remotewrite"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// maxErrorBodySize is the maximum number of bytes of an error response included in the returned error.
+const maxErrorBodySize = 1024
+
+// Writer writes the results of recording rules.
+type Writer interface {
+	// Write writes the numeric values of frames as samples of the metric name at time t.
+	// The labels of each sample are the labels of its field and extraLabels.
+	Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error
+}
+
+// NoopWriter is the Writer used when recording rules are disabled, it drops all results.
+type NoopWriter struct{}
+
+func (w NoopWriter) Write(_ context.Context, _ string, _ time.Time, _ data.Frames, _ map[string]string) error {
+	return nil
+}
+
+// PrometheusWriter writes the results of recording rules to a Prometheus remote write endpoint.
+type PrometheusWriter struct {
+	client            *http.Client
+	url               string
+	basicAuthUser     string
+	basicAuthPassword string
+	logger            log.Logger
+}
+
+func NewPrometheusWriter(cfg setting.RecordingRuleSettings, l log.Logger) (*PrometheusWriter, error) {
+	if _, err := url.Parse(cfg.URL); err != nil {
+		return nil, fmt.Errorf("failed to parse remote write URL: %w", err)
+	}
+
+	return &PrometheusWriter{
+		client:            &http.Client{Timeout: cfg.Timeout},
+		url:               cfg.URL,
+		basicAuthUser:     cfg.BasicAuthUsername,
+		basicAuthPassword: cfg.BasicAuthPassword,
+		logger:            l,
+	}, nil
+}
+
+func (w PrometheusWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+
+	series, err := TimeSeriesFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return err
+	}
+	if len(series) == 0 {
+		l.Debug("No samples to write", "metric", name)
+		return nil
+	}
+
+	body, err := remotewrite.TimeSeriesToBytes(series)
+	if err != nil {
+		return fmt.Errorf("failed to encode samples: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create remote write request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	if w.basicAuthUser != "" || w.basicAuthPassword != "" {
+		req.SetBasicAuth(w.basicAuthUser, w.basicAuthPassword)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send remote write request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			l.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	if resp.StatusCode/100 != 2 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+		return fmt.Errorf("remote write endpoint returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+
+	l.Debug("Wrote samples to remote write endpoint", "metric", name, "series", len(series))
+	return nil
+}
+
+// TimeSeriesFromFrames converts the numeric fields of frames to Prometheus time series of the metric name,
+// with a single sample at time t. Fields with more than one value, such as the fields of time series frames,
+// are recorded with their last non-null value.
+func TimeSeriesFromFrames(name string, t time.Time, frames data.Frames, extraLabels map[string]string) ([]prompb.TimeSeries, error) {
+	if !model.IsValidMetricName(model.LabelValue(name)) {
+		return nil, fmt.Errorf("invalid metric name '%s'", name)
+	}
+
+	timestamp := t.UnixNano() / int64(time.Millisecond)
+	seen := make(map[string]struct{})
+	var result []prompb.TimeSeries
+
+	for _, frame := range frames {
+		for _, field := range frame.Fields {
+			if !field.Type().Numeric() {
+				continue
+			}
+			value, ok := lastValue(field)
+			if !ok {
+				continue
+			}
+
+			labels, err := seriesLabels(name, field.Labels, extraLabels)
+			if err != nil {
+				return nil, err
+			}
+			key := labelsKey(labels)
+			if _, ok := seen[key]; ok {
+				return nil, fmt.Errorf("duplicate series for metric '%s' with labels %s", name, key)
+			}
+			seen[key] = struct{}{}
+
+			result = append(result, prompb.TimeSeries{
+				Labels:  labels,
+				Samples: []prompb.Sample{{Value: value, Timestamp: timestamp}},
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// lastValue returns the last non-null value of a numeric field.
+func lastValue(field *data.Field) (float64, bool) {
+	for i := field.Len() - 1; i >= 0; i-- {
+		v, err := field.NullableFloatAt(i)
+		if err == nil && v != nil {
+			return *v, true
+		}
+	}
+	return 0, false
+}
+
+// seriesLabels returns the sorted labels of a series, extraLabels take precedence over the labels of the field.
+func seriesLabels(name string, fieldLabels data.Labels, extraLabels map[string]string) ([]prompb.Label, error) {
+	merged := make(map[string]string, len(fieldLabels)+len(extraLabels)+1)
+	for k, v := range fieldLabels {
+		merged[k] = v
+	}
+	for k, v := range extraLabels {
+		merged[k] = v
+	}
+	merged[model.MetricNameLabel] = name
+
+	labels := make([]prompb.Label, 0, len(merged))
+	for k, v := range merged {
+		if !model.LabelName(k).IsValid() {
+			return nil, fmt.Errorf("invalid label name '%s'", k)
+		}
+		labels = append(labels, prompb.Label{Name: k, Value: v})
+	}
+	// remote write requires the labels to be sorted by name
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels, nil
+}
+
+func labelsKey(labels []prompb.Label) string {
+	var b strings.Builder
+	b.WriteString("{")
+	for i, l := range labels {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%s=%q", l.Name, l.Value)
+	}
+	b.WriteString("}")
+	return b.String()
+}
@@ -0,0 +1,137 @@
+package writer
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestTimeSeriesFromFrames(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	t.Run("converts numeric fields to samples", func(t *testing.T) {
+		frames := data.Frames{
+			data.NewFrame("",
+				data.NewField("A", data.Labels{"host": "a"}, []float64{1, 2}),
+				data.NewField("B", data.Labels{"host": "b"}, []*float64{floatPtr(3), nil}),
+				data.NewField("name", nil, []string{"x", "y"}),
+			),
+		}
+
+		series, err := TimeSeriesFromFrames("test_metric", now, frames, map[string]string{"team": "alerting"})
+		require.NoError(t, err)
+		require.Equal(t, []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "test_metric"},
+					{Name: "host", Value: "a"},
+					{Name: "team", Value: "alerting"},
+				},
+				Samples: []prompb.Sample{{Value: 2, Timestamp: now.UnixMilli()}},
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "test_metric"},
+					{Name: "host", Value: "b"},
+					{Name: "team", Value: "alerting"},
+				},
+				Samples: []prompb.Sample{{Value: 3, Timestamp: now.UnixMilli()}},
+			},
+		}, series)
+	})
+
+	t.Run("rule labels take precedence over field labels", func(t *testing.T) {
+		frames := data.Frames{data.NewFrame("", data.NewField("A", data.Labels{"team": "other"}, []float64{1}))}
+
+		series, err := TimeSeriesFromFrames("test_metric", now, frames, map[string]string{"team": "alerting"})
+		require.NoError(t, err)
+		require.Len(t, series, 1)
+		require.Contains(t, series[0].Labels, prompb.Label{Name: "team", Value: "alerting"})
+	})
+
+	t.Run("fails on duplicate series", func(t *testing.T) {
+		frames := data.Frames{data.NewFrame("",
+			data.NewField("A", data.Labels{"host": "a"}, []float64{1}),
+			data.NewField("B", data.Labels{"host": "a"}, []float64{2}),
+		)}
+
+		_, err := TimeSeriesFromFrames("test_metric", now, frames, nil)
+		require.ErrorContains(t, err, "duplicate series")
+	})
+
+	t.Run("fails on invalid metric name", func(t *testing.T) {
+		_, err := TimeSeriesFromFrames("invalid metric", now, nil, nil)
+		require.ErrorContains(t, err, "invalid metric name")
+	})
+
+	t.Run("fails on invalid label name", func(t *testing.T) {
+		frames := data.Frames{data.NewFrame("", data.NewField("A", data.Labels{"invalid-label": "a"}, []float64{1}))}
+
+		_, err := TimeSeriesFromFrames("test_metric", now, frames, nil)
+		require.ErrorContains(t, err, "invalid label name")
+	})
+}
+
+func TestPrometheusWriter(t *testing.T) {
+	frames := data.Frames{data.NewFrame("", data.NewField("A", data.Labels{"host": "a"}, []float64{42}))}
+
+	t.Run("writes samples to the remote write endpoint", func(t *testing.T) {
+		var received prompb.WriteRequest
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, pass, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "user", user)
+			require.Equal(t, "pass", pass)
+			require.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+
+			compressed, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			b, err := snappy.Decode(nil, compressed)
+			require.NoError(t, err)
+			require.NoError(t, proto.Unmarshal(b, &received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer srv.Close()
+
+		w, err := NewPrometheusWriter(setting.RecordingRuleSettings{
+			URL:               srv.URL,
+			BasicAuthUsername: "user",
+			BasicAuthPassword: "pass",
+			Timeout:           time.Second,
+		}, log.NewNopLogger())
+		require.NoError(t, err)
+
+		require.NoError(t, w.Write(context.Background(), "test_metric", time.Now(), frames, nil))
+		require.Len(t, received.Timeseries, 1)
+		require.Equal(t, float64(42), received.Timeseries[0].Samples[0].Value)
+	})
+
+	t.Run("returns error if the endpoint fails", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "out of order sample", http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		w, err := NewPrometheusWriter(setting.RecordingRuleSettings{URL: srv.URL, Timeout: time.Second}, log.NewNopLogger())
+		require.NoError(t, err)
+
+		err = w.Write(context.Background(), "test_metric", time.Now(), frames, nil)
+		require.ErrorContains(t, err, "status 400: out of order sample")
+	})
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,274 @@
+package clientmiddleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/grafana/grafana/pkg/plugins"
+)
+
+// userScopedPlugins are the plugins whose query results depend on the signed in user,
+// their queries are only deduplicated for the same user.
+var userScopedPlugins = map[string]bool{
+	"grafana": true,
+}
+
+// inflightQuery is a QueryData call shared by identical concurrent requests.
+type inflightQuery struct {
+	done    chan struct{}
+	resp    *backend.QueryDataResponse
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// queryDeduplicator holds the in-flight queries. It is shared by the DeduplicationMiddleware
+// instances, since the middlewares are created for every request.
+type queryDeduplicator struct {
+	mu       sync.Mutex
+	inflight map[string]*inflightQuery
+
+	deduplicated *prometheus.CounterVec
+}
+
+// NewDeduplicationMiddleware creates a new plugins.ClientMiddleware that coalesces identical concurrent
+// QueryData requests, so that they share a single call to the plugin. Requests are identical if they
+// have the same data source, queries, time ranges and forwarded HTTP headers, so requests forwarding
+// the identity of different users are never shared.
+//
+// The shared call runs with the context values of the request that started it, e.g. its signed in user
+// and tracing span, but is not canceled with it. The other requests get a copy of its response.
+func NewDeduplicationMiddleware(promRegisterer prometheus.Registerer) plugins.ClientMiddleware {
+	deduplicated := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Name:      "datasource_deduplicated_queries_total",
+		Help:      "Number of query requests that shared the response of an identical in-flight request",
+	}, []string{"plugin_id"})
+	promRegisterer.MustRegister(deduplicated)
+
+	d := &queryDeduplicator{
+		inflight:     map[string]*inflightQuery{},
+		deduplicated: deduplicated,
+	}
+
+	return plugins.ClientMiddlewareFunc(func(next plugins.Client) plugins.Client {
+		return &DeduplicationMiddleware{
+			queryDeduplicator: d,
+			next:              next,
+		}
+	})
+}
+
+type DeduplicationMiddleware struct {
+	*queryDeduplicator
+	next plugins.Client
+}
+
+func (m *DeduplicationMiddleware) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	if req == nil || req.PluginContext.DataSourceInstanceSettings == nil {
+		return m.next.QueryData(ctx, req)
+	}
+
+	key, err := queryKey(req)
+	if err != nil {
+		return m.next.QueryData(ctx, req)
+	}
+
+	m.mu.Lock()
+	call, exists := m.inflight[key]
+	if exists {
+		m.deduplicated.WithLabelValues(req.PluginContext.PluginID).Inc()
+	} else {
+		// The shared call must not be canceled when the request that started it is canceled,
+		// it is canceled once no request waits for it anymore. It keeps the values of the request
+		// that started it, e.g. its signed in user and tracing span, for all the requests sharing it
+		callCtx, cancel := context.WithCancel(detachedContext{ctx})
+		call = &inflightQuery{done: make(chan struct{}), cancel: cancel}
+		m.inflight[key] = call
+
+		go func() {
+			defer cancel()
+			resp, err := m.next.QueryData(callCtx, req)
+
+			m.mu.Lock()
+			if m.inflight[key] == call {
+				delete(m.inflight, key)
+			}
+			call.resp, call.err = resp, err
+			m.mu.Unlock()
+			close(call.done)
+		}()
+	}
+	call.waiters++
+	m.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return copyQueryDataResponse(call.resp), call.err
+	case <-ctx.Done():
+		m.mu.Lock()
+		call.waiters--
+		if call.waiters == 0 {
+			call.cancel()
+			if m.inflight[key] == call {
+				delete(m.inflight, key)
+			}
+		}
+		m.mu.Unlock()
+		return nil, ctx.Err()
+	}
+}
+
+// queryKey returns the key identifying identical query requests.
+func queryKey(req *backend.QueryDataRequest) (string, error) {
+	type query struct {
+		RefID         string
+		QueryType     string
+		JSON          any
+		From          int64
+		To            int64
+		Interval      time.Duration
+		MaxDataPoints int64
+	}
+
+	ds := req.PluginContext.DataSourceInstanceSettings
+	key := struct {
+		OrgID    int64
+		PluginID string
+		UID      string
+		Updated  int64
+		User     string
+		Headers  [][2]string
+		Queries  []query
+	}{
+		OrgID:    req.PluginContext.OrgID,
+		PluginID: req.PluginContext.PluginID,
+		UID:      ds.UID,
+		Updated:  ds.Updated.UnixNano(),
+	}
+
+	if userScopedPlugins[req.PluginContext.PluginID] {
+		if req.PluginContext.User == nil {
+			key.User = "anonymous"
+		} else {
+			key.User = req.PluginContext.User.Login
+		}
+	}
+
+	for k, v := range req.Headers {
+		key.Headers = append(key.Headers, [2]string{k, v})
+	}
+	sort.Slice(key.Headers, func(i, j int) bool { return key.Headers[i][0] < key.Headers[j][0] })
+
+	for _, q := range req.Queries {
+		// Unmarshalling the query JSON normalizes the order of its fields
+		var model any
+		if err := json.Unmarshal(q.JSON, &model); err != nil {
+			return "", err
+		}
+		key.Queries = append(key.Queries, query{
+			RefID:         q.RefID,
+			QueryType:     q.QueryType,
+			JSON:          model,
+			From:          q.TimeRange.From.UnixNano(),
+			To:            q.TimeRange.To.UnixNano(),
+			Interval:      q.Interval,
+			MaxDataPoints: q.MaxDataPoints,
+		})
+	}
+
+	b, err := json.Marshal(key)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(b)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// copyQueryDataResponse returns a deep copy of the response, so that the requests sharing it
+// can change their responses and frames independently.
+func copyQueryDataResponse(resp *backend.QueryDataResponse) *backend.QueryDataResponse {
+	if resp == nil {
+		return nil
+	}
+	responses := make(backend.Responses, len(resp.Responses))
+	for refID, dr := range resp.Responses {
+		frames, err := copyFrames(dr.Frames)
+		if err != nil {
+			dr.Frames = nil
+			dr.Error = err
+		} else {
+			dr.Frames = frames
+		}
+		responses[refID] = dr
+	}
+	return &backend.QueryDataResponse{Responses: responses}
+}
+
+// copyFrames returns a deep copy of the frames, including their metadata and field configs.
+func copyFrames(frames data.Frames) (data.Frames, error) {
+	if frames == nil {
+		return nil, nil
+	}
+	copied := make(data.Frames, 0, len(frames))
+	for _, frame := range frames {
+		if frame == nil {
+			copied = append(copied, nil)
+			continue
+		}
+		b, err := frame.MarshalArrow()
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy frame %s: %w", frame.Name, err)
+		}
+		frameCopy, err := data.UnmarshalArrowFrame(b)
+		if err != nil {
+			return nil, fmt.Errorf("failed to copy frame %s: %w", frame.Name, err)
+		}
+		copied = append(copied, frameCopy)
+	}
+	return copied, nil
+}
+
+// detachedContext is a context that keeps the values of its parent, but is never canceled.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (c detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (c detachedContext) Done() <-chan struct{}       { return nil }
+func (c detachedContext) Err() error                  { return nil }
+func (c detachedContext) Value(key any) any           { return c.parent.Value(key) }
+
+func (m *DeduplicationMiddleware) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return m.next.CallResource(ctx, req, sender)
+}
+
+func (m *DeduplicationMiddleware) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	return m.next.CheckHealth(ctx, req)
+}
+
+func (m *DeduplicationMiddleware) CollectMetrics(ctx context.Context, req *backend.CollectMetricsRequest) (*backend.CollectMetricsResult, error) {
+	return m.next.CollectMetrics(ctx, req)
+}
+
+func (m *DeduplicationMiddleware) SubscribeStream(ctx context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	return m.next.SubscribeStream(ctx, req)
+}
+
+func (m *DeduplicationMiddleware) PublishStream(ctx context.Context, req *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	return m.next.PublishStream(ctx, req)
+}
+
+func (m *DeduplicationMiddleware) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	return m.next.RunStream(ctx, req, sender)
+}
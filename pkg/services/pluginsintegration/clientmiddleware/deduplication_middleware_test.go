@@ -0,0 +1,149 @@
+package clientmiddleware
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/plugins/manager/client/clienttest"
+)
+
+func TestDeduplicationMiddleware(t *testing.T) {
+	timeRange := backend.TimeRange{From: time.Unix(1700000000, 0), To: time.Unix(1700003600, 0)}
+	newRequest := func(queryJSON string, headers map[string]string) *backend.QueryDataRequest {
+		return &backend.QueryDataRequest{
+			PluginContext: backend.PluginContext{
+				OrgID:                      1,
+				PluginID:                   "prometheus",
+				DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{UID: "prom"},
+			},
+			Headers: headers,
+			Queries: []backend.DataQuery{{RefID: "A", JSON: []byte(queryJSON), TimeRange: timeRange}},
+		}
+	}
+
+	// setup returns a decorator whose plugin calls block until release is closed
+	setup := func(t *testing.T) (*clienttest.ClientDecoratorTest, *int32, chan struct{}) {
+		t.Helper()
+
+		var calls int32
+		release := make(chan struct{})
+		cdt := clienttest.NewClientDecoratorTest(t, clienttest.WithMiddlewares(NewDeduplicationMiddleware(prometheus.NewRegistry())))
+		cdt.TestClient.QueryDataFunc = func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			atomic.AddInt32(&calls, 1)
+			select {
+			case <-release:
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			return &backend.QueryDataResponse{Responses: backend.Responses{"A": {}}}, nil
+		}
+		return cdt, &calls, release
+	}
+
+	run := func(t *testing.T, cdt *clienttest.ClientDecoratorTest, reqs ...*backend.QueryDataRequest) *sync.WaitGroup {
+		t.Helper()
+
+		var wg sync.WaitGroup
+		for _, req := range reqs {
+			wg.Add(1)
+			go func(req *backend.QueryDataRequest) {
+				defer wg.Done()
+				resp, err := cdt.Decorator.QueryData(context.Background(), req)
+				require.NoError(t, err)
+				require.Contains(t, resp.Responses, "A")
+			}(req)
+		}
+		return &wg
+	}
+
+	t.Run("shares identical concurrent queries", func(t *testing.T) {
+		cdt, calls, release := setup(t)
+
+		wg := run(t, cdt,
+			newRequest(`{"expr": "up", "interval": ""}`, nil),
+			newRequest(`{"interval": "", "expr": "up"}`, nil),
+			newRequest(`{"expr": "up", "interval": ""}`, nil),
+		)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(calls) == 1 }, time.Second, 5*time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		require.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("does not share different queries", func(t *testing.T) {
+		cdt, calls, release := setup(t)
+
+		wg := run(t, cdt,
+			newRequest(`{"expr": "up"}`, nil),
+			newRequest(`{"expr": "down"}`, nil),
+		)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(calls) == 2 }, time.Second, 5*time.Millisecond)
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("does not share queries forwarding different identities", func(t *testing.T) {
+		cdt, calls, release := setup(t)
+
+		wg := run(t, cdt,
+			newRequest(`{"expr": "up"}`, map[string]string{"Authorization": "Bearer user-1"}),
+			newRequest(`{"expr": "up"}`, map[string]string{"Authorization": "Bearer user-2"}),
+		)
+		require.Eventually(t, func() bool { return atomic.LoadInt32(calls) == 2 }, time.Second, 5*time.Millisecond)
+		close(release)
+		wg.Wait()
+	})
+
+	t.Run("a canceled request does not cancel the shared query", func(t *testing.T) {
+		cdt, calls, release := setup(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		canceled := make(chan error)
+		go func() {
+			_, err := cdt.Decorator.QueryData(ctx, newRequest(`{"expr": "up"}`, nil))
+			canceled <- err
+		}()
+		require.Eventually(t, func() bool { return atomic.LoadInt32(calls) == 1 }, time.Second, 5*time.Millisecond)
+
+		wg := run(t, cdt, newRequest(`{"expr": "up"}`, nil))
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-canceled, context.Canceled)
+
+		close(release)
+		wg.Wait()
+		require.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+}
+
+func TestCopyQueryDataResponse(t *testing.T) {
+	frame := data.NewFrame("series", data.NewField("value", data.Labels{"job": "grafana"}, []float64{1, 2}))
+	frame.SetMeta(&data.FrameMeta{ExecutedQueryString: "up"})
+	resp := &backend.QueryDataResponse{Responses: backend.Responses{"A": {Frames: data.Frames{frame}}}}
+
+	first := copyQueryDataResponse(resp)
+	second := copyQueryDataResponse(resp)
+
+	first.Responses["A"].Frames[0].Name = "changed"
+	first.Responses["A"].Frames[0].Fields[0].Set(0, float64(10))
+	first.Responses["A"].Frames[0].Fields[0].Labels["job"] = "changed"
+	first.Responses["A"].Frames[0].Meta.ExecutedQueryString = "changed"
+
+	for _, r := range []*backend.QueryDataResponse{resp, second} {
+		f := r.Responses["A"].Frames[0]
+		require.Equal(t, "series", f.Name)
+		require.Equal(t, float64(1), f.Fields[0].At(0))
+		require.Equal(t, "grafana", f.Fields[0].Labels["job"])
+		require.Equal(t, "up", f.Meta.ExecutedQueryString)
+	}
+}
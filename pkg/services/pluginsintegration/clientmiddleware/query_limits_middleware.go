@@ -0,0 +1,278 @@
+package clientmiddleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/plugins"
+	ngalertmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util/errutil"
+)
+
+// ErrQueryResponseLimitExceeded is the error of the query responses above the [query] response limits.
+var ErrQueryResponseLimitExceeded = errutil.BadRequest("query.responseLimitExceeded",
+	errutil.WithPublicMessage("The query response is too large, refine the query to return less data"))
+
+// queryLimits are the limits of a query response. A zero value disables the limit.
+type queryLimits struct {
+	maxSeries int64
+	maxRows   int64
+	maxBytes  int64
+	truncate  bool
+}
+
+func (l queryLimits) enabled() bool {
+	return l.maxSeries > 0 || l.maxRows > 0 || l.maxBytes > 0
+}
+
+// NewQueryLimitsMiddleware creates a new plugins.ClientMiddleware that enforces the maximum number
+// of series, rows and bytes in the response of each query. Responses above the limits either fail
+// with ErrQueryResponseLimitExceeded, or are truncated with a notice in the frame metadata.
+// Alert rule evaluations always fail, since a truncated response could silently resolve an alert.
+//
+// The limits are checked once the plugin has returned the whole response, they protect the clients
+// of the response but not the memory used to query the plugin.
+func NewQueryLimitsMiddleware(cfg *setting.Cfg) plugins.ClientMiddleware {
+	limits := queryLimits{
+		maxSeries: cfg.QueryMaxSeries,
+		maxRows:   cfg.QueryMaxRows,
+		maxBytes:  cfg.QueryMaxResponseBytes,
+		truncate:  cfg.QueryLimitExceededAction == "truncate",
+	}
+
+	return plugins.ClientMiddlewareFunc(func(next plugins.Client) plugins.Client {
+		return &QueryLimitsMiddleware{
+			limits: limits,
+			next:   next,
+		}
+	})
+}
+
+type QueryLimitsMiddleware struct {
+	limits queryLimits
+	next   plugins.Client
+}
+
+func (m *QueryLimitsMiddleware) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	resp, err := m.next.QueryData(ctx, req)
+	if err != nil || resp == nil || !m.limits.enabled() {
+		return resp, err
+	}
+
+	limits := m.limits
+	if _, fromAlert := req.Headers[ngalertmodels.FromAlertHeaderName]; fromAlert {
+		limits.truncate = false
+	}
+
+	for refID, dr := range resp.Responses {
+		if dr.Error != nil || len(dr.Frames) == 0 {
+			continue
+		}
+		frames, err := limits.apply(dr.Frames)
+		if err != nil {
+			resp.Responses[refID] = backend.DataResponse{Error: err}
+			continue
+		}
+		dr.Frames = frames
+		resp.Responses[refID] = dr
+	}
+
+	return resp, nil
+}
+
+// apply returns the frames within the limits. Frames above the limits are truncated if the limits
+// allow it, otherwise an error is returned.
+func (l queryLimits) apply(frames data.Frames) (data.Frames, error) {
+	var series, rows, bytes int64
+	result := make(data.Frames, 0, len(frames))
+	var notices []string
+
+	for i, frame := range frames {
+		if frame == nil {
+			continue
+		}
+
+		if frameSeries := countSeries(frame); l.maxSeries > 0 && series+frameSeries > l.maxSeries {
+			if !l.truncate {
+				return nil, ErrQueryResponseLimitExceeded.Errorf("query returned more than %d series", l.maxSeries)
+			}
+			notices = append(notices, fmt.Sprintf("the response was truncated to %d series", l.maxSeries))
+			if frame = keepSeries(frame, l.maxSeries-series); frame == nil {
+				break
+			}
+		}
+
+		if l.maxRows > 0 && rows+int64(frame.Rows()) > l.maxRows {
+			if !l.truncate {
+				return nil, ErrQueryResponseLimitExceeded.Errorf("query returned more than %d rows", l.maxRows)
+			}
+			notices = append(notices, fmt.Sprintf("the response was truncated to %d rows", l.maxRows))
+			if rows == l.maxRows {
+				break
+			}
+			frame = keepRows(frame, int(l.maxRows-rows))
+		}
+
+		if l.maxBytes > 0 {
+			size := estimateFrameSize(frame)
+			if bytes+size > l.maxBytes {
+				if !l.truncate {
+					return nil, ErrQueryResponseLimitExceeded.Errorf("query response is larger than %d bytes", l.maxBytes)
+				}
+				// Frames are not split by size, the remaining frames are dropped
+				notices = append(notices, fmt.Sprintf("the response was truncated to %d bytes, %d frames were dropped", l.maxBytes, len(frames)-i))
+				break
+			}
+			bytes += size
+		}
+
+		series += countSeries(frame)
+		rows += int64(frame.Rows())
+		result = append(result, frame)
+
+		if len(notices) > 0 {
+			break
+		}
+	}
+
+	if len(notices) > 0 {
+		if len(result) == 0 {
+			result = append(result, data.NewFrame(""))
+		}
+		for _, text := range notices {
+			result[len(result)-1].AppendNotices(data.Notice{Severity: data.NoticeSeverityWarning, Text: text})
+		}
+	}
+
+	return result, nil
+}
+
+// countSeries returns the number of series in the frame, a wide time series frame has one series
+// per value field. Other frames count as a single series.
+func countSeries(frame *data.Frame) int64 {
+	if schema := frame.TimeSeriesSchema(); schema.Type == data.TimeSeriesTypeWide {
+		return int64(len(schema.ValueIndices))
+	}
+	return 1
+}
+
+// estimateFrameSize returns the approximate size in bytes of the frame values, computed from the
+// length of its fields. Marshaling the frame would double the memory used by large responses.
+func estimateFrameSize(frame *data.Frame) int64 {
+	size := int64(len(frame.Name))
+	for _, field := range frame.Fields {
+		size += int64(len(field.Name))
+		for k, v := range field.Labels {
+			size += int64(len(k) + len(v))
+		}
+
+		switch field.Type().NonNullableType() {
+		case data.FieldTypeString:
+			for i := 0; i < field.Len(); i++ {
+				if v, ok := field.ConcreteAt(i); ok {
+					size += int64(len(v.(string)))
+				}
+			}
+		case data.FieldTypeJSON:
+			for i := 0; i < field.Len(); i++ {
+				if v, ok := field.ConcreteAt(i); ok {
+					size += int64(len(v.(json.RawMessage)))
+				}
+			}
+		default:
+			size += int64(field.Len()) * valueSize(field.Type().NonNullableType())
+		}
+	}
+	return size
+}
+
+// valueSize returns the size in bytes of a value of a fixed size field type
+func valueSize(ft data.FieldType) int64 {
+	switch ft {
+	case data.FieldTypeInt8, data.FieldTypeUint8, data.FieldTypeBool:
+		return 1
+	case data.FieldTypeInt16, data.FieldTypeUint16, data.FieldTypeEnum:
+		return 2
+	case data.FieldTypeInt32, data.FieldTypeUint32, data.FieldTypeFloat32:
+		return 4
+	default:
+		return 8
+	}
+}
+
+// keepSeries returns the frame with at most n series, or nil if no series can be kept.
+func keepSeries(frame *data.Frame, n int64) *data.Frame {
+	if n <= 0 {
+		return nil
+	}
+	schema := frame.TimeSeriesSchema()
+	if schema.Type != data.TimeSeriesTypeWide {
+		return frame
+	}
+
+	drop := map[int]bool{}
+	for _, idx := range schema.ValueIndices[n:] {
+		drop[idx] = true
+	}
+	fields := make([]*data.Field, 0, len(frame.Fields)-len(drop))
+	for i, f := range frame.Fields {
+		if !drop[i] {
+			fields = append(fields, f)
+		}
+	}
+
+	truncated := *frame
+	truncated.Fields = fields
+	truncated.Meta = copyFrameMeta(frame.Meta)
+	return &truncated
+}
+
+// keepRows returns a copy of the frame with its first n rows.
+func keepRows(frame *data.Frame, n int) *data.Frame {
+	truncated := frame.EmptyCopy()
+	truncated.Meta = copyFrameMeta(frame.Meta)
+	for i := 0; i < n && i < frame.Rows(); i++ {
+		truncated.AppendRow(frame.RowCopy(i)...)
+	}
+	return truncated
+}
+
+// copyFrameMeta returns a copy of the frame metadata, so that notices can be added to a truncated
+// frame without changing the original frame.
+func copyFrameMeta(meta *data.FrameMeta) *data.FrameMeta {
+	if meta == nil {
+		return nil
+	}
+	cp := *meta
+	cp.Notices = append([]data.Notice(nil), meta.Notices...)
+	return &cp
+}
+
+func (m *QueryLimitsMiddleware) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return m.next.CallResource(ctx, req, sender)
+}
+
+func (m *QueryLimitsMiddleware) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	return m.next.CheckHealth(ctx, req)
+}
+
+func (m *QueryLimitsMiddleware) CollectMetrics(ctx context.Context, req *backend.CollectMetricsRequest) (*backend.CollectMetricsResult, error) {
+	return m.next.CollectMetrics(ctx, req)
+}
+
+func (m *QueryLimitsMiddleware) SubscribeStream(ctx context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	return m.next.SubscribeStream(ctx, req)
+}
+
+func (m *QueryLimitsMiddleware) PublishStream(ctx context.Context, req *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	return m.next.PublishStream(ctx, req)
+}
+
+func (m *QueryLimitsMiddleware) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	return m.next.RunStream(ctx, req, sender)
+}
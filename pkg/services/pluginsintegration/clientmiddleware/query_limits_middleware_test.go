@@ -0,0 +1,137 @@
+package clientmiddleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/plugins/manager/client/clienttest"
+	ngalertmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestQueryLimitsMiddleware(t *testing.T) {
+	wideFrame := func(series int, rows int) *data.Frame {
+		frame := data.NewFrame("", data.NewField("time", nil, make([]time.Time, rows)))
+		for i := 0; i < series; i++ {
+			frame.Fields = append(frame.Fields, data.NewField("value", data.Labels{"series": string(rune('a' + i))}, make([]float64, rows)))
+		}
+		return frame
+	}
+
+	query := func(t *testing.T, cfg *setting.Cfg, frames ...*data.Frame) backend.DataResponse {
+		t.Helper()
+
+		cdt := clienttest.NewClientDecoratorTest(t, clienttest.WithMiddlewares(NewQueryLimitsMiddleware(cfg)))
+		cdt.TestClient.QueryDataFunc = func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			return &backend.QueryDataResponse{Responses: backend.Responses{"A": {Frames: frames}}}, nil
+		}
+
+		resp, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{})
+		require.NoError(t, err)
+		return resp.Responses["A"]
+	}
+
+	newCfg := func(action string) *setting.Cfg {
+		cfg := setting.NewCfg()
+		cfg.QueryLimitExceededAction = action
+		return cfg
+	}
+
+	t.Run("does not change responses without limits", func(t *testing.T) {
+		frames := data.Frames{wideFrame(3, 10), wideFrame(2, 10)}
+		resp := query(t, newCfg("error"), frames...)
+		require.NoError(t, resp.Error)
+		require.Equal(t, frames, resp.Frames)
+	})
+
+	t.Run("fails responses with too many series", func(t *testing.T) {
+		cfg := newCfg("error")
+		cfg.QueryMaxSeries = 4
+		resp := query(t, cfg, wideFrame(3, 10), wideFrame(2, 10))
+		require.True(t, errors.Is(resp.Error, ErrQueryResponseLimitExceeded))
+		require.Empty(t, resp.Frames)
+	})
+
+	t.Run("truncates responses with too many series", func(t *testing.T) {
+		cfg := newCfg("truncate")
+		cfg.QueryMaxSeries = 4
+		resp := query(t, cfg, wideFrame(3, 10), wideFrame(2, 10), wideFrame(1, 10))
+		require.NoError(t, resp.Error)
+		require.Len(t, resp.Frames, 2)
+		require.Len(t, resp.Frames[0].Fields, 4)
+		require.Len(t, resp.Frames[1].Fields, 2)
+		require.Len(t, resp.Frames[1].Meta.Notices, 1)
+		require.Equal(t, "the response was truncated to 4 series", resp.Frames[1].Meta.Notices[0].Text)
+	})
+
+	t.Run("fails alert rule queries with too many series in truncate mode", func(t *testing.T) {
+		cfg := newCfg("truncate")
+		cfg.QueryMaxSeries = 4
+		cdt := clienttest.NewClientDecoratorTest(t, clienttest.WithMiddlewares(NewQueryLimitsMiddleware(cfg)))
+		cdt.TestClient.QueryDataFunc = func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			return &backend.QueryDataResponse{Responses: backend.Responses{"A": {Frames: data.Frames{wideFrame(3, 10), wideFrame(2, 10)}}}}, nil
+		}
+
+		resp, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{Headers: map[string]string{ngalertmodels.FromAlertHeaderName: "true"}})
+		require.NoError(t, err)
+		require.True(t, errors.Is(resp.Responses["A"].Error, ErrQueryResponseLimitExceeded))
+		require.Empty(t, resp.Responses["A"].Frames)
+	})
+
+	t.Run("fails responses with too many rows", func(t *testing.T) {
+		cfg := newCfg("error")
+		cfg.QueryMaxRows = 15
+		resp := query(t, cfg, wideFrame(1, 10), wideFrame(1, 10))
+		require.True(t, errors.Is(resp.Error, ErrQueryResponseLimitExceeded))
+	})
+
+	t.Run("truncates responses with too many rows", func(t *testing.T) {
+		cfg := newCfg("truncate")
+		cfg.QueryMaxRows = 15
+		last := wideFrame(1, 10)
+		last.Meta = &data.FrameMeta{ExecutedQueryString: "up"}
+		resp := query(t, cfg, wideFrame(1, 10), last, wideFrame(1, 10))
+		require.NoError(t, resp.Error)
+		require.Len(t, resp.Frames, 2)
+		require.Equal(t, 10, resp.Frames[0].Rows())
+		require.Equal(t, 5, resp.Frames[1].Rows())
+		require.Equal(t, "up", resp.Frames[1].Meta.ExecutedQueryString)
+		require.Equal(t, "the response was truncated to 15 rows", resp.Frames[1].Meta.Notices[0].Text)
+		require.Empty(t, last.Meta.Notices)
+	})
+
+	t.Run("fails responses that are too large", func(t *testing.T) {
+		cfg := newCfg("error")
+		cfg.QueryMaxResponseBytes = 100
+		resp := query(t, cfg, wideFrame(1, 100))
+		require.True(t, errors.Is(resp.Error, ErrQueryResponseLimitExceeded))
+	})
+
+	t.Run("estimates the size of string fields from their values", func(t *testing.T) {
+		cfg := newCfg("error")
+		cfg.QueryMaxResponseBytes = 1000
+		resp := query(t, cfg, data.NewFrame("", data.NewField("line", nil, []string{strings.Repeat("a", 600), strings.Repeat("b", 600)})))
+		require.True(t, errors.Is(resp.Error, ErrQueryResponseLimitExceeded))
+
+		resp = query(t, cfg, data.NewFrame("", data.NewField("line", nil, []*string{nil, util.Pointer(strings.Repeat("a", 600))})))
+		require.NoError(t, resp.Error)
+	})
+
+	t.Run("drops frames above the size limit", func(t *testing.T) {
+		cfg := newCfg("truncate")
+		cfg.QueryMaxResponseBytes = 10000
+		resp := query(t, cfg, wideFrame(1, 10), wideFrame(1, 10000))
+		require.NoError(t, resp.Error)
+		require.Len(t, resp.Frames, 1)
+		require.Equal(t, 10, resp.Frames[0].Rows())
+		require.Equal(t, "the response was truncated to 10000 bytes, 1 frames were dropped", resp.Frames[0].Meta.Notices[0].Text)
+	})
+}
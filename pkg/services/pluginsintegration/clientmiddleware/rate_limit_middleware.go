@@ -0,0 +1,266 @@
+package clientmiddleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/plugins"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util/errutil"
+)
+
+var errQueryLimitExceeded = errutil.TooManyRequests("datasource.queryLimitExceeded",
+	errutil.WithPublicMessage("Too many queries to the data source, try again later"))
+
+// rateLimitSettings are the query limits of a single data source. A zero value disables the limit.
+type rateLimitSettings struct {
+	MaxConcurrentQueries int
+	MaxQueriesPerSecond  float64
+	QueueTimeout         time.Duration
+}
+
+func (s rateLimitSettings) enabled() bool {
+	return s.MaxConcurrentQueries > 0 || s.MaxQueriesPerSecond > 0
+}
+
+// datasourceLimiter enforces the rateLimitSettings of a data source.
+type datasourceLimiter struct {
+	settings rateLimitSettings
+	updated  time.Time
+	slots    chan struct{}
+	limiter  *rate.Limiter
+}
+
+func newDatasourceLimiter(settings rateLimitSettings, updated time.Time) *datasourceLimiter {
+	l := &datasourceLimiter{settings: settings, updated: updated}
+	if settings.MaxConcurrentQueries > 0 {
+		l.slots = make(chan struct{}, settings.MaxConcurrentQueries)
+	}
+	if settings.MaxQueriesPerSecond > 0 {
+		burst := int(math.Max(1, math.Ceil(settings.MaxQueriesPerSecond)))
+		l.limiter = rate.NewLimiter(rate.Limit(settings.MaxQueriesPerSecond), burst)
+	}
+	return l
+}
+
+// acquire waits until the query is allowed to run, and returns a function to call once it is done.
+func (l *datasourceLimiter) acquire(ctx context.Context) (func(), error) {
+	if l.settings.QueueTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.settings.QueueTimeout)
+		defer cancel()
+	}
+
+	if l.limiter != nil {
+		if err := l.limiter.Wait(ctx); err != nil {
+			// Wait fails right away if the deadline would be exceeded before a token is available
+			if ctx.Err() == nil {
+				return nil, context.DeadlineExceeded
+			}
+			return nil, ctx.Err()
+		}
+	}
+
+	if l.slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case l.slots <- struct{}{}:
+		return func() { <-l.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// NewRateLimitMiddleware creates a new plugins.ClientMiddleware that limits the number of concurrent
+// queries and the queries per second sent to each data source. Queries above the limits are queued
+// until they can run or the queue timeout expires.
+//
+// The limits are read from the maxConcurrentQueries, maxQueriesPerSecond and queryQueueTimeout fields
+// of the data source jsonData, and default to the [datasources] settings.
+func NewRateLimitMiddleware(cfg *setting.Cfg, promRegisterer prometheus.Registerer) plugins.ClientMiddleware {
+	queued := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "grafana",
+		Name:      "datasource_queued_queries",
+		Help:      "Number of queries waiting for the query limits of a data source",
+	}, []string{"plugin_id", "datasource_uid"})
+	queueDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "grafana",
+		Name:      "datasource_query_queue_duration_seconds",
+		Help:      "Time queries waited for the query limits of a data source",
+		Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 25, 50},
+	}, []string{"plugin_id", "datasource_uid"})
+	rejected := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Name:      "datasource_rejected_queries_total",
+		Help:      "Number of queries rejected because they waited too long for the query limits of a data source",
+	}, []string{"plugin_id", "datasource_uid"})
+	promRegisterer.MustRegister(queued, queueDuration, rejected)
+
+	limiters := &dataSourceLimiters{
+		defaults: rateLimitSettings{
+			MaxConcurrentQueries: cfg.DataSourceMaxConcurrentQueries,
+			MaxQueriesPerSecond:  cfg.DataSourceMaxQueriesPerSecond,
+			QueueTimeout:         cfg.DataSourceQueryQueueTimeout,
+		},
+		limiters:      map[string]*datasourceLimiter{},
+		logger:        log.New("rate_limit_middleware"),
+		queued:        queued,
+		queueDuration: queueDuration,
+		rejected:      rejected,
+	}
+
+	return plugins.ClientMiddlewareFunc(func(next plugins.Client) plugins.Client {
+		return &RateLimitMiddleware{
+			dataSourceLimiters: limiters,
+			next:               next,
+		}
+	})
+}
+
+// dataSourceLimiters holds the limiters of all data sources. It is shared by the
+// RateLimitMiddleware instances, since the middlewares are created for every request.
+type dataSourceLimiters struct {
+	defaults rateLimitSettings
+
+	mu sync.Mutex
+	// limiters by organization and data source UID
+	limiters map[string]*datasourceLimiter
+	logger   log.Logger
+
+	queued        *prometheus.GaugeVec
+	queueDuration *prometheus.HistogramVec
+	rejected      *prometheus.CounterVec
+}
+
+type RateLimitMiddleware struct {
+	*dataSourceLimiters
+	next plugins.Client
+}
+
+// settings returns the query limits of the data source, overriding the defaults with its jsonData.
+func (m *dataSourceLimiters) settings(ds *backend.DataSourceInstanceSettings) (rateLimitSettings, error) {
+	settings := m.defaults
+	if len(ds.JSONData) == 0 {
+		return settings, nil
+	}
+
+	var jsonData struct {
+		MaxConcurrentQueries *int     `json:"maxConcurrentQueries"`
+		MaxQueriesPerSecond  *float64 `json:"maxQueriesPerSecond"`
+		QueryQueueTimeout    *string  `json:"queryQueueTimeout"`
+	}
+	if err := json.Unmarshal(ds.JSONData, &jsonData); err != nil {
+		return settings, err
+	}
+
+	if jsonData.MaxConcurrentQueries != nil {
+		settings.MaxConcurrentQueries = *jsonData.MaxConcurrentQueries
+	}
+	if jsonData.MaxQueriesPerSecond != nil {
+		settings.MaxQueriesPerSecond = *jsonData.MaxQueriesPerSecond
+	}
+	if jsonData.QueryQueueTimeout != nil && *jsonData.QueryQueueTimeout != "" {
+		timeout, err := time.ParseDuration(*jsonData.QueryQueueTimeout)
+		if err != nil {
+			return settings, fmt.Errorf("invalid queryQueueTimeout: %w", err)
+		}
+		settings.QueueTimeout = timeout
+	}
+
+	return settings, nil
+}
+
+// limiter returns the limiter of the data source, or nil if its queries are not limited.
+// The limiter is replaced when the data source is updated. Data sources with invalid limits
+// in their jsonData use the default limits.
+func (m *dataSourceLimiters) limiter(orgID int64, ds *backend.DataSourceInstanceSettings) *datasourceLimiter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// data source UIDs are only unique within an organization
+	key := fmt.Sprintf("%d/%s", orgID, ds.UID)
+	if l, ok := m.limiters[key]; ok && l.updated.Equal(ds.Updated) {
+		return l
+	}
+
+	settings, err := m.settings(ds)
+	if err != nil {
+		m.logger.Warn("Invalid query limits in data source jsonData, using the default limits", "orgId", orgID, "datasourceUid", ds.UID, "error", err)
+		settings = m.defaults
+	}
+	if !settings.enabled() {
+		delete(m.limiters, key)
+		return nil
+	}
+
+	l := newDatasourceLimiter(settings, ds.Updated)
+	m.limiters[key] = l
+	return l
+}
+
+func (m *RateLimitMiddleware) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	if req == nil || req.PluginContext.DataSourceInstanceSettings == nil {
+		return m.next.QueryData(ctx, req)
+	}
+
+	ds := req.PluginContext.DataSourceInstanceSettings
+	l := m.limiter(req.PluginContext.OrgID, ds)
+	if l == nil {
+		return m.next.QueryData(ctx, req)
+	}
+
+	labels := prometheus.Labels{"plugin_id": req.PluginContext.PluginID, "datasource_uid": ds.UID}
+	queued := m.queued.With(labels)
+	queued.Inc()
+	start := time.Now()
+	release, err := l.acquire(ctx)
+	queued.Dec()
+	m.queueDuration.With(labels).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			m.rejected.With(labels).Inc()
+			return nil, errQueryLimitExceeded.Errorf("query to data source %s waited more than %s for its query limits", ds.UID, l.settings.QueueTimeout)
+		}
+		return nil, err
+	}
+	defer release()
+
+	return m.next.QueryData(ctx, req)
+}
+
+func (m *RateLimitMiddleware) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return m.next.CallResource(ctx, req, sender)
+}
+
+func (m *RateLimitMiddleware) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	return m.next.CheckHealth(ctx, req)
+}
+
+func (m *RateLimitMiddleware) CollectMetrics(ctx context.Context, req *backend.CollectMetricsRequest) (*backend.CollectMetricsResult, error) {
+	return m.next.CollectMetrics(ctx, req)
+}
+
+func (m *RateLimitMiddleware) SubscribeStream(ctx context.Context, req *backend.SubscribeStreamRequest) (*backend.SubscribeStreamResponse, error) {
+	return m.next.SubscribeStream(ctx, req)
+}
+
+func (m *RateLimitMiddleware) PublishStream(ctx context.Context, req *backend.PublishStreamRequest) (*backend.PublishStreamResponse, error) {
+	return m.next.PublishStream(ctx, req)
+}
+
+func (m *RateLimitMiddleware) RunStream(ctx context.Context, req *backend.RunStreamRequest, sender *backend.StreamSender) error {
+	return m.next.RunStream(ctx, req, sender)
+}
@@ -0,0 +1,189 @@
+package clientmiddleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/plugins/manager/client/clienttest"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	newDecorator := func(t *testing.T, cfg *setting.Cfg, queryData backend.QueryDataHandlerFunc) (*clienttest.ClientDecoratorTest, *prometheus.Registry) {
+		t.Helper()
+
+		registry := prometheus.NewRegistry()
+		cdt := clienttest.NewClientDecoratorTest(t, clienttest.WithMiddlewares(NewRateLimitMiddleware(cfg, registry)))
+		if queryData != nil {
+			cdt.TestClient.QueryDataFunc = queryData
+		}
+		return cdt, registry
+	}
+
+	pluginCtx := func(jsonData string) backend.PluginContext {
+		return backend.PluginContext{
+			PluginID: "elasticsearch",
+			DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+				UID:      "es",
+				JSONData: []byte(jsonData),
+				Updated:  time.Unix(1, 0),
+			},
+		}
+	}
+
+	t.Run("does not limit queries by default", func(t *testing.T) {
+		cdt, _ := newDecorator(t, setting.NewCfg(), nil)
+
+		_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: pluginCtx(`{}`)})
+		require.NoError(t, err)
+		require.NotNil(t, cdt.QueryDataReq)
+	})
+
+	t.Run("limits concurrent queries from jsonData", func(t *testing.T) {
+		var running, maxRunning int32
+		cdt, _ := newDecorator(t, setting.NewCfg(), func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&maxRunning)
+				if n <= m || atomic.CompareAndSwapInt32(&maxRunning, m, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return &backend.QueryDataResponse{}, nil
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: pluginCtx(`{"maxConcurrentQueries": 2}`)})
+				require.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+	})
+
+	t.Run("limits queries per second", func(t *testing.T) {
+		cfg := setting.NewCfg()
+		cfg.DataSourceMaxQueriesPerSecond = 20
+		cfg.DataSourceQueryQueueTimeout = time.Second
+		cdt, _ := newDecorator(t, cfg, nil)
+
+		start := time.Now()
+		for i := 0; i < 30; i++ {
+			_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: pluginCtx(`{}`)})
+			require.NoError(t, err)
+		}
+		// The first 20 queries use the burst, the next 10 are spaced by 50ms
+		require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+	})
+
+	t.Run("rejects queries that wait longer than the queue timeout", func(t *testing.T) {
+		block := make(chan struct{})
+		cdt, registry := newDecorator(t, setting.NewCfg(), func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			<-block
+			return &backend.QueryDataResponse{}, nil
+		})
+		pCtx := pluginCtx(`{"maxConcurrentQueries": 1, "queryQueueTimeout": "50ms"}`)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: pCtx})
+			require.NoError(t, err)
+		}()
+
+		require.Eventually(t, func() bool {
+			_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: pCtx})
+			return errors.Is(err, errQueryLimitExceeded)
+		}, time.Second, 10*time.Millisecond)
+		close(block)
+		<-done
+
+		count, err := testutil.GatherAndCount(registry, "grafana_datasource_rejected_queries_total")
+		require.NoError(t, err)
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("limits the data sources of each organization separately", func(t *testing.T) {
+		block := make(chan struct{})
+		cdt, _ := newDecorator(t, setting.NewCfg(), func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			if req.PluginContext.OrgID == 1 {
+				<-block
+			}
+			return &backend.QueryDataResponse{}, nil
+		})
+		defer close(block)
+
+		org1 := pluginCtx(`{"maxConcurrentQueries": 1, "queryQueueTimeout": "10ms"}`)
+		org1.OrgID = 1
+		go func() {
+			_, _ = cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: org1})
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		org2 := pluginCtx(`{"maxConcurrentQueries": 1, "queryQueueTimeout": "10ms"}`)
+		org2.OrgID = 2
+		_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: org2})
+		require.NoError(t, err)
+	})
+
+	t.Run("uses the default limits if jsonData is invalid", func(t *testing.T) {
+		cfg := setting.NewCfg()
+		cfg.DataSourceMaxConcurrentQueries = 1
+		cfg.DataSourceQueryQueueTimeout = 10 * time.Millisecond
+		block := make(chan struct{})
+		cdt, _ := newDecorator(t, cfg, func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			<-block
+			return &backend.QueryDataResponse{}, nil
+		})
+		defer close(block)
+
+		invalid := pluginCtx(`{"maxConcurrentQueries": 5, "queryQueueTimeout": "soon"}`)
+		go func() {
+			_, _ = cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: invalid})
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: invalid})
+		require.ErrorIs(t, err, errQueryLimitExceeded)
+	})
+
+	t.Run("disables the default limits from jsonData", func(t *testing.T) {
+		cfg := setting.NewCfg()
+		cfg.DataSourceMaxConcurrentQueries = 1
+		cfg.DataSourceQueryQueueTimeout = 10 * time.Millisecond
+		block := make(chan struct{})
+		cdt, _ := newDecorator(t, cfg, func(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+			<-block
+			return &backend.QueryDataResponse{}, nil
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 3; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := cdt.Decorator.QueryData(context.Background(), &backend.QueryDataRequest{PluginContext: pluginCtx(`{"maxConcurrentQueries": 0}`)})
+				require.NoError(t, err)
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(block)
+		wg.Wait()
+	})
+}
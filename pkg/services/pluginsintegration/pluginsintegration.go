@@ -176,6 +176,17 @@ func CreateMiddlewares(cfg *setting.Cfg, oAuthTokenService oauthtoken.OAuthToken
 		middlewares = append(middlewares, clientmiddleware.NewUserHeaderMiddleware())
 	}
 
+	// Deduplicate the queries after the middlewares forwarding the user identity in headers,
+	// so only requests with the same identity are shared
+	if cfg.QueryDeduplicateConcurrentQueries {
+		middlewares = append(middlewares, clientmiddleware.NewDeduplicationMiddleware(promRegisterer))
+	}
+
+	// Limit the queries after the caching and deduplication middlewares, so cached and shared
+	// responses are not rate limited and only responses within the response limits are cached
+	middlewares = append(middlewares, clientmiddleware.NewRateLimitMiddleware(cfg, promRegisterer))
+	middlewares = append(middlewares, clientmiddleware.NewQueryLimitsMiddleware(cfg))
+
 	middlewares = append(middlewares, clientmiddleware.NewHTTPClientMiddleware())
 
 	return middlewares
@@ -61,18 +61,89 @@ func (ruleGroupV1 *AlertRuleGroupV1) MapToModel() (models.AlertRuleGroupWithFold
 }
 
 type AlertRuleV1 struct {
-	UID          values.StringValue    `json:"uid" yaml:"uid"`
-	Title        values.StringValue    `json:"title" yaml:"title"`
-	Condition    values.StringValue    `json:"condition" yaml:"condition"`
-	Data         []QueryV1             `json:"data" yaml:"data"`
-	DashboardUID values.StringValue    `json:"dasboardUid" yaml:"dashboardUid"`
-	PanelID      values.Int64Value     `json:"panelId" yaml:"panelId"`
-	NoDataState  values.StringValue    `json:"noDataState" yaml:"noDataState"`
-	ExecErrState values.StringValue    `json:"execErrState" yaml:"execErrState"`
-	For          values.StringValue    `json:"for" yaml:"for"`
-	Annotations  values.StringMapValue `json:"annotations" yaml:"annotations"`
-	Labels       values.StringMapValue `json:"labels" yaml:"labels"`
-	IsPaused     values.BoolValue      `json:"isPaused" yaml:"isPaused"`
+	UID                  values.StringValue      `json:"uid" yaml:"uid"`
+	Title                values.StringValue      `json:"title" yaml:"title"`
+	Condition            values.StringValue      `json:"condition" yaml:"condition"`
+	Data                 []QueryV1               `json:"data" yaml:"data"`
+	DashboardUID         values.StringValue      `json:"dasboardUid" yaml:"dashboardUid"`
+	PanelID              values.Int64Value       `json:"panelId" yaml:"panelId"`
+	NoDataState          values.StringValue      `json:"noDataState" yaml:"noDataState"`
+	ExecErrState         values.StringValue      `json:"execErrState" yaml:"execErrState"`
+	For                  values.StringValue      `json:"for" yaml:"for"`
+	KeepFiringFor        values.StringValue      `json:"keepFiringFor" yaml:"keepFiringFor"`
+	Annotations          values.StringMapValue   `json:"annotations" yaml:"annotations"`
+	Labels               values.StringMapValue   `json:"labels" yaml:"labels"`
+	IsPaused             values.BoolValue        `json:"isPaused" yaml:"isPaused"`
+	NotificationSettings *NotificationSettingsV1 `json:"notification_settings" yaml:"notification_settings"`
+	DependsOn            []values.StringValue    `json:"dependsOn" yaml:"dependsOn"`
+	FlapDetection        *FlapDetectionV1        `json:"flapDetection" yaml:"flapDetection"`
+}
+
+type FlapDetectionV1 struct {
+	Threshold values.IntValue    `json:"threshold" yaml:"threshold"`
+	Window    values.StringValue `json:"window" yaml:"window"`
+}
+
+func (fdV1 *FlapDetectionV1) mapToModel() (models.FlapDetection, error) {
+	f := models.FlapDetection{
+		Threshold: fdV1.Threshold.Value(),
+	}
+	if window := fdV1.Window.Value(); window != "" {
+		d, err := model.ParseDuration(window)
+		if err != nil {
+			return models.FlapDetection{}, fmt.Errorf("failed to parse flap detection window: %w", err)
+		}
+		f.Window = time.Duration(d)
+	}
+	if err := f.Validate(); err != nil {
+		return models.FlapDetection{}, err
+	}
+	return f, nil
+}
+
+type NotificationSettingsV1 struct {
+	Receiver          values.StringValue   `json:"receiver" yaml:"receiver"`
+	GroupBy           []values.StringValue `json:"group_by" yaml:"group_by"`
+	GroupWait         values.StringValue   `json:"group_wait" yaml:"group_wait"`
+	GroupInterval     values.StringValue   `json:"group_interval" yaml:"group_interval"`
+	RepeatInterval    values.StringValue   `json:"repeat_interval" yaml:"repeat_interval"`
+	MuteTimeIntervals []values.StringValue `json:"mute_time_intervals" yaml:"mute_time_intervals"`
+}
+
+func (nsV1 *NotificationSettingsV1) mapToModel() (models.NotificationSettings, error) {
+	s := models.NotificationSettings{
+		Receiver: nsV1.Receiver.Value(),
+	}
+	for _, groupBy := range nsV1.GroupBy {
+		s.GroupBy = append(s.GroupBy, groupBy.Value())
+	}
+	for _, mt := range nsV1.MuteTimeIntervals {
+		s.MuteTimeIntervals = append(s.MuteTimeIntervals, mt.Value())
+	}
+	parseDuration := func(name string, v values.StringValue) (*model.Duration, error) {
+		if v.Value() == "" {
+			return nil, nil
+		}
+		d, err := model.ParseDuration(v.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		return &d, nil
+	}
+	var err error
+	if s.GroupWait, err = parseDuration("group_wait", nsV1.GroupWait); err != nil {
+		return models.NotificationSettings{}, err
+	}
+	if s.GroupInterval, err = parseDuration("group_interval", nsV1.GroupInterval); err != nil {
+		return models.NotificationSettings{}, err
+	}
+	if s.RepeatInterval, err = parseDuration("repeat_interval", nsV1.RepeatInterval); err != nil {
+		return models.NotificationSettings{}, err
+	}
+	if err := s.Validate(); err != nil {
+		return models.NotificationSettings{}, err
+	}
+	return s, nil
 }
 
 func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
@@ -91,6 +162,13 @@ func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
 		return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse: %w", alertRule.Title, err)
 	}
 	alertRule.For = time.Duration(duration)
+	if keepFiringFor := rule.KeepFiringFor.Value(); keepFiringFor != "" {
+		duration, err = model.ParseDuration(keepFiringFor)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse: %w", alertRule.Title, err)
+		}
+		alertRule.KeepFiringFor = time.Duration(duration)
+	}
 	dashboardUID := rule.DashboardUID.Value()
 	alertRule.DashboardUID = &dashboardUID
 	panelID := rule.PanelID.Value()
@@ -130,6 +208,21 @@ func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
 		return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse: no data set", alertRule.Title)
 	}
 	alertRule.IsPaused = rule.IsPaused.Value()
+	for _, uid := range rule.DependsOn {
+		alertRule.DependsOn = append(alertRule.DependsOn, uid.Value())
+	}
+	if rule.NotificationSettings != nil {
+		alertRule.NotificationSettings, err = rule.NotificationSettings.mapToModel()
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse: %w", alertRule.Title, err)
+		}
+	}
+	if rule.FlapDetection != nil {
+		alertRule.FlapDetection, err = rule.FlapDetection.mapToModel()
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse: %w", alertRule.Title, err)
+		}
+	}
 	return alertRule, nil
 }
 
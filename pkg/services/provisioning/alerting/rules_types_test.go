@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 	"gopkg.in/yaml.v3"
 
@@ -125,6 +126,60 @@ func TestRules(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 48*time.Hour, ruleMapped.For)
 	})
+	t.Run("a rule with a keep firing for duration should work", func(t *testing.T) {
+		rule := validRuleV1(t)
+		keepFiringFor := values.StringValue{}
+		err := yaml.Unmarshal([]byte("15m"), &keepFiringFor)
+		rule.KeepFiringFor = keepFiringFor
+		require.NoError(t, err)
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, 15*time.Minute, ruleMapped.KeepFiringFor)
+	})
+	t.Run("a rule with an invalid keep firing for duration should error", func(t *testing.T) {
+		rule := validRuleV1(t)
+		keepFiringFor := values.StringValue{}
+		err := yaml.Unmarshal([]byte("10x"), &keepFiringFor)
+		rule.KeepFiringFor = keepFiringFor
+		require.NoError(t, err)
+		_, err = rule.mapToModel(1)
+		require.Error(t, err)
+	})
+	t.Run("a rule with notification settings should map them correctly", func(t *testing.T) {
+		rule := validRuleV1(t)
+		settings := NotificationSettingsV1{}
+		err := yaml.Unmarshal([]byte("receiver: test\ngroup_by: [alertname]\nrepeat_interval: 4h"), &settings)
+		require.NoError(t, err)
+		rule.NotificationSettings = &settings
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, "test", ruleMapped.NotificationSettings.Receiver)
+		require.Equal(t, []string{"alertname"}, ruleMapped.NotificationSettings.GroupBy)
+		require.Equal(t, model.Duration(4*time.Hour), *ruleMapped.NotificationSettings.RepeatInterval)
+	})
+	t.Run("a rule with notification settings without receiver should error", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.NotificationSettings = &NotificationSettingsV1{}
+		_, err := rule.mapToModel(1)
+		require.Error(t, err)
+	})
+	t.Run("a rule with flap detection should work", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.FlapDetection = &FlapDetectionV1{}
+		err := yaml.Unmarshal([]byte("threshold: 4\nwindow: 30m"), rule.FlapDetection)
+		require.NoError(t, err)
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, models.FlapDetection{Threshold: 4, Window: 30 * time.Minute}, ruleMapped.FlapDetection)
+	})
+	t.Run("a rule with flap detection without a window should error", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.FlapDetection = &FlapDetectionV1{}
+		err := yaml.Unmarshal([]byte("threshold: 4"), rule.FlapDetection)
+		require.NoError(t, err)
+		_, err = rule.mapToModel(1)
+		require.Error(t, err)
+	})
 	t.Run("a rule with out a condition should error", func(t *testing.T) {
 		rule := validRuleV1(t)
 		rule.Condition = values.StringValue{}
@@ -38,6 +38,11 @@ type FileReader struct {
 	mux                     sync.RWMutex
 	usageTracker            *usageTracker
 	dbWriteAccessRestricted bool
+
+	// git is set for providers backed by a git repository, commit holds
+	// the SHA of the commit currently checked out.
+	git    *gitRepository
+	commit string
 }
 
 // NewDashboardFileReader returns a new filereader based on `config`
@@ -88,6 +93,18 @@ func (fr *FileReader) pollChanges(ctx context.Context) {
 // and applies any change to the database.
 func (fr *FileReader) walkDisk(ctx context.Context) error {
 	fr.log.Debug("Start walking disk", "path", fr.Path)
+	if fr.git != nil {
+		commit, err := fr.git.sync(ctx)
+		if err != nil {
+			if fr.commit == "" {
+				return fmt.Errorf("failed to sync git repository: %w", err)
+			}
+			fr.log.Warn("Failed to sync git repository, using last checkout", "commit", fr.commit, "error", err)
+		} else {
+			fr.commit = commit
+		}
+	}
+
 	resolvedPath := fr.resolvedPath()
 	if _, err := os.Stat(resolvedPath); err != nil {
 		return err
@@ -246,6 +263,12 @@ func (fr *FileReader) saveDashboard(ctx context.Context, path string, folderID i
 	provisioningMetadata.identity = dashboardIdentity{title: dash.Dashboard.Title, folderID: dash.Dashboard.FolderID}
 
 	if upToDate {
+		// the commit changes even if the dashboard file does not
+		if provisionedData != nil && provisionedData.CommitSHA != fr.commit && !fr.isDatabaseAccessRestricted() {
+			if err := fr.dashboardProvisioningService.UpdateProvisionedDashboardCommitSHA(ctx, provisionedData.ID, fr.commit); err != nil {
+				return provisioningMetadata, err
+			}
+		}
 		return provisioningMetadata, nil
 	}
 
@@ -265,6 +288,7 @@ func (fr *FileReader) saveDashboard(ctx context.Context, path string, folderID i
 			Name:       fr.Cfg.Name,
 			Updated:    resolvedFileInfo.ModTime().Unix(),
 			CheckSum:   jsonFile.checkSum,
+			CommitSHA:  fr.commit,
 		}
 		_, err := fr.dashboardProvisioningService.SaveProvisionedDashboard(ctx, dash, dp)
 		if err != nil {
@@ -0,0 +1,185 @@
+package dashboards
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/provisioning/utils"
+)
+
+const defaultGitRef = "main"
+
+// gitRepository keeps a local checkout of a remote git repository pinned
+// to a branch, tag or commit.
+type gitRepository struct {
+	url       string
+	ref       string
+	directory string
+	auth      transport.AuthMethod
+	log       log.Logger
+
+	// fetchInterval is the minimum time between two fetches of the remote,
+	// lastFetch the time of the last successful clone or fetch.
+	fetchInterval time.Duration
+	lastFetch     time.Time
+}
+
+// NewDashboardGitReader returns a FileReader that reads dashboards from a
+// local checkout of the git repository described by `cfg`. The checkout is
+// refreshed before every walk of the disk, at most once per
+// `fetchIntervalSeconds` when the option is set.
+func NewDashboardGitReader(cfg *config, log log.Logger, service dashboards.DashboardProvisioningService, dashboardStore utils.DashboardStore) (*FileReader, error) {
+	url, _ := cfg.Options["url"].(string)
+	if url == "" {
+		return nil, fmt.Errorf("failed to load dashboards, url param is required for git providers")
+	}
+
+	ref, _ := cfg.Options["ref"].(string)
+	if ref == "" {
+		ref = defaultGitRef
+	}
+
+	directory, _ := cfg.Options["directory"].(string)
+	if directory == "" {
+		directory = filepath.Join(os.TempDir(), "grafana-provisioning", "git", cfg.Name)
+	}
+
+	subPath, _ := cfg.Options["path"].(string)
+	subPath = filepath.Clean(filepath.Join(string(filepath.Separator), subPath))
+
+	foldersFromFilesStructure, _ := cfg.Options["foldersFromFilesStructure"].(bool)
+	if foldersFromFilesStructure && cfg.Folder != "" && cfg.FolderUID != "" {
+		return nil, fmt.Errorf("'folder' and 'folderUID' should be empty using 'foldersFromFilesStructure' option")
+	}
+
+	fetchIntervalSeconds, _ := cfg.Options["fetchIntervalSeconds"].(int)
+	if fetchIntervalSeconds < 0 {
+		return nil, fmt.Errorf("failed to load dashboards, fetchIntervalSeconds must not be negative")
+	}
+
+	repo := &gitRepository{
+		url:           url,
+		ref:           ref,
+		directory:     directory,
+		log:           log,
+		fetchInterval: time.Duration(fetchIntervalSeconds) * time.Second,
+	}
+
+	username, _ := cfg.Options["username"].(string)
+	password, _ := cfg.Options["password"].(string)
+	if username != "" || password != "" {
+		repo.auth = &http.BasicAuth{Username: username, Password: password}
+	}
+
+	return &FileReader{
+		Cfg:                          cfg,
+		Path:                         filepath.Join(directory, subPath),
+		log:                          log,
+		dashboardProvisioningService: service,
+		dashboardStore:               dashboardStore,
+		FoldersFromFilesStructure:    foldersFromFilesStructure,
+		usageTracker:                 newUsageTracker(),
+		git:                          repo,
+	}, nil
+}
+
+// sync clones the repository, or fetches it when a clone already exists, and
+// checks out the configured ref. It returns the SHA of the checked out commit.
+func (r *gitRepository) sync(ctx context.Context) (string, error) {
+	repo, err := r.open(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	hash, err := r.resolve(repo)
+	if err != nil {
+		return "", err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	if err := worktree.Checkout(&git.CheckoutOptions{Hash: *hash, Force: true}); err != nil {
+		return "", fmt.Errorf("failed to checkout %s: %w", r.ref, err)
+	}
+
+	return hash.String(), nil
+}
+
+func (r *gitRepository) open(ctx context.Context) (*git.Repository, error) {
+	repo, err := git.PlainOpen(r.directory)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		r.log.Info("Cloning git repository", "url", r.url, "directory", r.directory)
+		repo, err := git.PlainCloneContext(ctx, r.directory, false, &git.CloneOptions{
+			URL:        r.url,
+			Auth:       r.auth,
+			NoCheckout: true,
+			Tags:       git.AllTags,
+		})
+		if err == nil {
+			r.lastFetch = time.Now()
+		}
+		return repo, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remote, err := repo.Remote(git.DefaultRemoteName)
+	if err != nil {
+		return nil, err
+	}
+	if urls := remote.Config().URLs; len(urls) == 0 || urls[0] != r.url {
+		return nil, fmt.Errorf("directory %s contains a clone of a different repository", r.directory)
+	}
+
+	// every fetch is a round trip to the remote, even when nothing changed
+	if !r.lastFetch.IsZero() && time.Since(r.lastFetch) < r.fetchInterval {
+		return repo, nil
+	}
+
+	err = repo.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs: []gitconfig.RefSpec{
+			"+refs/heads/*:refs/remotes/origin/*",
+			"+refs/tags/*:refs/tags/*",
+		},
+		Auth:  r.auth,
+		Tags:  git.AllTags,
+		Force: true,
+	})
+	if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("failed to fetch %s: %w", r.url, err)
+	}
+	r.lastFetch = time.Now()
+
+	return repo, nil
+}
+
+// resolve looks the ref up as a remote branch first, then as a tag and
+// finally as a commit SHA.
+func (r *gitRepository) resolve(repo *git.Repository) (*plumbing.Hash, error) {
+	candidates := []string{"refs/remotes/" + git.DefaultRemoteName + "/" + r.ref, "refs/tags/" + r.ref, r.ref}
+	for _, candidate := range candidates {
+		hash, err := repo.ResolveRevision(plumbing.Revision(candidate))
+		if err == nil {
+			return hash, nil
+		}
+	}
+
+	return nil, fmt.Errorf("reference %s not found in %s", r.ref, r.url)
+}
@@ -0,0 +1,219 @@
+package dashboards
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+)
+
+// testGitRemote is a bare repository on disk with a working copy used to
+// push commits to it.
+type testGitRemote struct {
+	t    *testing.T
+	url  string
+	work string
+	repo *git.Repository
+}
+
+func newTestGitRemote(t *testing.T) *testGitRemote {
+	t.Helper()
+
+	url := filepath.Join(t.TempDir(), "remote.git")
+	bare, err := git.PlainInit(url, true)
+	require.NoError(t, err)
+	require.NoError(t, bare.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, "refs/heads/main")))
+
+	work := t.TempDir()
+	repo, err := git.PlainInit(work, false)
+	require.NoError(t, err)
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{url}})
+	require.NoError(t, err)
+
+	return &testGitRemote{t: t, url: url, work: work, repo: repo}
+}
+
+// commit writes the dashboard to the sub-path and pushes it to the main branch.
+func (r *testGitRemote) commit(subPath string, dashboard []byte) string {
+	r.t.Helper()
+
+	dir := filepath.Join(r.work, subPath)
+	require.NoError(r.t, os.MkdirAll(dir, 0750))
+	require.NoError(r.t, os.WriteFile(filepath.Join(dir, "dashboard.json"), dashboard, 0600))
+
+	worktree, err := r.repo.Worktree()
+	require.NoError(r.t, err)
+	_, err = worktree.Add(".")
+	require.NoError(r.t, err)
+	hash, err := worktree.Commit("update dashboard", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	})
+	require.NoError(r.t, err)
+
+	head, err := r.repo.Head()
+	require.NoError(r.t, err)
+	err = r.repo.Push(&git.PushOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec("+" + head.Name() + ":refs/heads/main")},
+	})
+	require.NoError(r.t, err)
+
+	return hash.String()
+}
+
+func (r *testGitRemote) tag(name string) {
+	r.t.Helper()
+
+	head, err := r.repo.Head()
+	require.NoError(r.t, err)
+	_, err = r.repo.CreateTag(name, head.Hash(), nil)
+	require.NoError(r.t, err)
+	err = r.repo.Push(&git.PushOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []gitconfig.RefSpec{gitconfig.RefSpec("refs/tags/" + name + ":refs/tags/" + name)},
+	})
+	require.NoError(r.t, err)
+}
+
+func TestDashboardGitReader(t *testing.T) {
+	logger := log.New("test-logger")
+	fakeStore := &fakeDashboardStore{}
+
+	first, err := os.ReadFile(filepath.Join(oneDashboard, "dashboard1.json"))
+	require.NoError(t, err)
+	second, err := os.ReadFile(filepath.Join(containingID, "dashboard1.json"))
+	require.NoError(t, err)
+
+	remote := newTestGitRemote(t)
+	firstCommit := remote.commit("dashboards", first)
+	remote.tag("v1")
+	secondCommit := remote.commit("dashboards", second)
+
+	setup := func(options map[string]any) *config {
+		options["url"] = remote.url
+		options["path"] = "dashboards"
+		options["directory"] = filepath.Join(t.TempDir(), "checkout")
+		return &config{Name: configName, Type: "git", OrgID: 1, Options: options}
+	}
+
+	walk := func(t *testing.T, cfg *config) (*FileReader, *dashboards.DashboardProvisioning) {
+		t.Helper()
+
+		var saved *dashboards.DashboardProvisioning
+		fakeService := &dashboards.FakeDashboardProvisioning{}
+		fakeService.On("GetProvisionedDashboardData", mock.Anything, configName).Return(nil, nil).Once()
+		fakeService.On("SaveProvisionedDashboard", mock.Anything, mock.Anything, mock.Anything).
+			Return(&dashboards.Dashboard{}, nil).Once().
+			Run(func(args mock.Arguments) {
+				saved = args.Get(2).(*dashboards.DashboardProvisioning)
+			})
+
+		reader, err := NewDashboardGitReader(cfg, logger, fakeService, fakeStore)
+		require.NoError(t, err)
+		require.NoError(t, reader.walkDisk(context.Background()))
+		fakeService.AssertExpectations(t)
+		require.NotNil(t, saved)
+
+		return reader, saved
+	}
+
+	t.Run("requires a url", func(t *testing.T) {
+		_, err := NewDashboardGitReader(&config{Name: configName, Type: "git", Options: map[string]any{}}, logger, nil, fakeStore)
+		require.Error(t, err)
+	})
+
+	t.Run("provisions the default branch", func(t *testing.T) {
+		reader, saved := walk(t, setup(map[string]any{}))
+		assert.Equal(t, secondCommit, saved.CommitSHA)
+		assert.Equal(t, filepath.Join(reader.git.directory, "dashboards", "dashboard.json"), saved.ExternalID)
+	})
+
+	t.Run("provisions a tag", func(t *testing.T) {
+		_, saved := walk(t, setup(map[string]any{"ref": "v1"}))
+		assert.Equal(t, firstCommit, saved.CommitSHA)
+	})
+
+	t.Run("provisions a commit", func(t *testing.T) {
+		_, saved := walk(t, setup(map[string]any{"ref": firstCommit}))
+		assert.Equal(t, firstCommit, saved.CommitSHA)
+	})
+
+	t.Run("fetches new commits on an existing checkout", func(t *testing.T) {
+		cfg := setup(map[string]any{})
+		_, saved := walk(t, cfg)
+		assert.Equal(t, secondCommit, saved.CommitSHA)
+
+		third := remote.commit("dashboards", first)
+		_, saved = walk(t, cfg)
+		assert.Equal(t, third, saved.CommitSHA)
+	})
+
+	t.Run("updates the commit of unchanged dashboards", func(t *testing.T) {
+		cfg := setup(map[string]any{})
+		_, saved := walk(t, cfg)
+		saved.ID = 1
+
+		commit := remote.commit("other", first)
+		fakeService := &dashboards.FakeDashboardProvisioning{}
+		fakeService.On("GetProvisionedDashboardData", mock.Anything, configName).Return([]*dashboards.DashboardProvisioning{saved}, nil).Once()
+		fakeService.On("UpdateProvisionedDashboardCommitSHA", mock.Anything, int64(1), commit).Return(nil).Once()
+
+		reader, err := NewDashboardGitReader(cfg, logger, fakeService, fakeStore)
+		require.NoError(t, err)
+		require.NoError(t, reader.walkDisk(context.Background()))
+		fakeService.AssertExpectations(t)
+	})
+
+	t.Run("fetches at most once per fetch interval", func(t *testing.T) {
+		fakeService := &dashboards.FakeDashboardProvisioning{}
+		fakeService.On("GetProvisionedDashboardData", mock.Anything, configName).Return(nil, nil)
+		fakeService.On("SaveProvisionedDashboard", mock.Anything, mock.Anything, mock.Anything).Return(&dashboards.Dashboard{}, nil)
+
+		reader, err := NewDashboardGitReader(setup(map[string]any{"fetchIntervalSeconds": 3600}), logger, fakeService, fakeStore)
+		require.NoError(t, err)
+		require.NoError(t, reader.walkDisk(context.Background()))
+		commit := reader.commit
+
+		// the clone is recent, so the new commit is not fetched
+		remote.commit("other", second)
+		require.NoError(t, reader.walkDisk(context.Background()))
+		assert.Equal(t, commit, reader.commit)
+
+		reader.git.lastFetch = time.Now().Add(-2 * time.Hour)
+		require.NoError(t, reader.walkDisk(context.Background()))
+		assert.NotEqual(t, commit, reader.commit)
+	})
+
+	t.Run("rejects a negative fetch interval", func(t *testing.T) {
+		_, err := NewDashboardGitReader(setup(map[string]any{"fetchIntervalSeconds": -1}), logger, nil, fakeStore)
+		require.Error(t, err)
+	})
+
+	t.Run("fails on an unknown ref", func(t *testing.T) {
+		reader, err := NewDashboardGitReader(setup(map[string]any{"ref": "unknown"}), logger, nil, fakeStore)
+		require.NoError(t, err)
+		require.Error(t, reader.walkDisk(context.Background()))
+	})
+
+	t.Run("refuses to reuse a checkout of another repository", func(t *testing.T) {
+		cfg := setup(map[string]any{})
+		walk(t, cfg)
+
+		cfg.Options["url"] = filepath.Join(t.TempDir(), "other.git")
+		reader, err := NewDashboardGitReader(cfg, logger, nil, fakeStore)
+		require.NoError(t, err)
+		require.Error(t, reader.walkDisk(context.Background()))
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/registry"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
@@ -27,9 +28,12 @@ import (
 	"github.com/grafana/grafana/pkg/services/provisioning/datasources"
 	"github.com/grafana/grafana/pkg/services/provisioning/notifiers"
 	"github.com/grafana/grafana/pkg/services/provisioning/plugins"
+	"github.com/grafana/grafana/pkg/services/provisioning/teams"
 	"github.com/grafana/grafana/pkg/services/quota"
 	"github.com/grafana/grafana/pkg/services/searchV2"
 	"github.com/grafana/grafana/pkg/services/secrets"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/user"
 	"github.com/grafana/grafana/pkg/setting"
 )
 
@@ -51,6 +55,12 @@ func ProvideService(
 	quotaService quota.Service,
 	secrectService secrets.Service,
 	orgService org.Service,
+	teamService team.Service,
+	userService user.Service,
+	teamPermissionsService accesscontrol.TeamPermissionsService,
+	folderPermissionsService accesscontrol.FolderPermissionsService,
+	dashboardPermissionsService accesscontrol.DashboardPermissionsService,
+	kvStore kvstore.KVStore,
 ) (*ProvisioningServiceImpl, error) {
 	s := &ProvisioningServiceImpl{
 		Cfg:                          cfg,
@@ -64,6 +74,7 @@ func ProvideService(
 		provisionDatasources:         datasources.Provision,
 		provisionPlugins:             plugins.Provision,
 		provisionAlerting:            prov_alerting.Provision,
+		provisionTeams:               teams.Provision,
 		dashboardProvisioningService: dashboardProvisioningService,
 		dashboardService:             dashboardService,
 		datasourceService:            datasourceService,
@@ -75,6 +86,12 @@ func ProvideService(
 		secretService:                secrectService,
 		log:                          log.New("provisioning"),
 		orgService:                   orgService,
+		teamService:                  teamService,
+		userService:                  userService,
+		teamPermissionsService:       teamPermissionsService,
+		folderPermissionsService:     folderPermissionsService,
+		dashboardPermissionsService:  dashboardPermissionsService,
+		kvStore:                      kvStore,
 	}
 	return s, nil
 }
@@ -87,6 +104,7 @@ type ProvisioningService interface {
 	ProvisionNotifications(ctx context.Context) error
 	ProvisionDashboards(ctx context.Context) error
 	ProvisionAlerting(ctx context.Context) error
+	ProvisionTeams(ctx context.Context) error
 	GetDashboardProvisionerResolvedPath(name string) string
 	GetAllowUIUpdatesFromConfig(name string) bool
 }
@@ -135,6 +153,7 @@ type ProvisioningServiceImpl struct {
 	provisionDatasources         func(context.Context, string, datasources.Store, datasources.CorrelationsStore, org.Service) error
 	provisionPlugins             func(context.Context, string, pluginstore.Store, pluginsettings.Service, org.Service) error
 	provisionAlerting            func(context.Context, prov_alerting.ProvisionerConfig) error
+	provisionTeams               func(context.Context, teams.ProvisionerConfig) error
 	mutex                        sync.Mutex
 	dashboardProvisioningService dashboardservice.DashboardProvisioningService
 	dashboardService             dashboardservice.DashboardService
@@ -145,6 +164,12 @@ type ProvisioningServiceImpl struct {
 	searchService                searchV2.SearchService
 	quotaService                 quota.Service
 	secretService                secrets.Service
+	teamService                  team.Service
+	userService                  user.Service
+	teamPermissionsService       accesscontrol.TeamPermissionsService
+	folderPermissionsService     accesscontrol.FolderPermissionsService
+	dashboardPermissionsService  accesscontrol.DashboardPermissionsService
+	kvStore                      kvstore.KVStore
 }
 
 func (ps *ProvisioningServiceImpl) RunInitProvisioners(ctx context.Context) error {
@@ -185,6 +210,13 @@ func (ps *ProvisioningServiceImpl) Run(ctx context.Context) error {
 		ps.searchService.TriggerReIndex()
 	}
 
+	// Folder and dashboard permissions are provisioned once the provisioned dashboards exist
+	err = ps.ProvisionTeams(ctx)
+	if err != nil {
+		ps.log.Error("Failed to provision teams and permissions", "error", err)
+		return err
+	}
+
 	for {
 		// Wait for unlock. This is tied to new dashboardProvisioner to be instantiated before we start polling.
 		ps.mutex.Lock()
@@ -298,6 +330,28 @@ func (ps *ProvisioningServiceImpl) ProvisionAlerting(ctx context.Context) error
 	return ps.provisionAlerting(ctx, cfg)
 }
 
+func (ps *ProvisioningServiceImpl) ProvisionTeams(ctx context.Context) error {
+	if ps.provisionTeams == nil {
+		return nil
+	}
+	teamsPath := filepath.Join(ps.Cfg.ProvisioningPath, "teams")
+	if err := ps.provisionTeams(ctx, teams.ProvisionerConfig{
+		Path:                        teamsPath,
+		OrgService:                  ps.orgService,
+		TeamService:                 ps.teamService,
+		UserService:                 ps.userService,
+		TeamPermissionsService:      ps.teamPermissionsService,
+		FolderPermissionsService:    ps.folderPermissionsService,
+		DashboardPermissionsService: ps.dashboardPermissionsService,
+		KVStore:                     ps.kvStore,
+	}); err != nil {
+		err = fmt.Errorf("%v: %w", "Team provisioning error", err)
+		ps.log.Error("Failed to provision teams and permissions", "error", err)
+		return err
+	}
+	return nil
+}
+
 func (ps *ProvisioningServiceImpl) GetDashboardProvisionerResolvedPath(name string) string {
 	return ps.dashboardProvisioner.GetProvisionerResolvedPath(name)
 }
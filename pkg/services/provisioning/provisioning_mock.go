@@ -9,6 +9,7 @@ type Calls struct {
 	ProvisionNotifications              []any
 	ProvisionDashboards                 []any
 	ProvisionAlerting                   []any
+	ProvisionTeams                      []any
 	GetDashboardProvisionerResolvedPath []any
 	GetAllowUIUpdatesFromConfig         []any
 	Run                                 []any
@@ -77,6 +78,11 @@ func (mock *ProvisioningServiceMock) ProvisionAlerting(ctx context.Context) erro
 	return nil
 }
 
+func (mock *ProvisioningServiceMock) ProvisionTeams(ctx context.Context) error {
+	mock.Calls.ProvisionTeams = append(mock.Calls.ProvisionTeams, nil)
+	return nil
+}
+
 func (mock *ProvisioningServiceMock) GetDashboardProvisionerResolvedPath(name string) string {
 	mock.Calls.GetDashboardProvisionerResolvedPath = append(mock.Calls.GetDashboardProvisionerResolvedPath, name)
 	if mock.GetDashboardProvisionerResolvedPathFunc != nil {
@@ -0,0 +1,175 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/provisioning/utils"
+)
+
+const (
+	teamPermissionMember = "Member"
+	teamPermissionAdmin  = "Admin"
+)
+
+type configReader struct {
+	log        log.Logger
+	orgService org.Service
+}
+
+func (cr *configReader) readConfig(ctx context.Context, path string) ([]*configs, error) {
+	var result []*configs
+
+	files, err := os.ReadDir(path)
+	if err != nil {
+		cr.log.Error("Can't read team provisioning files from directory", "path", path, "error", err)
+		return result, nil
+	}
+
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".yaml") || strings.HasSuffix(file.Name(), ".yml") {
+			cfg, err := cr.parseConfig(path, file)
+			if err != nil {
+				return nil, err
+			}
+
+			if cfg != nil {
+				result = append(result, cfg)
+			}
+		}
+	}
+
+	if err := cr.validate(ctx, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (cr *configReader) parseConfig(path string, file fs.DirEntry) (*configs, error) {
+	filename, _ := filepath.Abs(filepath.Join(path, file.Name()))
+
+	// nolint:gosec
+	// We can ignore the gosec G304 warning on this one because `filename` comes from ps.Cfg.ProvisioningPath
+	yamlFile, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiVersion *configVersion
+	if err := yaml.Unmarshal(yamlFile, &apiVersion); err != nil {
+		return nil, err
+	}
+	if apiVersion == nil {
+		// empty file
+		return nil, nil
+	}
+	if apiVersion.APIVersion != 1 {
+		return nil, fmt.Errorf("%s: unsupported apiVersion %d", filename, apiVersion.APIVersion)
+	}
+
+	v1 := &configsV1{}
+	if err := yaml.Unmarshal(yamlFile, v1); err != nil {
+		return nil, err
+	}
+
+	return v1.mapToAccessFromConfig(apiVersion.APIVersion), nil
+}
+
+func (cr *configReader) validate(ctx context.Context, cfgs []*configs) error {
+	for _, cfg := range cfgs {
+		for _, team := range cfg.Teams {
+			if team.Name == "" {
+				return fmt.Errorf("team in configuration doesn't contain required field name")
+			}
+			if err := cr.validateOrgID(ctx, &team.OrgID); err != nil {
+				return fmt.Errorf("failed to provision %q team: %w", team.Name, err)
+			}
+			for _, member := range team.Members {
+				if member.Login == "" && member.Email == "" {
+					return fmt.Errorf("member of %q team doesn't contain required field login or email", team.Name)
+				}
+				if member.Permission == "" {
+					member.Permission = teamPermissionMember
+				}
+				if member.Permission != teamPermissionMember && member.Permission != teamPermissionAdmin {
+					return fmt.Errorf("member of %q team has invalid permission %q", team.Name, member.Permission)
+				}
+			}
+		}
+
+		for _, team := range cfg.DeleteTeams {
+			if team.Name == "" {
+				return fmt.Errorf("deleted team in configuration doesn't contain required field name")
+			}
+			if team.OrgID == 0 {
+				team.OrgID = 1
+			}
+		}
+
+		for _, resources := range [][]*resourcePermissionsFromConfig{cfg.FolderPermissions, cfg.DashboardPermissions} {
+			for _, resource := range resources {
+				if err := cr.validateResourcePermissions(ctx, resource); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cr *configReader) validateResourcePermissions(ctx context.Context, resource *resourcePermissionsFromConfig) error {
+	if resource.UID == "" {
+		return fmt.Errorf("permissions in configuration don't contain required field uid")
+	}
+	if err := cr.validateOrgID(ctx, &resource.OrgID); err != nil {
+		return fmt.Errorf("failed to provision permissions of %q: %w", resource.UID, err)
+	}
+
+	for _, p := range resource.Permissions {
+		assignments := 0
+		for _, assignee := range []string{p.Team, p.User, p.Role} {
+			if assignee != "" {
+				assignments++
+			}
+		}
+		if assignments != 1 {
+			return fmt.Errorf("permission of %q must be granted to exactly one of team, user or role", resource.UID)
+		}
+		if p.Role != "" && !org.RoleType(p.Role).IsValid() {
+			return fmt.Errorf("permission of %q is granted to invalid role %q", resource.UID, p.Role)
+		}
+		if p.Permission == "" {
+			return fmt.Errorf("permission of %q doesn't contain required field permission", resource.UID)
+		}
+		if len(p.RawConditions) > 0 {
+			raw, err := json.Marshal(p.RawConditions)
+			if err != nil {
+				return fmt.Errorf("permission of %q has invalid conditions: %w", resource.UID, err)
+			}
+			if p.Conditions, err = accesscontrol.ParseCondition(string(raw)); err != nil {
+				return fmt.Errorf("permission of %q has invalid conditions: %w", resource.UID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (cr *configReader) validateOrgID(ctx context.Context, orgID *int64) error {
+	if *orgID == 0 {
+		*orgID = 1
+	}
+	return utils.CheckOrgExists(ctx, cr.orgService, *orgID)
+}
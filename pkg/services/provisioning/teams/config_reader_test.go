@@ -0,0 +1,85 @@
+package teams
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+)
+
+const (
+	correctProperties = "./testdata/test-configs/correct-properties"
+	brokenYaml        = "./testdata/test-configs/broken-yaml"
+	emptyFolder       = "./testdata/test-configs/empty_folder"
+	invalidPermission = "./testdata/test-configs/invalid-permission"
+)
+
+func TestConfigReader(t *testing.T) {
+	orgService := &orgtest.FakeOrgService{ExpectedOrg: &org.Org{}}
+
+	t.Run("Broken yaml should return error", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgService: orgService}
+		_, err := reader.readConfig(context.Background(), brokenYaml)
+		require.Error(t, err)
+	})
+
+	t.Run("Skip invalid directory", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgService: orgService}
+		cfg, err := reader.readConfig(context.Background(), emptyFolder)
+		require.NoError(t, err)
+		require.Len(t, cfg, 0)
+	})
+
+	t.Run("Permission granted to several assignees should return error", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgService: orgService}
+		_, err := reader.readConfig(context.Background(), invalidPermission)
+		require.Error(t, err)
+		require.Equal(t, `permission of "infrastructure" must be granted to exactly one of team, user or role`, err.Error())
+	})
+
+	t.Run("Can read correct properties", func(t *testing.T) {
+		reader := &configReader{log: log.New("test logger"), orgService: orgService}
+		cfgs, err := reader.readConfig(context.Background(), correctProperties)
+		require.NoError(t, err)
+		require.Len(t, cfgs, 1)
+		cfg := cfgs[0]
+
+		require.Len(t, cfg.Teams, 1)
+		require.Equal(t, &teamFromConfig{
+			OrgID: 2,
+			Name:  "SRE",
+			Email: "sre@example.com",
+			Members: []*teamMemberFromConfig{
+				{Login: "alice", Permission: teamPermissionAdmin},
+				{Email: "bob@example.com", Permission: teamPermissionMember},
+			},
+		}, cfg.Teams[0])
+
+		require.Equal(t, []*deleteTeamConfig{{OrgID: 1, Name: "Legacy"}}, cfg.DeleteTeams)
+
+		require.Equal(t, []*resourcePermissionsFromConfig{{
+			OrgID: 2,
+			UID:   "infrastructure",
+			Permissions: []*permissionFromConfig{
+				{Team: "SRE", Permission: "Admin"},
+				{Role: "Viewer", Permission: "View"},
+			},
+		}}, cfg.FolderPermissions)
+
+		require.Equal(t, []*resourcePermissionsFromConfig{{
+			OrgID: 2,
+			UID:   "on-call",
+			Permissions: []*permissionFromConfig{{
+				User:          "alice",
+				Permission:    "Edit",
+				RawConditions: map[string]interface{}{"cidrs": []interface{}{"10.0.0.0/8"}},
+				Conditions:    &accesscontrol.Condition{CIDRs: []string{"10.0.0.0/8"}},
+			}},
+		}}, cfg.DashboardPermissions)
+	})
+}
@@ -0,0 +1,49 @@
+package teams
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+)
+
+// kvNamespace holds the teams and resource permissions managed by provisioning.
+// Keys are formatted as "<resource>:<resource id>", e.g. "teams:1" or "folders:abc".
+const kvNamespace = "provisioning.teams"
+
+const (
+	resourceTeams      = "teams"
+	resourceFolders    = "folders"
+	resourceDashboards = "dashboards"
+)
+
+// IsProvisioned returns true if the resource (team membership, folder or dashboard permissions) is managed
+// by provisioning and should not be modified through the API.
+func IsProvisioned(ctx context.Context, kv kvstore.KVStore, orgID int64, resource, resourceID string) (bool, error) {
+	_, ok, err := kvstore.WithNamespace(kv, orgID, kvNamespace).Get(ctx, provisionedKey(resource, resourceID))
+	return ok, err
+}
+
+// IsTeamProvisioned returns true if the team is managed by provisioning
+func IsTeamProvisioned(ctx context.Context, kv kvstore.KVStore, orgID, teamID int64) (bool, error) {
+	return IsProvisioned(ctx, kv, orgID, resourceTeams, strconv.FormatInt(teamID, 10))
+}
+
+func provisionedKey(resource, resourceID string) string {
+	return accesscontrol.Scope(resource, resourceID)
+}
+
+// ProvisionedStore reports which teams and resource permissions are managed by provisioning
+type ProvisionedStore struct {
+	kv kvstore.KVStore
+}
+
+func ProvideProvisionedStore(kv kvstore.KVStore) *ProvisionedStore {
+	return &ProvisionedStore{kv: kv}
+}
+
+// IsProvisioned returns true if the permissions of the resource are managed by provisioning
+func (s *ProvisionedStore) IsProvisioned(ctx context.Context, orgID int64, resource, resourceID string) (bool, error) {
+	return IsProvisioned(ctx, s.kv, orgID, resource, resourceID)
+}
@@ -0,0 +1,329 @@
+package teams
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auth/identity"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+// ProvisionerConfig holds the services used to provision teams and permissions
+type ProvisionerConfig struct {
+	Path                        string
+	OrgService                  org.Service
+	TeamService                 team.Service
+	UserService                 user.Service
+	TeamPermissionsService      accesscontrol.TeamPermissionsService
+	FolderPermissionsService    accesscontrol.FolderPermissionsService
+	DashboardPermissionsService accesscontrol.DashboardPermissionsService
+	KVStore                     kvstore.KVStore
+}
+
+// Provision scans a directory for provisioning config files
+// and provisions the teams, team members and permissions in those files.
+func Provision(ctx context.Context, cfg ProvisionerConfig) error {
+	logger := log.New("provisioning.teams")
+	p := &TeamsProvisioner{
+		log:                  logger,
+		cfgProvider:          &configReader{log: logger, orgService: cfg.OrgService},
+		teamService:          cfg.TeamService,
+		userService:          cfg.UserService,
+		teamPermissions:      cfg.TeamPermissionsService,
+		folderPermissions:    cfg.FolderPermissionsService,
+		dashboardPermissions: cfg.DashboardPermissionsService,
+		kv:                   cfg.KVStore,
+	}
+	return p.applyChanges(ctx, cfg.Path)
+}
+
+// TeamsProvisioner is responsible for provisioning teams, team members and
+// folder and dashboard permissions based on configuration read by the `configReader`
+type TeamsProvisioner struct {
+	log                  log.Logger
+	cfgProvider          *configReader
+	teamService          team.Service
+	userService          user.Service
+	teamPermissions      accesscontrol.TeamPermissionsService
+	folderPermissions    accesscontrol.PermissionsService
+	dashboardPermissions accesscontrol.PermissionsService
+	kv                   kvstore.KVStore
+}
+
+// provisionedKeys tracks the resources provisioned by the current configuration, per organization
+type provisionedKeys map[int64]map[string]bool
+
+func (k provisionedKeys) add(orgID int64, key string) {
+	if k[orgID] == nil {
+		k[orgID] = map[string]bool{}
+	}
+	k[orgID][key] = true
+}
+
+func (ap *TeamsProvisioner) applyChanges(ctx context.Context, configPath string) error {
+	cfgs, err := ap.cfgProvider.readConfig(ctx, configPath)
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range cfgs {
+		if err := ap.deleteTeams(ctx, cfg.DeleteTeams); err != nil {
+			return err
+		}
+	}
+
+	provisioned := provisionedKeys{}
+	for _, cfg := range cfgs {
+		for _, t := range cfg.Teams {
+			teamID, err := ap.provisionTeam(ctx, t)
+			if err != nil {
+				return fmt.Errorf("failed to provision %q team: %w", t.Name, err)
+			}
+			provisioned.add(t.OrgID, provisionedKey(resourceTeams, strconv.FormatInt(teamID, 10)))
+		}
+	}
+
+	for _, cfg := range cfgs {
+		for _, r := range cfg.FolderPermissions {
+			if err := ap.provisionPermissions(ctx, ap.folderPermissions, resourceFolders, r); err != nil {
+				return fmt.Errorf("failed to provision permissions of %q folder: %w", r.UID, err)
+			}
+			provisioned.add(r.OrgID, provisionedKey(resourceFolders, r.UID))
+		}
+		for _, r := range cfg.DashboardPermissions {
+			if err := ap.provisionPermissions(ctx, ap.dashboardPermissions, resourceDashboards, r); err != nil {
+				return fmt.Errorf("failed to provision permissions of %q dashboard: %w", r.UID, err)
+			}
+			provisioned.add(r.OrgID, provisionedKey(resourceDashboards, r.UID))
+		}
+	}
+
+	return ap.releaseRemoved(ctx, provisioned)
+}
+
+func (ap *TeamsProvisioner) deleteTeams(ctx context.Context, teams []*deleteTeamConfig) error {
+	for _, t := range teams {
+		existing, err := ap.getTeamByName(ctx, t.OrgID, t.Name)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			continue
+		}
+
+		if err := ap.teamService.DeleteTeam(ctx, &team.DeleteTeamCommand{OrgID: t.OrgID, ID: existing.ID}); err != nil && !errors.Is(err, team.ErrTeamNotFound) {
+			return err
+		}
+		if err := kvstore.WithNamespace(ap.kv, t.OrgID, kvNamespace).Del(ctx, provisionedKey(resourceTeams, strconv.FormatInt(existing.ID, 10))); err != nil {
+			return err
+		}
+		ap.log.Info("Deleted team based on configuration", "name", t.Name, "orgId", t.OrgID)
+	}
+	return nil
+}
+
+func (ap *TeamsProvisioner) provisionTeam(ctx context.Context, t *teamFromConfig) (int64, error) {
+	existing, err := ap.getTeamByName(ctx, t.OrgID, t.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	var teamID int64
+	if existing == nil {
+		ap.log.Info("Inserting team from configuration", "name", t.Name, "orgId", t.OrgID)
+		created, err := ap.teamService.CreateTeam(t.Name, t.Email, t.OrgID)
+		if err != nil {
+			return 0, err
+		}
+		teamID = created.ID
+	} else {
+		teamID = existing.ID
+		if existing.Email != t.Email {
+			ap.log.Debug("Updating team from configuration", "name", t.Name, "orgId", t.OrgID)
+			if err := ap.teamService.UpdateTeam(ctx, &team.UpdateTeamCommand{ID: teamID, OrgID: t.OrgID, Name: t.Name, Email: t.Email}); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := kvstore.WithNamespace(ap.kv, t.OrgID, kvNamespace).Set(ctx, provisionedKey(resourceTeams, strconv.FormatInt(teamID, 10)), t.Name); err != nil {
+		return 0, err
+	}
+
+	return teamID, ap.provisionTeamMembers(ctx, t, teamID)
+}
+
+// provisionTeamMembers makes the team members match the configuration.
+// Memberships synced from external systems, e.g. LDAP, are left untouched.
+func (ap *TeamsProvisioner) provisionTeamMembers(ctx context.Context, t *teamFromConfig, teamID int64) error {
+	teamIDString := strconv.FormatInt(teamID, 10)
+
+	current, err := ap.teamService.GetTeamMembers(ctx, &team.GetTeamMembersQuery{
+		OrgID:        t.OrgID,
+		TeamID:       teamID,
+		SignedInUser: provisionerUser(t.OrgID),
+	})
+	if err != nil {
+		return err
+	}
+
+	desired := map[int64]string{}
+	for _, m := range t.Members {
+		loginOrEmail := m.Login
+		if loginOrEmail == "" {
+			loginOrEmail = m.Email
+		}
+		u, err := ap.userService.GetByLogin(ctx, &user.GetUserByLoginQuery{LoginOrEmail: loginOrEmail})
+		if err != nil {
+			return fmt.Errorf("failed to find team member %q: %w", loginOrEmail, err)
+		}
+		desired[u.ID] = m.Permission
+	}
+
+	for _, member := range current {
+		if member.External {
+			continue
+		}
+		if _, ok := desired[member.UserID]; ok {
+			continue
+		}
+		if _, err := ap.teamPermissions.SetUserPermission(ctx, t.OrgID, accesscontrol.User{ID: member.UserID}, teamIDString, ""); err != nil {
+			return err
+		}
+	}
+
+	for userID, permission := range desired {
+		if _, err := ap.teamPermissions.SetUserPermission(ctx, t.OrgID, accesscontrol.User{ID: userID}, teamIDString, permission); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// provisionPermissions sets the permissions of a folder or dashboard. The permissions granted by the
+// previous configuration that are no longer part of it are removed.
+func (ap *TeamsProvisioner) provisionPermissions(ctx context.Context, service accesscontrol.PermissionsService, resource string, r *resourcePermissionsFromConfig) error {
+	kv := kvstore.WithNamespace(ap.kv, r.OrgID, kvNamespace)
+	key := provisionedKey(resource, r.UID)
+
+	commands := make([]accesscontrol.SetResourcePermissionCommand, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		cmd := accesscontrol.SetResourcePermissionCommand{BuiltinRole: p.Role, Permission: p.Permission, Conditions: p.Conditions}
+		switch {
+		case p.Team != "":
+			t, err := ap.getTeamByName(ctx, r.OrgID, p.Team)
+			if err != nil {
+				return err
+			}
+			if t == nil {
+				return fmt.Errorf("team %q: %w", p.Team, team.ErrTeamNotFound)
+			}
+			cmd.TeamID = t.ID
+		case p.User != "":
+			u, err := ap.userService.GetByLogin(ctx, &user.GetUserByLoginQuery{LoginOrEmail: p.User})
+			if err != nil {
+				return fmt.Errorf("failed to find user %q: %w", p.User, err)
+			}
+			cmd.UserID = u.ID
+		}
+		commands = append(commands, cmd)
+	}
+
+	previous, err := getProvisionedCommands(ctx, kv, key)
+	if err != nil {
+		return err
+	}
+	for _, prev := range previous {
+		if !containsAssignment(commands, prev) {
+			prev.Permission = ""
+			commands = append(commands, prev)
+		}
+	}
+
+	if _, err := service.SetPermissions(ctx, r.OrgID, r.UID, commands...); err != nil {
+		return err
+	}
+
+	granted := make([]accesscontrol.SetResourcePermissionCommand, 0, len(commands))
+	for _, cmd := range commands {
+		if cmd.Permission != "" {
+			granted = append(granted, cmd)
+		}
+	}
+	raw, err := json.Marshal(granted)
+	if err != nil {
+		return err
+	}
+	return kv.Set(ctx, key, string(raw))
+}
+
+// releaseRemoved makes the teams and permissions that were provisioned by a previous configuration editable again
+func (ap *TeamsProvisioner) releaseRemoved(ctx context.Context, provisioned provisionedKeys) error {
+	keys, err := ap.kv.Keys(ctx, kvstore.AllOrganizations, kvNamespace, "")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if provisioned[key.OrgId][key.Key] {
+			continue
+		}
+		ap.log.Info("Releasing resource removed from configuration", "key", key.Key, "orgId", key.OrgId)
+		if err := ap.kv.Del(ctx, key.OrgId, kvNamespace, key.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ap *TeamsProvisioner) getTeamByName(ctx context.Context, orgID int64, name string) (*team.TeamDTO, error) {
+	result, err := ap.teamService.SearchTeams(ctx, &team.SearchTeamsQuery{
+		OrgID:        orgID,
+		Name:         name,
+		Limit:        1,
+		SignedInUser: provisionerUser(orgID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Teams) == 0 {
+		return nil, nil
+	}
+	return result.Teams[0], nil
+}
+
+func getProvisionedCommands(ctx context.Context, kv *kvstore.NamespacedKVStore, key string) ([]accesscontrol.SetResourcePermissionCommand, error) {
+	raw, ok, err := kv.Get(ctx, key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var commands []accesscontrol.SetResourcePermissionCommand
+	if err := json.Unmarshal([]byte(raw), &commands); err != nil {
+		return nil, err
+	}
+	return commands, nil
+}
+
+func containsAssignment(commands []accesscontrol.SetResourcePermissionCommand, cmd accesscontrol.SetResourcePermissionCommand) bool {
+	for _, c := range commands {
+		if c.UserID == cmd.UserID && c.TeamID == cmd.TeamID && c.BuiltinRole == cmd.BuiltinRole {
+			return true
+		}
+	}
+	return false
+}
+
+func provisionerUser(orgID int64) identity.Requester {
+	return accesscontrol.BackgroundUser("teams_provisioning", orgID, org.RoleAdmin, []accesscontrol.Permission{
+		{Action: accesscontrol.ActionTeamsRead, Scope: accesscontrol.ScopeTeamsAll},
+		{Action: accesscontrol.ActionOrgUsersRead, Scope: accesscontrol.ScopeUsersAll},
+	})
+}
@@ -0,0 +1,184 @@
+package teams
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/actest"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/services/org/orgtest"
+	"github.com/grafana/grafana/pkg/services/team"
+	"github.com/grafana/grafana/pkg/services/team/teamtest"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/services/user/usertest"
+)
+
+func TestTeamsProvisioner(t *testing.T) {
+	ctx := context.Background()
+
+	kv := kvstore.NewFakeKVStore()
+	teams := &fakeTeamService{teams: map[string]*team.TeamDTO{"Legacy": {ID: 5, OrgID: 1, Name: "Legacy"}}}
+	users := &fakeUserService{users: map[string]int64{"alice": 10, "bob@example.com": 11}}
+	teamPermissions := &fakePermissionsService{}
+	folderPermissions := &fakePermissionsService{}
+	dashboardPermissions := &fakePermissionsService{}
+
+	provision := func(t *testing.T, path string) error {
+		t.Helper()
+		return Provision(ctx, ProvisionerConfig{
+			Path:                        path,
+			OrgService:                  &orgtest.FakeOrgService{ExpectedOrg: &org.Org{}},
+			TeamService:                 teams,
+			UserService:                 users,
+			TeamPermissionsService:      teamPermissions,
+			FolderPermissionsService:    folderPermissions,
+			DashboardPermissionsService: dashboardPermissions,
+			KVStore:                     kv,
+		})
+	}
+
+	t.Run("provisions teams and permissions", func(t *testing.T) {
+		require.NoError(t, provision(t, correctProperties))
+
+		assert.Equal(t, []int64{5}, teams.deleted)
+		sre := teams.teams["SRE"]
+		require.NotNil(t, sre)
+		assert.Equal(t, "sre@example.com", sre.Email)
+
+		assert.ElementsMatch(t, []setCall{
+			{resourceID: "1", cmd: accesscontrol.SetResourcePermissionCommand{UserID: 10, Permission: teamPermissionAdmin}},
+			{resourceID: "1", cmd: accesscontrol.SetResourcePermissionCommand{UserID: 11, Permission: teamPermissionMember}},
+		}, teamPermissions.calls)
+		assert.ElementsMatch(t, []setCall{
+			{resourceID: "infrastructure", cmd: accesscontrol.SetResourcePermissionCommand{TeamID: sre.ID, Permission: "Admin"}},
+			{resourceID: "infrastructure", cmd: accesscontrol.SetResourcePermissionCommand{BuiltinRole: "Viewer", Permission: "View"}},
+		}, folderPermissions.calls)
+		assert.Equal(t, []setCall{
+			{resourceID: "on-call", cmd: accesscontrol.SetResourcePermissionCommand{UserID: 10, Permission: "Edit", Conditions: &accesscontrol.Condition{CIDRs: []string{"10.0.0.0/8"}}}},
+		}, dashboardPermissions.calls)
+
+		for _, key := range []struct{ resource, id string }{{"teams", "1"}, {"folders", "infrastructure"}, {"dashboards", "on-call"}} {
+			provisioned, err := IsProvisioned(ctx, kv, 2, key.resource, key.id)
+			require.NoError(t, err)
+			assert.True(t, provisioned, key)
+		}
+	})
+
+	t.Run("removes the grants and members that are no longer configured", func(t *testing.T) {
+		teamPermissions.calls, folderPermissions.calls, dashboardPermissions.calls = nil, nil, nil
+		teams.members = []*team.TeamMemberDTO{{UserID: 10}, {UserID: 11}, {UserID: 12, External: true}}
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "teams.yaml"), []byte(`apiVersion: 1
+teams:
+  - name: SRE
+    orgId: 2
+    email: sre@example.com
+    members:
+      - login: alice
+folderPermissions:
+  - uid: infrastructure
+    orgId: 2
+    permissions:
+      - team: SRE
+        permission: Edit
+`), 0600))
+		require.NoError(t, provision(t, dir))
+
+		assert.ElementsMatch(t, []setCall{
+			{resourceID: "1", cmd: accesscontrol.SetResourcePermissionCommand{UserID: 11, Permission: ""}},
+			{resourceID: "1", cmd: accesscontrol.SetResourcePermissionCommand{UserID: 10, Permission: teamPermissionMember}},
+		}, teamPermissions.calls)
+		assert.ElementsMatch(t, []setCall{
+			{resourceID: "infrastructure", cmd: accesscontrol.SetResourcePermissionCommand{TeamID: 1, Permission: "Edit"}},
+			{resourceID: "infrastructure", cmd: accesscontrol.SetResourcePermissionCommand{BuiltinRole: "Viewer", Permission: ""}},
+		}, folderPermissions.calls)
+		assert.Empty(t, dashboardPermissions.calls)
+
+		// the dashboard is no longer provisioned and can be edited through the API again
+		provisioned, err := IsProvisioned(ctx, kv, 2, "dashboards", "on-call")
+		require.NoError(t, err)
+		assert.False(t, provisioned)
+		provisioned, err = IsTeamProvisioned(ctx, kv, 2, 1)
+		require.NoError(t, err)
+		assert.True(t, provisioned)
+	})
+}
+
+type fakeTeamService struct {
+	teamtest.FakeService
+	teams   map[string]*team.TeamDTO
+	members []*team.TeamMemberDTO
+	deleted []int64
+	nextID  int64
+}
+
+func (s *fakeTeamService) CreateTeam(name, email string, orgID int64) (team.Team, error) {
+	s.nextID++
+	s.teams[name] = &team.TeamDTO{ID: s.nextID, OrgID: orgID, Name: name, Email: email}
+	return team.Team{ID: s.nextID, OrgID: orgID, Name: name, Email: email}, nil
+}
+
+func (s *fakeTeamService) DeleteTeam(ctx context.Context, cmd *team.DeleteTeamCommand) error {
+	for name, t := range s.teams {
+		if t.ID == cmd.ID {
+			delete(s.teams, name)
+			s.deleted = append(s.deleted, cmd.ID)
+		}
+	}
+	return nil
+}
+
+func (s *fakeTeamService) SearchTeams(ctx context.Context, query *team.SearchTeamsQuery) (team.SearchTeamQueryResult, error) {
+	result := team.SearchTeamQueryResult{}
+	if t, ok := s.teams[query.Name]; ok {
+		result.Teams = append(result.Teams, t)
+	}
+	return result, nil
+}
+
+func (s *fakeTeamService) GetTeamMembers(ctx context.Context, query *team.GetTeamMembersQuery) ([]*team.TeamMemberDTO, error) {
+	return s.members, nil
+}
+
+type fakeUserService struct {
+	usertest.FakeUserService
+	users map[string]int64
+}
+
+func (s *fakeUserService) GetByLogin(ctx context.Context, query *user.GetUserByLoginQuery) (*user.User, error) {
+	id, ok := s.users[query.LoginOrEmail]
+	if !ok {
+		return nil, user.ErrUserNotFound
+	}
+	return &user.User{ID: id, Login: query.LoginOrEmail}, nil
+}
+
+type setCall struct {
+	resourceID string
+	cmd        accesscontrol.SetResourcePermissionCommand
+}
+
+type fakePermissionsService struct {
+	actest.FakePermissionsService
+	calls []setCall
+}
+
+func (s *fakePermissionsService) SetUserPermission(ctx context.Context, orgID int64, user accesscontrol.User, resourceID, permission string) (*accesscontrol.ResourcePermission, error) {
+	s.calls = append(s.calls, setCall{resourceID: resourceID, cmd: accesscontrol.SetResourcePermissionCommand{UserID: user.ID, Permission: permission}})
+	return nil, nil
+}
+
+func (s *fakePermissionsService) SetPermissions(ctx context.Context, orgID int64, resourceID string, commands ...accesscontrol.SetResourcePermissionCommand) ([]accesscontrol.ResourcePermission, error) {
+	for _, cmd := range commands {
+		s.calls = append(s.calls, setCall{resourceID: resourceID, cmd: cmd})
+	}
+	return nil, nil
+}
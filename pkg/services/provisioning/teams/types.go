@@ -0,0 +1,170 @@
+package teams
+
+import (
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/provisioning/values"
+)
+
+// configs is a normalized data object for teams and permissions config data. Any config version should be mappable
+// to this type.
+type configs struct {
+	APIVersion int64
+
+	Teams                []*teamFromConfig
+	DeleteTeams          []*deleteTeamConfig
+	FolderPermissions    []*resourcePermissionsFromConfig
+	DashboardPermissions []*resourcePermissionsFromConfig
+}
+
+type teamFromConfig struct {
+	OrgID   int64
+	Name    string
+	Email   string
+	Members []*teamMemberFromConfig
+}
+
+type teamMemberFromConfig struct {
+	Login      string
+	Email      string
+	Permission string
+}
+
+type deleteTeamConfig struct {
+	OrgID int64
+	Name  string
+}
+
+type resourcePermissionsFromConfig struct {
+	OrgID       int64
+	UID         string
+	Permissions []*permissionFromConfig
+}
+
+// permissionFromConfig grants a permission to exactly one of a team, a user or a basic role
+type permissionFromConfig struct {
+	Team       string
+	User       string
+	Role       string
+	Permission string
+	// RawConditions are the conditions as written in the configuration, parsed into Conditions on validation
+	RawConditions map[string]interface{}
+	Conditions    *accesscontrol.Condition
+}
+
+type configVersion struct {
+	APIVersion int64 `json:"apiVersion" yaml:"apiVersion"`
+}
+
+type configsV1 struct {
+	configVersion
+
+	Teams                []*teamFromConfigV1                `json:"teams" yaml:"teams"`
+	DeleteTeams          []*deleteTeamConfigV1              `json:"deleteTeams" yaml:"deleteTeams"`
+	FolderPermissions    []*resourcePermissionsFromConfigV1 `json:"folderPermissions" yaml:"folderPermissions"`
+	DashboardPermissions []*resourcePermissionsFromConfigV1 `json:"dashboardPermissions" yaml:"dashboardPermissions"`
+}
+
+type teamFromConfigV1 struct {
+	OrgID   values.Int64Value         `json:"orgId" yaml:"orgId"`
+	Name    values.StringValue        `json:"name" yaml:"name"`
+	Email   values.StringValue        `json:"email" yaml:"email"`
+	Members []*teamMemberFromConfigV1 `json:"members" yaml:"members"`
+}
+
+type teamMemberFromConfigV1 struct {
+	Login      values.StringValue `json:"login" yaml:"login"`
+	Email      values.StringValue `json:"email" yaml:"email"`
+	Permission values.StringValue `json:"permission" yaml:"permission"`
+}
+
+type deleteTeamConfigV1 struct {
+	OrgID values.Int64Value  `json:"orgId" yaml:"orgId"`
+	Name  values.StringValue `json:"name" yaml:"name"`
+}
+
+type resourcePermissionsFromConfigV1 struct {
+	OrgID       values.Int64Value         `json:"orgId" yaml:"orgId"`
+	UID         values.StringValue        `json:"uid" yaml:"uid"`
+	Permissions []*permissionFromConfigV1 `json:"permissions" yaml:"permissions"`
+}
+
+type permissionFromConfigV1 struct {
+	Team       values.StringValue `json:"team" yaml:"team"`
+	User       values.StringValue `json:"user" yaml:"user"`
+	Role       values.StringValue `json:"role" yaml:"role"`
+	Permission values.StringValue `json:"permission" yaml:"permission"`
+	Conditions values.JSONValue   `json:"conditions" yaml:"conditions"`
+}
+
+// mapToAccessFromConfig maps config syntax to a normalized configs object. Every version
+// of the config syntax should have this function.
+func (cfg *configsV1) mapToAccessFromConfig(apiVersion int64) *configs {
+	r := &configs{APIVersion: apiVersion}
+	if cfg == nil {
+		return r
+	}
+
+	for _, t := range cfg.Teams {
+		if t == nil {
+			continue
+		}
+		team := &teamFromConfig{
+			OrgID: t.OrgID.Value(),
+			Name:  t.Name.Value(),
+			Email: t.Email.Value(),
+		}
+		for _, m := range t.Members {
+			if m == nil {
+				continue
+			}
+			team.Members = append(team.Members, &teamMemberFromConfig{
+				Login:      m.Login.Value(),
+				Email:      m.Email.Value(),
+				Permission: m.Permission.Value(),
+			})
+		}
+		r.Teams = append(r.Teams, team)
+	}
+
+	for _, t := range cfg.DeleteTeams {
+		if t == nil {
+			continue
+		}
+		r.DeleteTeams = append(r.DeleteTeams, &deleteTeamConfig{
+			OrgID: t.OrgID.Value(),
+			Name:  t.Name.Value(),
+		})
+	}
+
+	r.FolderPermissions = mapResourcePermissions(cfg.FolderPermissions)
+	r.DashboardPermissions = mapResourcePermissions(cfg.DashboardPermissions)
+
+	return r
+}
+
+func mapResourcePermissions(resources []*resourcePermissionsFromConfigV1) []*resourcePermissionsFromConfig {
+	var r []*resourcePermissionsFromConfig
+	for _, res := range resources {
+		if res == nil {
+			continue
+		}
+		mapped := &resourcePermissionsFromConfig{
+			OrgID: res.OrgID.Value(),
+			UID:   res.UID.Value(),
+		}
+		for _, p := range res.Permissions {
+			if p == nil {
+				continue
+			}
+			mapped.Permissions = append(mapped.Permissions, &permissionFromConfig{
+				Team:          p.Team.Value(),
+				User:          p.User.Value(),
+				Role:          p.Role.Value(),
+				Permission:    p.Permission.Value(),
+				RawConditions: p.Conditions.Value(),
+			})
+		}
+		r = append(r, mapped)
+	}
+	return r
+}
@@ -358,6 +358,14 @@ func (f *fakeServiceAccountService) DeleteServiceAccountToken(ctx context.Contex
 	return f.ExpectedErr
 }
 
+func (f *fakeServiceAccountService) UpdateTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error {
+	return f.ExpectedErr
+}
+
+func (f *fakeServiceAccountService) ApplyTokenPolicy(ctx context.Context, orgID int64, secondsToLive int64) (int64, error) {
+	return secondsToLive, nil
+}
+
 func (f *fakeServiceAccountService) MigrateApiKeysToServiceAccounts(ctx context.Context, orgID int64) (*serviceaccounts.MigrationResult, error) {
 	fmt.Printf("fake migration result: %v", f.ExpectedMigrationResult)
 	return f.ExpectedMigrationResult, f.ExpectedErr
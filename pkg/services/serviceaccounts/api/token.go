@@ -144,21 +144,13 @@ func (api *ServiceAccountsAPI) CreateToken(c *contextmodel.ReqContext) response.
 	// Force affected service account to be the one referenced in the URL
 	cmd.OrgId = c.SignedInUser.GetOrgID()
 
-	if api.cfg.ApiKeyMaxSecondsToLive != -1 {
-		if cmd.SecondsToLive == 0 {
-			return response.Error(http.StatusBadRequest, "Number of seconds before expiration should be set", nil)
-		}
-		if cmd.SecondsToLive > api.cfg.ApiKeyMaxSecondsToLive {
-			return response.Error(http.StatusBadRequest, "Number of seconds before expiration is greater than the global limit", nil)
-		}
+	cmd.SecondsToLive, err = api.service.ApplyTokenPolicy(c.Req.Context(), cmd.OrgId, cmd.SecondsToLive)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to apply token policy", err)
 	}
 
-	if api.cfg.SATokenExpirationDayLimit > 0 {
-		dayExpireLimit := time.Now().Add(time.Duration(api.cfg.SATokenExpirationDayLimit) * time.Hour * 24).Truncate(24 * time.Hour)
-		expirationDate := time.Now().Add(time.Duration(cmd.SecondsToLive) * time.Second).Truncate(24 * time.Hour)
-		if expirationDate.After(dayExpireLimit) {
-			return response.Respond(http.StatusBadRequest, "The expiration date input exceeds the limit for service account access tokens expiration date")
-		}
+	if err := serviceaccounts.ValidateTokenLifetime(api.cfg, cmd.SecondsToLive); err != nil {
+		return response.ErrOrFallback(http.StatusBadRequest, "Invalid token expiration", err)
 	}
 
 	newKeyInfo, err := satokengen.New(ServiceID)
@@ -221,6 +213,105 @@ func (api *ServiceAccountsAPI) DeleteToken(c *contextmodel.ReqContext) response.
 	return response.Success("Service account token deleted")
 }
 
+// swagger:route POST /serviceaccounts/{serviceAccountId}/tokens/{tokenId}/rotate service_accounts rotateToken
+//
+// # RotateToken replaces a service account token
+//
+// A new token is added to the service account and the rotated token stays valid for `overlapSeconds`,
+// giving clients time to switch to the new token.
+//
+// Required permissions (See note in the [introduction](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#service-account-api) for an explanation):
+// action: `serviceaccounts:write` scope: `serviceaccounts:id:1` (single service account)
+//
+// Responses:
+// 200: createTokenResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 404: notFoundError
+// 500: internalServerError
+func (api *ServiceAccountsAPI) RotateToken(c *contextmodel.ReqContext) response.Response {
+	saID, err := strconv.ParseInt(web.Params(c.Req)[":serviceAccountId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "Service Account ID is invalid", err)
+	}
+
+	tokenID, err := strconv.ParseInt(web.Params(c.Req)[":tokenId"], 10, 64)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "Token ID is invalid", err)
+	}
+
+	cmd := serviceaccounts.RotateServiceAccountTokenCommand{}
+	if err = web.Bind(c.Req, &cmd); err != nil {
+		return response.Error(http.StatusBadRequest, "Bad request data", err)
+	}
+	cmd.OrgId = c.SignedInUser.GetOrgID()
+
+	newKeyInfo, err := satokengen.New(ServiceID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Generating service account token failed", err)
+	}
+	cmd.Key = newKeyInfo.HashedKey
+
+	apiKey, err := api.service.RotateServiceAccountToken(c.Req.Context(), saID, tokenID, &cmd)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to rotate service account token", err)
+	}
+
+	result := &dtos.NewApiKeyResult{
+		ID:   apiKey.ID,
+		Name: apiKey.Name,
+		Key:  newKeyInfo.ClientSecret,
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
+
+// swagger:route GET /serviceaccounts/token-policy service_accounts getTokenPolicy
+//
+// # Get the service account token policy of the organization
+//
+// Required permissions (See note in the [introduction](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#service-account-api) for an explanation):
+// action: `serviceaccounts:read` scope: `serviceaccounts:*`
+//
+// Responses:
+// 200: tokenPolicyResponse
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (api *ServiceAccountsAPI) GetTokenPolicy(c *contextmodel.ReqContext) response.Response {
+	policy, err := api.service.GetTokenPolicy(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to get token policy", err)
+	}
+	return response.JSON(http.StatusOK, policy)
+}
+
+// swagger:route PUT /serviceaccounts/token-policy service_accounts updateTokenPolicy
+//
+// # Update the service account token policy of the organization
+//
+// Required permissions (See note in the [introduction](https://grafana.com/docs/grafana/latest/developers/http_api/serviceaccount/#service-account-api) for an explanation):
+// action: `serviceaccounts.token-policy:write`
+//
+// Responses:
+// 200: tokenPolicyResponse
+// 400: badRequestError
+// 401: unauthorisedError
+// 403: forbiddenError
+// 500: internalServerError
+func (api *ServiceAccountsAPI) UpdateTokenPolicy(c *contextmodel.ReqContext) response.Response {
+	policy := serviceaccounts.TokenPolicy{}
+	if err := web.Bind(c.Req, &policy); err != nil {
+		return response.Error(http.StatusBadRequest, "Bad request data", err)
+	}
+
+	if err := api.service.UpdateTokenPolicy(c.Req.Context(), c.SignedInUser.GetOrgID(), &policy); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "Failed to update token policy", err)
+	}
+	return response.JSON(http.StatusOK, policy)
+}
+
 // swagger:parameters listTokens
 type ListTokensParams struct {
 	// in:path
@@ -243,6 +334,28 @@ type DeleteTokenParams struct {
 	ServiceAccountId int64 `json:"serviceAccountId"`
 }
 
+// swagger:parameters rotateToken
+type RotateTokenParams struct {
+	// in:path
+	TokenId int64 `json:"tokenId"`
+	// in:path
+	ServiceAccountId int64 `json:"serviceAccountId"`
+	// in:body
+	Body serviceaccounts.RotateServiceAccountTokenCommand
+}
+
+// swagger:parameters updateTokenPolicy
+type UpdateTokenPolicyParams struct {
+	// in:body
+	Body serviceaccounts.TokenPolicy
+}
+
+// swagger:response tokenPolicyResponse
+type TokenPolicyResponse struct {
+	// in:body
+	Body *serviceaccounts.TokenPolicy
+}
+
 // swagger:response listTokensResponse
 type ListTokensResponse struct {
 	// in:body
@@ -176,3 +176,40 @@ func TestServiceAccountsAPI_DeleteToken(t *testing.T) {
 		})
 	}
 }
+
+func TestServiceAccountsAPI_UpdateTokenPolicy(t *testing.T) {
+	type TestCase struct {
+		desc         string
+		permissions  []accesscontrol.Permission
+		expectedCode int
+	}
+
+	tests := []TestCase{
+		{
+			desc:         "should be able to update the token policy with correct permission",
+			permissions:  []accesscontrol.Permission{{Action: serviceaccounts.ActionTokenPolicyWrite}},
+			expectedCode: http.StatusOK,
+		},
+		{
+			desc:         "should not be able to update the token policy with service account write permission",
+			permissions:  []accesscontrol.Permission{{Action: serviceaccounts.ActionWrite, Scope: serviceaccounts.ScopeAll}},
+			expectedCode: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			server := setupTests(t, func(a *ServiceAccountsAPI) {
+				a.service = &fakeServiceAccountService{}
+			})
+
+			req := server.NewRequest(http.MethodPut, "/api/serviceaccounts/token-policy", strings.NewReader(`{"maxSecondsToLive": 3600}`))
+			webtest.RequestWithSignedInUser(req, &user.SignedInUser{OrgID: 1, Permissions: map[int64]map[string][]string{1: accesscontrol.GroupScopesByAction(tt.permissions)}})
+			res, err := server.SendJSON(req)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.expectedCode, res.StatusCode)
+			require.NoError(t, res.Body.Close())
+		})
+	}
+}
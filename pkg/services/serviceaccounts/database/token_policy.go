@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/infra/kvstore"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+)
+
+const (
+	kvNamespace                 = "serviceaccounts"
+	kvTokenPolicyKey            = "token_policy"
+	kvTokenExpiryNotifiedPrefix = "token_expiry_notified_"
+)
+
+// GetTokenPolicy returns the token policy of the organization, or an empty policy when none is set
+func (s *ServiceAccountsStoreImpl) GetTokenPolicy(ctx context.Context, orgID int64) (*serviceaccounts.TokenPolicy, error) {
+	policy := &serviceaccounts.TokenPolicy{}
+	value, exists, err := kvstore.WithNamespace(s.kvStore, orgID, kvNamespace).Get(ctx, kvTokenPolicyKey)
+	if err != nil || !exists {
+		return policy, err
+	}
+
+	if err := json.Unmarshal([]byte(value), policy); err != nil {
+		return nil, fmt.Errorf("failed to decode token policy: %w", err)
+	}
+	return policy, nil
+}
+
+// SetTokenPolicy stores the token policy of the organization
+func (s *ServiceAccountsStoreImpl) SetTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error {
+	value, err := json.Marshal(policy)
+	if err != nil {
+		return err
+	}
+	return kvstore.WithNamespace(s.kvStore, orgID, kvNamespace).Set(ctx, kvTokenPolicyKey, string(value))
+}
+
+// ListTokenPolicies returns the token policies of all organizations that have one
+func (s *ServiceAccountsStoreImpl) ListTokenPolicies(ctx context.Context) (map[int64]*serviceaccounts.TokenPolicy, error) {
+	keys, err := s.kvStore.Keys(ctx, kvstore.AllOrganizations, kvNamespace, kvTokenPolicyKey)
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[int64]*serviceaccounts.TokenPolicy, len(keys))
+	for _, key := range keys {
+		if key.Key != kvTokenPolicyKey {
+			continue
+		}
+		policy, err := s.GetTokenPolicy(ctx, key.OrgId)
+		if err != nil {
+			return nil, err
+		}
+		policies[key.OrgId] = policy
+	}
+	return policies, nil
+}
+
+// IsTokenExpiryNotified returns true if the expiry notification of the token has already been sent to the channel
+func (s *ServiceAccountsStoreImpl) IsTokenExpiryNotified(ctx context.Context, orgID, tokenID int64, channel string) (bool, error) {
+	_, exists, err := kvstore.WithNamespace(s.kvStore, orgID, kvNamespace).Get(ctx, tokenExpiryNotifiedKey(tokenID, channel))
+	return exists, err
+}
+
+// SetTokenExpiryNotified records that the expiry notification of the token has been sent to the channel
+func (s *ServiceAccountsStoreImpl) SetTokenExpiryNotified(ctx context.Context, orgID, tokenID int64, channel string) error {
+	return kvstore.WithNamespace(s.kvStore, orgID, kvNamespace).Set(ctx, tokenExpiryNotifiedKey(tokenID, channel), "true")
+}
+
+// DeleteTokenExpiryNotified removes the records of the expiry notifications of the token on all channels
+func (s *ServiceAccountsStoreImpl) DeleteTokenExpiryNotified(ctx context.Context, orgID, tokenID int64) error {
+	kv := kvstore.WithNamespace(s.kvStore, orgID, kvNamespace)
+	keys, err := kv.Keys(ctx, kvTokenExpiryNotifiedPrefix)
+	if err != nil {
+		return err
+	}
+	suffix := "_" + strconv.FormatInt(tokenID, 10)
+	for _, key := range keys {
+		if !strings.HasSuffix(key.Key, suffix) {
+			continue
+		}
+		if err := kv.Del(ctx, key.Key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func tokenExpiryNotifiedKey(tokenID int64, channel string) string {
+	return kvTokenExpiryNotifiedPrefix + channel + "_" + strconv.FormatInt(tokenID, 10)
+}
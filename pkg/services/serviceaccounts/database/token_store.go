@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/services/apikey"
@@ -41,6 +42,40 @@ func (s *ServiceAccountsStoreImpl) ListTokens(
 	return result, err
 }
 
+// ListExpiringTokens returns all the service account tokens of the organization that expire between from and to,
+// as unix timestamps. Unlike ListTokens, the result is not capped.
+func (s *ServiceAccountsStoreImpl) ListExpiringTokens(ctx context.Context, orgID, from, to int64) ([]apikey.APIKey, error) {
+	result := make([]apikey.APIKey, 0)
+	err := s.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		err := sess.Where("org_id = ? AND service_account_id IS NOT NULL", orgID).
+			And("expires BETWEEN ? AND ?", from, to).
+			Asc("expires").
+			Find(&result)
+		if err != nil {
+			return fmt.Errorf("%s: %w", "list expiring tokens error", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// ListActiveTokens returns all the service account tokens of the organization that are neither expired nor revoked.
+// Unlike ListTokens, the result is not capped.
+func (s *ServiceAccountsStoreImpl) ListActiveTokens(ctx context.Context, orgID int64) ([]apikey.APIKey, error) {
+	result := make([]apikey.APIKey, 0)
+	err := s.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		err := sess.Where("org_id = ? AND service_account_id IS NOT NULL", orgID).
+			And("(expires IS NULL OR expires > ?)", time.Now().Unix()).
+			And("(is_revoked IS NULL OR is_revoked = ?)", false).
+			Find(&result)
+		if err != nil {
+			return fmt.Errorf("%s: %w", "list active tokens error", err)
+		}
+		return nil
+	})
+	return result, err
+}
+
 func (s *ServiceAccountsStoreImpl) AddServiceAccountToken(ctx context.Context, serviceAccountId int64, cmd *serviceaccounts.AddServiceAccountTokenCommand) (*apikey.APIKey, error) {
 	var apiKey *apikey.APIKey
 
@@ -131,3 +166,26 @@ func (s *ServiceAccountsStoreImpl) assignApiKeyToServiceAccount(ctx context.Cont
 		return nil
 	})
 }
+
+// InTransaction runs fn in a transaction, so that the store calls made with its context are committed together
+func (s *ServiceAccountsStoreImpl) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.sqlStore.InTransaction(ctx, fn)
+}
+
+// UpdateServiceAccountTokenExpiry sets the expiration of the token, as a unix timestamp
+func (s *ServiceAccountsStoreImpl) UpdateServiceAccountTokenExpiry(ctx context.Context, orgId, serviceAccountId, tokenId, expires int64) error {
+	rawSQL := "UPDATE api_key SET expires = ? WHERE id=? and org_id=? and service_account_id=?"
+
+	return s.sqlStore.WithDbSession(ctx, func(sess *db.Session) error {
+		result, err := sess.Exec(rawSQL, expires, tokenId, orgId, serviceAccountId)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if affected == 0 {
+			return serviceaccounts.ErrServiceAccountTokenNotFound.Errorf("service account token with id %d not found for service account with id %d", tokenId, serviceAccountId)
+		}
+
+		return err
+	})
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -176,3 +177,154 @@ func TestStore_DeleteServiceAccountToken(t *testing.T) {
 		}
 	}
 }
+
+func TestStore_UpdateServiceAccountTokenExpiry(t *testing.T) {
+	userToCreate := tests.TestUser{Login: "servicetestwithTeam@admin", IsServiceAccount: true}
+	db, store := setupTestDatabase(t)
+	sa := tests.SetupUserServiceAccount(t, db, userToCreate)
+
+	keyName := t.Name()
+	key, err := apikeygen.New(sa.OrgID, keyName)
+	require.NoError(t, err)
+
+	cmd := serviceaccounts.AddServiceAccountTokenCommand{
+		Name:          keyName,
+		OrgId:         sa.OrgID,
+		Key:           key.HashedKey,
+		SecondsToLive: 0,
+	}
+
+	newKey, err := store.AddServiceAccountToken(context.Background(), sa.ID, &cmd)
+	require.NoError(t, err)
+
+	expires := time.Now().Add(time.Hour).Unix()
+	err = store.UpdateServiceAccountTokenExpiry(context.Background(), sa.OrgID, sa.ID, newKey.ID, expires)
+	require.NoError(t, err)
+
+	keys, err := store.ListTokens(context.Background(), &serviceaccounts.GetSATokensQuery{
+		OrgID:            &sa.OrgID,
+		ServiceAccountID: &sa.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.NotNil(t, keys[0].Expires)
+	require.Equal(t, expires, *keys[0].Expires)
+
+	err = store.UpdateServiceAccountTokenExpiry(context.Background(), sa.OrgID, sa.ID, newKey.ID+1, expires)
+	require.ErrorIs(t, err, serviceaccounts.ErrServiceAccountTokenNotFound)
+}
+
+func TestStore_TokenPolicy(t *testing.T) {
+	_, store := setupTestDatabase(t)
+	ctx := context.Background()
+
+	policy, err := store.GetTokenPolicy(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, &serviceaccounts.TokenPolicy{}, policy)
+
+	expected := &serviceaccounts.TokenPolicy{
+		MaxSecondsToLive:     90 * 24 * 3600,
+		ExpiryWarningSeconds: 7 * 24 * 3600,
+		NotificationEmails:   []string{"admin@example.com"},
+	}
+	require.NoError(t, store.SetTokenPolicy(ctx, 1, expected))
+
+	policy, err = store.GetTokenPolicy(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, expected, policy)
+
+	// policies are stored per organization
+	policy, err = store.GetTokenPolicy(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, &serviceaccounts.TokenPolicy{}, policy)
+
+	notified, err := store.IsTokenExpiryNotified(ctx, 1, 10, "email")
+	require.NoError(t, err)
+	require.False(t, notified)
+	require.NoError(t, store.SetTokenExpiryNotified(ctx, 1, 10, "email"))
+	notified, err = store.IsTokenExpiryNotified(ctx, 1, 10, "email")
+	require.NoError(t, err)
+	require.True(t, notified)
+
+	// notifications are stored per channel
+	notified, err = store.IsTokenExpiryNotified(ctx, 1, 10, "webhook")
+	require.NoError(t, err)
+	require.False(t, notified)
+
+	policies, err := store.ListTokenPolicies(ctx)
+	require.NoError(t, err)
+	require.Equal(t, map[int64]*serviceaccounts.TokenPolicy{1: expected}, policies)
+
+	// the notifications of a token are deleted on all channels, without affecting other tokens
+	require.NoError(t, store.SetTokenExpiryNotified(ctx, 1, 10, "webhook"))
+	require.NoError(t, store.SetTokenExpiryNotified(ctx, 1, 110, "email"))
+	require.NoError(t, store.DeleteTokenExpiryNotified(ctx, 1, 10))
+	for _, channel := range []string{"email", "webhook"} {
+		notified, err = store.IsTokenExpiryNotified(ctx, 1, 10, channel)
+		require.NoError(t, err)
+		require.False(t, notified)
+	}
+	notified, err = store.IsTokenExpiryNotified(ctx, 1, 110, "email")
+	require.NoError(t, err)
+	require.True(t, notified)
+}
+
+func TestStore_ListExpiringTokens(t *testing.T) {
+	userToCreate := tests.TestUser{Login: "servicetestwithTeam@admin", IsServiceAccount: true}
+	db, store := setupTestDatabase(t)
+	sa := tests.SetupUserServiceAccount(t, db, userToCreate)
+	ctx := context.Background()
+
+	for name, secondsToLive := range map[string]int64{"soon": 3600, "later": 30 * 24 * 3600, "never": 0} {
+		key, err := apikeygen.New(sa.OrgID, name)
+		require.NoError(t, err)
+		_, err = store.AddServiceAccountToken(ctx, sa.ID, &serviceaccounts.AddServiceAccountTokenCommand{
+			Name:          name,
+			OrgId:         sa.OrgID,
+			Key:           key.HashedKey,
+			SecondsToLive: secondsToLive,
+		})
+		require.NoError(t, err)
+	}
+
+	now := time.Now()
+	keys, err := store.ListExpiringTokens(ctx, sa.OrgID, now.Unix(), now.Add(24*time.Hour).Unix())
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, "soon", keys[0].Name)
+
+	keys, err = store.ListExpiringTokens(ctx, sa.OrgID+1, now.Unix(), now.Add(24*time.Hour).Unix())
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
+
+func TestStore_ListActiveTokens(t *testing.T) {
+	userToCreate := tests.TestUser{Login: "servicetestwithTeam@admin", IsServiceAccount: true}
+	db, store := setupTestDatabase(t)
+	sa := tests.SetupUserServiceAccount(t, db, userToCreate)
+	ctx := context.Background()
+
+	ids := map[string]int64{}
+	for _, name := range []string{"active", "revoked", "expired"} {
+		key, err := apikeygen.New(sa.OrgID, name)
+		require.NoError(t, err)
+		token, err := store.AddServiceAccountToken(ctx, sa.ID, &serviceaccounts.AddServiceAccountTokenCommand{
+			Name:  name,
+			OrgId: sa.OrgID,
+			Key:   key.HashedKey,
+		})
+		require.NoError(t, err)
+		ids[name] = token.ID
+	}
+	require.NoError(t, store.RevokeServiceAccountToken(ctx, sa.OrgID, sa.ID, ids["revoked"]))
+	require.NoError(t, store.UpdateServiceAccountTokenExpiry(ctx, sa.OrgID, sa.ID, ids["expired"], time.Now().Add(-time.Hour).Unix()))
+
+	keys, err := store.ListActiveTokens(ctx, sa.OrgID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	require.Equal(t, "active", keys[0].Name)
+
+	keys, err = store.ListActiveTokens(ctx, sa.OrgID+1)
+	require.NoError(t, err)
+	require.Empty(t, keys)
+}
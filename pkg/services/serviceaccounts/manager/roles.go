@@ -69,7 +69,22 @@ func RegisterRoles(service accesscontrol.Service) error {
 		Grants: []string{string(org.RoleAdmin)},
 	}
 
-	if err := service.DeclareFixedRoles(saReader, saCreator, saWriter); err != nil {
+	saTokenPolicyWriter := accesscontrol.RoleRegistration{
+		Role: accesscontrol.RoleDTO{
+			Name:        "fixed:serviceaccounts.token-policy:writer",
+			DisplayName: "Service account token policy writer",
+			Description: "Update the service account token policy of the organization.",
+			Group:       "Service accounts",
+			Permissions: []accesscontrol.Permission{
+				{
+					Action: serviceaccounts.ActionTokenPolicyWrite,
+				},
+			},
+		},
+		Grants: []string{string(org.RoleAdmin)},
+	}
+
+	if err := service.DeclareFixedRoles(saReader, saCreator, saWriter, saTokenPolicyWriter); err != nil {
 		return err
 	}
 
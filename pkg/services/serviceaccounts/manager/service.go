@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/grafana/pkg/infra/usagestats"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts/api"
@@ -25,6 +26,7 @@ import (
 const (
 	metricsCollectionInterval = time.Minute * 30
 	defaultSecretScanInterval = time.Minute * 5
+	tokenExpiryCheckInterval  = time.Hour
 )
 
 type ServiceAccountsService struct {
@@ -35,6 +37,10 @@ type ServiceAccountsService struct {
 
 	secretScanEnabled  bool
 	secretScanInterval time.Duration
+
+	notificationService notifications.Service
+	appURL              string
+	cfg                 *setting.Cfg
 }
 
 func ProvideServiceAccountsService(
@@ -49,6 +55,7 @@ func ProvideServiceAccountsService(
 	orgService org.Service,
 	permissionService accesscontrol.ServiceAccountPermissionsService,
 	accesscontrolService accesscontrol.Service,
+	notificationService notifications.Service,
 ) (*ServiceAccountsService, error) {
 	serviceAccountsStore := database.ProvideServiceAccountsStore(
 		cfg,
@@ -59,9 +66,12 @@ func ProvideServiceAccountsService(
 		orgService,
 	)
 	s := &ServiceAccountsService{
-		store:         serviceAccountsStore,
-		log:           log.New("serviceaccounts"),
-		backgroundLog: log.New("serviceaccounts.background"),
+		store:               serviceAccountsStore,
+		log:                 log.New("serviceaccounts"),
+		backgroundLog:       log.New("serviceaccounts.background"),
+		notificationService: notificationService,
+		appURL:              cfg.AppURL,
+		cfg:                 cfg,
 	}
 
 	if err := RegisterRoles(accesscontrolService); err != nil {
@@ -77,12 +87,14 @@ func ProvideServiceAccountsService(
 	s.secretScanInterval = cfg.SectionWithEnvOverrides("secretscan").
 		Key("interval").MustDuration(defaultSecretScanInterval)
 	if s.secretScanEnabled {
-		var errSecret error
-		s.secretScanService, errSecret = secretscan.NewService(s.store, cfg)
+		secretScanService, errSecret := secretscan.NewService(s.store, cfg)
 		if errSecret != nil {
 			s.secretScanEnabled = false
 			s.log.Warn("Failed to initialize secret scan service. secret scan is disabled",
 				"error", errSecret.Error())
+		} else {
+			s.secretScanService = secretScanService
+			secretScanService.RegisterAPIEndpoints(routeRegister, ac)
 		}
 	}
 
@@ -99,6 +111,9 @@ func (sa *ServiceAccountsService) Run(ctx context.Context) error {
 	updateStatsTicker := time.NewTicker(metricsCollectionInterval)
 	defer updateStatsTicker.Stop()
 
+	tokenExpiryTicker := time.NewTicker(tokenExpiryCheckInterval)
+	defer tokenExpiryTicker.Stop()
+
 	// Enforce a minimum interval of 1 minute.
 	if sa.secretScanEnabled && sa.secretScanInterval < time.Minute {
 		sa.backgroundLog.Warn("Secret scan interval is too low, increasing to " +
@@ -136,6 +151,12 @@ func (sa *ServiceAccountsService) Run(ctx context.Context) error {
 			if _, err := sa.getUsageMetrics(ctx); err != nil {
 				sa.backgroundLog.Warn("Failed to get usage metrics", "error", err.Error())
 			}
+		case <-tokenExpiryTicker.C:
+			sa.backgroundLog.Debug("Checking for expiring tokens")
+
+			if err := sa.notifyExpiringTokens(ctx); err != nil {
+				sa.backgroundLog.Warn("Failed to check for expiring tokens", "error", err.Error())
+			}
 		case <-tokenCheckTicker.C:
 			sa.backgroundLog.Debug("Checking for leaked tokens")
 
@@ -222,7 +243,13 @@ func (sa *ServiceAccountsService) DeleteServiceAccountToken(ctx context.Context,
 	if err := validServiceAccountTokenID(tokenID); err != nil {
 		return err
 	}
-	return sa.store.DeleteServiceAccountToken(ctx, orgID, serviceAccountID, tokenID)
+	if err := sa.store.DeleteServiceAccountToken(ctx, orgID, serviceAccountID, tokenID); err != nil {
+		return err
+	}
+	if err := sa.store.DeleteTokenExpiryNotified(ctx, orgID, tokenID); err != nil {
+		sa.log.Warn("Failed to delete the expiry notifications of the token", "tokenId", tokenID, "error", err)
+	}
+	return nil
 }
 
 func (sa *ServiceAccountsService) MigrateApiKey(ctx context.Context, orgID, keyID int64) error {
@@ -10,6 +10,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/apikey"
 	"github.com/grafana/grafana/pkg/services/org"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	"github.com/grafana/grafana/pkg/setting"
 )
 
 type FakeServiceAccountStore struct {
@@ -22,6 +23,11 @@ type FakeServiceAccountStore struct {
 	ExpectedAPIKeys                         []apikey.APIKey
 	ExpectedAPIKey                          *apikey.APIKey
 	ExpectedBoolean                         bool
+	ExpectedTokenPolicy                     *serviceaccounts.TokenPolicy
+	NotifiedTokenChannels                   map[string]bool
+	ExpiringTokensWindows                   map[int64][2]int64
+	InTransactionCalls                      int
+	ExpectedTokenExpiry                     *int64
 	ExpectedError                           error
 }
 
@@ -80,6 +86,38 @@ func (f *FakeServiceAccountStore) ListTokens(ctx context.Context, query *service
 	return f.ExpectedAPIKeys, f.ExpectedError
 }
 
+// ListExpiringTokens is a fake listing the tokens expiring in a time window, it records the window per organization.
+func (f *FakeServiceAccountStore) ListExpiringTokens(ctx context.Context, orgID, from, to int64) ([]apikey.APIKey, error) {
+	if f.ExpiringTokensWindows == nil {
+		f.ExpiringTokensWindows = map[int64][2]int64{}
+	}
+	f.ExpiringTokensWindows[orgID] = [2]int64{from, to}
+	result := []apikey.APIKey{}
+	for _, key := range f.ExpectedAPIKeys {
+		if key.OrgID == orgID && key.Expires != nil && *key.Expires >= from && *key.Expires <= to {
+			result = append(result, key)
+		}
+	}
+	return result, f.ExpectedError
+}
+
+// ListActiveTokens is a fake listing the tokens of the organization.
+func (f *FakeServiceAccountStore) ListActiveTokens(ctx context.Context, orgID int64) ([]apikey.APIKey, error) {
+	result := []apikey.APIKey{}
+	for _, key := range f.ExpectedAPIKeys {
+		if key.OrgID == orgID {
+			result = append(result, key)
+		}
+	}
+	return result, f.ExpectedError
+}
+
+// InTransaction is a fake running fn without a transaction.
+func (f *FakeServiceAccountStore) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.InTransactionCalls++
+	return fn(ctx)
+}
+
 // RevokeServiceAccountToken is a fake revoking a service account token.
 func (f *FakeServiceAccountStore) RevokeServiceAccountToken(ctx context.Context, orgId, serviceAccountId, tokenId int64) error {
 	return f.ExpectedError
@@ -95,6 +133,54 @@ func (f *FakeServiceAccountStore) DeleteServiceAccountToken(ctx context.Context,
 	return f.ExpectedError
 }
 
+// UpdateServiceAccountTokenExpiry is a fake updating the expiration of a service account token.
+func (f *FakeServiceAccountStore) UpdateServiceAccountTokenExpiry(ctx context.Context, orgID, serviceAccountID, tokenID, expires int64) error {
+	f.ExpectedTokenExpiry = &expires
+	return f.ExpectedError
+}
+
+// GetTokenPolicy is a fake getting the token policy of an organization.
+func (f *FakeServiceAccountStore) GetTokenPolicy(ctx context.Context, orgID int64) (*serviceaccounts.TokenPolicy, error) {
+	if f.ExpectedTokenPolicy == nil {
+		return &serviceaccounts.TokenPolicy{}, f.ExpectedError
+	}
+	return f.ExpectedTokenPolicy, f.ExpectedError
+}
+
+// SetTokenPolicy is a fake setting the token policy of an organization.
+func (f *FakeServiceAccountStore) SetTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error {
+	f.ExpectedTokenPolicy = policy
+	return f.ExpectedError
+}
+
+// ListTokenPolicies is a fake listing the token policies, it returns the expected policy for organization 1.
+func (f *FakeServiceAccountStore) ListTokenPolicies(ctx context.Context) (map[int64]*serviceaccounts.TokenPolicy, error) {
+	if f.ExpectedTokenPolicy == nil {
+		return map[int64]*serviceaccounts.TokenPolicy{}, f.ExpectedError
+	}
+	return map[int64]*serviceaccounts.TokenPolicy{1: f.ExpectedTokenPolicy}, f.ExpectedError
+}
+
+// IsTokenExpiryNotified is a fake checking if a token expiry notification has been sent.
+func (f *FakeServiceAccountStore) IsTokenExpiryNotified(ctx context.Context, orgID, tokenID int64, channel string) (bool, error) {
+	return f.NotifiedTokenChannels[channel], f.ExpectedError
+}
+
+// SetTokenExpiryNotified is a fake recording a token expiry notification.
+func (f *FakeServiceAccountStore) SetTokenExpiryNotified(ctx context.Context, orgID, tokenID int64, channel string) error {
+	if f.NotifiedTokenChannels == nil {
+		f.NotifiedTokenChannels = map[string]bool{}
+	}
+	f.NotifiedTokenChannels[channel] = true
+	return f.ExpectedError
+}
+
+// DeleteTokenExpiryNotified is a fake removing the token expiry notifications.
+func (f *FakeServiceAccountStore) DeleteTokenExpiryNotified(ctx context.Context, orgID, tokenID int64) error {
+	f.NotifiedTokenChannels = nil
+	return f.ExpectedError
+}
+
 // GetUsageMetrics is a fake getting usage metrics.
 func (f *FakeServiceAccountStore) GetUsageMetrics(ctx context.Context) (*serviceaccounts.Stats, error) {
 	return f.ExpectedStats, f.ExpectedError
@@ -110,7 +196,7 @@ func (f *SecretsCheckerFake) CheckTokens(ctx context.Context) error {
 
 func TestProvideServiceAccount_DeleteServiceAccount(t *testing.T) {
 	storeMock := newServiceAccountStoreFake()
-	svc := ServiceAccountsService{storeMock, log.New("test"), log.New("background.test"), &SecretsCheckerFake{}, false, 0}
+	svc := ServiceAccountsService{storeMock, log.New("test"), log.New("background.test"), &SecretsCheckerFake{}, false, 0, nil, "", setting.NewCfg()}
 	testOrgId := 1
 
 	t.Run("should create service account", func(t *testing.T) {
@@ -18,8 +18,18 @@ type store interface {
 	MigrateApiKeysToServiceAccounts(ctx context.Context, orgID int64) (*serviceaccounts.MigrationResult, error)
 	MigrateApiKey(ctx context.Context, orgID int64, keyId int64) error
 	ListTokens(ctx context.Context, query *serviceaccounts.GetSATokensQuery) ([]apikey.APIKey, error)
+	ListExpiringTokens(ctx context.Context, orgID, from, to int64) ([]apikey.APIKey, error)
+	ListActiveTokens(ctx context.Context, orgID int64) ([]apikey.APIKey, error)
 	RevokeServiceAccountToken(ctx context.Context, orgId, serviceAccountId, tokenId int64) error
 	AddServiceAccountToken(ctx context.Context, serviceAccountID int64, cmd *serviceaccounts.AddServiceAccountTokenCommand) (*apikey.APIKey, error)
 	DeleteServiceAccountToken(ctx context.Context, orgID, serviceAccountID, tokenID int64) error
+	UpdateServiceAccountTokenExpiry(ctx context.Context, orgID, serviceAccountID, tokenID, expires int64) error
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 	GetUsageMetrics(ctx context.Context) (*serviceaccounts.Stats, error)
+	GetTokenPolicy(ctx context.Context, orgID int64) (*serviceaccounts.TokenPolicy, error)
+	SetTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error
+	ListTokenPolicies(ctx context.Context) (map[int64]*serviceaccounts.TokenPolicy, error)
+	IsTokenExpiryNotified(ctx context.Context, orgID, tokenID int64, channel string) (bool, error)
+	SetTokenExpiryNotified(ctx context.Context, orgID, tokenID int64, channel string) error
+	DeleteTokenExpiryNotified(ctx context.Context, orgID, tokenID int64) error
 }
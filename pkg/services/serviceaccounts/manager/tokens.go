@@ -0,0 +1,250 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+)
+
+const tmplTokenExpiring = "service_account_token_expiring"
+
+// channels of the token expiry notifications
+const (
+	tokenExpiryChannelEmail   = "email"
+	tokenExpiryChannelWebhook = "webhook"
+)
+
+// rotatedTokenSuffix matches the timestamp appended to the name of rotated tokens
+var rotatedTokenSuffix = regexp.MustCompile(`-\d{14}$`)
+
+func (sa *ServiceAccountsService) GetTokenPolicy(ctx context.Context, orgID int64) (*serviceaccounts.TokenPolicy, error) {
+	if err := validOrgID(orgID); err != nil {
+		return nil, err
+	}
+	return sa.store.GetTokenPolicy(ctx, orgID)
+}
+
+func (sa *ServiceAccountsService) UpdateTokenPolicy(ctx context.Context, orgID int64, policy *serviceaccounts.TokenPolicy) error {
+	if err := validOrgID(orgID); err != nil {
+		return err
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	return sa.store.SetTokenPolicy(ctx, orgID, policy)
+}
+
+// ApplyTokenPolicy returns the lifetime of a new token after applying the organization token policy.
+// Tokens without expiration are forced to expire after the maximum lifetime of the policy.
+func (sa *ServiceAccountsService) ApplyTokenPolicy(ctx context.Context, orgID int64, secondsToLive int64) (int64, error) {
+	policy, err := sa.GetTokenPolicy(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+	if policy.MaxSecondsToLive == 0 {
+		return secondsToLive, nil
+	}
+	if secondsToLive == 0 {
+		return policy.MaxSecondsToLive, nil
+	}
+	if secondsToLive > policy.MaxSecondsToLive {
+		return 0, serviceaccounts.ErrTokenExceedsPolicy.Errorf("token lifetime %d exceeds the organization maximum %d", secondsToLive, policy.MaxSecondsToLive)
+	}
+	return secondsToLive, nil
+}
+
+// RotateServiceAccountToken adds a replacement token to the service account and shortens the
+// expiration of the rotated token to the requested overlap window.
+func (sa *ServiceAccountsService) RotateServiceAccountToken(ctx context.Context, serviceAccountID, tokenID int64, cmd *serviceaccounts.RotateServiceAccountTokenCommand) (*apikey.APIKey, error) {
+	if err := validOrgID(cmd.OrgId); err != nil {
+		return nil, err
+	}
+	if err := validServiceAccountID(serviceAccountID); err != nil {
+		return nil, err
+	}
+	if err := validServiceAccountTokenID(tokenID); err != nil {
+		return nil, err
+	}
+	if cmd.OverlapSeconds < 0 || cmd.SecondsToLive < 0 {
+		return nil, serviceaccounts.ErrInvalidTokenExpiration.Errorf("invalid token rotation durations")
+	}
+
+	tokens, err := sa.store.ListTokens(ctx, &serviceaccounts.GetSATokensQuery{OrgID: &cmd.OrgId, ServiceAccountID: &serviceAccountID})
+	if err != nil {
+		return nil, err
+	}
+	var rotated *apikey.APIKey
+	for i := range tokens {
+		if tokens[i].ID == tokenID {
+			rotated = &tokens[i]
+			break
+		}
+	}
+	if rotated == nil {
+		return nil, serviceaccounts.ErrServiceAccountTokenNotFound.Errorf("service account token with id %d not found", tokenID)
+	}
+
+	now := time.Now()
+	if (rotated.IsRevoked != nil && *rotated.IsRevoked) || (rotated.Expires != nil && time.Unix(*rotated.Expires, 0).Before(now)) {
+		return nil, serviceaccounts.ErrTokenAlreadyExpired.Errorf("service account token with id %d is expired or revoked", tokenID)
+	}
+
+	name := cmd.Name
+	if name == "" {
+		name = rotatedTokenSuffix.ReplaceAllString(rotated.Name, "") + "-" + now.UTC().Format("20060102150405")
+	}
+
+	// keep the lifetime of the rotated token by default
+	secondsToLive := cmd.SecondsToLive
+	if secondsToLive == 0 && rotated.Expires != nil {
+		secondsToLive = *rotated.Expires - rotated.Created.Unix()
+	}
+	secondsToLive, err = sa.ApplyTokenPolicy(ctx, cmd.OrgId, secondsToLive)
+	if err != nil {
+		return nil, err
+	}
+	if err := serviceaccounts.ValidateTokenLifetime(sa.cfg, secondsToLive); err != nil {
+		return nil, err
+	}
+
+	// add the replacement and expire the rotated token together, so that a failure leaves the rotated token unchanged
+	var replacement *apikey.APIKey
+	err = sa.store.InTransaction(ctx, func(ctx context.Context) error {
+		replacement, err = sa.store.AddServiceAccountToken(ctx, serviceAccountID, &serviceaccounts.AddServiceAccountTokenCommand{
+			Name:          name,
+			OrgId:         cmd.OrgId,
+			Key:           cmd.Key,
+			SecondsToLive: secondsToLive,
+		})
+		if err != nil {
+			return err
+		}
+
+		overlapEnd := now.Add(time.Duration(cmd.OverlapSeconds) * time.Second).Unix()
+		if rotated.Expires == nil || *rotated.Expires > overlapEnd {
+			if err := sa.store.UpdateServiceAccountTokenExpiry(ctx, cmd.OrgId, serviceAccountID, tokenID, overlapEnd); err != nil {
+				return fmt.Errorf("failed to expire rotated token: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// the rotated token expires at a new date, so it is notified again
+	if err := sa.store.DeleteTokenExpiryNotified(ctx, cmd.OrgId, tokenID); err != nil {
+		sa.log.Warn("Failed to delete the expiry notifications of the rotated token", "tokenId", tokenID, "error", err)
+	}
+
+	return replacement, nil
+}
+
+// notifyExpiringTokens sends the expiry notifications configured in the organization token
+// policies. Each token is notified once per channel.
+func (sa *ServiceAccountsService) notifyExpiringTokens(ctx context.Context) error {
+	policies, err := sa.store.ListTokenPolicies(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for orgID, policy := range policies {
+		if policy.ExpiryWarningSeconds == 0 || (len(policy.NotificationEmails) == 0 && policy.WebhookURL == "") {
+			continue
+		}
+
+		tokens, err := sa.store.ListExpiringTokens(ctx, orgID, now.Unix(), now.Add(time.Duration(policy.ExpiryWarningSeconds)*time.Second).Unix())
+		if err != nil {
+			sa.backgroundLog.Warn("Failed to list expiring tokens", "orgId", orgID, "error", err)
+			continue
+		}
+		for _, token := range tokens {
+			if token.Expires == nil || token.ServiceAccountId == nil || (token.IsRevoked != nil && *token.IsRevoked) {
+				continue
+			}
+			sa.notifyExpiringToken(ctx, policy, token, time.Unix(*token.Expires, 0))
+		}
+	}
+
+	return nil
+}
+
+// notifyExpiringToken sends the expiry notification of the token to each channel of the policy.
+// The channels are recorded separately, so a failing channel is retried without notifying the others again.
+func (sa *ServiceAccountsService) notifyExpiringToken(ctx context.Context, policy *serviceaccounts.TokenPolicy, token apikey.APIKey, expiresAt time.Time) {
+	channels := map[string]func(*serviceaccounts.ServiceAccountProfileDTO) error{}
+	if len(policy.NotificationEmails) > 0 {
+		channels[tokenExpiryChannelEmail] = func(serviceAccount *serviceaccounts.ServiceAccountProfileDTO) error {
+			return sa.notificationService.SendEmailCommandHandler(ctx, &notifications.SendEmailCommand{
+				To:       policy.NotificationEmails,
+				Template: tmplTokenExpiring,
+				Data: map[string]any{
+					"ServiceAccountName": serviceAccount.Name,
+					"TokenName":          token.Name,
+					"ExpiresAt":          expiresAt.UTC().Format(time.RFC1123),
+					"ServiceAccountUrl":  fmt.Sprintf("%s/org/serviceaccounts/%d", strings.TrimSuffix(sa.appURL, "/"), serviceAccount.Id),
+				},
+			})
+		}
+	}
+	if policy.WebhookURL != "" {
+		channels[tokenExpiryChannelWebhook] = func(serviceAccount *serviceaccounts.ServiceAccountProfileDTO) error {
+			body, err := json.Marshal(serviceaccounts.ExpiringTokenNotification{
+				OrgID:              token.OrgID,
+				ServiceAccountID:   serviceAccount.Id,
+				ServiceAccountName: serviceAccount.Name,
+				TokenID:            token.ID,
+				TokenName:          token.Name,
+				ExpiresAt:          expiresAt.UTC(),
+			})
+			if err != nil {
+				return err
+			}
+			return sa.notificationService.SendWebhookSync(ctx, &notifications.SendWebhookSync{
+				Url:         policy.WebhookURL,
+				Body:        string(body),
+				HttpMethod:  "POST",
+				ContentType: "application/json",
+			})
+		}
+	}
+
+	var serviceAccount *serviceaccounts.ServiceAccountProfileDTO
+	for _, channel := range []string{tokenExpiryChannelEmail, tokenExpiryChannelWebhook} {
+		send, ok := channels[channel]
+		if !ok {
+			continue
+		}
+		notified, err := sa.store.IsTokenExpiryNotified(ctx, token.OrgID, token.ID, channel)
+		if err != nil {
+			sa.backgroundLog.Warn("Failed to check token expiry notification", "tokenId", token.ID, "channel", channel, "error", err)
+			continue
+		}
+		if notified {
+			continue
+		}
+
+		if serviceAccount == nil {
+			serviceAccount, err = sa.store.RetrieveServiceAccount(ctx, token.OrgID, *token.ServiceAccountId)
+			if err != nil {
+				sa.backgroundLog.Warn("Failed to send token expiry notification", "tokenId", token.ID, "error", err)
+				return
+			}
+		}
+		if err := send(serviceAccount); err != nil {
+			sa.backgroundLog.Warn("Failed to send token expiry notification", "tokenId", token.ID, "channel", channel, "error", err)
+			continue
+		}
+		if err := sa.store.SetTokenExpiryNotified(ctx, token.OrgID, token.ID, channel); err != nil {
+			sa.backgroundLog.Warn("Failed to save token expiry notification", "tokenId", token.ID, "channel", channel, "error", err)
+		}
+	}
+}
@@ -0,0 +1,242 @@
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/apikey"
+	"github.com/grafana/grafana/pkg/services/notifications"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestServiceAccountsService_ApplyTokenPolicy(t *testing.T) {
+	storeMock := newServiceAccountStoreFake()
+	svc := ServiceAccountsService{store: storeMock, log: log.New("test")}
+	ctx := context.Background()
+
+	t.Run("without policy the lifetime is unchanged", func(t *testing.T) {
+		secondsToLive, err := svc.ApplyTokenPolicy(ctx, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), secondsToLive)
+	})
+
+	storeMock.ExpectedTokenPolicy = &serviceaccounts.TokenPolicy{MaxSecondsToLive: 3600}
+
+	t.Run("tokens without expiration are forced to expire", func(t *testing.T) {
+		secondsToLive, err := svc.ApplyTokenPolicy(ctx, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3600), secondsToLive)
+	})
+
+	t.Run("shorter lifetimes are kept", func(t *testing.T) {
+		secondsToLive, err := svc.ApplyTokenPolicy(ctx, 1, 60)
+		require.NoError(t, err)
+		assert.Equal(t, int64(60), secondsToLive)
+	})
+
+	t.Run("longer lifetimes are rejected", func(t *testing.T) {
+		_, err := svc.ApplyTokenPolicy(ctx, 1, 7200)
+		require.ErrorIs(t, err, serviceaccounts.ErrTokenExceedsPolicy)
+	})
+}
+
+func TestServiceAccountsService_UpdateTokenPolicy(t *testing.T) {
+	storeMock := newServiceAccountStoreFake()
+	svc := ServiceAccountsService{store: storeMock, log: log.New("test")}
+	ctx := context.Background()
+
+	err := svc.UpdateTokenPolicy(ctx, 1, &serviceaccounts.TokenPolicy{MaxSecondsToLive: 3600, ExpiryWarningSeconds: 7200})
+	require.ErrorIs(t, err, serviceaccounts.ErrInvalidTokenPolicy)
+
+	err = svc.UpdateTokenPolicy(ctx, 1, &serviceaccounts.TokenPolicy{NotificationEmails: []string{"not-an-email"}})
+	require.ErrorIs(t, err, serviceaccounts.ErrInvalidTokenPolicy)
+
+	policy := &serviceaccounts.TokenPolicy{MaxSecondsToLive: 3600, ExpiryWarningSeconds: 600, WebhookURL: "https://example.com/hook"}
+	require.NoError(t, svc.UpdateTokenPolicy(ctx, 1, policy))
+	assert.Equal(t, policy, storeMock.ExpectedTokenPolicy)
+}
+
+func TestServiceAccountsService_RotateServiceAccountToken(t *testing.T) {
+	ctx := context.Background()
+	created := time.Now().Add(-24 * time.Hour)
+	expires := created.Add(30 * 24 * time.Hour).Unix()
+	revoked := false
+
+	setup := func() (*FakeServiceAccountStore, *ServiceAccountsService) {
+		storeMock := newServiceAccountStoreFake()
+		storeMock.ExpectedAPIKeys = []apikey.APIKey{{
+			ID:               2,
+			OrgID:            1,
+			Name:             "deploy",
+			Created:          created,
+			Expires:          &expires,
+			ServiceAccountId: new(int64),
+			IsRevoked:        &revoked,
+		}}
+		storeMock.ExpectedAPIKey = &apikey.APIKey{ID: 3, Name: "deploy-new"}
+		cfg := setting.NewCfg()
+		cfg.ApiKeyMaxSecondsToLive = -1
+		return storeMock, &ServiceAccountsService{store: storeMock, log: log.New("test"), cfg: cfg}
+	}
+
+	t.Run("shortens the expiration of the rotated token", func(t *testing.T) {
+		storeMock, svc := setup()
+		token, err := svc.RotateServiceAccountToken(ctx, 1, 2, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1, OverlapSeconds: 3600})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), token.ID)
+
+		require.NotNil(t, storeMock.ExpectedTokenExpiry)
+		assert.InDelta(t, time.Now().Add(time.Hour).Unix(), *storeMock.ExpectedTokenExpiry, 5)
+		assert.Equal(t, 1, storeMock.InTransactionCalls)
+	})
+
+	t.Run("forgets the expiry notifications of the rotated token", func(t *testing.T) {
+		storeMock, svc := setup()
+		storeMock.NotifiedTokenChannels = map[string]bool{tokenExpiryChannelEmail: true}
+		_, err := svc.RotateServiceAccountToken(ctx, 1, 2, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1, OverlapSeconds: 3600})
+		require.NoError(t, err)
+		assert.Empty(t, storeMock.NotifiedTokenChannels)
+	})
+
+	t.Run("applies the global limits to the replacement token", func(t *testing.T) {
+		storeMock, svc := setup()
+		svc.cfg.ApiKeyMaxSecondsToLive = 3600
+		_, err := svc.RotateServiceAccountToken(ctx, 1, 2, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1, OverlapSeconds: 3600})
+		require.ErrorIs(t, err, serviceaccounts.ErrTokenExceedsLimit)
+		assert.Nil(t, storeMock.ExpectedTokenExpiry)
+
+		// tokens that never expire are replaced by tokens that never expire
+		storeMock.ExpectedAPIKeys[0].Expires = nil
+		_, err = svc.RotateServiceAccountToken(ctx, 1, 2, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1, OverlapSeconds: 3600})
+		require.ErrorIs(t, err, serviceaccounts.ErrTokenExpirationRequired)
+
+		svc.cfg.ApiKeyMaxSecondsToLive = -1
+		svc.cfg.SATokenExpirationDayLimit = 7
+		_, err = svc.RotateServiceAccountToken(ctx, 1, 2, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1, OverlapSeconds: 3600, SecondsToLive: 30 * 24 * 3600})
+		require.ErrorIs(t, err, serviceaccounts.ErrTokenExceedsLimit)
+	})
+
+	t.Run("keeps the expiration of the rotated token when it expires before the overlap", func(t *testing.T) {
+		storeMock, svc := setup()
+		_, err := svc.RotateServiceAccountToken(ctx, 1, 2, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1, OverlapSeconds: 365 * 24 * 3600})
+		require.NoError(t, err)
+		assert.Nil(t, storeMock.ExpectedTokenExpiry)
+	})
+
+	t.Run("fails for unknown tokens", func(t *testing.T) {
+		_, svc := setup()
+		_, err := svc.RotateServiceAccountToken(ctx, 1, 5, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1})
+		require.ErrorIs(t, err, serviceaccounts.ErrServiceAccountTokenNotFound)
+	})
+
+	t.Run("fails for revoked tokens", func(t *testing.T) {
+		storeMock, svc := setup()
+		isRevoked := true
+		storeMock.ExpectedAPIKeys[0].IsRevoked = &isRevoked
+		_, err := svc.RotateServiceAccountToken(ctx, 1, 2, &serviceaccounts.RotateServiceAccountTokenCommand{OrgId: 1})
+		require.ErrorIs(t, err, serviceaccounts.ErrTokenAlreadyExpired)
+	})
+}
+
+func TestServiceAccountsService_NotifyExpiringTokens(t *testing.T) {
+	ctx := context.Background()
+	soon := time.Now().Add(time.Hour).Unix()
+	later := time.Now().Add(30 * 24 * time.Hour).Unix()
+	saID := int64(4)
+
+	storeMock := newServiceAccountStoreFake()
+	storeMock.ExpectedAPIKeys = []apikey.APIKey{
+		{ID: 1, OrgID: 1, Name: "expiring", Expires: &soon, ServiceAccountId: &saID},
+		{ID: 2, OrgID: 1, Name: "valid", Expires: &later, ServiceAccountId: &saID},
+		{ID: 3, OrgID: 1, Name: "no-expiry", ServiceAccountId: &saID},
+	}
+	storeMock.ExpectedServiceAccountProfileDTO = &serviceaccounts.ServiceAccountProfileDTO{Id: saID, Name: "ci"}
+	storeMock.ExpectedTokenPolicy = &serviceaccounts.TokenPolicy{
+		ExpiryWarningSeconds: 24 * 3600,
+		NotificationEmails:   []string{"admin@example.com"},
+		WebhookURL:           "https://example.com/hook",
+	}
+
+	notified := []string{}
+	notificationService := notifications.MockNotificationService()
+	notificationService.WebhookHandler = func(ctx context.Context, cmd *notifications.SendWebhookSync) error {
+		payload := serviceaccounts.ExpiringTokenNotification{}
+		require.NoError(t, json.Unmarshal([]byte(cmd.Body), &payload))
+		notified = append(notified, payload.TokenName)
+		return nil
+	}
+
+	svc := ServiceAccountsService{
+		store:               storeMock,
+		log:                 log.New("test"),
+		backgroundLog:       log.New("background.test"),
+		notificationService: notificationService,
+		appURL:              "http://localhost:3000/",
+	}
+
+	require.NoError(t, svc.notifyExpiringTokens(ctx))
+	assert.Equal(t, []string{"expiring"}, notified)
+	require.Contains(t, storeMock.ExpiringTokensWindows, int64(1))
+	window := storeMock.ExpiringTokensWindows[1]
+	assert.Equal(t, int64(24*3600), window[1]-window[0])
+	assert.Equal(t, []string{"admin@example.com"}, notificationService.Email.To)
+	assert.Equal(t, "http://localhost:3000/org/serviceaccounts/4", notificationService.Email.Data["ServiceAccountUrl"])
+
+	// tokens are notified once
+	notified = []string{}
+	notificationService.Email = notifications.SendEmailCommand{}
+	require.NoError(t, svc.notifyExpiringTokens(ctx))
+	assert.Empty(t, notified)
+	assert.Empty(t, notificationService.Email.To)
+}
+
+func TestServiceAccountsService_NotifyExpiringTokensPerChannel(t *testing.T) {
+	ctx := context.Background()
+	soon := time.Now().Add(time.Hour).Unix()
+	saID := int64(4)
+
+	storeMock := newServiceAccountStoreFake()
+	storeMock.ExpectedAPIKeys = []apikey.APIKey{{ID: 1, OrgID: 1, Name: "expiring", Expires: &soon, ServiceAccountId: &saID}}
+	storeMock.ExpectedServiceAccountProfileDTO = &serviceaccounts.ServiceAccountProfileDTO{Id: saID, Name: "ci"}
+	storeMock.ExpectedTokenPolicy = &serviceaccounts.TokenPolicy{
+		ExpiryWarningSeconds: 24 * 3600,
+		NotificationEmails:   []string{"admin@example.com"},
+		WebhookURL:           "https://example.com/hook",
+	}
+
+	webhookErr := errors.New("webhook unavailable")
+	webhookCalls := 0
+	notificationService := notifications.MockNotificationService()
+	notificationService.WebhookHandler = func(ctx context.Context, cmd *notifications.SendWebhookSync) error {
+		webhookCalls++
+		return webhookErr
+	}
+
+	svc := ServiceAccountsService{
+		store:               storeMock,
+		log:                 log.New("test"),
+		backgroundLog:       log.New("background.test"),
+		notificationService: notificationService,
+	}
+
+	require.NoError(t, svc.notifyExpiringTokens(ctx))
+	assert.Equal(t, 1, webhookCalls)
+	assert.Equal(t, []string{"admin@example.com"}, notificationService.Email.To)
+	assert.Equal(t, map[string]bool{tokenExpiryChannelEmail: true}, storeMock.NotifiedTokenChannels)
+
+	// the failed webhook is retried without sending the email again
+	webhookErr = nil
+	notificationService.Email = notifications.SendEmailCommand{}
+	require.NoError(t, svc.notifyExpiringTokens(ctx))
+	assert.Equal(t, 2, webhookCalls)
+	assert.Empty(t, notificationService.Email.To)
+	assert.Equal(t, map[string]bool{tokenExpiryChannelEmail: true, tokenExpiryChannelWebhook: true}, storeMock.NotifiedTokenChannels)
+}
@@ -1,12 +1,15 @@
 package serviceaccounts
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/grafana/grafana/pkg/models/roletype"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/auth/identity"
 	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
 	"github.com/grafana/grafana/pkg/util/errutil"
 )
 
@@ -27,6 +30,7 @@ const (
 	ActionDelete           = "serviceaccounts:delete"
 	ActionPermissionsRead  = "serviceaccounts.permissions:read"
 	ActionPermissionsWrite = "serviceaccounts.permissions:write"
+	ActionTokenPolicyWrite = "serviceaccounts.token-policy:write"
 )
 
 var (
@@ -41,6 +45,11 @@ var (
 	ErrServiceAccountTokenNotFound       = errutil.NotFound("serviceaccounts.ErrTokenNotFound", errutil.WithPublicMessage("service account token not found"))
 	ErrInvalidTokenExpiration            = errutil.ValidationFailed("serviceaccounts.ErrInvalidInput", errutil.WithPublicMessage("invalid SecondsToLive value"))
 	ErrDuplicateToken                    = errutil.BadRequest("serviceaccounts.ErrTokenAlreadyExists", errutil.WithPublicMessage("service account token with given name already exists in the organization"))
+	ErrTokenExceedsPolicy                = errutil.BadRequest("serviceaccounts.ErrTokenExceedsPolicy", errutil.WithPublicMessage("token expiration exceeds the maximum lifetime allowed by the organization token policy"))
+	ErrInvalidTokenPolicy                = errutil.BadRequest("serviceaccounts.ErrInvalidTokenPolicy", errutil.WithPublicMessage("invalid service account token policy"))
+	ErrTokenAlreadyExpired               = errutil.BadRequest("serviceaccounts.ErrTokenAlreadyExpired", errutil.WithPublicMessage("expired or revoked tokens can not be rotated"))
+	ErrTokenExpirationRequired           = errutil.BadRequest("serviceaccounts.ErrTokenExpirationRequired", errutil.WithPublicMessage("Number of seconds before expiration should be set"))
+	ErrTokenExceedsLimit                 = errutil.BadRequest("serviceaccounts.ErrTokenExceedsLimit", errutil.WithPublicMessage("The expiration date input exceeds the limit for service account access tokens expiration date"))
 )
 
 type MigrationResult struct {
@@ -108,6 +117,87 @@ type AddServiceAccountTokenCommand struct {
 	SecondsToLive int64  `json:"secondsToLive"`
 }
 
+// swagger:model
+type RotateServiceAccountTokenCommand struct {
+	// Name of the replacement token. Defaults to the name of the rotated token with a timestamp suffix.
+	Name string `json:"name"`
+	// Lifetime of the replacement token. Defaults to the lifetime of the rotated token.
+	SecondsToLive int64 `json:"secondsToLive"`
+	// Number of seconds the rotated token remains valid after the rotation,
+	// giving clients time to switch to the replacement token.
+	OverlapSeconds int64  `json:"overlapSeconds"`
+	OrgId          int64  `json:"-"`
+	Key            string `json:"-"`
+}
+
+// TokenPolicy is the organization wide policy applied to service account tokens
+// swagger:model
+type TokenPolicy struct {
+	// Maximum lifetime of a token. Tokens created without an expiration are forced to expire after this delay.
+	// 0 disables the policy.
+	// example: 7776000
+	MaxSecondsToLive int64 `json:"maxSecondsToLive"`
+	// Send a notification this many seconds before a token expires. 0 disables the notifications.
+	// example: 604800
+	ExpiryWarningSeconds int64 `json:"expiryWarningSeconds"`
+	// Email addresses notified about expiring tokens
+	NotificationEmails []string `json:"notificationEmails"`
+	// URL receiving a webhook about expiring tokens
+	WebhookURL string `json:"webhookUrl"`
+}
+
+func (p *TokenPolicy) Validate() error {
+	if p.MaxSecondsToLive < 0 || p.ExpiryWarningSeconds < 0 {
+		return ErrInvalidTokenPolicy.Errorf("durations must be positive")
+	}
+	if p.MaxSecondsToLive > 0 && p.ExpiryWarningSeconds >= p.MaxSecondsToLive {
+		return ErrInvalidTokenPolicy.Errorf("expiry warning must be shorter than the maximum token lifetime")
+	}
+	for _, email := range p.NotificationEmails {
+		if !util.IsEmail(email) {
+			return ErrInvalidTokenPolicy.Errorf("invalid notification email %q", email)
+		}
+	}
+	if p.WebhookURL != "" {
+		u, err := url.Parse(p.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ErrInvalidTokenPolicy.Errorf("invalid webhook url %q", p.WebhookURL)
+		}
+	}
+	return nil
+}
+
+// ValidateTokenLifetime checks the lifetime of a new token against the limits configured for the instance
+func ValidateTokenLifetime(cfg *setting.Cfg, secondsToLive int64) error {
+	if cfg.ApiKeyMaxSecondsToLive != -1 {
+		if secondsToLive == 0 {
+			return ErrTokenExpirationRequired.Errorf("token without expiration")
+		}
+		if secondsToLive > cfg.ApiKeyMaxSecondsToLive {
+			return ErrTokenExceedsLimit.Errorf("token lifetime %d exceeds the global limit %d", secondsToLive, cfg.ApiKeyMaxSecondsToLive)
+		}
+	}
+
+	if cfg.SATokenExpirationDayLimit > 0 {
+		dayExpireLimit := time.Now().Add(time.Duration(cfg.SATokenExpirationDayLimit) * time.Hour * 24).Truncate(24 * time.Hour)
+		expirationDate := time.Now().Add(time.Duration(secondsToLive) * time.Second).Truncate(24 * time.Hour)
+		if expirationDate.After(dayExpireLimit) {
+			return ErrTokenExceedsLimit.Errorf("token expiration exceeds the limit of %d days", cfg.SATokenExpirationDayLimit)
+		}
+	}
+	return nil
+}
+
+// ExpiringTokenNotification is the payload of the webhook sent when a token is about to expire
+type ExpiringTokenNotification struct {
+	OrgID              int64     `json:"orgId"`
+	ServiceAccountID   int64     `json:"serviceAccountId"`
+	ServiceAccountName string    `json:"serviceAccountName"`
+	TokenID            int64     `json:"tokenId"`
+	TokenName          string    `json:"tokenName"`
+	ExpiresAt          time.Time `json:"expiresAt"`
+}
+
 type SearchOrgServiceAccountsQuery struct {
 	OrgID        int64
 	Query        string
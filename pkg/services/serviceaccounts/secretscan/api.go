@@ -0,0 +1,59 @@
+package secretscan
+
+import (
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware/requestmeta"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts"
+)
+
+// maximum size of a text blob submitted for scanning
+const maxScanRequestSize = 10 * 1024 * 1024
+
+type scanResponse struct {
+	Leaked []leakedTokenDTO `json:"leaked"`
+}
+
+type leakedTokenDTO struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+// RegisterAPIEndpoints registers the endpoint used by external systems (CI pipelines,
+// pre-receive hooks) to submit text for leaked token scanning.
+func (s *Service) RegisterAPIEndpoints(router routing.RouteRegister, ac accesscontrol.AccessControl) {
+	auth := accesscontrol.Middleware(ac)
+	router.Group("/api/serviceaccounts/secretscan", func(secretscanRoute routing.RouteRegister) {
+		secretscanRoute.Post("/scan", auth(accesscontrol.EvalPermission(serviceaccounts.ActionWrite)), routing.Wrap(s.scanTextHandler))
+	}, requestmeta.SetOwner(requestmeta.TeamAuth))
+}
+
+// POST /api/serviceaccounts/secretscan/scan?source=<source>
+//
+// The request body is scanned as plain text. Active tokens of the organization of the
+// user found in it are revoked and/or reported to the configured webhook.
+func (s *Service) scanTextHandler(c *contextmodel.ReqContext) response.Response {
+	source := c.Query("source")
+	if source == "" {
+		source = "api"
+	}
+
+	body := http.MaxBytesReader(c.Resp, c.Req.Body, maxScanRequestSize)
+	defer func() { _ = body.Close() }()
+
+	found, err := s.ScanText(c.Req.Context(), c.SignedInUser.GetOrgID(), source, body)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to scan text", err)
+	}
+
+	result := scanResponse{Leaked: make([]leakedTokenDTO, 0, len(found))}
+	for _, token := range found {
+		result.Leaked = append(result.Leaked, leakedTokenDTO{Type: token.Type, URL: token.URL})
+	}
+
+	return response.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,178 @@
+package secretscan
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/grafana/grafana/pkg/components/apikeygen"
+	"github.com/grafana/grafana/pkg/components/satokengen"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	tokenTypeServiceAccount = "grafana_service_account_token"
+	tokenTypeAPIKey         = "grafana_api_key"
+
+	// files larger than this are not scanned
+	maxScannedFileSize = 1024 * 1024
+)
+
+var (
+	// glsa_<32 chars secret>_<8 chars checksum>
+	serviceAccountTokenPattern = regexp.MustCompile(`\bglsa_[A-Za-z0-9]{32}_[0-9a-f]{8}\b`)
+	// legacy API keys are base64 encoded JSON objects starting with {"k":"
+	apiKeyPattern = regexp.MustCompile(`eyJrIjoi[A-Za-z0-9+/]+={0,2}`)
+
+	errNoLocalScanPaths = errors.New("local secretscan detector requires at least one path")
+)
+
+// localDetector is a self-hosted CheckerClient that looks for tokens in
+// local directories (for example git repository checkouts) instead of
+// sending token hashes to the Grafana.com secret scanning service.
+type localDetector struct {
+	paths  []string
+	logger log.Logger
+}
+
+func newLocalDetector(paths []string) (*localDetector, error) {
+	if len(paths) == 0 {
+		return nil, errNoLocalScanPaths
+	}
+	return &localDetector{paths: paths, logger: log.New("secretscan.local")}, nil
+}
+
+// CheckTokens walks the configured paths and returns the tokens found with one of the given hashes.
+func (d *localDetector) CheckTokens(ctx context.Context, keyHashes []string) ([]Token, error) {
+	wanted := make(map[string]bool, len(keyHashes))
+	for _, h := range keyHashes {
+		wanted[h] = true
+	}
+
+	tokens := make([]Token, 0)
+	for _, root := range d.paths {
+		err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if entry.IsDir() {
+				if entry.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+
+			info, err := entry.Info()
+			if err != nil || info.Size() > maxScannedFileSize {
+				return nil
+			}
+
+			found, err := scanFile(path, wanted)
+			if err != nil {
+				// an unreadable file does not prevent the other files from being checked
+				d.logger.Warn("Failed to scan file, skipping", "path", path, "error", err)
+				return nil
+			}
+			tokens = append(tokens, found...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", root, err)
+		}
+	}
+
+	return tokens, nil
+}
+
+func scanFile(path string, wanted map[string]bool) ([]Token, error) {
+	// #nosec G304 -- paths are configured by the administrator
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	return scanReader(f, "file://"+path, wanted)
+}
+
+// scanReader returns the tokens found in r with one of the wanted hashes.
+// The reported URL is the source followed by the line number.
+func scanReader(r io.Reader, source string, wanted map[string]bool) ([]Token, error) {
+	tokens := make([]Token, 0)
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScannedFileSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if !strings.Contains(text, "glsa_") && !strings.Contains(text, "eyJrIjoi") {
+			continue
+		}
+
+		for _, candidate := range findCandidates(text) {
+			if !wanted[candidate.Hash] {
+				continue
+			}
+			candidate.URL = fmt.Sprintf("%s#L%d", source, line)
+			candidate.ReportedAt = time.Now().UTC().Format(time.RFC3339)
+			tokens = append(tokens, candidate)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		// binary files or very long lines are not expected to contain tokens
+		if errors.Is(err, bufio.ErrTooLong) {
+			return tokens, nil
+		}
+		return nil, err
+	}
+
+	return tokens, nil
+}
+
+// findCandidates returns the hashed form of every token-like string in text.
+func findCandidates(text string) []Token {
+	candidates := make([]Token, 0)
+
+	for _, match := range serviceAccountTokenPattern.FindAllString(text, -1) {
+		key, err := satokengen.Decode(match)
+		if err != nil {
+			continue // checksum does not match, not a real token
+		}
+		hash, err := key.Hash()
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, Token{Type: tokenTypeServiceAccount, Hash: hash})
+	}
+
+	for _, match := range apiKeyPattern.FindAllString(text, -1) {
+		key, err := apikeygen.Decode(match)
+		if err != nil {
+			continue
+		}
+		hash, err := util.EncodePassword(key.Key, key.Name)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, Token{Type: tokenTypeAPIKey, Hash: hash})
+	}
+
+	return candidates
+}
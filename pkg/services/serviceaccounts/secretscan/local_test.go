@@ -0,0 +1,141 @@
+package secretscan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/components/apikeygen"
+	"github.com/grafana/grafana/pkg/components/satokengen"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/apikey"
+)
+
+func TestLocalDetector_CheckTokens(t *testing.T) {
+	ctx := context.Background()
+
+	saToken, err := satokengen.New("sa")
+	require.NoError(t, err)
+	otherToken, err := satokengen.New("sa")
+	require.NoError(t, err)
+	apiKey, err := apikeygen.New(1, "test")
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"),
+		[]byte("url: http://grafana\ntoken: "+saToken.ClientSecret+"\n"), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "scripts"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "scripts", "run.sh"),
+		[]byte("curl -H \"Authorization: Bearer "+apiKey.ClientSecret+"\" http://grafana\n"), 0600))
+	// tokens in the git directory are not reported
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "ORIG_HEAD"),
+		[]byte(otherToken.ClientSecret), 0600))
+
+	detector, err := newLocalDetector([]string{dir})
+	require.NoError(t, err)
+
+	t.Run("returns the tokens with a wanted hash", func(t *testing.T) {
+		tokens, err := detector.CheckTokens(ctx, []string{saToken.HashedKey, apiKey.HashedKey, otherToken.HashedKey})
+		require.NoError(t, err)
+		require.Len(t, tokens, 2)
+
+		byHash := map[string]Token{}
+		for _, token := range tokens {
+			byHash[token.Hash] = token
+		}
+
+		assert.Equal(t, tokenTypeServiceAccount, byHash[saToken.HashedKey].Type)
+		assert.Equal(t, "file://"+filepath.Join(dir, "config.yaml")+"#L2", byHash[saToken.HashedKey].URL)
+		assert.Equal(t, tokenTypeAPIKey, byHash[apiKey.HashedKey].Type)
+		assert.Equal(t, "file://"+filepath.Join(dir, "scripts", "run.sh")+"#L1", byHash[apiKey.HashedKey].URL)
+	})
+
+	t.Run("ignores tokens that are not active", func(t *testing.T) {
+		tokens, err := detector.CheckTokens(ctx, []string{otherToken.HashedKey})
+		require.NoError(t, err)
+		assert.Empty(t, tokens)
+	})
+
+	t.Run("skips the files that cannot be scanned", func(t *testing.T) {
+		if os.Geteuid() == 0 {
+			t.Skip("file permissions are not enforced for root")
+		}
+		unreadable := filepath.Join(dir, "unreadable.txt")
+		require.NoError(t, os.WriteFile(unreadable, []byte(otherToken.ClientSecret), 0000))
+		t.Cleanup(func() { _ = os.Remove(unreadable) })
+
+		tokens, err := detector.CheckTokens(ctx, []string{saToken.HashedKey})
+		require.NoError(t, err)
+		require.Len(t, tokens, 1)
+	})
+
+	t.Run("requires a path", func(t *testing.T) {
+		_, err := newLocalDetector(nil)
+		require.ErrorIs(t, err, errNoLocalScanPaths)
+	})
+}
+
+func TestScanReader_InvalidChecksum(t *testing.T) {
+	saToken, err := satokengen.New("sa")
+	require.NoError(t, err)
+
+	// flip the last checksum character so the token no longer decodes
+	tampered := []byte(saToken.ClientSecret)
+	if tampered[len(tampered)-1] == '0' {
+		tampered[len(tampered)-1] = '1'
+	} else {
+		tampered[len(tampered)-1] = '0'
+	}
+
+	tokens, err := scanReader(strings.NewReader(string(tampered)), "test", map[string]bool{saToken.HashedKey: true})
+	require.NoError(t, err)
+	assert.Empty(t, tokens)
+}
+
+func TestService_ScanText(t *testing.T) {
+	ctx := context.Background()
+
+	saToken, err := satokengen.New("sa")
+	require.NoError(t, err)
+
+	falseBool := false
+	saID := int64(3)
+	tokenStore := &MockTokenRetriever{keys: []apikey.APIKey{{
+		ID:               1,
+		OrgID:            2,
+		Name:             "test",
+		Key:              saToken.HashedKey,
+		ServiceAccountId: &saID,
+		IsRevoked:        &falseBool,
+	}}}
+	webHookClient := &MockSecretScanNotifier{}
+
+	service := &Service{
+		store:         tokenStore,
+		webHookClient: webHookClient,
+		logger:        log.New("secretscan"),
+		webHookNotify: true,
+		revoke:        true,
+	}
+
+	found, err := service.ScanText(ctx, 1, "ci-pipeline", strings.NewReader("GRAFANA_TOKEN="+saToken.ClientSecret))
+	require.NoError(t, err)
+	require.Empty(t, found, "tokens of other organizations should not be checked")
+	require.Empty(t, tokenStore.revokeCalls)
+	require.Equal(t, []any{int64(1)}, tokenStore.listCalls, "only the tokens of the organization should be listed")
+
+	found, err = service.ScanText(ctx, 2, "ci-pipeline", strings.NewReader("GRAFANA_TOKEN="+saToken.ClientSecret))
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "ci-pipeline#L1", found[0].URL)
+
+	require.Len(t, tokenStore.revokeCalls, 1)
+	assert.Equal(t, []any{int64(2), int64(3), int64(1)}, tokenStore.revokeCalls[0])
+	require.Len(t, webHookClient.notifyCalls, 1)
+}
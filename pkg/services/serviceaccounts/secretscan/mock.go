@@ -24,6 +24,18 @@ func (m *MockTokenRetriever) ListTokens(
 	return m.keys, m.errList
 }
 
+func (m *MockTokenRetriever) ListActiveTokens(ctx context.Context, orgID int64) ([]apikey.APIKey, error) {
+	m.listCalls = append(m.listCalls, orgID)
+
+	tokens := make([]apikey.APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		if key.OrgID == orgID {
+			tokens = append(tokens, key)
+		}
+	}
+	return tokens, m.errList
+}
+
 func (m *MockTokenRetriever) RevokeServiceAccountToken(
 	ctx context.Context, orgID, serviceAccountID, tokenID int64,
 ) error {
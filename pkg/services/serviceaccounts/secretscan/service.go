@@ -3,20 +3,31 @@ package secretscan
 import (
 	"context"
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/apikey"
 	"github.com/grafana/grafana/pkg/services/serviceaccounts"
 	"github.com/grafana/grafana/pkg/setting"
+	"github.com/grafana/grafana/pkg/util"
 )
 
 const defaultURL = "https://secret-scanning.grafana.net"
 
+const (
+	// DetectorGrafanaCom sends token hashes to the Grafana.com secret scanning service
+	DetectorGrafanaCom = "grafana_com"
+	// DetectorLocal scans local directories for tokens, for air-gapped installations
+	DetectorLocal = "local"
+)
+
 type Checker interface {
 	CheckTokens(ctx context.Context) error
 }
 
+// CheckerClient is a leaked token detector.
+// Given the hashes of all active tokens, it returns the tokens that have been found in the wild.
 type CheckerClient interface {
 	CheckTokens(ctx context.Context, keyHashes []string) ([]Token, error)
 }
@@ -27,6 +38,7 @@ type WebHookClient interface {
 
 type SATokenRetriever interface {
 	ListTokens(ctx context.Context, query *serviceaccounts.GetSATokensQuery) ([]apikey.APIKey, error)
+	ListActiveTokens(ctx context.Context, orgID int64) ([]apikey.APIKey, error)
 	RevokeServiceAccountToken(ctx context.Context, orgID, serviceAccountID, tokenID int64) error
 }
 
@@ -41,14 +53,14 @@ type Service struct {
 }
 
 func NewService(store SATokenRetriever, cfg *setting.Cfg) (*Service, error) {
-	secretscanBaseURL := cfg.SectionWithEnvOverrides("secretscan").Key("base_url").MustString(defaultURL)
+	section := cfg.SectionWithEnvOverrides("secretscan")
 	// URL to send outgoing webhook when a token is leaked.
-	oncallURL := cfg.SectionWithEnvOverrides("secretscan").Key("oncall_url").MustString("")
-	revoke := cfg.SectionWithEnvOverrides("secretscan").Key("revoke").MustBool(true)
+	oncallURL := section.Key("oncall_url").MustString("")
+	revoke := section.Key("revoke").MustBool(true)
 
-	client, err := newClient(secretscanBaseURL, cfg.BuildVersion, cfg.Env == setting.Dev)
+	client, err := newDetector(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create secretscan client: %w", err)
+		return nil, err
 	}
 
 	var webHookClient WebHookClient
@@ -70,6 +82,30 @@ func NewService(store SATokenRetriever, cfg *setting.Cfg) (*Service, error) {
 	}, nil
 }
 
+// newDetector returns the CheckerClient configured with `detector` in the [secretscan] section.
+func newDetector(cfg *setting.Cfg) (CheckerClient, error) {
+	section := cfg.SectionWithEnvOverrides("secretscan")
+
+	switch detector := section.Key("detector").MustString(DetectorGrafanaCom); detector {
+	case DetectorGrafanaCom:
+		secretscanBaseURL := section.Key("base_url").MustString(defaultURL)
+		client, err := newClient(secretscanBaseURL, cfg.BuildVersion, cfg.Env == setting.Dev)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create secretscan client: %w", err)
+		}
+		return client, nil
+	case DetectorLocal:
+		paths := util.SplitString(section.Key("local_scan_paths").MustString(""))
+		client, err := newLocalDetector(paths)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create local secretscan detector: %w", err)
+		}
+		return client, nil
+	default:
+		return nil, fmt.Errorf("unknown secretscan detector: %s", detector)
+	}
+}
+
 func (s *Service) RetrieveActiveTokens(ctx context.Context) ([]apikey.APIKey, error) {
 	saTokens, err := s.store.ListTokens(ctx, &serviceaccounts.GetSATokensQuery{})
 	if err != nil {
@@ -112,6 +148,39 @@ func (s *Service) CheckTokens(ctx context.Context) error {
 		return fmt.Errorf("failed to check tokens: %w", err)
 	}
 
+	s.handleLeakedTokens(ctx, secretscanTokens, hashMap)
+
+	return nil
+}
+
+// ScanText checks a text blob submitted by an external system (for example a CI pipeline
+// or a pre-receive hook) for active tokens of the given organization. Leaked tokens are
+// handled like the ones reported by the configured detector.
+func (s *Service) ScanText(ctx context.Context, orgID int64, source string, text io.Reader) ([]Token, error) {
+	// all the tokens of the organization are checked, RetrieveActiveTokens is capped
+	tokens, err := s.store.ListActiveTokens(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve tokens for checking: %w", err)
+	}
+
+	hashes, hashMap := s.filterCheckableTokens(tokens)
+	wanted := make(map[string]bool, len(hashes))
+	for _, h := range hashes {
+		wanted[h] = true
+	}
+
+	found, err := scanReader(text, source, wanted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan text: %w", err)
+	}
+
+	s.handleLeakedTokens(ctx, found, hashMap)
+
+	return found, nil
+}
+
+// handleLeakedTokens revokes and notifies about the leaked tokens, depending on the configuration.
+func (s *Service) handleLeakedTokens(ctx context.Context, secretscanTokens []Token, hashMap map[string]apikey.APIKey) {
 	// Revoke leaked tokens.
 	// Could be done in bulk but we don't expect more than 1 or 2 tokens to be leaked per check.
 	for _, secretscanToken := range secretscanTokens {
@@ -139,8 +208,6 @@ func (s *Service) CheckTokens(ctx context.Context) error {
 			"token_id", leakedToken.ID, "token", leakedToken.Name, "org", leakedToken.OrgID,
 			"serviceAccount", *leakedToken.ServiceAccountId, "revoked", s.revoke)
 	}
-
-	return nil
 }
 
 // filterCheckableTokens returns a list of tokens that can be checked and a map of tokens to their hashes.
@@ -186,4 +186,8 @@ func AddMigration(mg *migrator.Migrator) {
 	mg.AddMigration("add permission identifier index", migrator.NewAddIndexMigration(permissionV1, &migrator.Index{
 		Cols: []string{"identifier"},
 	}))
+
+	mg.AddMigration("add permission conditions column", migrator.NewAddColumnMigration(permissionV1, &migrator.Column{
+		Name: "conditions", Type: migrator.DB_Text, Nullable: true,
+	}))
 }
@@ -152,7 +152,7 @@ func addDashboardMigration(mg *Migrator) {
 	}))
 
 	mg.AddMigration("Add column uid in dashboard", NewAddColumnMigration(dashboardV2, &Column{
-		Name: "uid", Type: DB_NVarchar, Length: 40, Nullable: true,
+		Name: "uid", Type: DB_NVarchar, Length: 64, Nullable: true,
 	}))
 
 	mg.AddMigration("Update uid column values in dashboard", NewRawSQLMigration("").
@@ -234,4 +234,8 @@ func addDashboardMigration(mg *Migrator) {
 	mg.AddMigration("Add isPublic for dashboard", NewAddColumnMigration(dashboardV2, &Column{
 		Name: "is_public", Type: DB_Bool, Nullable: false, Default: "0",
 	}))
+
+	mg.AddMigration("Add commit_sha column to dashboard_provisioning", NewAddColumnMigration(dashboardExtrasTableV2, &Column{
+		Name: "commit_sha", Type: DB_NVarchar, Length: 64, Nullable: true,
+	}))
 }
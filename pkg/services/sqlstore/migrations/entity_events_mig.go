@@ -15,4 +15,8 @@ func addEntityEventsTableMigration(mg *Migrator) {
 	}
 
 	mg.AddMigration("create entity_events table", NewAddTableMigration(entityEventsTable))
+
+	mg.AddMigration("add folder_uid column to entity_event", NewAddColumnMigration(entityEventsTable, &Column{
+		Name: "folder_uid", Type: DB_NVarchar, Length: 40, Nullable: true,
+	}))
 }
@@ -24,6 +24,74 @@ func AddTablesMigrations(mg *migrator.Migrator) {
 	mg.AddMigration("add last_applied column to alert_configuration_history", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_configuration_history"}, &migrator.Column{
 		Name: "last_applied", Type: migrator.DB_Int, Nullable: false, Default: "0",
 	}))
+
+	mg.AddMigration("add record column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name: "record", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add record column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name: "record", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add keep_firing_for column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name: "keep_firing_for", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
+
+	mg.AddMigration("add keep_firing_for column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name: "keep_firing_for", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
+
+	mg.AddMigration("add notification_settings column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name: "notification_settings", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add notification_settings column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name: "notification_settings", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add depends_on column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name: "depends_on", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add depends_on column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name: "depends_on", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add updated_by column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name: "updated_by", Type: migrator.DB_NVarchar, Length: 190, Nullable: true,
+	}))
+
+	mg.AddMigration("add updated_by column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name: "updated_by", Type: migrator.DB_NVarchar, Length: 190, Nullable: true,
+	}))
+
+	mg.AddMigration("create alert_instance_acknowledgement table", migrator.NewAddTableMigration(migrator.Table{
+		Name: "alert_instance_acknowledgement",
+		Columns: []*migrator.Column{
+			{Name: "rule_org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "labels_hash", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "user_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "login", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "comment", Type: migrator.DB_Text, Nullable: true},
+			{Name: "created_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "expires_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "suppress_notifications", Type: migrator.DB_Bool, Nullable: false, Default: "0"},
+		},
+		PrimaryKeys: []string{"rule_org_id", "rule_uid", "labels_hash"},
+	}))
+
+	mg.AddMigration("add flap_detection column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name: "flap_detection", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add flap_detection column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name: "flap_detection", Type: migrator.DB_Text, Nullable: true,
+	}))
+
+	mg.AddMigration("add firing_changes column to alert_instance table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_instance"}, &migrator.Column{
+		Name: "firing_changes", Type: migrator.DB_Text, Nullable: true,
+	}))
 	// End of migration log, add new migrations above this line.
 }
 
@@ -19,6 +19,10 @@ import (
 // maximum possible capacity for recursive queries array: one query for folder and one for dashboard actions
 const maximumRecursiveQueries = 2
 
+// conditional permissions depend on the request and are never matched by the permission subqueries,
+// the resources they grant are taken from the permissions of the user evaluated in memory instead
+const unconditionalPermissions = " AND (conditions IS NULL OR conditions = '')"
+
 type clause struct {
 	string
 	params []any
@@ -145,7 +149,7 @@ func (f *accessControlDashboardPermissionFilter) buildClauses() {
 
 		if len(toCheck) > 0 {
 			if !useSelfContainedPermissions {
-				builder.WriteString("(dashboard.uid IN (SELECT substr(scope, 16) FROM permission WHERE scope LIKE 'dashboards:uid:%'")
+				builder.WriteString("(dashboard.uid IN (SELECT substr(scope, 16) FROM permission WHERE scope LIKE 'dashboards:uid:%'" + unconditionalPermissions)
 				builder.WriteString(rolesFilter)
 				args = append(args, params...)
 
@@ -158,6 +162,11 @@ func (f *accessControlDashboardPermissionFilter) buildClauses() {
 					args = append(args, len(toCheck))
 				}
 				builder.WriteString(") AND NOT dashboard.is_folder)")
+				if grantedFilter, grantedArgs := grantedDashboardsFilter(toCheck, f.user); grantedFilter != "" {
+					builder.WriteString(" OR ")
+					builder.WriteString(grantedFilter)
+					args = append(args, grantedArgs...)
+				}
 			} else {
 				actions := parseStringSliceFromInterfaceSlice(toCheck)
 
@@ -175,7 +184,7 @@ func (f *accessControlDashboardPermissionFilter) buildClauses() {
 			builder.WriteString(" OR ")
 
 			if !useSelfContainedPermissions {
-				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%' ")
+				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%'" + unconditionalPermissions)
 				permSelector.WriteString(rolesFilter)
 				permSelectorArgs = append(permSelectorArgs, params...)
 
@@ -187,6 +196,9 @@ func (f *accessControlDashboardPermissionFilter) buildClauses() {
 					permSelectorArgs = append(permSelectorArgs, toCheck...)
 					permSelectorArgs = append(permSelectorArgs, len(toCheck))
 				}
+				grantedUnion, grantedArgs := grantedFoldersUnion(toCheck, f.user)
+				permSelector.WriteString(grantedUnion)
+				permSelectorArgs = append(permSelectorArgs, grantedArgs...)
 			} else {
 				actions := parseStringSliceFromInterfaceSlice(toCheck)
 
@@ -236,6 +248,13 @@ func (f *accessControlDashboardPermissionFilter) buildClauses() {
 			if hasAccessToRoot(toCheck, f.user) {
 				builder.WriteString(" OR (dashboard.folder_id = 0 AND NOT dashboard.is_folder)")
 			}
+
+			// Include the dashboards with the tags the user has been granted access to by conditional permissions
+			if tagsFilter, tagsArgs := dashboardTagsFilter(toCheck, f.user); tagsFilter != "" {
+				builder.WriteString(" OR ")
+				builder.WriteString(tagsFilter)
+				args = append(args, tagsArgs...)
+			}
 		} else {
 			builder.WriteString("NOT dashboard.is_folder")
 		}
@@ -253,7 +272,7 @@ func (f *accessControlDashboardPermissionFilter) buildClauses() {
 		toCheck := actionsToCheck(f.folderActions, f.user.GetPermissions(), folderWildcards)
 		if len(toCheck) > 0 {
 			if !useSelfContainedPermissions {
-				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%'")
+				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%'" + unconditionalPermissions)
 				permSelector.WriteString(rolesFilter)
 				permSelectorArgs = append(permSelectorArgs, params...)
 				if len(toCheck) == 1 {
@@ -264,6 +283,9 @@ func (f *accessControlDashboardPermissionFilter) buildClauses() {
 					permSelectorArgs = append(permSelectorArgs, toCheck...)
 					permSelectorArgs = append(permSelectorArgs, len(toCheck))
 				}
+				grantedUnion, grantedArgs := grantedFoldersUnion(toCheck, f.user)
+				permSelector.WriteString(grantedUnion)
+				permSelectorArgs = append(permSelectorArgs, grantedArgs...)
 			} else {
 				actions := parseStringSliceFromInterfaceSlice(toCheck)
 
@@ -431,6 +453,41 @@ func getAllowedUIDs(actions []string, user identity.Requester, scopePrefix strin
 	return args
 }
 
+// dashboardTagsFilter returns the clause matching the dashboards with a tag the user has been granted all the actions on
+func dashboardTagsFilter(actionsToCheck []any, user identity.Requester) (string, []any) {
+	actions := parseStringSliceFromInterfaceSlice(actionsToCheck)
+	if len(actions) == 0 {
+		return "", nil
+	}
+
+	tags := getAllowedUIDs(actions, user, accesscontrol.ScopeTag(dashboards.ScopeDashboardsRoot, ""))
+	if len(tags) == 0 {
+		return "", nil
+	}
+	return "(dashboard.id IN (SELECT dashboard_id FROM dashboard_tag WHERE term IN (?" + strings.Repeat(", ?", len(tags)-1) + ")) AND NOT dashboard.is_folder)", tags
+}
+
+// grantedDashboardsFilter returns the clause matching the dashboards granted by the permissions of the user,
+// which include the conditional permissions that are active for the request
+func grantedDashboardsFilter(actionsToCheck []any, user identity.Requester) (string, []any) {
+	uids := getAllowedUIDs(parseStringSliceFromInterfaceSlice(actionsToCheck), user, dashboards.ScopeDashboardsPrefix)
+	if len(uids) == 0 {
+		return "", nil
+	}
+	return "(dashboard.uid IN (?" + strings.Repeat(", ?", len(uids)-1) + ") AND NOT dashboard.is_folder)", uids
+}
+
+// grantedFoldersUnion returns the union adding the folders granted by the permissions of the user to a folder
+// permission subquery, these include the conditional permissions that are active for the request
+func grantedFoldersUnion(actionsToCheck []any, user identity.Requester) (string, []any) {
+	uids := getAllowedUIDs(parseStringSliceFromInterfaceSlice(actionsToCheck), user, dashboards.ScopeFoldersPrefix)
+	if len(uids) == 0 {
+		return "", nil
+	}
+	args := append([]any{user.GetOrgID()}, uids...)
+	return " UNION SELECT uid FROM dashboard WHERE is_folder AND org_id = ? AND uid IN (?" + strings.Repeat(", ?", len(uids)-1) + ")", args
+}
+
 // Checks if the user has the required permissions on the root (used to be the General folder)
 func hasAccessToRoot(actionsToCheck []any, user identity.Requester) bool {
 	generalFolderScope := dashboards.ScopeFoldersProvider.GetResourceScopeUID(folder.GeneralFolderUID)
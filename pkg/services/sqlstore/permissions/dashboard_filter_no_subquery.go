@@ -58,7 +58,7 @@ func (f *accessControlDashboardPermissionFilterNoFolderSubquery) buildClauses()
 
 		if len(toCheck) > 0 {
 			if !useSelfContainedPermissions {
-				builder.WriteString("(dashboard.uid IN (SELECT substr(scope, 16) FROM permission WHERE scope LIKE 'dashboards:uid:%'")
+				builder.WriteString("(dashboard.uid IN (SELECT substr(scope, 16) FROM permission WHERE scope LIKE 'dashboards:uid:%'" + unconditionalPermissions)
 				builder.WriteString(rolesFilter)
 				args = append(args, params...)
 
@@ -71,6 +71,11 @@ func (f *accessControlDashboardPermissionFilterNoFolderSubquery) buildClauses()
 					args = append(args, len(toCheck))
 				}
 				builder.WriteString(") AND NOT dashboard.is_folder)")
+				if grantedFilter, grantedArgs := grantedDashboardsFilter(toCheck, f.user); grantedFilter != "" {
+					builder.WriteString(" OR ")
+					builder.WriteString(grantedFilter)
+					args = append(args, grantedArgs...)
+				}
 			} else {
 				actions := parseStringSliceFromInterfaceSlice(toCheck)
 
@@ -88,7 +93,7 @@ func (f *accessControlDashboardPermissionFilterNoFolderSubquery) buildClauses()
 			builder.WriteString(" OR ")
 
 			if !useSelfContainedPermissions {
-				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%' ")
+				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%'" + unconditionalPermissions)
 				permSelector.WriteString(rolesFilter)
 				permSelectorArgs = append(permSelectorArgs, params...)
 
@@ -100,6 +105,9 @@ func (f *accessControlDashboardPermissionFilterNoFolderSubquery) buildClauses()
 					permSelectorArgs = append(permSelectorArgs, toCheck...)
 					permSelectorArgs = append(permSelectorArgs, len(toCheck))
 				}
+				grantedUnion, grantedArgs := grantedFoldersUnion(toCheck, f.user)
+				permSelector.WriteString(grantedUnion)
+				permSelectorArgs = append(permSelectorArgs, grantedArgs...)
 			} else {
 				actions := parseStringSliceFromInterfaceSlice(toCheck)
 
@@ -151,6 +159,13 @@ func (f *accessControlDashboardPermissionFilterNoFolderSubquery) buildClauses()
 			if hasAccessToRoot(toCheck, f.user) {
 				builder.WriteString(" OR (dashboard.folder_id = 0 AND NOT dashboard.is_folder)")
 			}
+
+			// Include the dashboards with the tags the user has been granted access to by conditional permissions
+			if tagsFilter, tagsArgs := dashboardTagsFilter(toCheck, f.user); tagsFilter != "" {
+				builder.WriteString(" OR ")
+				builder.WriteString(tagsFilter)
+				args = append(args, tagsArgs...)
+			}
 		} else {
 			builder.WriteString("NOT dashboard.is_folder")
 		}
@@ -168,7 +183,7 @@ func (f *accessControlDashboardPermissionFilterNoFolderSubquery) buildClauses()
 		toCheck := actionsToCheck(f.folderActions, f.user.GetPermissions(), folderWildcards)
 		if len(toCheck) > 0 {
 			if !useSelfContainedPermissions {
-				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%'")
+				permSelector.WriteString("(SELECT substr(scope, 13) FROM permission WHERE scope LIKE 'folders:uid:%'" + unconditionalPermissions)
 				permSelector.WriteString(rolesFilter)
 				permSelectorArgs = append(permSelectorArgs, params...)
 				if len(toCheck) == 1 {
@@ -179,6 +194,9 @@ func (f *accessControlDashboardPermissionFilterNoFolderSubquery) buildClauses()
 					permSelectorArgs = append(permSelectorArgs, toCheck...)
 					permSelectorArgs = append(permSelectorArgs, len(toCheck))
 				}
+				grantedUnion, grantedArgs := grantedFoldersUnion(toCheck, f.user)
+				permSelector.WriteString(grantedUnion)
+				permSelectorArgs = append(permSelectorArgs, grantedArgs...)
 			} else {
 				actions := parseStringSliceFromInterfaceSlice(toCheck)
 
@@ -109,6 +109,24 @@ func TestIntegration_DashboardPermissionFilter(t *testing.T) {
 			},
 			expectedResult: 20,
 		},
+		{
+			desc:       "Should be able to view dashboards with active conditional dashboard and folder scopes",
+			permission: dashboards.PERMISSION_VIEW,
+			permissions: []accesscontrol.Permission{
+				{Action: dashboards.ActionDashboardsRead, Scope: "dashboards:uid:13", Conditions: `{"notAfter":"2100-01-01T00:00:00Z"}`},
+				{Action: dashboards.ActionDashboardsRead, Scope: "folders:uid:8", Conditions: `{"notAfter":"2100-01-01T00:00:00Z"}`},
+			},
+			expectedResult: 11,
+		},
+		{
+			desc:       "Should be able to view folders with active conditional folder scopes",
+			permission: dashboards.PERMISSION_VIEW,
+			permissions: []accesscontrol.Permission{
+				{Action: dashboards.ActionFoldersRead, Scope: "folders:uid:3", Conditions: `{"notAfter":"2100-01-01T00:00:00Z"}`},
+				{Action: dashboards.ActionFoldersRead, Scope: "folders:uid:6"},
+			},
+			expectedResult: 2,
+		},
 		{
 			desc:       "Should be able to view all folders with folder wildcard",
 			permission: dashboards.PERMISSION_VIEW,
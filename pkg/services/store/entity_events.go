@@ -3,11 +3,16 @@ package store
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/registry"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/featuremgmt"
 	"github.com/grafana/grafana/pkg/setting"
 )
@@ -27,6 +32,10 @@ const (
 	EntityTypeFolder    EntityType = "folder"
 	EntityTypeImage     EntityType = "image"
 	EntityTypeJSON      EntityType = "json"
+
+	EntityTypeDataSource   EntityType = "datasource"
+	EntityTypeAlertRule    EntityType = "alert-rule"
+	EntityTypeLibraryPanel EntityType = "library-panel"
 )
 
 // CreateDatabaseEntityId creates entityId for entities stored in the existing SQL tables
@@ -42,10 +51,41 @@ func CreateDatabaseEntityId(internalId any, orgId int64, entityType EntityType)
 	return fmt.Sprintf("database/%d/%s/%s", orgId, entityType, internalIdAsString)
 }
 
+// ParseDatabaseEntityId is the inverse of CreateDatabaseEntityId
+func ParseDatabaseEntityId(entityId string) (orgId int64, entityType EntityType, internalId string, err error) {
+	parts := strings.SplitN(entityId, "/", 4)
+	if len(parts) != 4 || parts[0] != "database" {
+		return 0, "", "", fmt.Errorf("invalid database entity id: %s", entityId)
+	}
+	orgId, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, "", "", fmt.Errorf("invalid org id in entity id: %s", entityId)
+	}
+	return orgId, EntityType(parts[2]), parts[3], nil
+}
+
+// NewDatabaseEntityEvent creates an event for entities stored in the existing SQL tables
+func NewDatabaseEntityEvent(eventType EntityEventType, internalId any, orgId int64, entityType EntityType) *EntityEvent {
+	return &EntityEvent{
+		EventType: eventType,
+		EntityId:  CreateDatabaseEntityId(internalId, orgId, entityType),
+		Created:   time.Now().Unix(),
+	}
+}
+
+// EntityEventsEnabled checks if the existing SQL stores should write to the entity_event table
+func EntityEventsEnabled(features featuremgmt.FeatureToggles) bool {
+	return features != nil && (features.IsEnabled(featuremgmt.FlagPanelTitleSearch) ||
+		features.IsEnabled(featuremgmt.FlagEntityEventsStream))
+}
+
 type EntityEvent struct {
 	Id        int64
 	EventType EntityEventType
 	EntityId  string
+	// FolderUID is the folder of the entity when the event was created.  It is used to check
+	// permissions for entities that are scoped by folder, and for entities that have been deleted
+	FolderUID string `xorm:"folder_uid"`
 	Created   int64
 }
 
@@ -65,28 +105,45 @@ type EntityEventsService interface {
 	registry.CanBeDisabled
 	GetLastEvent(ctx context.Context) (*EntityEvent, error)
 	GetAllEventsAfter(ctx context.Context, id int64) ([]*EntityEvent, error)
+	GetEventsAfter(ctx context.Context, id int64, limit int) ([]*EntityEvent, error)
 
 	deleteEventsOlderThan(ctx context.Context, duration time.Duration) error
 }
 
-func ProvideEntityEventsService(cfg *setting.Cfg, sqlStore db.DB, features featuremgmt.FeatureToggles) EntityEventsService {
-	if !features.IsEnabled(featuremgmt.FlagPanelTitleSearch) {
+func ProvideEntityEventsService(cfg *setting.Cfg, sqlStore db.DB, features featuremgmt.FeatureToggles,
+	routeRegister routing.RouteRegister, ac accesscontrol.AccessControl) EntityEventsService {
+	if !EntityEventsEnabled(features) {
 		return &dummyEntityEventsService{}
 	}
 
-	return &entityEventService{
+	s := &entityEventService{
 		sql:           sqlStore,
 		features:      features,
+		ac:            ac,
 		log:           log.New("entity-events"),
 		eventHandlers: make([]EventHandler, 0),
+		pollInterval:  time.Second,
+		notify:        make(chan struct{}),
 	}
+	if features.IsEnabled(featuremgmt.FlagEntityEventsStream) {
+		routeRegister.Group("/api/entity-events", s.registerHTTPRoutes)
+	}
+	return s
 }
 
 type entityEventService struct {
 	sql           db.DB
 	log           log.Logger
 	features      featuremgmt.FeatureToggles
+	ac            accesscontrol.AccessControl
 	eventHandlers []EventHandler
+	pollInterval  time.Duration
+
+	// notify is closed and replaced when the poller sees new events, to wake up all the
+	// long-poll and stream requests with a single query of the database
+	mu          sync.Mutex
+	notify      chan struct{}
+	lastEventID int64
 }
 
 func (e *entityEventService) GetLastEvent(ctx context.Context) (*EntityEvent, error) {
@@ -112,6 +169,15 @@ func (e *entityEventService) GetAllEventsAfter(ctx context.Context, id int64) ([
 	return evs, err
 }
 
+func (e *entityEventService) GetEventsAfter(ctx context.Context, id int64, limit int) ([]*EntityEvent, error) {
+	var evs = make([]*EntityEvent, 0)
+	err := e.sql.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.OrderBy("id asc").Where("id > ?", id).Limit(limit).Find(&evs)
+	})
+
+	return evs, err
+}
+
 func (e *entityEventService) deleteEventsOlderThan(ctx context.Context, duration time.Duration) error {
 	return e.sql.WithDbSession(ctx, func(sess *db.Session) error {
 		maxCreated := time.Now().Add(-duration)
@@ -127,6 +193,9 @@ func (e *entityEventService) IsDisabled() bool {
 
 func (e *entityEventService) Run(ctx context.Context) error {
 	clean := time.NewTicker(1 * time.Hour)
+	if e.features.IsEnabled(featuremgmt.FlagEntityEventsStream) {
+		go e.pollEvents(ctx)
+	}
 
 	for {
 		select {
@@ -145,6 +214,44 @@ func (e *entityEventService) Run(ctx context.Context) error {
 	}
 }
 
+// pollEvents checks for new events and notifies the waiting requests
+func (e *entityEventService) pollEvents(ctx context.Context) {
+	ticker := time.NewTicker(e.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		last, err := e.GetLastEvent(ctx)
+		if err != nil {
+			e.log.Warn("Failed to read the last entity event", "error", err)
+			continue
+		}
+		if last == nil {
+			continue
+		}
+
+		e.mu.Lock()
+		if last.Id > e.lastEventID {
+			e.lastEventID = last.Id
+			close(e.notify)
+			e.notify = make(chan struct{})
+		}
+		e.mu.Unlock()
+	}
+}
+
+// eventsNotifier returns a channel that is closed when the poller sees new events.
+// Get the channel before reading the events, so events created in between are not missed
+func (e *entityEventService) eventsNotifier() <-chan struct{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.notify
+}
+
 type dummyEntityEventsService struct {
 }
 
@@ -168,6 +275,10 @@ func (d dummyEntityEventsService) GetAllEventsAfter(ctx context.Context, id int6
 	return make([]*EntityEvent, 0), nil
 }
 
+func (d dummyEntityEventsService) GetEventsAfter(ctx context.Context, id int64, limit int) ([]*EntityEvent, error) {
+	return make([]*EntityEvent, 0), nil
+}
+
 func (d dummyEntityEventsService) deleteEventsOlderThan(ctx context.Context, duration time.Duration) error {
 	return nil
 }
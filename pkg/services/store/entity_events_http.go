@@ -0,0 +1,247 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/auth/identity"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+)
+
+const (
+	maxEventsPerBatch   = 100
+	maxLongPollWait     = 60 * time.Second
+	sseKeepAliveTimeout = 15 * time.Second
+)
+
+// EntityEventDTO is the external representation of an entity event
+type EntityEventDTO struct {
+	ID        int64           `json:"id"`
+	EventType EntityEventType `json:"eventType"`
+	Kind      EntityType      `json:"kind"`
+	UID       string          `json:"uid"`
+	OrgID     int64           `json:"orgId"`
+	Created   int64           `json:"created"`
+}
+
+type EntityEventsResponse struct {
+	Events []EntityEventDTO `json:"events"`
+	// The ID to pass as `after` in the next request
+	LastEventID int64 `json:"lastEventId"`
+}
+
+func (e *entityEventService) registerHTTPRoutes(route routing.RouteRegister) {
+	route.Get("/", middleware.ReqSignedIn, routing.Wrap(e.doPoll))
+	route.Get("/stream", middleware.ReqSignedIn, e.doStream)
+}
+
+// doPoll returns the visible events after the requested ID.  When `wait` is set and no
+// events are available, the request is held open until an event arrives or the wait expires
+func (e *entityEventService) doPoll(c *contextmodel.ReqContext) response.Response {
+	after, err := e.getStartID(c)
+	if err != nil {
+		return response.Error(http.StatusBadRequest, "invalid event id", err)
+	}
+	limit := c.QueryInt("limit")
+	if limit < 1 || limit > maxEventsPerBatch {
+		limit = maxEventsPerBatch
+	}
+	var wait time.Duration
+	if v := c.Query("wait"); v != "" {
+		wait, err = time.ParseDuration(v)
+		if err != nil {
+			return response.Error(http.StatusBadRequest, "invalid wait duration", err)
+		}
+		if wait > maxLongPollWait {
+			wait = maxLongPollWait
+		}
+	}
+
+	ctx := c.Req.Context()
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	rsp := &EntityEventsResponse{Events: make([]EntityEventDTO, 0), LastEventID: after}
+	for {
+		notify := e.eventsNotifier()
+		evs, err := e.GetEventsAfter(ctx, rsp.LastEventID, limit)
+		if err != nil {
+			return response.Error(http.StatusInternalServerError, "failed to read events", err)
+		}
+		for _, ev := range evs {
+			rsp.LastEventID = ev.Id
+			if dto, ok := e.toVisibleDTO(ctx, c.SignedInUser, ev); ok {
+				rsp.Events = append(rsp.Events, dto)
+			}
+		}
+		if len(rsp.Events) > 0 || len(evs) == limit || wait <= 0 {
+			return response.JSON(http.StatusOK, rsp)
+		}
+
+		select {
+		case <-ctx.Done():
+			return response.JSON(http.StatusOK, rsp)
+		case <-timeout.C:
+			return response.JSON(http.StatusOK, rsp)
+		case <-notify:
+		}
+	}
+}
+
+// doStream writes events as server-sent events.  Clients can resume after a disconnect
+// with the standard `Last-Event-ID` header
+func (e *entityEventService) doStream(c *contextmodel.ReqContext) {
+	after, err := e.getStartID(c)
+	if err != nil {
+		c.JsonApiErr(http.StatusBadRequest, "invalid event id", err)
+		return
+	}
+	flusher, ok := c.Resp.(http.Flusher)
+	if !ok {
+		c.JsonApiErr(http.StatusInternalServerError, "streaming is not supported", nil)
+		return
+	}
+
+	c.Resp.Header().Set("Content-Type", "text/event-stream")
+	c.Resp.Header().Set("Cache-Control", "no-cache")
+	c.Resp.Header().Set("Connection", "keep-alive")
+	c.Resp.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx := c.Req.Context()
+	keepAlive := time.NewTicker(sseKeepAliveTimeout)
+	defer keepAlive.Stop()
+	lastWrite := time.Now()
+	// a closed channel reads the events right away, for the first batch and when more events are pending
+	pending := make(chan struct{})
+	close(pending)
+	notify := (<-chan struct{})(pending)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAlive.C:
+		case <-notify:
+		}
+
+		notify = e.eventsNotifier()
+		evs, err := e.GetEventsAfter(ctx, after, maxEventsPerBatch)
+		if err != nil {
+			e.log.Warn("Failed to read entity events", "error", err)
+			continue
+		}
+		for _, ev := range evs {
+			after = ev.Id
+			dto, ok := e.toVisibleDTO(ctx, c.SignedInUser, ev)
+			if !ok {
+				continue
+			}
+			body, err := json.Marshal(dto)
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(c.Resp, "id: %d\nevent: %s\ndata: %s\n\n", ev.Id, ev.EventType, body); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+		if len(evs) == maxEventsPerBatch {
+			notify = pending
+		}
+
+		if time.Since(lastWrite) >= sseKeepAliveTimeout {
+			if _, err := fmt.Fprint(c.Resp, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			lastWrite = time.Now()
+		}
+		flusher.Flush()
+	}
+}
+
+// getStartID reads the resume position from the `Last-Event-ID` header or `after` query parameter.
+// When neither is set, only events created after the request will be returned
+func (e *entityEventService) getStartID(c *contextmodel.ReqContext) (int64, error) {
+	v := c.Req.Header.Get("Last-Event-ID")
+	if v == "" {
+		v = c.Query("after")
+	}
+	if v != "" {
+		return strconv.ParseInt(v, 10, 64)
+	}
+
+	last, err := e.GetLastEvent(c.Req.Context())
+	if err != nil || last == nil {
+		return 0, err
+	}
+	return last.Id, nil
+}
+
+// toVisibleDTO converts the event and checks if the user is allowed to see it
+func (e *entityEventService) toVisibleDTO(ctx context.Context, user identity.Requester, ev *EntityEvent) (EntityEventDTO, bool) {
+	dto := EntityEventDTO{
+		ID:        ev.Id,
+		EventType: ev.EventType,
+		Created:   ev.Created,
+	}
+	orgID, kind, uid, err := ParseDatabaseEntityId(ev.EntityId)
+	if err != nil || orgID != user.GetOrgID() {
+		return dto, false
+	}
+	dto.OrgID = orgID
+	dto.Kind = kind
+	dto.UID = uid
+
+	evaluator := getReadEvaluator(kind, uid, ev.FolderUID)
+	if evaluator == nil {
+		return dto, false
+	}
+	// deleted entities cannot be resolved to their folders anymore, so only the scopes stored in the event are checked
+	if ev.EventType == EntityEventTypeDelete {
+		return dto, evaluator.Evaluate(user.GetPermissions())
+	}
+	ok, err := e.ac.Evaluate(ctx, user, evaluator)
+	if err != nil {
+		e.log.Debug("Failed to evaluate entity event permissions", "entityId", ev.EntityId, "error", err)
+		return dto, false
+	}
+	return dto, ok
+}
+
+// getReadEvaluator returns the permission required to see events for an entity.
+// Dashboards, folders and library panels can also be read through the folder stored in the event, and
+// alert rules are scoped by it, so rule events without a folder are not visible
+func getReadEvaluator(kind EntityType, uid string, folderUID string) accesscontrol.Evaluator {
+	switch kind {
+	case EntityTypeDashboard:
+		return withFolderScope(dashboards.ActionDashboardsRead, dashboards.ScopeDashboardsProvider.GetResourceScopeUID(uid), folderUID)
+	case EntityTypeFolder:
+		return withFolderScope(dashboards.ActionFoldersRead, dashboards.ScopeFoldersProvider.GetResourceScopeUID(uid), folderUID)
+	case EntityTypeDataSource:
+		return accesscontrol.EvalPermission(datasources.ActionRead, datasources.ScopeProvider.GetResourceScopeUID(uid))
+	case EntityTypeAlertRule:
+		if folderUID == "" {
+			return nil
+		}
+		return accesscontrol.EvalPermission(accesscontrol.ActionAlertingRuleRead, dashboards.ScopeFoldersProvider.GetResourceScopeUID(folderUID))
+	case EntityTypeLibraryPanel:
+		return withFolderScope(accesscontrol.ActionLibraryPanelsRead, accesscontrol.Scope("library.panels", "uid", uid), folderUID)
+	}
+	return nil
+}
+
+func withFolderScope(action string, scope string, folderUID string) accesscontrol.Evaluator {
+	if folderUID == "" {
+		return accesscontrol.EvalPermission(action, scope)
+	}
+	return accesscontrol.EvalPermission(action, scope, dashboards.ScopeFoldersProvider.GetResourceScopeUID(folderUID))
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/accesscontrol/acimpl"
+	"github.com/grafana/grafana/pkg/services/dashboards"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestEntityEventsPermissionFilter(t *testing.T) {
+	svc := &entityEventService{
+		ac:  acimpl.ProvideAccessControl(setting.NewCfg()),
+		log: log.New("entity-event-test"),
+	}
+	usr := &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: accesscontrol.GroupScopesByAction([]accesscontrol.Permission{
+				{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeDashboardsProvider.GetResourceScopeUID("visible")},
+				{Action: datasources.ActionRead, Scope: datasources.ScopeAll},
+				{Action: dashboards.ActionDashboardsRead, Scope: dashboards.ScopeFoldersProvider.GetResourceScopeUID("folder")},
+				{Action: accesscontrol.ActionAlertingRuleRead, Scope: dashboards.ScopeFoldersProvider.GetResourceScopeUID("folder")},
+				{Action: accesscontrol.ActionLibraryPanelsRead, Scope: dashboards.ScopeFoldersProvider.GetResourceScopeUID("folder")},
+			}),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		event   *EntityEvent
+		visible bool
+	}{
+		{
+			name:    "dashboard with permission",
+			event:   NewDatabaseEntityEvent(EntityEventTypeUpdate, "visible", 1, EntityTypeDashboard),
+			visible: true,
+		},
+		{
+			name:  "dashboard without permission",
+			event: NewDatabaseEntityEvent(EntityEventTypeUpdate, "hidden", 1, EntityTypeDashboard),
+		},
+		{
+			name:  "dashboard in another org",
+			event: NewDatabaseEntityEvent(EntityEventTypeUpdate, "visible", 2, EntityTypeDashboard),
+		},
+		{
+			name:    "datasource with wildcard permission",
+			event:   NewDatabaseEntityEvent(EntityEventTypeDelete, "ds", 1, EntityTypeDataSource),
+			visible: true,
+		},
+		{
+			name:    "deleted dashboard in a readable folder",
+			event:   withFolder(NewDatabaseEntityEvent(EntityEventTypeDelete, "hidden", 1, EntityTypeDashboard), "folder"),
+			visible: true,
+		},
+		{
+			name:  "deleted dashboard in another folder",
+			event: withFolder(NewDatabaseEntityEvent(EntityEventTypeDelete, "hidden", 1, EntityTypeDashboard), "other"),
+		},
+		{
+			name:    "alert rule in a readable folder",
+			event:   withFolder(NewDatabaseEntityEvent(EntityEventTypeCreate, "rule", 1, EntityTypeAlertRule), "folder"),
+			visible: true,
+		},
+		{
+			name:    "deleted alert rule in a readable folder",
+			event:   withFolder(NewDatabaseEntityEvent(EntityEventTypeDelete, "rule", 1, EntityTypeAlertRule), "folder"),
+			visible: true,
+		},
+		{
+			name:  "alert rule in another folder",
+			event: withFolder(NewDatabaseEntityEvent(EntityEventTypeCreate, "rule", 1, EntityTypeAlertRule), "other"),
+		},
+		{
+			name:    "library panel in a readable folder",
+			event:   withFolder(NewDatabaseEntityEvent(EntityEventTypeUpdate, "panel", 1, EntityTypeLibraryPanel), "folder"),
+			visible: true,
+		},
+		{
+			name:    "deleted library panel in a readable folder",
+			event:   withFolder(NewDatabaseEntityEvent(EntityEventTypeDelete, "panel", 1, EntityTypeLibraryPanel), "folder"),
+			visible: true,
+		},
+		{
+			name:  "library panel in another folder",
+			event: withFolder(NewDatabaseEntityEvent(EntityEventTypeUpdate, "panel", 1, EntityTypeLibraryPanel), "other"),
+		},
+		{
+			name:  "alert rule without folder",
+			event: NewDatabaseEntityEvent(EntityEventTypeCreate, "rule", 1, EntityTypeAlertRule),
+		},
+		{
+			name:  "unknown kind",
+			event: NewDatabaseEntityEvent(EntityEventTypeCreate, "x", 1, EntityTypeImage),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.Id = 10
+			dto, ok := svc.toVisibleDTO(context.Background(), usr, tt.event)
+			require.Equal(t, tt.visible, ok)
+			if ok {
+				require.Equal(t, int64(10), dto.ID)
+				require.Equal(t, tt.event.EventType, dto.EventType)
+				require.NotEmpty(t, dto.UID)
+				require.NotEmpty(t, dto.Kind)
+			}
+		})
+	}
+}
+
+func withFolder(ev *EntityEvent, folderUID string) *EntityEvent {
+	ev.FolderUID = folderUID
+	return ev
+}
@@ -37,6 +37,29 @@ func (_m *MockEntityEventsService) GetAllEventsAfter(ctx context.Context, id int
 	return r0, r1
 }
 
+// GetEventsAfter provides a mock function with given fields: ctx, id, limit
+func (_m *MockEntityEventsService) GetEventsAfter(ctx context.Context, id int64, limit int) ([]*EntityEvent, error) {
+	ret := _m.Called(ctx, id, limit)
+
+	var r0 []*EntityEvent
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []*EntityEvent); ok {
+		r0 = rf(ctx, id, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*EntityEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, id, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLastEvent provides a mock function with given fields: ctx
 func (_m *MockEntityEventsService) GetLastEvent(ctx context.Context) (*EntityEvent, error) {
 	ret := _m.Called(ctx)
@@ -193,3 +193,50 @@ func TestIntegrationCreateDatabaseEntityId(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDatabaseEntityId(t *testing.T) {
+	id := CreateDatabaseEntityId("abc/def", 2, EntityTypeAlertRule)
+	require.Equal(t, "database/2/alert-rule/abc/def", id)
+
+	orgID, kind, uid, err := ParseDatabaseEntityId(id)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), orgID)
+	require.Equal(t, EntityTypeAlertRule, kind)
+	require.Equal(t, "abc/def", uid)
+
+	_, _, _, err = ParseDatabaseEntityId("database/x/dashboard/abc")
+	require.Error(t, err)
+	_, _, _, err = ParseDatabaseEntityId("other/1/dashboard/abc")
+	require.Error(t, err)
+}
+
+func TestIntegrationEntityEventsPoller(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	service := &entityEventService{
+		sql:          db.InitTestDB(t),
+		log:          log.New("entity-event-test"),
+		pollInterval: 10 * time.Millisecond,
+		notify:       make(chan struct{}),
+	}
+	go service.pollEvents(ctx)
+
+	notify := service.eventsNotifier()
+	select {
+	case <-notify:
+		t.Fatal("should not notify without events")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.NoError(t, saveEvent(ctx, service.sql, SaveEventCmd{EntityId: "database/1/dashboard/abc", EventType: EntityEventTypeCreate}))
+	select {
+	case <-notify:
+	case <-time.After(5 * time.Second):
+		t.Fatal("should notify when an event is created")
+	}
+	require.NotEqual(t, notify, service.eventsNotifier())
+}
@@ -2,6 +2,7 @@ package teamapi
 
 import (
 	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/middleware/requestmeta"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/dashboards"
@@ -19,6 +20,7 @@ type TeamAPI struct {
 	cfg                    *setting.Cfg
 	preferenceService      pref.Service
 	ds                     dashboards.DashboardService
+	kvStore                kvstore.KVStore
 }
 
 func ProvideTeamAPI(
@@ -31,6 +33,7 @@ func ProvideTeamAPI(
 	cfg *setting.Cfg,
 	preferenceService pref.Service,
 	ds dashboards.DashboardService,
+	kvStore kvstore.KVStore,
 ) *TeamAPI {
 	tapi := &TeamAPI{
 		teamService:            teamService,
@@ -40,6 +43,7 @@ func ProvideTeamAPI(
 		cfg:                    cfg,
 		preferenceService:      preferenceService,
 		ds:                     ds,
+		kvStore:                kvStore,
 	}
 
 	tapi.registerRoutes(routeRegister, acEvaluator)
@@ -12,6 +12,7 @@ import (
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/preference/prefapi"
+	"github.com/grafana/grafana/pkg/services/provisioning/teams"
 	"github.com/grafana/grafana/pkg/services/team"
 	"github.com/grafana/grafana/pkg/services/team/sortopts"
 	"github.com/grafana/grafana/pkg/util"
@@ -93,6 +94,9 @@ func (tapi *TeamAPI) updateTeam(c *contextmodel.ReqContext) response.Response {
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
 	}
+	if resp := tapi.provisionedTeamResponse(c, cmd.ID); resp != nil {
+		return resp
+	}
 
 	if err := tapi.teamService.UpdateTeam(c.Req.Context(), &cmd); err != nil {
 		if errors.Is(err, team.ErrTeamNameTaken) {
@@ -120,6 +124,9 @@ func (tapi *TeamAPI) deleteTeamByID(c *contextmodel.ReqContext) response.Respons
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
 	}
+	if resp := tapi.provisionedTeamResponse(c, teamID); resp != nil {
+		return resp
+	}
 
 	if err := tapi.teamService.DeleteTeam(c.Req.Context(), &team.DeleteTeamCommand{OrgID: orgID, ID: teamID}); err != nil {
 		if errors.Is(err, team.ErrTeamNotFound) {
@@ -373,6 +380,18 @@ func (tapi *TeamAPI) getMultiAccessControlMetadata(c *contextmodel.ReqContext,
 	return accesscontrol.GetResourcesMetadata(c.Req.Context(), c.SignedInUser.GetPermissions(), prefix, resourceIDs)
 }
 
+// provisionedTeamResponse returns an error response if the team is managed by provisioning
+func (tapi *TeamAPI) provisionedTeamResponse(c *contextmodel.ReqContext, teamID int64) response.Response {
+	provisioned, err := teams.IsTeamProvisioned(c.Req.Context(), tapi.kvStore, c.SignedInUser.GetOrgID(), teamID)
+	if err != nil {
+		return response.Error(http.StatusInternalServerError, "Failed to check if the team is provisioned", err)
+	}
+	if provisioned {
+		return response.Error(http.StatusBadRequest, "Cannot modify a provisioned team", nil)
+	}
+	return nil
+}
+
 // Metadata helpers
 // getAccessControlMetadata returns the accesscontrol metadata associated with a given resource
 func (tapi *TeamAPI) getAccessControlMetadata(c *contextmodel.ReqContext,
@@ -82,6 +82,9 @@ func (tapi *TeamAPI) addTeamMember(c *contextmodel.ReqContext) response.Response
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "teamId is invalid", err)
 	}
+	if resp := tapi.provisionedTeamResponse(c, cmd.TeamID); resp != nil {
+		return resp
+	}
 
 	isTeamMember, err := tapi.teamService.IsTeamMember(c.SignedInUser.GetOrgID(), cmd.TeamID, cmd.UserID)
 	if err != nil {
@@ -124,6 +127,9 @@ func (tapi *TeamAPI) updateTeamMember(c *contextmodel.ReqContext) response.Respo
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "userId is invalid", err)
 	}
+	if resp := tapi.provisionedTeamResponse(c, teamId); resp != nil {
+		return resp
+	}
 	orgId := c.SignedInUser.GetOrgID()
 
 	isTeamMember, err := tapi.teamService.IsTeamMember(orgId, teamId, userId)
@@ -171,6 +177,9 @@ func (tapi *TeamAPI) removeTeamMember(c *contextmodel.ReqContext) response.Respo
 	if err != nil {
 		return response.Error(http.StatusBadRequest, "userId is invalid", err)
 	}
+	if resp := tapi.provisionedTeamResponse(c, teamId); resp != nil {
+		return resp
+	}
 
 	teamIDString := strconv.FormatInt(teamId, 10)
 	if _, err := tapi.teamPermissionsService.SetUserPermission(c.Req.Context(), orgId, accesscontrol.User{ID: userId}, teamIDString, ""); err != nil {
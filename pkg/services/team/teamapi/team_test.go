@@ -1,6 +1,7 @@
 package teamapi
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/infra/kvstore"
 	"github.com/grafana/grafana/pkg/services/accesscontrol"
 	pref "github.com/grafana/grafana/pkg/services/preference"
 	"github.com/grafana/grafana/pkg/services/preference/preftest"
@@ -160,6 +162,45 @@ func TestTeamAPIEndpoint_UpdateTeam(t *testing.T) {
 	})
 }
 
+func TestTeamAPIEndpoint_UpdateProvisionedTeam(t *testing.T) {
+	kv := kvstore.NewFakeKVStore()
+	require.NoError(t, kv.Set(context.Background(), 1, "provisioning.teams", "teams:1", "MyTestTeam"))
+
+	server := SetupAPITestServer(t, func(hs *TeamAPI) {
+		hs.teamService = &teamtest.FakeService{ExpectedTeamDTO: &team.TeamDTO{}}
+		hs.kvStore = kv
+	})
+
+	permissions := []accesscontrol.Permission{
+		{Action: accesscontrol.ActionTeamsWrite, Scope: "teams:*"},
+		{Action: accesscontrol.ActionTeamsDelete, Scope: "teams:*"},
+	}
+
+	t.Run("Provisioned team cannot be updated", func(t *testing.T) {
+		req := server.NewRequest(http.MethodPut, fmt.Sprintf(detailTeamURL, 1), strings.NewReader(teamCmd))
+		res, err := server.SendJSON(webtest.RequestWithSignedInUser(req, authedUserWithPermissions(1, 1, permissions)))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Provisioned team cannot be deleted", func(t *testing.T) {
+		req := server.NewRequest(http.MethodDelete, fmt.Sprintf(detailTeamURL, 1), http.NoBody)
+		res, err := server.Send(webtest.RequestWithSignedInUser(req, authedUserWithPermissions(1, 1, permissions)))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+
+	t.Run("Other teams can be updated", func(t *testing.T) {
+		req := server.NewRequest(http.MethodPut, fmt.Sprintf(detailTeamURL, 2), strings.NewReader(teamCmd))
+		res, err := server.SendJSON(webtest.RequestWithSignedInUser(req, authedUserWithPermissions(1, 1, permissions)))
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		require.NoError(t, res.Body.Close())
+	})
+}
+
 // Given a team with a user, when the user is granted X permission,
 // Then the endpoint should return 200 if the user has accesscontrol.ActionTeamsDelete with teams:id:1 scope
 // else return 403
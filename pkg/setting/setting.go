@@ -243,6 +243,7 @@ type Cfg struct {
 	PluginCatalogHiddenPlugins       []string
 	PluginAdminEnabled               bool
 	PluginAdminExternalManageEnabled bool
+	PluginRepositoryIndexURL         string
 	PluginForcePublicKeyDownload     bool
 	PluginSkipPublicKeyDownload      bool
 	DisablePlugins                   []string
@@ -390,7 +391,19 @@ type Cfg struct {
 	GrafanaJavascriptAgent GrafanaJavascriptAgent
 
 	// Data sources
-	DataSourceLimit int
+	DataSourceLimit                int
+	DataSourceMaxConcurrentQueries int
+	DataSourceMaxQueriesPerSecond  float64
+	DataSourceQueryQueueTimeout    time.Duration
+
+	// Query response limits
+	QueryMaxSeries           int64
+	QueryMaxRows             int64
+	QueryMaxResponseBytes    int64
+	QueryLimitExceededAction string
+
+	// Coalesce identical concurrent data source queries
+	QueryDeduplicateConcurrentQueries bool
 
 	// SQL Data sources
 	SqlDatasourceMaxOpenConnsDefault    int
@@ -1186,6 +1199,7 @@ func (cfg *Cfg) Load(args CommandLineArgs) error {
 	}
 
 	cfg.readDataSourcesSettings()
+	cfg.readQuerySettings()
 	cfg.readSqlDataSourceSettings()
 
 	cfg.Storage = readStorageSettings(iniFile)
@@ -1958,6 +1972,18 @@ func (cfg *Cfg) GetContentDeliveryURL(prefix string) string {
 func (cfg *Cfg) readDataSourcesSettings() {
 	datasources := cfg.Raw.Section("datasources")
 	cfg.DataSourceLimit = datasources.Key("datasource_limit").MustInt(5000)
+	cfg.DataSourceMaxConcurrentQueries = datasources.Key("max_concurrent_queries").MustInt(0)
+	cfg.DataSourceMaxQueriesPerSecond = datasources.Key("max_queries_per_second").MustFloat64(0)
+	cfg.DataSourceQueryQueueTimeout = datasources.Key("query_queue_timeout").MustDuration(30 * time.Second)
+}
+
+func (cfg *Cfg) readQuerySettings() {
+	query := cfg.SectionWithEnvOverrides("query")
+	cfg.QueryMaxSeries = query.Key("max_series").MustInt64(0)
+	cfg.QueryMaxRows = query.Key("max_rows").MustInt64(0)
+	cfg.QueryMaxResponseBytes = query.Key("max_response_bytes").MustInt64(0)
+	cfg.QueryLimitExceededAction = query.Key("limit_exceeded_action").In("error", []string{"error", "truncate"})
+	cfg.QueryDeduplicateConcurrentQueries = query.Key("deduplicate_concurrent_queries").MustBool(false)
 }
 
 func (cfg *Cfg) readSqlDataSourceSettings() {
@@ -54,6 +54,7 @@ const (
 	screenshotsMaxCaptureTimeout            = 30 * time.Second
 	screenshotsDefaultMaxConcurrent         = 5
 	screenshotsDefaultUploadImageStorage    = false
+	recordingRulesDefaultTimeout            = 10 * time.Second
 	// SchedulerBaseInterval base interval of the scheduler. Controls how often the scheduler fetches database for new changes as well as schedules evaluation of a rule
 	// changing this value is discouraged because this could cause existing alert definition
 	// with intervals that are not exactly divided by this number not to be evaluated
@@ -80,6 +81,8 @@ type UnifiedAlertingSettings struct {
 	HARedisPassword                string
 	HARedisDB                      int
 	HARedisMaxConns                int
+	NotifierURLs                   []string
+	NotifierToken                  string
 	MaxAttempts                    int64
 	MinInterval                    time.Duration
 	EvaluationTimeout              time.Duration
@@ -95,6 +98,7 @@ type UnifiedAlertingSettings struct {
 	Screenshots                   UnifiedAlertingScreenshotSettings
 	ReservedLabels                UnifiedAlertingReservedLabelSettings
 	StateHistory                  UnifiedAlertingStateHistorySettings
+	RecordingRules                RecordingRuleSettings
 	RemoteAlertmanager            RemoteAlertmanagerSettings
 	// MaxStateSaveConcurrency controls the number of goroutines (per rule) that can save alert state in parallel.
 	MaxStateSaveConcurrency int
@@ -136,6 +140,16 @@ type UnifiedAlertingStateHistorySettings struct {
 	ExternalLabels        map[string]string
 }
 
+// RecordingRuleSettings contains the configuration of the Prometheus remote write
+// endpoint that Grafana-managed recording rules write their results to.
+type RecordingRuleSettings struct {
+	Enabled           bool
+	URL               string
+	BasicAuthUsername string
+	BasicAuthPassword string
+	Timeout           time.Duration
+}
+
 // IsEnabled returns true if UnifiedAlertingSettings.Enabled is either nil or true.
 // It hides the implementation details of the Enabled and simplifies its usage.
 func (u *UnifiedAlertingSettings) IsEnabled() bool {
@@ -261,6 +275,13 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 			uaCfg.HAPeers = append(uaCfg.HAPeers, peer)
 		}
 	}
+	uaCfg.NotifierURLs = make([]string, 0)
+	for _, u := range strings.Split(ua.Key("notifier_urls").MustString(""), ",") {
+		if u = strings.TrimSpace(u); u != "" {
+			uaCfg.NotifierURLs = append(uaCfg.NotifierURLs, u)
+		}
+	}
+	uaCfg.NotifierToken = ua.Key("notifier_token").MustString("")
 
 	// TODO load from ini file
 	uaCfg.DefaultConfiguration = alertmanagerDefaultConfiguration
@@ -397,6 +418,18 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	}
 	uaCfg.StateHistory = uaCfgStateHistory
 
+	recordingRules := iniFile.Section("recording_rules")
+	uaCfg.RecordingRules = RecordingRuleSettings{
+		Enabled:           recordingRules.Key("enabled").MustBool(false),
+		URL:               recordingRules.Key("url").MustString(""),
+		BasicAuthUsername: recordingRules.Key("basic_auth_username").MustString(""),
+		BasicAuthPassword: recordingRules.Key("basic_auth_password").MustString(""),
+		Timeout:           recordingRules.Key("timeout").MustDuration(recordingRulesDefaultTimeout),
+	}
+	if uaCfg.RecordingRules.Enabled && uaCfg.RecordingRules.URL == "" {
+		return errors.New("recording rules are enabled but the remote write url is not set in [recording_rules]")
+	}
+
 	uaCfg.MaxStateSaveConcurrency = ua.Key("max_state_save_concurrency").MustInt(1)
 
 	cfg.UnifiedAlerting = uaCfg
@@ -30,6 +30,16 @@ var newIndexPattern = func(interval string, pattern string) (indexPattern, error
 	return newDynamicIndexPattern(interval, pattern)
 }
 
+// ResolveIndices returns the indices of the index pattern for the time range, interval is the
+// configured index pattern interval, such as "Daily", or an empty string for a static index.
+func ResolveIndices(interval string, pattern string, timeRange backend.TimeRange) ([]string, error) {
+	ip, err := newIndexPattern(interval, pattern)
+	if err != nil {
+		return nil, err
+	}
+	return ip.GetIndices(timeRange)
+}
+
 type staticIndexPattern struct {
 	indexName string
 }
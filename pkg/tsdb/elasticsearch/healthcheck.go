@@ -0,0 +1,180 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+// minSupportedVersion is the oldest Elasticsearch version supported by the data source. Older versions
+// are still queried, with a warning, like in the query editor.
+var minSupportedVersion = semver.MustParse("7.16.0")
+
+const unsupportedVersionMessage = "Support for Elasticsearch versions after their end-of-life (currently versions < 7.16) was removed. Using unsupported version of Elasticsearch may lead to unexpected and incorrect results."
+
+type versionInfo struct {
+	Version struct {
+		Number       string `json:"number"`
+		BuildFlavor  string `json:"build_flavor"`
+		Distribution string `json:"distribution"`
+	} `json:"version"`
+}
+
+// fieldMappings is the response of the get field mapping API, by index and field
+type fieldMappings map[string]struct {
+	Mappings map[string]struct {
+		FullName string `json:"full_name"`
+		Mapping  map[string]struct {
+			Type string `json:"type"`
+		} `json:"mapping"`
+	} `json:"mappings"`
+}
+
+type healthDetails struct {
+	Version    string   `json:"version"`
+	Flavor     string   `json:"flavor,omitempty"`
+	Compatible bool     `json:"compatible"`
+	Indices    []string `json:"indices,omitempty"`
+}
+
+// CheckHealth checks that the indices of the configured index pattern for the current interval exist
+// and map the time field as a date. Unsupported Elasticsearch versions are reported with a warning.
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	logger := s.logger.FromContext(ctx)
+
+	ds, err := s.getDSInfo(ctx, req.PluginContext)
+	if err != nil {
+		logger.Error("Failed to get data source info", "error", err)
+		return healthError("Failed to get data source info: %v", err), nil
+	}
+
+	var info versionInfo
+	if err := s.getJSON(ctx, ds, "", nil, &info); err != nil {
+		logger.Warn("Failed to get Elasticsearch version", "error", err)
+		return healthError("Failed to connect to Elasticsearch: %v", err), nil
+	}
+
+	details := healthDetails{Version: info.Version.Number, Flavor: info.Version.BuildFlavor}
+	if info.Version.Distribution == "opensearch" {
+		return healthErrorWithDetails(details, "OpenSearch is not supported by the Elasticsearch data source, use the OpenSearch data source instead"), nil
+	}
+	version, err := semver.NewVersion(info.Version.Number)
+	if err != nil {
+		return healthErrorWithDetails(details, "Failed to parse Elasticsearch version %q: %v", info.Version.Number, err), nil
+	}
+	// Serverless deployments report a fixed version, and are always up to date
+	details.Compatible = info.Version.BuildFlavor == "serverless" || !version.LessThan(minSupportedVersion)
+
+	now := time.Now()
+	indices, err := es.ResolveIndices(ds.Interval, ds.Database, backend.TimeRange{From: now, To: now})
+	if err != nil {
+		return healthErrorWithDetails(details, "Invalid index pattern %q: %v", ds.Database, err), nil
+	}
+	details.Indices = indices
+
+	target := strings.Join(indices, ",")
+	if target == "" {
+		target = "_all"
+	}
+	timeField := ds.ConfiguredFields.TimeField
+
+	var mappings fieldMappings
+	params := url.Values{"ignore_unavailable": []string{"true"}, "allow_no_indices": []string{"true"}}
+	if err := s.getJSON(ctx, ds, path.Join(target, "_mapping", "field", timeField), params, &mappings); err != nil {
+		logger.Warn("Failed to get time field mapping", "error", err, "indices", target)
+		return healthErrorWithDetails(details, "Failed to get the mapping of time field %q: %v", timeField, err), nil
+	}
+	if len(mappings) == 0 {
+		return healthErrorWithDetails(details, "No index found for index pattern %q, expected %s", ds.Database, target), nil
+	}
+
+	var mapped bool
+	for index, m := range mappings {
+		field, ok := m.Mappings[timeField]
+		if !ok {
+			continue
+		}
+		for _, mapping := range field.Mapping {
+			if mapping.Type != "date" && mapping.Type != "date_nanos" {
+				return healthErrorWithDetails(details, "Time field %q has type %q in index %q, it must be a date", timeField, mapping.Type, index), nil
+			}
+			mapped = true
+		}
+	}
+	if !mapped {
+		return healthErrorWithDetails(details, "Time field %q was not found in index %s", timeField, target), nil
+	}
+
+	jsonDetails, err := json.Marshal(details)
+	if err != nil {
+		return nil, err
+	}
+	message := fmt.Sprintf("Elasticsearch data source is healthy, version %s", details.Version)
+	if !details.Compatible {
+		message = fmt.Sprintf("WARNING: %s %s", unsupportedVersionMessage, message)
+	}
+	return &backend.CheckHealthResult{
+		Status:      backend.HealthStatusOk,
+		Message:     message,
+		JSONDetails: jsonDetails,
+	}, nil
+}
+
+// getJSON sends a GET request to the Elasticsearch API at path, and decodes the JSON response into v.
+func (s *Service) getJSON(ctx context.Context, ds *es.DatasourceInfo, p string, params url.Values, v any) error {
+	esUrl, err := url.Parse(ds.URL)
+	if err != nil {
+		return err
+	}
+	esUrl.Path = path.Join(esUrl.Path, p)
+	esUrl.RawQuery = params.Encode()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, esUrl.String(), nil)
+	if err != nil {
+		return err
+	}
+
+	response, err := ds.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			s.logger.FromContext(ctx).Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %d: %s", response.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, v)
+}
+
+func healthError(format string, args ...any) *backend.CheckHealthResult {
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusError,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+func healthErrorWithDetails(details healthDetails, format string, args ...any) *backend.CheckHealthResult {
+	result := healthError(format, args...)
+	result.JSONDetails, _ = json.Marshal(details)
+	return result
+}
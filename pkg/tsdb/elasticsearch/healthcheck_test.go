@@ -0,0 +1,130 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	es "github.com/grafana/grafana/pkg/tsdb/elasticsearch/client"
+)
+
+type testInstanceManager struct {
+	dsInfo es.DatasourceInfo
+}
+
+func (m testInstanceManager) Get(_ context.Context, _ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.dsInfo, nil
+}
+
+func (m testInstanceManager) Do(_ context.Context, _ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
+
+func TestCheckHealth(t *testing.T) {
+	today := "logs-" + time.Now().UTC().Format("2006.01.02")
+
+	setup := func(t *testing.T, version string, mapping string, interval string) (*Service, *[]string) {
+		t.Helper()
+
+		var requested []string
+		srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			requested = append(requested, r.URL.Path)
+			if r.URL.Path == "/" {
+				_, _ = rw.Write([]byte(version))
+				return
+			}
+			_, _ = rw.Write([]byte(mapping))
+		}))
+		t.Cleanup(srv.Close)
+
+		database := "logs-*"
+		if interval != "" {
+			database = "[logs-]YYYY.MM.DD"
+		}
+
+		return &Service{
+			im: testInstanceManager{dsInfo: es.DatasourceInfo{
+				HTTPClient:       srv.Client(),
+				URL:              srv.URL,
+				Database:         database,
+				Interval:         interval,
+				ConfiguredFields: es.ConfiguredFields{TimeField: "@timestamp"},
+			}},
+			logger: log.New("tsdb.elasticsearch.test"),
+		}, &requested
+	}
+
+	dateMapping := `{"` + today + `": {"mappings": {"@timestamp": {"full_name": "@timestamp", "mapping": {"@timestamp": {"type": "date"}}}}}}`
+
+	t.Run("healthy daily index pattern", func(t *testing.T) {
+		s, requested := setup(t, `{"version": {"number": "8.9.0", "build_flavor": "default"}}`, dateMapping, "Daily")
+
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusOk, res.Status, res.Message)
+		assert.Equal(t, []string{"/", "/" + today + "/_mapping/field/@timestamp"}, *requested)
+
+		var details healthDetails
+		require.NoError(t, json.Unmarshal(res.JSONDetails, &details))
+		assert.Equal(t, healthDetails{Version: "8.9.0", Flavor: "default", Compatible: true, Indices: []string{today}}, details)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		s, _ := setup(t, `{"version": {"number": "7.10.2"}}`, dateMapping, "")
+
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusOk, res.Status)
+		assert.True(t, strings.HasPrefix(res.Message, "WARNING: "+unsupportedVersionMessage), res.Message)
+
+		var details healthDetails
+		require.NoError(t, json.Unmarshal(res.JSONDetails, &details))
+		assert.False(t, details.Compatible)
+	})
+
+	t.Run("opensearch", func(t *testing.T) {
+		s, _ := setup(t, `{"version": {"number": "2.9.0", "distribution": "opensearch"}}`, dateMapping, "")
+
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+		assert.Contains(t, res.Message, "OpenSearch is not supported")
+	})
+
+	t.Run("missing index", func(t *testing.T) {
+		s, _ := setup(t, `{"version": {"number": "8.9.0"}}`, `{}`, "Daily")
+
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+		assert.Equal(t, `No index found for index pattern "[logs-]YYYY.MM.DD", expected `+today, res.Message)
+	})
+
+	t.Run("missing time field", func(t *testing.T) {
+		s, _ := setup(t, `{"version": {"number": "8.9.0"}}`, `{"logs-1": {"mappings": {}}}`, "")
+
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+		assert.Equal(t, `Time field "@timestamp" was not found in index logs-*`, res.Message)
+	})
+
+	t.Run("time field is not a date", func(t *testing.T) {
+		s, _ := setup(t, `{"version": {"number": "8.9.0"}}`, `{"logs-1": {"mappings": {"@timestamp": {"full_name": "@timestamp", "mapping": {"@timestamp": {"type": "keyword"}}}}}}`, "")
+
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+		assert.Equal(t, `Time field "@timestamp" has type "keyword" in index "logs-1", it must be a date`, res.Message)
+	})
+}
@@ -0,0 +1,42 @@
+package graphite
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+// CheckHealth runs the same metric find query as the data source configuration page
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+
+	body, statusCode, err := s.doResourceRequest(ctx, dsInfo, "metrics/find", url.Values{"query": []string{"*"}})
+	if err != nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Failed to connect to Graphite: %v", err),
+		}, nil
+	}
+	if statusCode/100 != 2 {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Graphite returned status %d: %s", statusCode, string(body)),
+		}, nil
+	}
+	if _, err := processMetricFind(body); err != nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Failed to parse Graphite response: %v", err),
+		}, nil
+	}
+
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusOk,
+		Message: "Data source is working",
+	}, nil
+}
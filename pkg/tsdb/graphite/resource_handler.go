@@ -0,0 +1,149 @@
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+)
+
+// Graphite serializes unbounded function parameter defaults as the invalid JSON literal Infinity
+var infinityDefault = regexp.MustCompile(`"default": ?Infinity`)
+
+type metricFindValue struct {
+	Text       string `json:"text"`
+	Expandable bool   `json:"expandable"`
+}
+
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return s.resourceHandler.CallResource(ctx, req, sender)
+}
+
+func (s *Service) newResourceMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics/find", s.handleResourceReq("metrics/find", processMetricFind))
+	mux.HandleFunc("/tags", s.handleResourceReq("tags/autoComplete/tags", nil))
+	mux.HandleFunc("/tags/values", s.handleResourceReq("tags/autoComplete/values", nil))
+	mux.HandleFunc("/functions", s.handleResourceReq("functions", processFunctions))
+	return mux
+}
+
+// handleResourceReq forwards the query parameters of the resource request to the Graphite API at
+// `graphitePath`, and optionally post-processes the response body with `process`.
+func (s *Service) handleResourceReq(graphitePath string, process func([]byte) ([]byte, error)) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeResponse(rw, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", req.Method))
+			return
+		}
+
+		ctx := req.Context()
+		dsInfo, err := s.getDSInfo(ctx, httpadapter.PluginConfigFromContext(ctx))
+		if err != nil {
+			writeResponse(rw, http.StatusInternalServerError, fmt.Sprintf("unexpected error %v", err))
+			return
+		}
+
+		body, statusCode, err := s.doResourceRequest(ctx, dsInfo, graphitePath, req.URL.Query())
+		if err != nil {
+			writeResponse(rw, http.StatusBadGateway, fmt.Sprintf("failed to query graphite: %v", err))
+			return
+		}
+		if statusCode/100 != 2 {
+			writeResponse(rw, statusCode, fmt.Sprintf("graphite returned %d: %s", statusCode, string(body)))
+			return
+		}
+
+		if process != nil {
+			if body, err = process(body); err != nil {
+				writeResponse(rw, http.StatusInternalServerError, fmt.Sprintf("failed to process graphite response: %v", err))
+				return
+			}
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		if _, err := rw.Write(body); err != nil {
+			logger.FromContext(ctx).Error("Failed to write resource response", "error", err)
+		}
+	}
+}
+
+func (s *Service) doResourceRequest(ctx context.Context, dsInfo *datasourceInfo, graphitePath string, params url.Values) ([]byte, int, error) {
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return nil, 0, err
+	}
+	u.Path = path.Join(u.Path, graphitePath)
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.FromContext(ctx).Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return body, res.StatusCode, nil
+}
+
+// processMetricFind converts the /metrics/find response to the values used by template variables
+func processMetricFind(body []byte) ([]byte, error) {
+	var metrics []struct {
+		Text       string `json:"text"`
+		Expandable any    `json:"expandable"`
+	}
+	if err := json.Unmarshal(body, &metrics); err != nil {
+		return nil, err
+	}
+
+	values := make([]metricFindValue, 0, len(metrics))
+	for _, m := range metrics {
+		// Older Graphite versions return expandable as 0 or 1
+		expandable := false
+		switch e := m.Expandable.(type) {
+		case bool:
+			expandable = e
+		case float64:
+			expandable = e != 0
+		}
+		values = append(values, metricFindValue{Text: m.Text, Expandable: expandable})
+	}
+
+	return json.Marshal(values)
+}
+
+func processFunctions(body []byte) ([]byte, error) {
+	body = infinityDefault.ReplaceAll(body, []byte(`"default": 1e9999`))
+	if !json.Valid(body) {
+		return nil, fmt.Errorf("invalid functions list")
+	}
+	return body, nil
+}
+
+func writeResponse(rw http.ResponseWriter, code int, msg string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	body, _ := json.Marshal(map[string]string{"message": msg})
+	_, _ = rw.Write(body)
+}
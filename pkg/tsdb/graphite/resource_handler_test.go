@@ -0,0 +1,139 @@
+package graphite
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInstanceManager struct {
+	dsInfo datasourceInfo
+}
+
+func (m testInstanceManager) Get(_ context.Context, _ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.dsInfo, nil
+}
+
+func (m testInstanceManager) Do(_ context.Context, _ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
+
+func setupResourceService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	s := &Service{im: testInstanceManager{dsInfo: datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL}}}
+	s.resourceHandler = httpadapter.New(s.newResourceMux())
+	return s
+}
+
+func callResource(t *testing.T, s *Service, url string) *backend.CallResourceResponse {
+	t.Helper()
+
+	sender := &mockedCallResourceResponseSender{}
+	err := s.CallResource(context.Background(), &backend.CallResourceRequest{
+		Method: http.MethodGet,
+		Path:   strings.SplitN(url, "?", 2)[0],
+		URL:    url,
+	}, sender)
+	require.NoError(t, err)
+	require.NotNil(t, sender.Response)
+	return sender.Response
+}
+
+type mockedCallResourceResponseSender struct {
+	Response *backend.CallResourceResponse
+}
+
+func (s *mockedCallResourceResponseSender) Send(resp *backend.CallResourceResponse) error {
+	s.Response = resp
+	return nil
+}
+
+func TestCallResource(t *testing.T) {
+	var requested string
+	s := setupResourceService(t, func(rw http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		switch r.URL.Path {
+		case "/metrics/find":
+			_, _ = rw.Write([]byte(`[{"text": "cpu", "expandable": 1, "leaf": 0}, {"text": "load", "expandable": false, "leaf": true}]`))
+		case "/tags/autoComplete/tags":
+			_, _ = rw.Write([]byte(`["datacenter", "host"]`))
+		case "/tags/autoComplete/values":
+			_, _ = rw.Write([]byte(`["host-1", "host-2"]`))
+		case "/functions":
+			_, _ = rw.Write([]byte(`{"holtWintersForecast": {"params": [{"name": "bootstrapInterval", "default": Infinity}]}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte("not found"))
+		}
+	})
+
+	t.Run("metric find", func(t *testing.T) {
+		res := callResource(t, s, "metrics/find?query=servers.*&from=-1h")
+		require.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "/metrics/find?from=-1h&query=servers.%2A", requested)
+		assert.JSONEq(t, `[{"text": "cpu", "expandable": true}, {"text": "load", "expandable": false}]`, string(res.Body))
+	})
+
+	t.Run("tag keys", func(t *testing.T) {
+		res := callResource(t, s, "tags?tagPrefix=h")
+		require.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "/tags/autoComplete/tags?tagPrefix=h", requested)
+		assert.JSONEq(t, `["datacenter", "host"]`, string(res.Body))
+	})
+
+	t.Run("tag values", func(t *testing.T) {
+		res := callResource(t, s, "tags/values?tag=host")
+		require.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "/tags/autoComplete/values?tag=host", requested)
+		assert.JSONEq(t, `["host-1", "host-2"]`, string(res.Body))
+	})
+
+	t.Run("functions", func(t *testing.T) {
+		res := callResource(t, s, "functions")
+		require.Equal(t, http.StatusOK, res.Status)
+		assert.Contains(t, string(res.Body), `"default": 1e9999`)
+	})
+
+	t.Run("graphite errors are returned", func(t *testing.T) {
+		s := setupResourceService(t, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte("invalid query"))
+		})
+		res := callResource(t, s, "metrics/find?query=(")
+		require.Equal(t, http.StatusBadRequest, res.Status)
+		assert.Contains(t, string(res.Body), "invalid query")
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		s := setupResourceService(t, func(rw http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/metrics/find", r.URL.Path)
+			_, _ = rw.Write([]byte(`[{"text": "cpu", "expandable": true}]`))
+		})
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusOk, res.Status)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		s := setupResourceService(t, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		})
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+	})
+}
@@ -0,0 +1,46 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+// CheckHealth runs the same metric name suggestion as the data source configuration page
+func (s *Service) CheckHealth(ctx context.Context, req *backend.CheckHealthRequest) (*backend.CheckHealthResult, error) {
+	dsInfo, err := s.getDSInfo(ctx, req.PluginContext)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{"type": []string{"metrics"}, "q": []string{"cpu"}, "max": []string{"1"}}
+	body, statusCode, err := s.doResourceRequest(ctx, dsInfo, "api/suggest", params)
+	if err != nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Failed to connect to OpenTSDB: %v", err),
+		}, nil
+	}
+	if statusCode/100 != 2 {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("OpenTSDB returned status %d: %s", statusCode, string(body)),
+		}, nil
+	}
+
+	var suggestions []string
+	if err := json.Unmarshal(body, &suggestions); err != nil {
+		return &backend.CheckHealthResult{
+			Status:  backend.HealthStatusError,
+			Message: fmt.Sprintf("Failed to parse OpenTSDB response: %v", err),
+		}, nil
+	}
+
+	return &backend.CheckHealthResult{
+		Status:  backend.HealthStatusOk,
+		Message: "Data source is working",
+	}, nil
+}
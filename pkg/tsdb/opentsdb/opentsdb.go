@@ -15,6 +15,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/datasource"
 	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/components/simplejson"
@@ -26,13 +27,17 @@ import (
 var logger = log.New("tsdb.opentsdb")
 
 type Service struct {
-	im instancemgmt.InstanceManager
+	im              instancemgmt.InstanceManager
+	resourceHandler backend.CallResourceHandler
 }
 
 func ProvideService(httpClientProvider httpclient.Provider) *Service {
-	return &Service{
+	s := &Service{
 		im: datasource.NewInstanceManager(newInstanceSettings(httpClientProvider)),
 	}
+	s.resourceHandler = httpadapter.New(s.newResourceMux())
+
+	return s
 }
 
 type datasourceInfo struct {
@@ -0,0 +1,114 @@
+package opentsdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+)
+
+// suggestTypes are the types of names the OpenTSDB /api/suggest endpoint can complete
+var suggestTypes = map[string]bool{
+	"metrics": true,
+	"tagk":    true,
+	"tagv":    true,
+}
+
+func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	return s.resourceHandler.CallResource(ctx, req, sender)
+}
+
+func (s *Service) newResourceMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/suggest", s.handleSuggest)
+	mux.HandleFunc("/aggregators", s.handleResourceReq("api/aggregators"))
+	mux.HandleFunc("/filters", s.handleResourceReq("api/config/filters"))
+	return mux
+}
+
+// handleSuggest completes metric names, tag keys and tag values
+func (s *Service) handleSuggest(rw http.ResponseWriter, req *http.Request) {
+	if t := req.URL.Query().Get("type"); !suggestTypes[t] {
+		writeResponse(rw, http.StatusBadRequest, fmt.Sprintf("invalid suggest type %q", t))
+		return
+	}
+
+	s.handleResourceReq("api/suggest")(rw, req)
+}
+
+// handleResourceReq forwards the query parameters of the resource request to the OpenTSDB API at `apiPath`.
+func (s *Service) handleResourceReq(apiPath string) http.HandlerFunc {
+	return func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeResponse(rw, http.StatusMethodNotAllowed, fmt.Sprintf("method %s is not allowed", req.Method))
+			return
+		}
+
+		ctx := req.Context()
+		dsInfo, err := s.getDSInfo(ctx, httpadapter.PluginConfigFromContext(ctx))
+		if err != nil {
+			writeResponse(rw, http.StatusInternalServerError, fmt.Sprintf("unexpected error %v", err))
+			return
+		}
+
+		body, statusCode, err := s.doResourceRequest(ctx, dsInfo, apiPath, req.URL.Query())
+		if err != nil {
+			writeResponse(rw, http.StatusBadGateway, fmt.Sprintf("failed to query OpenTSDB: %v", err))
+			return
+		}
+		if statusCode/100 != 2 {
+			writeResponse(rw, statusCode, fmt.Sprintf("OpenTSDB returned %d: %s", statusCode, string(body)))
+			return
+		}
+
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(http.StatusOK)
+		if _, err := rw.Write(body); err != nil {
+			logger.FromContext(ctx).Error("Failed to write resource response", "error", err)
+		}
+	}
+}
+
+func (s *Service) doResourceRequest(ctx context.Context, dsInfo *datasourceInfo, apiPath string, params url.Values) ([]byte, int, error) {
+	u, err := url.Parse(dsInfo.URL)
+	if err != nil {
+		return nil, 0, err
+	}
+	u.Path = path.Join(u.Path, apiPath)
+	u.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if err := res.Body.Close(); err != nil {
+			logger.FromContext(ctx).Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return body, res.StatusCode, nil
+}
+
+func writeResponse(rw http.ResponseWriter, code int, msg string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(code)
+	body, _ := json.Marshal(map[string]string{"message": msg})
+	_, _ = rw.Write(body)
+}
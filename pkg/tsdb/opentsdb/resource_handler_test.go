@@ -0,0 +1,123 @@
+package opentsdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/resource/httpadapter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInstanceManager struct {
+	dsInfo *datasourceInfo
+}
+
+func (m testInstanceManager) Get(_ context.Context, _ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.dsInfo, nil
+}
+
+func (m testInstanceManager) Do(_ context.Context, _ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
+
+type mockedCallResourceResponseSender struct {
+	Response *backend.CallResourceResponse
+}
+
+func (s *mockedCallResourceResponseSender) Send(resp *backend.CallResourceResponse) error {
+	s.Response = resp
+	return nil
+}
+
+func setupResourceService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	s := &Service{im: testInstanceManager{dsInfo: &datasourceInfo{HTTPClient: srv.Client(), URL: srv.URL}}}
+	s.resourceHandler = httpadapter.New(s.newResourceMux())
+	return s
+}
+
+func callResource(t *testing.T, s *Service, url string) *backend.CallResourceResponse {
+	t.Helper()
+
+	sender := &mockedCallResourceResponseSender{}
+	err := s.CallResource(context.Background(), &backend.CallResourceRequest{
+		Method: http.MethodGet,
+		Path:   strings.SplitN(url, "?", 2)[0],
+		URL:    url,
+	}, sender)
+	require.NoError(t, err)
+	require.NotNil(t, sender.Response)
+	return sender.Response
+}
+
+func TestCallResource(t *testing.T) {
+	var requested string
+	s := setupResourceService(t, func(rw http.ResponseWriter, r *http.Request) {
+		requested = r.URL.String()
+		switch r.URL.Path {
+		case "/api/suggest":
+			_, _ = rw.Write([]byte(`["host", "hostname"]`))
+		case "/api/aggregators":
+			_, _ = rw.Write([]byte(`["avg", "sum"]`))
+		case "/api/config/filters":
+			_, _ = rw.Write([]byte(`{"wildcard": {}}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	t.Run("suggest tag keys", func(t *testing.T) {
+		res := callResource(t, s, "suggest?type=tagk&q=ho&max=10")
+		require.Equal(t, http.StatusOK, res.Status)
+		assert.Equal(t, "/api/suggest?max=10&q=ho&type=tagk", requested)
+		assert.JSONEq(t, `["host", "hostname"]`, string(res.Body))
+	})
+
+	t.Run("suggest rejects unknown types", func(t *testing.T) {
+		res := callResource(t, s, "suggest?type=unknown")
+		require.Equal(t, http.StatusBadRequest, res.Status)
+	})
+
+	t.Run("aggregators", func(t *testing.T) {
+		res := callResource(t, s, "aggregators")
+		require.Equal(t, http.StatusOK, res.Status)
+		assert.JSONEq(t, `["avg", "sum"]`, string(res.Body))
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		res := callResource(t, s, "filters")
+		require.Equal(t, http.StatusOK, res.Status)
+		assert.JSONEq(t, `{"wildcard": {}}`, string(res.Body))
+	})
+}
+
+func TestCheckHealth(t *testing.T) {
+	t.Run("healthy", func(t *testing.T) {
+		s := setupResourceService(t, func(rw http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/api/suggest", r.URL.Path)
+			_, _ = rw.Write([]byte(`["cpu.usage"]`))
+		})
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusOk, res.Status)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		s := setupResourceService(t, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadGateway)
+		})
+		res, err := s.CheckHealth(context.Background(), &backend.CheckHealthRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, backend.HealthStatusError, res.Status)
+	})
+}
@@ -105,6 +105,22 @@ func (c *Client) QueryResource(ctx context.Context, req *backend.CallResourceReq
 	return c.doer.Do(httpRequest)
 }
 
+// Get sends a GET request to the Prometheus HTTP API endpoint with the query parameters.
+func (c *Client) Get(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
+	u, err := c.createUrl(endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = params.Encode()
+
+	httpRequest, err := createRequest(ctx, http.MethodGet, u, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.doer.Do(httpRequest)
+}
+
 func (c *Client) createQueryRequest(ctx context.Context, endpoint string, qv map[string]string) (*http.Request, error) {
 	if strings.ToUpper(c.method) == http.MethodPost {
 		u, err := c.createUrl(endpoint, nil)
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -30,9 +32,10 @@ type Service struct {
 }
 
 type instance struct {
-	queryData    *querydata.QueryData
-	resource     *resource.Resource
-	versionCache *cache.Cache
+	queryData     *querydata.QueryData
+	resource      *resource.Resource
+	versionCache  *cache.Cache
+	metadataCache *cache.Cache
 }
 
 func ProvideService(httpClientProvider *httpclient.Provider, cfg *setting.Cfg, features featuremgmt.FeatureToggles) *Service {
@@ -70,9 +73,10 @@ func newInstanceSettings(httpClientProvider *httpclient.Provider, cfg *setting.C
 		}
 
 		return instance{
-			queryData:    qd,
-			resource:     r,
-			versionCache: cache.New(time.Minute*1, time.Minute*5),
+			queryData:     qd,
+			resource:      r,
+			versionCache:  cache.New(time.Minute*1, time.Minute*5),
+			metadataCache: cache.New(time.Minute*1, time.Minute*5),
 		}, nil
 	}
 }
@@ -116,6 +120,10 @@ func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceReq
 		return sender.Send(vResp)
 	}
 
+	if resource.IsMetadataPath(req.Path) {
+		return s.callMetadataResource(ctx, i, req, sender)
+	}
+
 	resp, err := i.resource.Execute(ctx, req)
 	if err != nil {
 		return err
@@ -124,6 +132,35 @@ func (s *Service) CallResource(ctx context.Context, req *backend.CallResourceReq
 	return sender.Send(resp)
 }
 
+// identityHeaders are the forwarded headers that can change the metadata visible to the user.
+var identityHeaders = []string{"Authorization", "X-Id-Token", "X-Grafana-User"}
+
+// callMetadataResource serves the metadata resources, caching successful responses per data source.
+func (s *Service) callMetadataResource(ctx context.Context, i *instance, req *backend.CallResourceRequest, sender backend.CallResourceResponseSender) error {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return err
+	}
+	// The metadata is only shared between users forwarding the same identity
+	key := strings.ToLower(req.Path) + "?" + u.Query().Encode()
+	for _, h := range identityHeaders {
+		key += "\n" + req.GetHTTPHeader(h)
+	}
+
+	if resp, found := i.metadataCache.Get(key); found {
+		return sender.Send(resp.(*backend.CallResourceResponse))
+	}
+
+	resp, err := i.resource.ExecuteMetadata(ctx, req)
+	if err != nil {
+		return err
+	}
+	if resp.Status == http.StatusOK {
+		i.metadataCache.Set(key, resp, cache.DefaultExpiration)
+	}
+	return sender.Send(resp)
+}
+
 func (s *Service) getInstance(ctx context.Context, pluginCtx backend.PluginContext) (*instance, error) {
 	i, err := s.im.Get(ctx, pluginCtx)
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -117,3 +118,56 @@ func TestService(t *testing.T) {
 		})
 	})
 }
+
+type recordingSender struct {
+	resp *backend.CallResourceResponse
+}
+
+func (sender *recordingSender) Send(resp *backend.CallResourceResponse) error {
+	sender.resp = resp
+	return nil
+}
+
+func TestMetadataResourceCache(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = rw.Write([]byte(`{"status": "success", "data": ["api", "web"]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	service := &Service{
+		im: datasource.NewInstanceManager(newInstanceSettings(httpclient.NewProvider(), &setting.Cfg{}, &featuremgmt.FeatureManager{}, backend.NewLoggerWith("logger", "test"))),
+	}
+	pCtx := backend.PluginContext{
+		PluginID: "prometheus",
+		DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+			ID:       1,
+			URL:      srv.URL,
+			JSONData: []byte(`{}`),
+		},
+	}
+
+	call := func(url string, headers map[string][]string) *backend.CallResourceResponse {
+		sender := &recordingSender{}
+		err := service.CallResource(context.Background(), &backend.CallResourceRequest{
+			PluginContext: pCtx,
+			Path:          "label-values",
+			URL:           url,
+			Headers:       headers,
+		}, sender)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, sender.resp.Status)
+		return sender.resp
+	}
+
+	call("label-values?label=job&match[]=up", nil)
+	call("label-values?match[]=up&label=job", nil)
+	require.Equal(t, 1, calls)
+
+	call("label-values?label=job&match[]=down", nil)
+	require.Equal(t, 2, calls)
+
+	call("label-values?label=job&match[]=up", map[string][]string{"Authorization": {"Bearer other-user"}})
+	require.Equal(t, 3, calls)
+}
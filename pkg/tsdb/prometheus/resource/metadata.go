@@ -0,0 +1,252 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+)
+
+// Resource paths served by Grafana from the Prometheus API responses, used by the query builder and
+// the alert rule editor.
+const (
+	CardinalityPath = "cardinality"
+	MetadataPath    = "metadata"
+	LabelValuesPath = "label-values"
+)
+
+// IsMetadataPath returns whether the resource path is served by ExecuteMetadata.
+func IsMetadataPath(p string) bool {
+	switch strings.ToLower(p) {
+	case CardinalityPath, MetadataPath, LabelValuesPath:
+		return true
+	}
+	return false
+}
+
+// Cardinality is the series cardinality of the data source, and of the match[] selectors of the request.
+type Cardinality struct {
+	TotalSeries uint64        `json:"totalSeries"`
+	TopMetrics  []MetricCount `json:"topMetrics"`
+	// MatchedSeries is the number of series matching each match[] selector.
+	MatchedSeries map[string]uint64 `json:"matchedSeries,omitempty"`
+}
+
+type MetricCount struct {
+	Name   string `json:"name"`
+	Series uint64 `json:"series"`
+}
+
+type MetricMetadata struct {
+	Type string `json:"type"`
+	Help string `json:"help"`
+	Unit string `json:"unit"`
+}
+
+// apiError is an error returned by the Prometheus API.
+type apiError struct {
+	status    int
+	errorType string
+	msg       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s: %s", e.errorType, e.msg)
+}
+
+// ExecuteMetadata serves the CardinalityPath, MetadataPath and LabelValuesPath resources.
+func (r *Resource) ExecuteMetadata(ctx context.Context, req *backend.CallResourceRequest) (*backend.CallResourceResponse, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	params := u.Query()
+
+	var result any
+	switch strings.ToLower(req.Path) {
+	case CardinalityPath:
+		result, err = r.cardinality(ctx, params)
+	case MetadataPath:
+		result, err = r.metricMetadata(ctx, params)
+	case LabelValuesPath:
+		result, err = r.labelValues(ctx, params)
+	default:
+		return nil, fmt.Errorf("unknown metadata resource %q", req.Path)
+	}
+
+	if err != nil {
+		r.log.FromContext(ctx).Warn("Failed to get metadata resource", "path", req.Path, "error", err)
+		status := http.StatusInternalServerError
+		var apiErr *apiError
+		if errors.As(err, &apiErr) {
+			status = apiErr.status
+		}
+		return jsonResponse(status, map[string]string{"message": err.Error()})
+	}
+
+	return jsonResponse(http.StatusOK, result)
+}
+
+func (r *Resource) cardinality(ctx context.Context, params url.Values) (*Cardinality, error) {
+	var status struct {
+		HeadStats struct {
+			NumSeries uint64 `json:"numSeries"`
+		} `json:"headStats"`
+		SeriesCountByMetricName []struct {
+			Name  string `json:"name"`
+			Value uint64 `json:"value"`
+		} `json:"seriesCountByMetricName"`
+	}
+	statusParams := url.Values{}
+	if limit := params.Get("limit"); limit != "" {
+		statusParams.Set("limit", limit)
+	}
+	if err := r.getAPI(ctx, "api/v1/status/tsdb", statusParams, &status); err != nil {
+		return nil, err
+	}
+
+	result := &Cardinality{
+		TotalSeries: status.HeadStats.NumSeries,
+		TopMetrics:  make([]MetricCount, 0, len(status.SeriesCountByMetricName)),
+	}
+	for _, m := range status.SeriesCountByMetricName {
+		result.TopMetrics = append(result.TopMetrics, MetricCount{Name: m.Name, Series: m.Value})
+	}
+
+	for _, selector := range params["match[]"] {
+		count, err := r.countSeries(ctx, selector)
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedSeries == nil {
+			result.MatchedSeries = map[string]uint64{}
+		}
+		result.MatchedSeries[selector] = count
+	}
+
+	return result, nil
+}
+
+// countSeries returns the number of series currently matching the selector.
+func (r *Resource) countSeries(ctx context.Context, selector string) (uint64, error) {
+	var data struct {
+		Result []struct {
+			Value [2]any `json:"value"`
+		} `json:"result"`
+	}
+	if err := r.getAPI(ctx, "api/v1/query", url.Values{"query": []string{fmt.Sprintf("count(%s)", selector)}}, &data); err != nil {
+		return 0, err
+	}
+	if len(data.Result) == 0 {
+		return 0, nil
+	}
+	value, ok := data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("unexpected count value %v", data.Result[0].Value[1])
+	}
+	count, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(count), nil
+}
+
+func (r *Resource) metricMetadata(ctx context.Context, params url.Values) (map[string]MetricMetadata, error) {
+	metadataParams := url.Values{}
+	for _, key := range []string{"metric", "limit"} {
+		if v := params.Get(key); v != "" {
+			metadataParams.Set(key, v)
+		}
+	}
+
+	var data map[string][]MetricMetadata
+	if err := r.getAPI(ctx, "api/v1/metadata", metadataParams, &data); err != nil {
+		return nil, err
+	}
+
+	// A metric can have different metadata in different targets, the first one is used
+	result := make(map[string]MetricMetadata, len(data))
+	for metric, metadata := range data {
+		if len(metadata) > 0 {
+			result[metric] = metadata[0]
+		}
+	}
+	return result, nil
+}
+
+func (r *Resource) labelValues(ctx context.Context, params url.Values) ([]string, error) {
+	label := params.Get("label")
+	if label == "" {
+		return nil, &apiError{status: http.StatusBadRequest, errorType: "bad_data", msg: "label parameter is required"}
+	}
+
+	valuesParams := url.Values{}
+	for _, key := range []string{"match[]", "start", "end"} {
+		if v, ok := params[key]; ok {
+			valuesParams[key] = v
+		}
+	}
+
+	var data []string
+	if err := r.getAPI(ctx, path.Join("api/v1/label", url.PathEscape(label), "values"), valuesParams, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// getAPI sends a GET request to the Prometheus API, and decodes the data of the response into v.
+func (r *Resource) getAPI(ctx context.Context, endpoint string, params url.Values, v any) error {
+	resp, err := r.promClient.Get(ctx, endpoint, params)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			r.log.FromContext(ctx).Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var apiResp struct {
+		Status    string          `json:"status"`
+		Data      json.RawMessage `json:"data"`
+		ErrorType string          `json:"errorType"`
+		Error     string          `json:"error"`
+	}
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return &apiError{status: http.StatusBadGateway, errorType: "invalid_response", msg: fmt.Sprintf("status %d: %s", resp.StatusCode, string(body))}
+	}
+	if apiResp.Status != "success" {
+		status := resp.StatusCode
+		if status/100 == 2 {
+			status = http.StatusBadGateway
+		}
+		return &apiError{status: status, errorType: apiResp.ErrorType, msg: apiResp.Error}
+	}
+
+	return json.Unmarshal(apiResp.Data, v)
+}
+
+func jsonResponse(status int, v any) (*backend.CallResourceResponse, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &backend.CallResourceResponse{
+		Status:  status,
+		Headers: map[string][]string{"Content-Type": {"application/json"}},
+		Body:    body,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package resource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteMetadata(t *testing.T) {
+	var requests []*http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r)
+		switch r.URL.Path {
+		case "/api/v1/status/tsdb":
+			_, _ = rw.Write([]byte(`{"status": "success", "data": {"headStats": {"numSeries": 1500}, "seriesCountByMetricName": [{"name": "http_requests_total", "value": 900}, {"name": "up", "value": 10}]}}`))
+		case "/api/v1/query":
+			_, _ = rw.Write([]byte(`{"status": "success", "data": {"resultType": "vector", "result": [{"metric": {}, "value": [1700000000, "42"]}]}}`))
+		case "/api/v1/metadata":
+			_, _ = rw.Write([]byte(`{"status": "success", "data": {"http_requests_total": [{"type": "counter", "help": "Total HTTP requests", "unit": ""}, {"type": "counter", "help": "Other help", "unit": ""}]}}`))
+		case "/api/v1/label/job/values":
+			_, _ = rw.Write([]byte(`{"status": "success", "data": ["api", "web"]}`))
+		default:
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte(`{"status": "error", "errorType": "bad_data", "error": "invalid parameter"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	r, err := New(srv.Client(), backend.DataSourceInstanceSettings{URL: srv.URL, JSONData: []byte(`{}`)}, backend.NewLoggerWith("logger", "test"))
+	require.NoError(t, err)
+
+	execute := func(t *testing.T, path string, url string) *backend.CallResourceResponse {
+		t.Helper()
+		requests = nil
+		resp, err := r.ExecuteMetadata(context.Background(), &backend.CallResourceRequest{Path: path, URL: url})
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("cardinality", func(t *testing.T) {
+		resp := execute(t, CardinalityPath, `cardinality?limit=2&match[]=http_requests_total{job="api"}`)
+		require.Equal(t, http.StatusOK, resp.Status)
+
+		var result Cardinality
+		require.NoError(t, json.Unmarshal(resp.Body, &result))
+		assert.Equal(t, Cardinality{
+			TotalSeries:   1500,
+			TopMetrics:    []MetricCount{{Name: "http_requests_total", Series: 900}, {Name: "up", Series: 10}},
+			MatchedSeries: map[string]uint64{`http_requests_total{job="api"}`: 42},
+		}, result)
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, "2", requests[0].URL.Query().Get("limit"))
+		assert.Equal(t, `count(http_requests_total{job="api"})`, requests[1].URL.Query().Get("query"))
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		resp := execute(t, MetadataPath, "metadata?metric=http_requests_total")
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.JSONEq(t, `{"http_requests_total": {"type": "counter", "help": "Total HTTP requests", "unit": ""}}`, string(resp.Body))
+		assert.Equal(t, "http_requests_total", requests[0].URL.Query().Get("metric"))
+	})
+
+	t.Run("label values scoped by match[]", func(t *testing.T) {
+		resp := execute(t, LabelValuesPath, `label-values?label=job&match[]=up&start=1700000000&end=1700003600`)
+		require.Equal(t, http.StatusOK, resp.Status)
+		assert.JSONEq(t, `["api", "web"]`, string(resp.Body))
+		assert.Equal(t, []string{"up"}, requests[0].URL.Query()["match[]"])
+		assert.Equal(t, "1700000000", requests[0].URL.Query().Get("start"))
+	})
+
+	t.Run("label values without label", func(t *testing.T) {
+		resp := execute(t, LabelValuesPath, "label-values")
+		require.Equal(t, http.StatusBadRequest, resp.Status)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("prometheus errors are returned", func(t *testing.T) {
+		resp := execute(t, LabelValuesPath, "label-values?label=unknown")
+		require.Equal(t, http.StatusBadRequest, resp.Status)
+		assert.JSONEq(t, `{"message": "bad_data: invalid parameter"}`, string(resp.Body))
+	})
+}
@@ -77,8 +77,13 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 }
 
 func (s *Service) query(ctx context.Context, pCtx backend.PluginContext, query backend.DataQuery) (*backend.DataResponse, error) {
-	if query.QueryType == string(dataquery.TempoQueryTypeTraceId) {
+	switch query.QueryType {
+	case string(dataquery.TempoQueryTypeTraceId):
 		return s.getTrace(ctx, pCtx, query)
+	case string(dataquery.TempoQueryTypeTraceql), string(dataquery.TempoQueryTypeTraceqlSearch):
+		return s.searchTraceQL(ctx, pCtx, query)
+	case QueryTypeTraceqlMetrics:
+		return s.queryTraceQLMetrics(ctx, pCtx, query)
 	}
 
 	return nil, fmt.Errorf("unsupported query type: '%s' for query with refID '%s'", query.QueryType, query.RefID)
@@ -0,0 +1,344 @@
+package tempo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/tracing"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana/pkg/tsdb/tempo/kinds/dataquery"
+	"github.com/grafana/tempo/pkg/tempopb"
+	v1 "github.com/grafana/tempo/pkg/tempopb/common/v1"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// QueryTypeTraceqlMetrics runs TraceQL metrics queries, such as rate() or count_over_time(),
+// and returns one time series per series computed by Tempo.
+const QueryTypeTraceqlMetrics = "traceqlMetrics"
+
+// metricsResponse is the response of the Tempo /api/metrics/query_range endpoint.
+type metricsResponse struct {
+	Series []struct {
+		Labels []struct {
+			Key   string         `json:"key"`
+			Value map[string]any `json:"value"`
+		} `json:"labels"`
+		Samples []struct {
+			TimestampMs json.Number `json:"timestampMs"`
+			Value       float64     `json:"value"`
+		} `json:"samples"`
+	} `json:"series"`
+}
+
+// searchTraceQL runs a TraceQL search and returns the matching traces, or their
+// matching spans when the query asks for the spans table.
+func (s *Service) searchTraceQL(ctx context.Context, pCtx backend.PluginContext, query backend.DataQuery) (*backend.DataResponse, error) {
+	ctx, span := tracing.DefaultTracer().Start(ctx, "datasource.tempo.searchTraceQL", trace.WithAttributes(
+		attribute.String("queryType", query.QueryType),
+	))
+	defer span.End()
+
+	model := &dataquery.TempoQuery{}
+	if err := json.Unmarshal(query.JSON, model); err != nil {
+		return nil, err
+	}
+	if model.Query == nil || *model.Query == "" {
+		return &backend.DataResponse{Error: fmt.Errorf("TraceQL query is required for query with refID '%s'", query.RefID)}, nil
+	}
+
+	params := url.Values{
+		"q":     []string{*model.Query},
+		"start": []string{strconv.FormatInt(query.TimeRange.From.Unix(), 10)},
+		"end":   []string{strconv.FormatInt(query.TimeRange.To.Unix(), 10)},
+	}
+	if model.Limit != nil {
+		params.Set("limit", strconv.FormatInt(*model.Limit, 10))
+	}
+	if model.Spss != nil {
+		params.Set("spss", strconv.FormatInt(*model.Spss, 10))
+	}
+
+	dsInfo, err := s.getDSInfo(ctx, pCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.get(ctx, dsInfo, "/api/search", params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return &backend.DataResponse{Error: err}, nil
+	}
+
+	sr := &tempopb.SearchResponse{}
+	unmarshaler := jsonpb.Unmarshaler{AllowUnknownFields: true}
+	if err := unmarshaler.Unmarshal(bytes.NewReader(body), sr); err != nil {
+		return nil, fmt.Errorf("failed to parse tempo search response: %w", err)
+	}
+	span.SetAttributes(attribute.Int("traces_count", len(sr.Traces)))
+
+	var frame *data.Frame
+	if model.TableType != nil && *model.TableType == dataquery.SearchTableTypeSpans {
+		frame = spansToFrame(sr.Traces)
+	} else {
+		frame = tracesToFrame(sr.Traces)
+	}
+	frame.RefID = query.RefID
+
+	return &backend.DataResponse{Frames: data.Frames{frame}}, nil
+}
+
+// queryTraceQLMetrics runs a TraceQL metrics query over the time range of the query.
+func (s *Service) queryTraceQLMetrics(ctx context.Context, pCtx backend.PluginContext, query backend.DataQuery) (*backend.DataResponse, error) {
+	ctx, span := tracing.DefaultTracer().Start(ctx, "datasource.tempo.queryTraceQLMetrics", trace.WithAttributes(
+		attribute.String("queryType", query.QueryType),
+	))
+	defer span.End()
+
+	model := &dataquery.TempoQuery{}
+	if err := json.Unmarshal(query.JSON, model); err != nil {
+		return nil, err
+	}
+	if model.Query == nil || *model.Query == "" {
+		return &backend.DataResponse{Error: fmt.Errorf("TraceQL query is required for query with refID '%s'", query.RefID)}, nil
+	}
+
+	params := url.Values{
+		"q":     []string{*model.Query},
+		"start": []string{strconv.FormatInt(query.TimeRange.From.Unix(), 10)},
+		"end":   []string{strconv.FormatInt(query.TimeRange.To.Unix(), 10)},
+	}
+	if query.Interval > 0 {
+		params.Set("step", formatStep(query.Interval))
+	}
+
+	dsInfo, err := s.getDSInfo(ctx, pCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.get(ctx, dsInfo, "/api/metrics/query_range", params)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return &backend.DataResponse{Error: err}, nil
+	}
+
+	var mr metricsResponse
+	if err := json.Unmarshal(body, &mr); err != nil {
+		return nil, fmt.Errorf("failed to parse tempo metrics response: %w", err)
+	}
+
+	frames := make(data.Frames, 0, len(mr.Series))
+	for _, series := range mr.Series {
+		labels := data.Labels{}
+		for _, l := range series.Labels {
+			labels[l.Key] = jsonValueToString(l.Value)
+		}
+
+		times := make([]time.Time, 0, len(series.Samples))
+		values := make([]float64, 0, len(series.Samples))
+		for _, sample := range series.Samples {
+			ms, err := sample.TimestampMs.Int64()
+			if err != nil {
+				return nil, fmt.Errorf("invalid sample timestamp %q: %w", sample.TimestampMs, err)
+			}
+			times = append(times, time.UnixMilli(ms).UTC())
+			values = append(values, sample.Value)
+		}
+
+		frame := data.NewFrame("",
+			data.NewField(data.TimeSeriesTimeFieldName, nil, times),
+			data.NewField(data.TimeSeriesValueFieldName, labels, values),
+		)
+		frame.RefID = query.RefID
+		frame.Meta = &data.FrameMeta{Type: data.FrameTypeTimeSeriesMulti, ExecutedQueryString: *model.Query}
+		frames = append(frames, frame)
+	}
+
+	return &backend.DataResponse{Frames: frames}, nil
+}
+
+// formatStep formats the step in whole seconds, as expected by Tempo, e.g. "60s"
+func formatStep(interval time.Duration) string {
+	seconds := int64(math.Ceil(interval.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10) + "s"
+}
+
+func (s *Service) get(ctx context.Context, dsInfo *Datasource, path string, params url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dsInfo.URL+path+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	s.logger.FromContext(ctx).Debug("Tempo request", "url", req.URL.String())
+	resp, err := dsInfo.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed get to tempo: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			s.logger.FromContext(ctx).Warn("Failed to close response body", "err", err)
+		}
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tempo request failed with status: %s body: %s", resp.Status, string(body))
+	}
+
+	return body, nil
+}
+
+func tracesToFrame(traces []*tempopb.TraceSearchMetadata) *data.Frame {
+	frame := data.NewFrame("Traces",
+		data.NewField("traceID", nil, []string{}),
+		data.NewField("startTime", nil, []time.Time{}),
+		data.NewField("traceService", nil, []string{}),
+		data.NewField("traceName", nil, []string{}),
+		data.NewField("traceDuration", nil, []float64{}).SetConfig(&data.FieldConfig{Unit: "ms"}),
+		data.NewField("matched", nil, []int64{}),
+	)
+	frame.Meta = &data.FrameMeta{PreferredVisualization: data.VisTypeTable}
+
+	for _, t := range traces {
+		var matched int64
+		for _, ss := range traceSpanSets(t) {
+			matched += int64(ss.Matched)
+		}
+		frame.AppendRow(
+			t.TraceID,
+			time.Unix(0, int64(t.StartTimeUnixNano)).UTC(),
+			t.RootServiceName,
+			t.RootTraceName,
+			float64(t.DurationMs),
+			matched,
+		)
+	}
+
+	return frame
+}
+
+// spansToFrame returns one row per matched span, with one column per span attribute.
+func spansToFrame(traces []*tempopb.TraceSearchMetadata) *data.Frame {
+	type spanRow struct {
+		traceID string
+		span    *tempopb.Span
+		attrs   map[string]string
+	}
+
+	var rows []spanRow
+	attrNames := map[string]bool{}
+	for _, t := range traces {
+		for _, ss := range traceSpanSets(t) {
+			for _, sp := range ss.Spans {
+				attrs := map[string]string{}
+				for _, kv := range append(ss.Attributes, sp.Attributes...) {
+					attrs[kv.Key] = anyValueToString(kv.Value)
+					attrNames[kv.Key] = true
+				}
+				rows = append(rows, spanRow{traceID: t.TraceID, span: sp, attrs: attrs})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(attrNames))
+	for name := range attrNames {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := []*data.Field{
+		data.NewField("traceID", nil, []string{}),
+		data.NewField("spanID", nil, []string{}),
+		data.NewField("time", nil, []time.Time{}),
+		data.NewField("name", nil, []string{}),
+		data.NewField("duration", nil, []float64{}).SetConfig(&data.FieldConfig{Unit: "ns"}),
+	}
+	for _, name := range names {
+		fields = append(fields, data.NewField(name, nil, []string{}))
+	}
+	frame := data.NewFrame("Spans", fields...)
+	frame.Meta = &data.FrameMeta{PreferredVisualization: data.VisTypeTable}
+
+	for _, row := range rows {
+		values := []any{
+			row.traceID,
+			row.span.SpanID,
+			time.Unix(0, int64(row.span.StartTimeUnixNano)).UTC(),
+			row.span.Name,
+			float64(row.span.DurationNanos),
+		}
+		for _, name := range names {
+			values = append(values, row.attrs[name])
+		}
+		frame.AppendRow(values...)
+	}
+
+	return frame
+}
+
+// traceSpanSets returns the span sets of the trace, older Tempo versions only set SpanSet.
+func traceSpanSets(t *tempopb.TraceSearchMetadata) []*tempopb.SpanSet {
+	if len(t.SpanSets) > 0 {
+		return t.SpanSets
+	}
+	if t.SpanSet != nil {
+		return []*tempopb.SpanSet{t.SpanSet}
+	}
+	return nil
+}
+
+func anyValueToString(v *v1.AnyValue) string {
+	if v == nil {
+		return ""
+	}
+	switch value := v.GetValue().(type) {
+	case *v1.AnyValue_StringValue:
+		return value.StringValue
+	case *v1.AnyValue_IntValue:
+		return strconv.FormatInt(value.IntValue, 10)
+	case *v1.AnyValue_DoubleValue:
+		return strconv.FormatFloat(value.DoubleValue, 'f', -1, 64)
+	case *v1.AnyValue_BoolValue:
+		return strconv.FormatBool(value.BoolValue)
+	default:
+		return v.String()
+	}
+}
+
+// jsonValueToString converts a JSON encoded OTLP AnyValue, such as {"stringValue": "api"}, to a string.
+func jsonValueToString(v map[string]any) string {
+	for _, value := range v {
+		switch value := value.(type) {
+		case string:
+			return value
+		case float64:
+			return strconv.FormatFloat(value, 'f', -1, 64)
+		case bool:
+			return strconv.FormatBool(value)
+		}
+	}
+	return ""
+}
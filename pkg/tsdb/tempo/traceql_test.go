@@ -0,0 +1,192 @@
+package tempo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/instancemgmt"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testInstanceManager struct {
+	dsInfo *Datasource
+}
+
+func (m testInstanceManager) Get(_ context.Context, _ backend.PluginContext) (instancemgmt.Instance, error) {
+	return m.dsInfo, nil
+}
+
+func (m testInstanceManager) Do(_ context.Context, _ backend.PluginContext, _ instancemgmt.InstanceCallbackFunc) error {
+	return nil
+}
+
+func setupTraceQLService(t *testing.T, handler http.HandlerFunc) *Service {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &Service{
+		im:     testInstanceManager{dsInfo: &Datasource{HTTPClient: srv.Client(), URL: srv.URL}},
+		logger: log.New("tempo-test"),
+	}
+}
+
+func runQuery(t *testing.T, s *Service, queryType string, model map[string]any) backend.DataResponse {
+	t.Helper()
+
+	raw, err := json.Marshal(model)
+	require.NoError(t, err)
+
+	res, err := s.QueryData(context.Background(), &backend.QueryDataRequest{
+		Queries: []backend.DataQuery{{
+			RefID:     "A",
+			QueryType: queryType,
+			JSON:      raw,
+			Interval:  time.Minute,
+			TimeRange: backend.TimeRange{From: time.Unix(1700000000, 0), To: time.Unix(1700003600, 0)},
+		}},
+	})
+	require.NoError(t, err)
+	return res.Responses["A"]
+}
+
+const searchResponse = `{
+	"traces": [{
+		"traceID": "2f3e0cee77ae5dc9c17ade3689eb2e54",
+		"rootServiceName": "shop-backend",
+		"rootTraceName": "update-billing",
+		"startTimeUnixNano": "1700000100000000000",
+		"durationMs": 1200,
+		"spanSets": [{
+			"matched": 2,
+			"spans": [
+				{"spanID": "5b4a9b8d8f2b6c1a", "name": "db-query", "startTimeUnixNano": "1700000100000000000", "durationNanos": "500000000", "attributes": [{"key": "db.system", "value": {"stringValue": "postgres"}}]},
+				{"spanID": "1c2d3e4f5a6b7c8d", "name": "http-get", "startTimeUnixNano": "1700000100500000000", "durationNanos": "200000000", "attributes": [{"key": "http.status_code", "value": {"intValue": "200"}}]}
+			],
+			"attributes": [{"key": "by(resource.service.name)", "value": {"stringValue": "shop-backend"}}]
+		}]
+	}],
+	"metrics": {"inspectedTraces": 10}
+}`
+
+func TestSearchTraceQL(t *testing.T) {
+	var requested *http.Request
+	s := setupTraceQLService(t, func(rw http.ResponseWriter, r *http.Request) {
+		requested = r
+		_, _ = rw.Write([]byte(searchResponse))
+	})
+
+	t.Run("returns a traces table", func(t *testing.T) {
+		res := runQuery(t, s, "traceql", map[string]any{"query": "{ span.http.status_code = 200 }", "limit": 20, "spss": 3})
+		require.NoError(t, res.Error)
+		require.Equal(t, "/api/search", requested.URL.Path)
+		assert.Equal(t, "{ span.http.status_code = 200 }", requested.URL.Query().Get("q"))
+		assert.Equal(t, "1700000000", requested.URL.Query().Get("start"))
+		assert.Equal(t, "1700003600", requested.URL.Query().Get("end"))
+		assert.Equal(t, "20", requested.URL.Query().Get("limit"))
+		assert.Equal(t, "3", requested.URL.Query().Get("spss"))
+
+		require.Len(t, res.Frames, 1)
+		frame := res.Frames[0]
+		assert.Equal(t, "A", frame.RefID)
+		assert.Equal(t, data.VisTypeTable, string(frame.Meta.PreferredVisualization))
+		require.Equal(t, 1, frame.Rows())
+		assert.Equal(t, "2f3e0cee77ae5dc9c17ade3689eb2e54", frame.Fields[0].At(0))
+		assert.Equal(t, time.Unix(0, 1700000100000000000).UTC(), frame.Fields[1].At(0))
+		assert.Equal(t, "shop-backend", frame.Fields[2].At(0))
+		assert.Equal(t, "update-billing", frame.Fields[3].At(0))
+		assert.Equal(t, float64(1200), frame.Fields[4].At(0))
+		assert.Equal(t, int64(2), frame.Fields[5].At(0))
+	})
+
+	t.Run("returns a spans table", func(t *testing.T) {
+		res := runQuery(t, s, "traceqlSearch", map[string]any{"query": "{}", "tableType": "spans"})
+		require.NoError(t, res.Error)
+
+		require.Len(t, res.Frames, 1)
+		frame := res.Frames[0]
+		require.Equal(t, 2, frame.Rows())
+
+		names := make([]string, 0, len(frame.Fields))
+		for _, f := range frame.Fields {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"traceID", "spanID", "time", "name", "duration", "by(resource.service.name)", "db.system", "http.status_code"}, names)
+
+		assert.Equal(t, "5b4a9b8d8f2b6c1a", frame.Fields[1].At(0))
+		assert.Equal(t, float64(500000000), frame.Fields[4].At(0))
+		assert.Equal(t, "shop-backend", frame.Fields[5].At(1))
+		assert.Equal(t, "postgres", frame.Fields[6].At(0))
+		assert.Equal(t, "", frame.Fields[6].At(1))
+		assert.Equal(t, "200", frame.Fields[7].At(1))
+	})
+
+	t.Run("returns a validation error for an empty query", func(t *testing.T) {
+		s := setupTraceQLService(t, func(rw http.ResponseWriter, r *http.Request) {
+			t.Fatal("tempo should not be queried")
+		})
+		res := runQuery(t, s, "traceqlSearch", map[string]any{"query": ""})
+		require.Error(t, res.Error)
+		assert.Contains(t, res.Error.Error(), "TraceQL query is required")
+	})
+
+	t.Run("returns tempo errors in the response", func(t *testing.T) {
+		s := setupTraceQLService(t, func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusBadRequest)
+			_, _ = rw.Write([]byte("invalid TraceQL query"))
+		})
+		res := runQuery(t, s, "traceql", map[string]any{"query": "{"})
+		require.Error(t, res.Error)
+		assert.Contains(t, res.Error.Error(), "invalid TraceQL query")
+	})
+}
+
+func TestQueryTraceQLMetrics(t *testing.T) {
+	var requested *http.Request
+	s := setupTraceQLService(t, func(rw http.ResponseWriter, r *http.Request) {
+		requested = r
+		_, _ = rw.Write([]byte(`{
+			"series": [
+				{
+					"labels": [{"key": "resource.service.name", "value": {"stringValue": "shop-backend"}}],
+					"samples": [{"timestampMs": "1700000000000", "value": 1.5}, {"timestampMs": 1700000060000, "value": 2}]
+				},
+				{
+					"labels": [{"key": "resource.service.name", "value": {"stringValue": "auth"}}],
+					"samples": [{"timestampMs": "1700000000000", "value": 0.5}]
+				}
+			]
+		}`))
+	})
+
+	res := runQuery(t, s, QueryTypeTraceqlMetrics, map[string]any{"query": "{} | rate() by (resource.service.name)"})
+	require.NoError(t, res.Error)
+	require.Equal(t, "/api/metrics/query_range", requested.URL.Path)
+	assert.Equal(t, "60s", requested.URL.Query().Get("step"))
+
+	require.Len(t, res.Frames, 2)
+	frame := res.Frames[0]
+	assert.Equal(t, data.FrameTypeTimeSeriesMulti, frame.Meta.Type)
+	assert.Equal(t, data.Labels{"resource.service.name": "shop-backend"}, frame.Fields[1].Labels)
+	require.Equal(t, 2, frame.Rows())
+	assert.Equal(t, time.UnixMilli(1700000060000).UTC(), frame.Fields[0].At(1))
+	assert.Equal(t, 2.0, frame.Fields[1].At(1))
+	assert.Equal(t, data.Labels{"resource.service.name": "auth"}, res.Frames[1].Fields[1].Labels)
+}
+
+func TestQueryUnsupportedType(t *testing.T) {
+	s := setupTraceQLService(t, func(rw http.ResponseWriter, r *http.Request) {})
+	_, err := s.QueryData(context.Background(), &backend.QueryDataRequest{
+		Queries: []backend.DataQuery{{RefID: "A", QueryType: "serviceMap", JSON: []byte(`{}`)}},
+	})
+	require.Error(t, err)
+}
@@ -141,18 +141,6 @@ describe('ElasticDatasource', () => {
     });
   });
 
-  describe('When testing datasource with index pattern', () => {
-    it('should translate index pattern to current day', async () => {
-      const { ds, fetchMock } = getTestContext({ jsonData: { interval: 'Daily' } });
-
-      await ds.testDatasource();
-
-      const today = toUtc().format('YYYY.MM.DD');
-      const lastCall = fetchMock.mock.calls[fetchMock.mock.calls.length - 1];
-      expect(lastCall[0].url).toBe(`${ELASTICSEARCH_MOCK_URL}/test-${today}/_mapping`);
-    });
-  });
-
   describe('When issuing metric query with interval pattern', () => {
     async function runScenario() {
       const range = { from: toUtc([2015, 4, 30, 10]), to: toUtc([2015, 5, 1, 10]), raw: { from: '', to: '' } };
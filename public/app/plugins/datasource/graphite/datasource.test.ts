@@ -32,6 +32,7 @@ describe('graphiteDatasource', () => {
 
     const instanceSettings = {
       url: '/api/datasources/proxy/1',
+      uid: 'graphite-uid',
       name: 'graphiteProd',
       jsonData: {
         rollupIndicatorEnabled: true,
@@ -285,15 +286,25 @@ describe('graphiteDatasource', () => {
   });
 
   describe('when fetching Graphite function descriptions', () => {
-    // `"default": Infinity` (invalid JSON) in params passed by Graphite API in 1.1.7
-    const INVALID_JSON =
-      '{"testFunction":{"name":"function","description":"description","module":"graphite.render.functions","group":"Transform","params":[{"name":"param","type":"intOrInf","required":true,"default":Infinity}]}}';
+    // the backend replaces the `"default": Infinity` (invalid JSON) passed by Graphite API in 1.1.7 with 1e9999
+    const FUNCTIONS = {
+      testFunction: {
+        name: 'function',
+        description: 'description',
+        module: 'graphite.render.functions',
+        group: 'Transform',
+        params: [{ name: 'param', type: 'intOrInf', required: true, default: Infinity }],
+      },
+    };
 
-    it('should parse the response with an invalid JSON', async () => {
-      fetchMock.mockImplementation(() => {
-        return of(createFetchResponse(INVALID_JSON));
+    it('should fetch the functions from the backend', async () => {
+      let requestOptions: BackendSrvRequest | undefined;
+      fetchMock.mockImplementation((options) => {
+        requestOptions = options;
+        return of(createFetchResponse(FUNCTIONS));
       });
       const funcDefs = await ctx.ds.getFuncDefs();
+      expect(requestOptions?.url).toBe('/api/datasources/uid/graphite-uid/resources/functions');
       expect(funcDefs).toEqual({
         testFunction: {
           category: 'Transform',
@@ -426,7 +437,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/tags');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags');
       expect(requestOptions.params?.expr).toEqual([]);
       expect(results).not.toBe(null);
     });
@@ -436,7 +447,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/tags');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags');
       expect(requestOptions.params?.expr).toEqual(['server=backend_01']);
       expect(results).not.toBe(null);
     });
@@ -446,7 +457,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/tags');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags');
       expect(requestOptions.params?.expr).toEqual(['server=backend_01']);
       expect(results).not.toBe(null);
     });
@@ -456,7 +467,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual([]);
       expect(results).not.toBe(null);
@@ -467,7 +478,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual(['server=~backend*']);
       expect(results).not.toBe(null);
@@ -478,7 +489,7 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual([]);
       expect(results).not.toBe(null);
@@ -489,13 +500,13 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/tags/autoComplete/values');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/tags/values');
       expect(requestOptions.params?.tag).toBe('server');
       expect(requestOptions.params?.expr).toEqual(['server=~backend*']);
       expect(results).not.toBe(null);
     });
 
-    it('/metrics/find should be POST', () => {
+    it('should request /metrics/find from the backend', () => {
       ctx.templateSrv.init([
         {
           type: 'query',
@@ -506,11 +517,9 @@ describe('graphiteDatasource', () => {
       ctx.ds.metricFindQuery('[[foo]]').then((data: any) => {
         results = data;
       });
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
-      expect(requestOptions.method).toEqual('POST');
-      expect(requestOptions.headers).toHaveProperty('Content-Type', 'application/x-www-form-urlencoded');
-      expect(requestOptions.data).toMatch(`query=bar`);
-      expect(requestOptions).toHaveProperty('params');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
+      expect(requestOptions.method).toEqual('GET');
+      expect(requestOptions.params).toEqual({ query: 'bar' });
     });
 
     it('should interpolate $__searchFilter with searchFilter', () => {
@@ -518,9 +527,8 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
-      expect(requestOptions.params).toEqual({});
-      expect(requestOptions.data).toEqual('query=app.backend*');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
+      expect(requestOptions.params).toEqual({ query: 'app.backend*' });
       expect(results).not.toBe(null);
     });
 
@@ -529,9 +537,8 @@ describe('graphiteDatasource', () => {
         results = data;
       });
 
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
-      expect(requestOptions.params).toEqual({});
-      expect(requestOptions.data).toEqual('query=app.*');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
+      expect(requestOptions.params).toEqual({ query: 'app.*' });
       expect(results).not.toBe(null);
     });
 
@@ -550,7 +557,7 @@ describe('graphiteDatasource', () => {
       ctx.ds.metricFindQuery(stringQuery).then((data) => {
         results = data;
       });
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
       expect(results).not.toBe(null);
 
       const objectQuery = {
@@ -560,7 +567,7 @@ describe('graphiteDatasource', () => {
         datasource: ctx.ds,
       };
       const data = await ctx.ds.metricFindQuery(objectQuery);
-      expect(requestOptions.url).toBe('/api/datasources/proxy/1/metrics/find');
+      expect(requestOptions.url).toBe('/api/datasources/uid/graphite-uid/resources/metrics/find');
       expect(data).toBeTruthy();
     });
 
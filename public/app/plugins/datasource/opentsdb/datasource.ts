@@ -13,26 +13,25 @@ import {
   map as _map,
   toPairs,
 } from 'lodash';
-import { lastValueFrom, merge, Observable, of } from 'rxjs';
+import { from, lastValueFrom, merge, Observable, of } from 'rxjs';
 import { catchError, map } from 'rxjs/operators';
 
 import {
   AnnotationEvent,
   DataQueryRequest,
   DataQueryResponse,
-  DataSourceApi,
   dateMath,
   ScopedVars,
   toDataFrame,
 } from '@grafana/data';
-import { FetchResponse, getBackendSrv } from '@grafana/runtime';
+import { DataSourceWithBackend, FetchResponse, getBackendSrv } from '@grafana/runtime';
 import { getTemplateSrv, TemplateSrv } from 'app/features/templating/template_srv';
 
 import { AnnotationEditor } from './components/AnnotationEditor';
 import { prepareAnnotation } from './migrations';
 import { OpenTsdbFilter, OpenTsdbOptions, OpenTsdbQuery } from './types';
 
-export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenTsdbOptions> {
+export default class OpenTsDatasource extends DataSourceWithBackend<OpenTsdbQuery, OpenTsdbOptions> {
   type: any;
   url: any;
   name: any;
@@ -260,11 +259,7 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
   }
 
   _performSuggestQuery(query: string, type: string): Observable<any> {
-    return this._get('/api/suggest', { type, q: query, max: this.lookupLimit }).pipe(
-      map((result: any) => {
-        return result.data;
-      })
-    );
+    return from(this.getResource('suggest', { type, q: query, max: this.lookupLimit }));
   }
 
   _performMetricKeyValueLookup(metric: string, keys: any): Observable<any[]> {
@@ -397,31 +392,17 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
     return Promise.resolve([]);
   }
 
-  testDatasource() {
-    return lastValueFrom(
-      this._performSuggestQuery('cpu', 'metrics').pipe(
-        map(() => {
-          return { status: 'success', message: 'Data source is working' };
-        })
-      )
-    );
-  }
-
   getAggregators() {
     if (this.aggregatorsPromise) {
       return this.aggregatorsPromise;
     }
 
-    this.aggregatorsPromise = lastValueFrom(
-      this._get('/api/aggregators').pipe(
-        map((result: any) => {
-          if (result.data && isArray(result.data)) {
-            return result.data.sort();
-          }
-          return [];
-        })
-      )
-    );
+    this.aggregatorsPromise = this.getResource('aggregators').then((aggregators: any) => {
+      if (aggregators && isArray(aggregators)) {
+        return aggregators.sort();
+      }
+      return [];
+    });
     return this.aggregatorsPromise;
   }
 
@@ -430,16 +411,12 @@ export default class OpenTsDatasource extends DataSourceApi<OpenTsdbQuery, OpenT
       return this.filterTypesPromise;
     }
 
-    this.filterTypesPromise = lastValueFrom(
-      this._get('/api/config/filters').pipe(
-        map((result: any) => {
-          if (result.data) {
-            return Object.keys(result.data).sort();
-          }
-          return [];
-        })
-      )
-    );
+    this.filterTypesPromise = this.getResource('filters').then((filters: any) => {
+      if (filters) {
+        return Object.keys(filters).sort();
+      }
+      return [];
+    });
     return this.filterTypesPromise;
   }
 
@@ -29,7 +29,7 @@ describe('opentsdb', () => {
     const fetchMock = jest.spyOn(backendSrv, 'fetch');
     fetchMock.mockImplementation(() => of(createFetchResponse(data)));
 
-    const instanceSettings = { url: '', jsonData: { tsdbVersion: 1 } };
+    const instanceSettings = { url: '', uid: 'opentsdb-uid', jsonData: { tsdbVersion: 1 } };
     const replace = jest.fn((value) => value);
     const templateSrv = {
       replace,
@@ -47,7 +47,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('metrics(pew)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/suggest');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/uid/opentsdb-uid/resources/suggest');
       expect(fetchMock.mock.calls[0][0].params?.type).toBe('metrics');
       expect(fetchMock.mock.calls[0][0].params?.q).toBe('pew');
       expect(results).not.toBe(null);
@@ -103,7 +103,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('suggest_tagk(foo)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/suggest');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/uid/opentsdb-uid/resources/suggest');
       expect(fetchMock.mock.calls[0][0].params?.type).toBe('tagk');
       expect(fetchMock.mock.calls[0][0].params?.q).toBe('foo');
       expect(results).not.toBe(null);
@@ -115,7 +115,7 @@ describe('opentsdb', () => {
       const results = await ds.metricFindQuery('suggest_tagv(bar)');
 
       expect(fetchMock).toHaveBeenCalledTimes(1);
-      expect(fetchMock.mock.calls[0][0].url).toBe('/api/suggest');
+      expect(fetchMock.mock.calls[0][0].url).toBe('/api/datasources/uid/opentsdb-uid/resources/suggest');
       expect(fetchMock.mock.calls[0][0].params?.type).toBe('tagv');
       expect(fetchMock.mock.calls[0][0].params?.q).toBe('bar');
       expect(results).not.toBe(null);
@@ -34,6 +34,7 @@ export interface DashboardMeta {
   canMakeEditable?: boolean;
   provisioned?: boolean;
   provisionedExternalId?: string;
+  provisionedCommit?: string;
   isStarred?: boolean;
   showSettings?: boolean;
   expires?: string;